@@ -109,7 +109,7 @@ func main() {
 	collectionPlaces := mongoClient.Database(mongoDB).Collection("places")
 	placeMongoRepository := repository.NewPlaceMongoRepository(collectionPlaces)
 	placeQueueRabbitRepository := repository.NewPlaceQueueRabbitRepository(ctx, publisher, exchange, routingKey)
-	placeService := service.NewDefaultPlaceService(placeMongoRepository, categoryMongoRepository, placeQueueRabbitRepository, nil, nil)
+	placeService := service.NewDefaultPlaceService(placeMongoRepository, categoryMongoRepository, nil, placeQueueRabbitRepository, nil, nil, nil)
 
 	done := make(chan struct{}, 1)
 	go func() {
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/repository"
+	"walk_backend/internal/app/service"
+	"walk_backend/internal/pkg/elastic"
+	"walk_backend/internal/pkg/env"
+
+	"github.com/rs/zerolog"
+	rabbitmq "github.com/wagslane/go-rabbitmq"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+var (
+	mode      = flag.String("mode", string(model.ReindexModeIndex), "Reindex mode: index - write to a new index and switch alias, queue - only publish reindex messages")
+	alias     = flag.String("alias", "places", "Read alias of the place index")
+	batchSize = flag.Int64("batch-size", 500, "Places per batch")
+	resume    = flag.Bool("resume", true, "Resume the last unfinished job")
+)
+
+func init() {
+	flag.Parse()
+}
+
+func main() {
+
+	env := env.New()
+
+	log := zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout, NoColor: true}).With().Timestamp().Logger()
+	logErr := zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr, NoColor: true}).With().Timestamp().Logger()
+
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+
+	// Create context that listens for the interrupt signal from the OS.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	reindexMode := model.ReindexMode(*mode)
+
+	// ENV
+	mongoURI := env.GetMust("MONGO_URI")
+	mongoDB := env.GetMust("MONGO_INITDB_NAME")
+
+	// DB
+	mongoClient, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	if err != nil {
+		logErr.Fatal().Err(err).Caller().Send()
+	}
+	defer func() {
+		if err = mongoClient.Disconnect(context.Background()); err != nil {
+			log.Info().Err(err).Caller().Send()
+		}
+	}()
+	if err = mongoClient.Ping(ctx, readpref.Primary()); err != nil {
+		logErr.Fatal().Err(err).Caller().Send()
+	}
+	log.Print("Сonnected to MongoDB")
+
+	collectionPlaces := mongoClient.Database(mongoDB).Collection("places")
+	placeMongoRepository := repository.NewPlaceMongoRepository(collectionPlaces)
+	collectionReindexState := mongoClient.Database(mongoDB).Collection("reindex_state")
+	reindexStateMongoRepository := repository.NewReindexStateMongoRepository(collectionReindexState)
+
+	var placeSearchRepository service.ReindexSearchRepositoryInterface
+	var placeQueueRepository service.PlaceQueueRepositoryInterface
+
+	switch reindexMode {
+	case model.ReindexModeIndex:
+		elasticClient := elastic.NewClient(
+			env.GetMust("ELASTICSEARCH_HOSTS"),
+			env.Get("ELASTICSEARCH_USERNAME"),
+			env.Get("ELASTICSEARCH_PASSWORD"),
+		)
+		placeSearchRepository = repository.NewPlaceSearchElasticRepository(elasticClient)
+	case model.ReindexModeQueue:
+		publisher, err := rabbitmq.NewPublisher(
+			env.GetMust("RABBITMQ_URI"),
+			rabbitmq.Config{},
+			rabbitmq.WithPublisherOptionsLogging,
+		)
+		if err != nil {
+			logErr.Fatal().Err(err).Caller().Send()
+		}
+		defer publisher.Close()
+		log.Print("Connected to RabbitMQ")
+
+		placeQueueRepository = repository.NewPlaceQueueRabbitRepository(
+			ctx,
+			publisher,
+			env.GetMust("RABBITMQ_EXCHANGE_REINDEX"),
+			env.GetMust("RABBITMQ_ROUTING_PLACE_KEY"),
+		)
+	default:
+		logErr.Fatal().Str("mode", *mode).Msg("unknown reindex mode")
+	}
+
+	reindexService := service.NewDefaultReindexService(
+		placeMongoRepository,
+		placeSearchRepository,
+		reindexStateMongoRepository,
+		placeQueueRepository,
+	)
+
+	state, err := reindexService.Reindex(ctx, service.ReindexOptions{
+		Mode:      reindexMode,
+		Alias:     *alias,
+		BatchSize: *batchSize,
+		Resume:    *resume,
+		Progress: func(state *model.ReindexState) {
+			log.Info().
+				Str("job", state.ID.String()).
+				Str("index", state.Index).
+				Int64("processed", state.Processed).
+				Int64("total", state.Total).
+				Msgf("reindex progress %d/%d", state.Processed, state.Total)
+		},
+	})
+	if err != nil {
+		if state != nil {
+			logErr.Error().Err(err).Str("job", state.ID.String()).Int64("processed", state.Processed).Msg("reindex interrupted, run again to resume")
+		}
+		logErr.Fatal().Err(err).Caller().Send()
+	}
+
+	log.Info().
+		Str("job", state.ID.String()).
+		Str("mode", string(state.Mode)).
+		Str("alias", state.Alias).
+		Str("index", state.Index).
+		Int64("processed", state.Processed).
+		Msg("reindex done")
+}
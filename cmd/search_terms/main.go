@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"walk_backend/internal/app/repository"
+	"walk_backend/internal/app/service"
+	"walk_backend/internal/pkg/env"
+
+	"github.com/rs/zerolog"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+var (
+	batchSize = flag.Int64("batch-size", 500, "Places per batch")
+)
+
+func init() {
+	flag.Parse()
+}
+
+func main() {
+
+	env := env.New()
+
+	log := zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout, NoColor: true}).With().Timestamp().Logger()
+	logErr := zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr, NoColor: true}).With().Timestamp().Logger()
+
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+
+	// Create context that listens for the interrupt signal from the OS.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// ENV
+	mongoURI := env.GetMust("MONGO_URI")
+	mongoDB := env.GetMust("MONGO_INITDB_NAME")
+
+	// DB
+	mongoClient, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	if err != nil {
+		logErr.Fatal().Err(err).Caller().Send()
+	}
+	defer func() {
+		if err = mongoClient.Disconnect(context.Background()); err != nil {
+			log.Info().Err(err).Caller().Send()
+		}
+	}()
+	if err = mongoClient.Ping(ctx, readpref.Primary()); err != nil {
+		logErr.Fatal().Err(err).Caller().Send()
+	}
+	log.Print("Сonnected to MongoDB")
+
+	collectionPlaces := mongoClient.Database(mongoDB).Collection("places")
+	placeMongoRepository := repository.NewPlaceMongoRepository(collectionPlaces)
+
+	searchTermsService := service.NewDefaultSearchTermsService(placeMongoRepository)
+
+	processed, err := searchTermsService.Backfill(ctx, *batchSize, func(processed int64) {
+		log.Info().Int64("processed", processed).Msg("search terms progress")
+	})
+	if err != nil {
+		logErr.Error().Err(err).Int64("processed", processed).Msg("search terms backfill interrupted, run again to finish")
+		logErr.Fatal().Err(err).Caller().Send()
+	}
+
+	log.Info().Int64("processed", processed).Msg("search terms backfill done")
+}
@@ -0,0 +1,152 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+
+	"walk_backend/internal/app/api/middleware"
+	"walk_backend/internal/app/dto"
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/context"
+)
+
+// ServiceInterface ...
+type ServiceInterface interface {
+	ChangePassword(ctx context.Context, username string, currentPassword string, newPassword string) error
+}
+
+// CredentialServiceInterface ...
+type CredentialServiceInterface interface {
+	RevokeAll(ctx context.Context, username string, exceptSessionID model.ID) (int64, error)
+}
+
+// LoginThrottleServiceInterface ...
+type LoginThrottleServiceInterface interface {
+	Check(ctx context.Context, username string, ip string) error
+	Fail(ctx context.Context, username string, ip string) error
+	Succeed(ctx context.Context, username string, ip string) error
+}
+
+// AccountHandler current user account handler struct
+type AccountHandler struct {
+	ctx         context.Context
+	routerAuth  *gin.RouterGroup
+	service     ServiceInterface
+	credentials CredentialServiceInterface
+	throttle    LoginThrottleServiceInterface
+}
+
+// NewHandler create new account handler
+func NewHandler(
+	ctx context.Context,
+	routerAuth *gin.RouterGroup,
+	service ServiceInterface,
+	credentials CredentialServiceInterface,
+	throttle LoginThrottleServiceInterface,
+) *AccountHandler {
+	return &AccountHandler{
+		ctx:         ctx,
+		routerAuth:  routerAuth,
+		service:     service,
+		credentials: credentials,
+		throttle:    throttle,
+	}
+}
+
+// ChangePasswordHandler ...
+//
+// swagger:operation POST /me/password account changePassword
+// Change the password of the current user, signs out every other session and revokes bearer tokens and API keys
+// ---
+// produces:
+// - application/json
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'400':
+//	  description: Invalid input or the new password violates the password policy
+//	'403':
+//	  description: Invalid current password
+//	'429':
+//	  description: Too many failed attempts, see Retry-After
+func (handler *AccountHandler) ChangePasswordHandler(c *gin.Context) {
+
+	dto := dto.NewPasswordChangeDTO()
+	if err := c.ShouldBindJSON(dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	username := middleware.UserFromContext(c).Username
+	if middleware.AbortThrottled(c, handler.throttle.Check(handler.ctx, username, c.ClientIP())) {
+		middleware.RecordAudit(c, &model.AuditEvent{
+			Type:    model.AuditEventPasswordChange,
+			Outcome: model.AuditOutcomeFailure,
+			Reason:  model.AuditReasonThrottled,
+		})
+		return
+	}
+
+	err := handler.service.ChangePassword(handler.ctx, username, dto.CurrentPassword, dto.NewPassword)
+	if err != nil {
+		_ = c.Error(err)
+		var validationErr *model.ValidationError
+		if errors.Is(err, service.ErrInvalidCurrentPassword) {
+			if err := handler.throttle.Fail(handler.ctx, username, c.ClientIP()); err != nil {
+				_ = c.Error(err)
+			}
+			middleware.RecordAudit(c, &model.AuditEvent{
+				Type:    model.AuditEventPasswordChange,
+				Outcome: model.AuditOutcomeFailure,
+				Reason:  model.AuditReasonInvalidCredentials,
+			})
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		} else if errors.As(err, &validationErr) {
+			middleware.RecordAudit(c, &model.AuditEvent{
+				Type:    model.AuditEventPasswordChange,
+				Outcome: model.AuditOutcomeFailure,
+				Reason:  model.AuditReasonInvalidInput,
+			})
+			c.JSON(http.StatusBadRequest, gin.H{"error": model.ErrInvalidModel.Error(), "fields": validationErr.Fields})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err := handler.throttle.Succeed(handler.ctx, username, c.ClientIP()); err != nil {
+		_ = c.Error(err)
+	}
+	middleware.RecordAudit(c, &model.AuditEvent{
+		Type:    model.AuditEventPasswordChange,
+		Outcome: model.AuditOutcomeSuccess,
+	})
+
+	except := model.NilID
+	if m := middleware.SessionTokenFromContext(c); m != nil {
+		except = m.FamilyID
+	}
+	revoked, err := handler.credentials.RevokeAll(handler.ctx, username, except)
+	if err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Password changed, error revoke other credentials"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password changed", "revoked": revoked})
+}
+
+// Make ...
+func (handler *AccountHandler) Make() {
+	handler.MakeRoutes()
+}
+
+// MakeRoutes make account routes
+func (handler *AccountHandler) MakeRoutes() {
+
+	handler.routerAuth.POST("/me/password", middleware.DenyAPIKey(), handler.ChangePasswordHandler)
+}
@@ -0,0 +1,97 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	accountMock "walk_backend/internal/app/api/handlers/account/mock"
+	"walk_backend/internal/app/api/middleware"
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountHandler(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	user, err := model.NewUserModel("Wozniak", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+	assert.Nil(t, err)
+	currentID, _ := model.NewID()
+
+	router := gin.Default()
+	apiV1auth := router.Group("/api/v1", func(c *gin.Context) {
+		c.Set(middleware.ContextUserKey, user)
+		c.Set(middleware.ContextSessionTokenKey, &model.SessionToken{FamilyID: currentID, Username: "Wozniak"})
+	})
+
+	mockService := accountMock.NewMockServiceInterface(controller)
+	mockCredentialService := accountMock.NewMockCredentialServiceInterface(controller)
+	mockThrottle := accountMock.NewMockLoginThrottleServiceInterface(controller)
+
+	mh := NewHandler(context.Background(), apiV1auth, mockService, mockCredentialService, mockThrottle)
+	mh.MakeRoutes()
+
+	serve := func(current string, new string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]string{"currentPassword": current, "newPassword": new})
+		request, _ := http.NewRequest(http.MethodPost, "/api/v1/me/password", bytes.NewReader(body))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	t.Run("Invalid_input", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve("password", "").Code)
+	})
+
+	t.Run("Throttled", func(t *testing.T) {
+		mockThrottle.EXPECT().Check(context.Background(), "Wozniak", gomock.Any()).Return(&service.ThrottledError{RetryAfter: time.Minute})
+
+		recorder := serve("password", "Apple-II-1977")
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+		assert.Equal(t, "60", recorder.Header().Get("Retry-After"))
+	})
+
+	t.Run("Invalid_current_password", func(t *testing.T) {
+		mockThrottle.EXPECT().Check(context.Background(), "Wozniak", gomock.Any()).Return(nil)
+		mockService.EXPECT().ChangePassword(context.Background(), "Wozniak", "wrong", "Apple-II-1977").Return(service.ErrInvalidCurrentPassword)
+		mockThrottle.EXPECT().Fail(context.Background(), "Wozniak", gomock.Any()).Return(nil)
+
+		assert.Equal(t, http.StatusForbidden, serve("wrong", "Apple-II-1977").Code)
+	})
+
+	t.Run("Policy", func(t *testing.T) {
+		validationErr := &model.ValidationError{}
+		validationErr.Add("newPassword", "is too common")
+
+		mockThrottle.EXPECT().Check(context.Background(), "Wozniak", gomock.Any()).Return(nil)
+		mockService.EXPECT().ChangePassword(context.Background(), "Wozniak", "password", "qwerty123").Return(validationErr)
+
+		recorder := serve("password", "qwerty123")
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+		var body struct {
+			Fields map[string][]string `json:"fields"`
+		}
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.Equal(t, []string{"is too common"}, body.Fields["newPassword"])
+	})
+
+	t.Run("Ok", func(t *testing.T) {
+		mockThrottle.EXPECT().Check(context.Background(), "Wozniak", gomock.Any()).Return(nil)
+		mockService.EXPECT().ChangePassword(context.Background(), "Wozniak", "password", "Apple-II-1977").Return(nil)
+		mockThrottle.EXPECT().Succeed(context.Background(), "Wozniak", gomock.Any()).Return(nil)
+		mockCredentialService.EXPECT().RevokeAll(context.Background(), "Wozniak", currentID).Return(int64(2), nil)
+
+		assert.Equal(t, http.StatusOK, serve("password", "Apple-II-1977").Code)
+	})
+}
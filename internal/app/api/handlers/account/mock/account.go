@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/api/handlers/account/account.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockServiceInterface is a mock of ServiceInterface interface.
+type MockServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceInterfaceMockRecorder
+}
+
+// MockServiceInterfaceMockRecorder is the mock recorder for MockServiceInterface.
+type MockServiceInterfaceMockRecorder struct {
+	mock *MockServiceInterface
+}
+
+// NewMockServiceInterface creates a new mock instance.
+func NewMockServiceInterface(ctrl *gomock.Controller) *MockServiceInterface {
+	mock := &MockServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceInterface) EXPECT() *MockServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ChangePassword mocks base method.
+func (m *MockServiceInterface) ChangePassword(ctx context.Context, username, currentPassword, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, username, currentPassword, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockServiceInterfaceMockRecorder) ChangePassword(ctx, username, currentPassword, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockServiceInterface)(nil).ChangePassword), ctx, username, currentPassword, newPassword)
+}
+
+// MockCredentialServiceInterface is a mock of CredentialServiceInterface interface.
+type MockCredentialServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCredentialServiceInterfaceMockRecorder
+}
+
+// MockCredentialServiceInterfaceMockRecorder is the mock recorder for MockCredentialServiceInterface.
+type MockCredentialServiceInterfaceMockRecorder struct {
+	mock *MockCredentialServiceInterface
+}
+
+// NewMockCredentialServiceInterface creates a new mock instance.
+func NewMockCredentialServiceInterface(ctrl *gomock.Controller) *MockCredentialServiceInterface {
+	mock := &MockCredentialServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockCredentialServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCredentialServiceInterface) EXPECT() *MockCredentialServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// RevokeAll mocks base method.
+func (m *MockCredentialServiceInterface) RevokeAll(ctx context.Context, username string, exceptSessionID model.ID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, username, exceptSessionID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockCredentialServiceInterfaceMockRecorder) RevokeAll(ctx, username, exceptSessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockCredentialServiceInterface)(nil).RevokeAll), ctx, username, exceptSessionID)
+}
+
+// MockLoginThrottleServiceInterface is a mock of LoginThrottleServiceInterface interface.
+type MockLoginThrottleServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginThrottleServiceInterfaceMockRecorder
+}
+
+// MockLoginThrottleServiceInterfaceMockRecorder is the mock recorder for MockLoginThrottleServiceInterface.
+type MockLoginThrottleServiceInterfaceMockRecorder struct {
+	mock *MockLoginThrottleServiceInterface
+}
+
+// NewMockLoginThrottleServiceInterface creates a new mock instance.
+func NewMockLoginThrottleServiceInterface(ctrl *gomock.Controller) *MockLoginThrottleServiceInterface {
+	mock := &MockLoginThrottleServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockLoginThrottleServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginThrottleServiceInterface) EXPECT() *MockLoginThrottleServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockLoginThrottleServiceInterface) Check(ctx context.Context, username, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, username, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockLoginThrottleServiceInterfaceMockRecorder) Check(ctx, username, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockLoginThrottleServiceInterface)(nil).Check), ctx, username, ip)
+}
+
+// Fail mocks base method.
+func (m *MockLoginThrottleServiceInterface) Fail(ctx context.Context, username, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", ctx, username, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockLoginThrottleServiceInterfaceMockRecorder) Fail(ctx, username, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockLoginThrottleServiceInterface)(nil).Fail), ctx, username, ip)
+}
+
+// Succeed mocks base method.
+func (m *MockLoginThrottleServiceInterface) Succeed(ctx context.Context, username, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Succeed", ctx, username, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Succeed indicates an expected call of Succeed.
+func (mr *MockLoginThrottleServiceInterfaceMockRecorder) Succeed(ctx, username, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Succeed", reflect.TypeOf((*MockLoginThrottleServiceInterface)(nil).Succeed), ctx, username, ip)
+}
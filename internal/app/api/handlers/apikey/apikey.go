@@ -0,0 +1,182 @@
+package apikey
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"walk_backend/internal/app/api/middleware"
+	"walk_backend/internal/app/api/presenter"
+	"walk_backend/internal/app/dto"
+	"walk_backend/internal/app/model"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/context"
+)
+
+// ServiceInterface ...
+type ServiceInterface interface {
+	Create(ctx context.Context, user *model.User, name string, scopes []model.APIKeyScope, expiresAt *time.Time) (string, *model.APIKey, error)
+	List(ctx context.Context, username string) ([]*model.APIKey, error)
+	Revoke(ctx context.Context, username string, id model.ID) error
+}
+
+// PresenterInterface ...
+type PresenterInterface interface {
+	MakeCreated(key string, m *model.APIKey) *presenter.APIKey
+	MakeList(mList []*model.APIKey) []*presenter.APIKey
+}
+
+// APIKeysHandler API keys of the current user handler struct
+type APIKeysHandler struct {
+	ctx        context.Context
+	routerAuth *gin.RouterGroup
+	service    ServiceInterface
+	presenter  PresenterInterface
+}
+
+// NewHandler create new API keys handler
+func NewHandler(
+	ctx context.Context,
+	routerAuth *gin.RouterGroup,
+	service ServiceInterface,
+	presenter PresenterInterface,
+) *APIKeysHandler {
+	return &APIKeysHandler{
+		ctx:        ctx,
+		routerAuth: routerAuth,
+		service:    service,
+		presenter:  presenter,
+	}
+}
+
+// ListAPIKeysHandler ...
+//
+// swagger:operation GET /me/api-keys apiKeys listAPIKeys
+// Active API keys of the current user, newest first, keys are never shown again
+// ---
+// produces:
+// - application/json
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'401':
+//	  description: Invalid credentials
+//	'403':
+//	  description: Not allowed with an API key
+func (handler *APIKeysHandler) ListAPIKeysHandler(c *gin.Context) {
+
+	list, err := handler.service.List(handler.ctx, middleware.UserFromContext(c).Username)
+	if err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	data := handler.presenter.MakeList(list)
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// NewAPIKeyHandler ...
+//
+// swagger:operation POST /me/api-keys apiKeys newAPIKey
+// Create an API key of the current user, the key is shown only in this response.
+// Send it in the X-API-Key header, requests act as the user within the scopes
+// ---
+// consumes:
+// - application/json
+// produces:
+// - application/json
+// responses:
+//
+//	'201':
+//	  description: Successful operation
+//	'400':
+//	  description: Invalid input, unknown scope, scope not granted by the roles or expiry not in the future
+//	'403':
+//	  description: Access denied
+func (handler *APIKeysHandler) NewAPIKeyHandler(c *gin.Context) {
+
+	dto := dto.NewAPIKeyCreateDTO()
+	if err := c.ShouldBindJSON(dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	scopes := make([]model.APIKeyScope, 0, len(dto.Scopes))
+	for _, scope := range dto.Scopes {
+		scopes = append(scopes, model.APIKeyScope(scope))
+	}
+
+	key, m, err := handler.service.Create(handler.ctx, middleware.UserFromContext(c), dto.Name, scopes, dto.ExpiresAt)
+	if err != nil {
+		_ = c.Error(err)
+		var validationErr *model.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": model.ErrInvalidModel.Error(), "fields": validationErr.Fields})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	data := handler.presenter.MakeCreated(key, m)
+	c.JSON(http.StatusCreated, gin.H{"data": data})
+}
+
+// RevokeAPIKeyHandler ...
+//
+// swagger:operation DELETE /me/api-keys/{id} apiKeys revokeAPIKey
+// Revoke an API key of the current user
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     description: ID of the API key
+//     required: true
+//     type: string
+//
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'400':
+//	  description: Invalid API key ID
+//	'404':
+//	  description: API key not found
+func (handler *APIKeysHandler) RevokeAPIKeyHandler(c *gin.Context) {
+
+	id, err := model.StringToID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = handler.service.Revoke(handler.ctx, middleware.UserFromContext(c).Username, id)
+	if err != nil {
+		_ = c.Error(err)
+		if errors.Is(err, model.ErrModelNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "API key revoked"})
+}
+
+// Make ...
+func (handler *APIKeysHandler) Make() {
+	handler.MakeRoutes()
+}
+
+// MakeRoutes make API keys routes, keys can not manage keys
+func (handler *APIKeysHandler) MakeRoutes() {
+
+	denyAPIKey := middleware.DenyAPIKey()
+	handler.routerAuth.GET("/me/api-keys", denyAPIKey, handler.ListAPIKeysHandler)
+	handler.routerAuth.POST("/me/api-keys", middleware.RequirePermission(model.PermissionAPIKeyCreate), handler.NewAPIKeyHandler)
+	handler.routerAuth.DELETE("/me/api-keys/:id", denyAPIKey, handler.RevokeAPIKeyHandler)
+}
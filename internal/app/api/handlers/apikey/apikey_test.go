@@ -0,0 +1,121 @@
+package apikey
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apikeyMock "walk_backend/internal/app/api/handlers/apikey/mock"
+	"walk_backend/internal/app/api/middleware"
+	"walk_backend/internal/app/api/presenter"
+	"walk_backend/internal/app/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeysHandler(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	user, err := model.NewUserModel("Wozniak", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+	assert.Nil(t, err)
+	user.Roles = []model.Role{model.RoleEditor}
+	keyID, _ := model.NewID()
+	key := &model.APIKey{
+		ID:        keyID,
+		Prefix:    "walk_abcdefgh",
+		Username:  "Wozniak",
+		Name:      "importer",
+		Scopes:    []model.APIKeyScope{model.APIKeyScopePlacesWrite},
+		CreatedAt: time.Now(),
+	}
+
+	router := gin.Default()
+	apiV1auth := router.Group("/api/v1", func(c *gin.Context) {
+		c.Set(middleware.ContextUserKey, user)
+		if c.GetHeader(middleware.APIKeyHeader) != "" {
+			c.Set(middleware.ContextAPIKeyKey, key)
+		}
+	})
+
+	mockService := apikeyMock.NewMockServiceInterface(controller)
+
+	mh := NewHandler(context.Background(), apiV1auth, mockService, presenter.NewAPIKeyPresenter())
+	mh.MakeRoutes()
+
+	serve := func(method string, path string, body string, apiKey string) *httptest.ResponseRecorder {
+		request, _ := http.NewRequest(method, "/api/v1"+path, strings.NewReader(body))
+		if apiKey != "" {
+			request.Header.Set(middleware.APIKeyHeader, apiKey)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	t.Run("Create", func(t *testing.T) {
+
+		mockService.
+			EXPECT().
+			Create(context.Background(), user, "importer", []model.APIKeyScope{model.APIKeyScopePlacesWrite}, nil).
+			Return("walk_abcdefghsecret", key, nil)
+
+		recorder := serve(http.MethodPost, "/me/api-keys", `{"name":"importer","scopes":["places:write"]}`, "")
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+
+		var body struct {
+			Data *presenter.APIKey `json:"data"`
+		}
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.Equal(t, "walk_abcdefghsecret", body.Data.Key)
+		assert.Equal(t, []string{"places:write"}, body.Data.Scopes)
+	})
+
+	t.Run("Create_invalid", func(t *testing.T) {
+
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, "/me/api-keys", `{"name":"importer","scopes":["users:write"]}`, "").Code)
+
+		validationErr := &model.ValidationError{}
+		validationErr.Add("scopes", "categories:write is not granted by your roles")
+		mockService.EXPECT().Create(context.Background(), user, "importer", gomock.Any(), nil).Return("", nil, validationErr)
+
+		recorder := serve(http.MethodPost, "/me/api-keys", `{"name":"importer","scopes":["categories:write"]}`, "")
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "not granted")
+	})
+
+	t.Run("List", func(t *testing.T) {
+
+		mockService.EXPECT().List(context.Background(), "Wozniak").Return([]*model.APIKey{key}, nil)
+
+		recorder := serve(http.MethodGet, "/me/api-keys", "", "")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "walk_abcdefgh")
+		assert.NotContains(t, recorder.Body.String(), `"key"`)
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+
+		mockService.EXPECT().Revoke(context.Background(), "Wozniak", keyID).Return(nil)
+		assert.Equal(t, http.StatusOK, serve(http.MethodDelete, "/me/api-keys/"+keyID.String(), "", "").Code)
+
+		mockService.EXPECT().Revoke(context.Background(), "Wozniak", keyID).Return(model.ErrModelNotFound)
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodDelete, "/me/api-keys/"+keyID.String(), "", "").Code)
+
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodDelete, "/me/api-keys/invalid", "", "").Code)
+	})
+
+	t.Run("API_key", func(t *testing.T) {
+
+		assert.Equal(t, http.StatusForbidden, serve(http.MethodGet, "/me/api-keys", "", "walk_abcdefghsecret").Code)
+		assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, "/me/api-keys", `{"name":"more","scopes":["places:write"]}`, "walk_abcdefghsecret").Code)
+		assert.Equal(t, http.StatusForbidden, serve(http.MethodDelete, "/me/api-keys/"+keyID.String(), "", "walk_abcdefghsecret").Code)
+	})
+}
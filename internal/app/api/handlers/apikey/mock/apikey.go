@@ -0,0 +1,134 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/api/handlers/apikey/apikey.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	time "time"
+	presenter "walk_backend/internal/app/api/presenter"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockServiceInterface is a mock of ServiceInterface interface.
+type MockServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceInterfaceMockRecorder
+}
+
+// MockServiceInterfaceMockRecorder is the mock recorder for MockServiceInterface.
+type MockServiceInterfaceMockRecorder struct {
+	mock *MockServiceInterface
+}
+
+// NewMockServiceInterface creates a new mock instance.
+func NewMockServiceInterface(ctrl *gomock.Controller) *MockServiceInterface {
+	mock := &MockServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceInterface) EXPECT() *MockServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockServiceInterface) Create(ctx context.Context, user *model.User, name string, scopes []model.APIKeyScope, expiresAt *time.Time) (string, *model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, user, name, scopes, expiresAt)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*model.APIKey)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockServiceInterfaceMockRecorder) Create(ctx, user, name, scopes, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockServiceInterface)(nil).Create), ctx, user, name, scopes, expiresAt)
+}
+
+// List mocks base method.
+func (m *MockServiceInterface) List(ctx context.Context, username string) ([]*model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, username)
+	ret0, _ := ret[0].([]*model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceInterfaceMockRecorder) List(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockServiceInterface)(nil).List), ctx, username)
+}
+
+// Revoke mocks base method.
+func (m *MockServiceInterface) Revoke(ctx context.Context, username string, id model.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, username, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockServiceInterfaceMockRecorder) Revoke(ctx, username, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockServiceInterface)(nil).Revoke), ctx, username, id)
+}
+
+// MockPresenterInterface is a mock of PresenterInterface interface.
+type MockPresenterInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenterInterfaceMockRecorder
+}
+
+// MockPresenterInterfaceMockRecorder is the mock recorder for MockPresenterInterface.
+type MockPresenterInterfaceMockRecorder struct {
+	mock *MockPresenterInterface
+}
+
+// NewMockPresenterInterface creates a new mock instance.
+func NewMockPresenterInterface(ctrl *gomock.Controller) *MockPresenterInterface {
+	mock := &MockPresenterInterface{ctrl: ctrl}
+	mock.recorder = &MockPresenterInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresenterInterface) EXPECT() *MockPresenterInterfaceMockRecorder {
+	return m.recorder
+}
+
+// MakeCreated mocks base method.
+func (m_2 *MockPresenterInterface) MakeCreated(key string, m *model.APIKey) *presenter.APIKey {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "MakeCreated", key, m)
+	ret0, _ := ret[0].(*presenter.APIKey)
+	return ret0
+}
+
+// MakeCreated indicates an expected call of MakeCreated.
+func (mr *MockPresenterInterfaceMockRecorder) MakeCreated(key, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeCreated", reflect.TypeOf((*MockPresenterInterface)(nil).MakeCreated), key, m)
+}
+
+// MakeList mocks base method.
+func (m *MockPresenterInterface) MakeList(mList []*model.APIKey) []*presenter.APIKey {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MakeList", mList)
+	ret0, _ := ret[0].([]*presenter.APIKey)
+	return ret0
+}
+
+// MakeList indicates an expected call of MakeList.
+func (mr *MockPresenterInterfaceMockRecorder) MakeList(mList interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeList", reflect.TypeOf((*MockPresenterInterface)(nil).MakeList), mList)
+}
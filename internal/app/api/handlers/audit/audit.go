@@ -0,0 +1,169 @@
+package audit
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"walk_backend/internal/app/api/middleware"
+	"walk_backend/internal/app/api/presenter"
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/context"
+)
+
+// ServiceInterface ...
+type ServiceInterface interface {
+	Find(ctx context.Context, filter *model.AuditEventFilter, offset int64, limit int64) ([]*model.AuditEvent, error)
+}
+
+// PresenterInterface ...
+type PresenterInterface interface {
+	MakeList(mList []*model.AuditEvent) []*presenter.AuditEvent
+}
+
+// AuditHandler security audit log handler
+type AuditHandler struct {
+	ctx        context.Context
+	routerAuth *gin.RouterGroup
+	service    ServiceInterface
+	presenter  PresenterInterface
+}
+
+// NewHandler create new audit handler
+func NewHandler(
+	ctx context.Context,
+	routerAuth *gin.RouterGroup,
+	service ServiceInterface,
+	presenter PresenterInterface,
+) *AuditHandler {
+	return &AuditHandler{
+		ctx:        ctx,
+		routerAuth: routerAuth,
+		service:    service,
+		presenter:  presenter,
+	}
+}
+
+// ListHandler ...
+//
+// swagger:operation GET /admin/audit audit listAuditEvents
+// Page of security events, newest first
+// ---
+// produces:
+// - application/json
+// parameters:
+//   - name: type
+//     in: query
+//     description: event type, e.g. login, logout, token_refresh, access_denied
+//     required: false
+//     type: string
+//   - name: outcome
+//     in: query
+//     description: success or failure
+//     required: false
+//     type: string
+//   - name: actor
+//     in: query
+//     description: username
+//     required: false
+//     type: string
+//   - name: ip
+//     in: query
+//     description: client IP
+//     required: false
+//     type: string
+//   - name: from
+//     in: query
+//     description: RFC 3339 time, events at or after it
+//     required: false
+//     type: string
+//   - name: to
+//     in: query
+//     description: RFC 3339 time, events before it
+//     required: false
+//     type: string
+//   - name: offset
+//     in: query
+//     description: number of events to skip
+//     required: false
+//     type: integer
+//   - name: limit
+//     in: query
+//     description: max number of events, up to 200
+//     required: false
+//     type: integer
+//
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'400':
+//	  description: Invalid input
+//	'403':
+//	  description: Access denied
+func (handler *AuditHandler) ListHandler(c *gin.Context) {
+
+	filter := &model.AuditEventFilter{
+		Type:    model.AuditEventType(c.Query("type")),
+		Outcome: model.AuditOutcome(c.Query("outcome")),
+		Actor:   c.Query("actor"),
+		IP:      c.Query("ip"),
+	}
+	var err error
+	if v := c.Query("from"); v != "" {
+		if filter.From, err = time.Parse(time.RFC3339, v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": service.ErrInvalidAuditFilter.Error()})
+			return
+		}
+	}
+	if v := c.Query("to"); v != "" {
+		if filter.To, err = time.Parse(time.RFC3339, v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": service.ErrInvalidAuditFilter.Error()})
+			return
+		}
+	}
+
+	var offset int64
+	if v := c.Query("offset"); v != "" {
+		if offset, err = strconv.ParseInt(v, 10, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": service.ErrInvalidPaging.Error()})
+			return
+		}
+	}
+	limit := service.DefaultAuditEventsLimit
+	if v := c.Query("limit"); v != "" {
+		if limit, err = strconv.ParseInt(v, 10, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": service.ErrInvalidPaging.Error()})
+			return
+		}
+	}
+
+	events, err := handler.service.Find(handler.ctx, filter, offset, limit)
+	if err != nil {
+		_ = c.Error(err)
+		if errors.Is(err, service.ErrInvalidPaging) || errors.Is(err, service.ErrInvalidAuditFilter) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	data := handler.presenter.MakeList(events)
+	c.JSON(http.StatusOK, gin.H{"data": data, "offset": offset, "limit": limit})
+}
+
+// Make ...
+func (handler *AuditHandler) Make() {
+	handler.MakeRoutes()
+}
+
+// MakeRoutes make audit routes
+func (handler *AuditHandler) MakeRoutes() {
+
+	handler.routerAuth.GET("/admin/audit", middleware.RequirePermission(model.PermissionAuditRead), handler.ListHandler)
+}
@@ -0,0 +1,103 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	auditMock "walk_backend/internal/app/api/handlers/audit/mock"
+	"walk_backend/internal/app/api/middleware"
+	"walk_backend/internal/app/api/presenter"
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditHandler(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	admin, err := model.NewUserModel("admin", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+	assert.Nil(t, err)
+	admin.Roles = []model.Role{model.RoleAdmin}
+	editor, err := model.NewUserModel("editor", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+	assert.Nil(t, err)
+	editor.Roles = []model.Role{model.RoleEditor}
+
+	current := admin
+	router := gin.Default()
+	apiV1auth := router.Group("/api/v1", func(c *gin.Context) {
+		c.Set(middleware.ContextUserKey, current)
+	})
+
+	mockService := auditMock.NewMockServiceInterface(controller)
+
+	mh := NewHandler(context.Background(), apiV1auth, mockService, presenter.NewAuditEventPresenter())
+	mh.MakeRoutes()
+
+	serve := func(query string) *httptest.ResponseRecorder {
+		request, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/audit"+query, nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	t.Run("List", func(t *testing.T) {
+
+		id, err := model.NewID()
+		assert.Nil(t, err)
+		mockService.
+			EXPECT().
+			Find(context.Background(), gomock.Any(), int64(0), service.DefaultAuditEventsLimit).
+			DoAndReturn(func(_ context.Context, filter *model.AuditEventFilter, _ int64, _ int64) ([]*model.AuditEvent, error) {
+				assert.Equal(t, model.AuditEventLogin, filter.Type)
+				assert.Equal(t, model.AuditOutcomeFailure, filter.Outcome)
+				assert.Equal(t, "user", filter.Actor)
+				assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), filter.From.UTC())
+				assert.True(t, filter.To.IsZero())
+				return []*model.AuditEvent{{
+					ID:      id,
+					Type:    model.AuditEventLogin,
+					Outcome: model.AuditOutcomeFailure,
+					Reason:  model.AuditReasonInvalidCredentials,
+					Actor:   "user",
+					IP:      "192.0.2.1",
+				}}, nil
+			})
+
+		var body struct {
+			Data  []*presenter.AuditEvent `json:"data"`
+			Limit int64                   `json:"limit"`
+		}
+		recorder := serve("?type=login&outcome=failure&actor=user&from=2026-10-01T00:00:00Z")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.Len(t, body.Data, 1)
+		assert.Equal(t, id.String(), body.Data[0].ID)
+		assert.Equal(t, "invalid_credentials", body.Data[0].Reason)
+		assert.Equal(t, service.DefaultAuditEventsLimit, body.Limit)
+	})
+
+	t.Run("Invalid_filter", func(t *testing.T) {
+
+		assert.Equal(t, http.StatusBadRequest, serve("?from=yesterday").Code)
+		assert.Equal(t, http.StatusBadRequest, serve("?limit=many").Code)
+
+		mockService.EXPECT().Find(context.Background(), gomock.Any(), int64(0), service.DefaultAuditEventsLimit).Return(nil, service.ErrInvalidAuditFilter)
+		assert.Equal(t, http.StatusBadRequest, serve("?type=unknown").Code)
+	})
+
+	t.Run("Access_denied", func(t *testing.T) {
+
+		current = editor
+		defer func() { current = admin }()
+		assert.Equal(t, http.StatusForbidden, serve("").Code)
+	})
+}
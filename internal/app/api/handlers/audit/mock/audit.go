@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/api/handlers/audit/audit.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	presenter "walk_backend/internal/app/api/presenter"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockServiceInterface is a mock of ServiceInterface interface.
+type MockServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceInterfaceMockRecorder
+}
+
+// MockServiceInterfaceMockRecorder is the mock recorder for MockServiceInterface.
+type MockServiceInterfaceMockRecorder struct {
+	mock *MockServiceInterface
+}
+
+// NewMockServiceInterface creates a new mock instance.
+func NewMockServiceInterface(ctrl *gomock.Controller) *MockServiceInterface {
+	mock := &MockServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceInterface) EXPECT() *MockServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockServiceInterface) Find(ctx context.Context, filter *model.AuditEventFilter, offset, limit int64) ([]*model.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, filter, offset, limit)
+	ret0, _ := ret[0].([]*model.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockServiceInterfaceMockRecorder) Find(ctx, filter, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockServiceInterface)(nil).Find), ctx, filter, offset, limit)
+}
+
+// MockPresenterInterface is a mock of PresenterInterface interface.
+type MockPresenterInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenterInterfaceMockRecorder
+}
+
+// MockPresenterInterfaceMockRecorder is the mock recorder for MockPresenterInterface.
+type MockPresenterInterfaceMockRecorder struct {
+	mock *MockPresenterInterface
+}
+
+// NewMockPresenterInterface creates a new mock instance.
+func NewMockPresenterInterface(ctrl *gomock.Controller) *MockPresenterInterface {
+	mock := &MockPresenterInterface{ctrl: ctrl}
+	mock.recorder = &MockPresenterInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresenterInterface) EXPECT() *MockPresenterInterfaceMockRecorder {
+	return m.recorder
+}
+
+// MakeList mocks base method.
+func (m *MockPresenterInterface) MakeList(mList []*model.AuditEvent) []*presenter.AuditEvent {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MakeList", mList)
+	ret0, _ := ret[0].([]*presenter.AuditEvent)
+	return ret0
+}
+
+// MakeList indicates an expected call of MakeList.
+func (mr *MockPresenterInterfaceMockRecorder) MakeList(mList interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeList", reflect.TypeOf((*MockPresenterInterface)(nil).MakeList), mList)
+}
@@ -3,7 +3,9 @@ package auth
 import (
 	"errors"
 	"net/http"
+	"time"
 
+	"walk_backend/internal/app/api/middleware"
 	"walk_backend/internal/app/api/presenter"
 	"walk_backend/internal/app/dto"
 	"walk_backend/internal/app/model"
@@ -16,14 +18,43 @@ import (
 
 // ServiceInterface ...
 type ServiceInterface interface {
-	Registration(ctx context.Context, dto *dto.AuthLogin) (*model.User, error)
+	Registration(ctx context.Context, dto *dto.AuthRegistration) (*model.User, error)
 	Login(ctx context.Context, dto *dto.AuthLogin) (*model.User, error)
-	GenerateToken() (string, error)
+}
+
+// SessionTokenServiceInterface ...
+type SessionTokenServiceInterface interface {
+	Issue(ctx context.Context, username string, device model.Device) (string, *model.SessionToken, error)
+	Rotate(ctx context.Context, token string, device model.Device) (string, *model.SessionToken, error)
+	Revoke(ctx context.Context, token string) error
+}
+
+// LoginThrottleServiceInterface ...
+type LoginThrottleServiceInterface interface {
+	Check(ctx context.Context, username string, ip string) error
+	Fail(ctx context.Context, username string, ip string) error
+	Succeed(ctx context.Context, username string, ip string) error
+}
+
+// TokenServiceInterface ...
+type TokenServiceInterface interface {
+	Issue(ctx context.Context, username string) (*model.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (*model.TokenPair, error)
+	Revoke(ctx context.Context, token string) error
+}
+
+// TwoFactorServiceInterface ...
+type TwoFactorServiceInterface interface {
+	Challenge(ctx context.Context, user *model.User, tokens bool) (string, *model.TwoFactorChallenge, error)
+	FindChallenge(ctx context.Context, challenge string) (*model.TwoFactorChallenge, error)
+	Verify(ctx context.Context, challenge string, code string) (*model.User, error)
 }
 
 // TokenPresenterInterface ...
 type TokenPresenterInterface interface {
-	Make(token string) *presenter.Token
+	Make(token string, expires time.Time) *presenter.Token
+	MakeChallenge(challenge string, expires time.Time) *presenter.Token
+	MakePair(pair *model.TokenPair) *presenter.Token
 }
 
 // AuthHandler auth handler
@@ -31,6 +62,10 @@ type AuthHandler struct {
 	ctx       context.Context
 	router    *gin.RouterGroup
 	service   ServiceInterface
+	throttle  LoginThrottleServiceInterface
+	sessions  SessionTokenServiceInterface
+	tokens    TokenServiceInterface
+	twoFactor TwoFactorServiceInterface
 	presenter TokenPresenterInterface
 }
 
@@ -39,12 +74,20 @@ func NewHandler(
 	ctx context.Context,
 	router *gin.RouterGroup,
 	service ServiceInterface,
+	throttle LoginThrottleServiceInterface,
+	sessions SessionTokenServiceInterface,
+	tokens TokenServiceInterface,
+	twoFactor TwoFactorServiceInterface,
 	presenter TokenPresenterInterface,
 ) *AuthHandler {
 	return &AuthHandler{
 		ctx:       ctx,
 		router:    router,
 		service:   service,
+		throttle:  throttle,
+		sessions:  sessions,
+		tokens:    tokens,
+		twoFactor: twoFactor,
 		presenter: presenter,
 	}
 }
@@ -52,7 +95,7 @@ func NewHandler(
 // SignUpHandler registration
 //
 // swagger:operation POST /auth/registration auth signUp
-// Registration with username and password
+// Registration with username, password and email, the account is unverified until the emailed link is opened
 // ---
 // produces:
 // - application/json
@@ -62,43 +105,77 @@ func NewHandler(
 //	  description: Successful operation
 //	'400':
 //	  description: Invalid input
+//	'429':
+//	  description: Too many failed attempts, see Retry-After
 //	'500':
 //	  description: Invalid credentials
 func (handler *AuthHandler) SignUpHandler(c *gin.Context) {
 
-	dto := dto.NewAuthLoginDTO()
+	dto := dto.NewAuthRegistrationDTO()
 	if err := c.ShouldBindJSON(&dto); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	// registration is throttled per IP only, a username is not an account yet
+	if middleware.AbortThrottled(c, handler.throttle.Check(handler.ctx, "", c.ClientIP())) {
+		audit(c, model.AuditEventRegistration, model.AuditOutcomeFailure, model.AuditMethodPassword, dto.Username, model.AuditReasonThrottled)
+		return
+	}
+
 	_, err := handler.service.Registration(handler.ctx, dto)
 	if err != nil {
 		_ = c.Error(err)
 		if errors.Is(err, service.ErrInvalidUsernameOrPassword) {
+			if err := handler.throttle.Fail(handler.ctx, "", c.ClientIP()); err != nil {
+				_ = c.Error(err)
+			}
+			audit(c, model.AuditEventRegistration, model.AuditOutcomeFailure, model.AuditMethodPassword, dto.Username, model.AuditReasonInvalidInput)
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		var validationErr *model.ValidationError
+		if errors.As(err, &validationErr) {
+			audit(c, model.AuditEventRegistration, model.AuditOutcomeFailure, model.AuditMethodPassword, dto.Username, model.AuditReasonInvalidInput)
+			c.JSON(http.StatusBadRequest, gin.H{"error": model.ErrInvalidModel.Error(), "fields": validationErr.Fields})
+			return
+		}
 		c.AbortWithStatusJSON(http.StatusInternalServerError, "Auth service registration error")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "User signed up"})
+	audit(c, model.AuditEventRegistration, model.AuditOutcomeSuccess, model.AuditMethodPassword, dto.Username, "")
+	c.JSON(http.StatusOK, gin.H{"message": "User signed up, check your email to verify it"})
 }
 
 // SignInHandler login
 //
 // swagger:operation POST /auth/login auth signIn
-// Login with username and password
+// Login with username and password, tokens=true issues bearer access and refresh tokens instead of a session.
+// Users with two-factor authentication get a challenge to complete at /auth/login/2fa
 // ---
 // produces:
 // - application/json
+// parameters:
+//   - name: tokens
+//     in: query
+//     description: issue bearer tokens
+//     type: boolean
+//
 // responses:
 //
 //	'200':
 //	  description: Successful operation
+//	'202':
+//	  description: Two-factor code required
+//	'400':
+//	  description: Bearer tokens are disabled
 //	'401':
 //	  description: Invalid credentials
+//	'403':
+//	  description: Email not verified
+//	'429':
+//	  description: Too many failed attempts, see Retry-After
 //	'500':
 //	  description: Status Internal Server
 func (handler *AuthHandler) SignInHandler(c *gin.Context) {
@@ -109,18 +186,136 @@ func (handler *AuthHandler) SignInHandler(c *gin.Context) {
 		return
 	}
 
+	if middleware.AbortThrottled(c, handler.throttle.Check(handler.ctx, dto.Username, c.ClientIP())) {
+		audit(c, model.AuditEventLogin, model.AuditOutcomeFailure, model.AuditMethodPassword, dto.Username, model.AuditReasonThrottled)
+		return
+	}
+
 	user, err := handler.service.Login(handler.ctx, dto)
 	if err != nil {
 		_ = c.Error(err)
 		if errors.Is(err, service.ErrInvalidUsernameOrPassword) {
+			if err := handler.throttle.Fail(handler.ctx, dto.Username, c.ClientIP()); err != nil {
+				_ = c.Error(err)
+			}
+			audit(c, model.AuditEventLogin, model.AuditOutcomeFailure, model.AuditMethodPassword, dto.Username, model.AuditReasonInvalidCredentials)
 			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			return
+		} else if errors.Is(err, service.ErrEmailNotVerified) {
+			audit(c, model.AuditEventLogin, model.AuditOutcomeFailure, model.AuditMethodPassword, dto.Username, model.AuditReasonEmailNotVerified)
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
 		}
 		c.AbortWithStatusJSON(http.StatusInternalServerError, "Auth service login error")
 		return
 	}
+	// the throttle is reset by the second factor, a known password does not reset the code attempts
+	if user.IsTwoFactorEnabled() {
+		challenge, m, err := handler.twoFactor.Challenge(handler.ctx, user, c.Query("tokens") == "true")
+		if err != nil {
+			_ = c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error create two-factor challenge"})
+			return
+		}
+		data := handler.presenter.MakeChallenge(challenge, m.ExpiresAt)
+		c.JSON(http.StatusAccepted, gin.H{"message": "Two-factor code required", "data": data})
+		return
+	}
+	if err := handler.throttle.Succeed(handler.ctx, dto.Username, c.ClientIP()); err != nil {
+		_ = c.Error(err)
+	}
 
-	sessionTokenNew, err := handler.service.GenerateToken()
+	handler.signIn(c, user, c.Query("tokens") == "true", model.AuditMethodPassword)
+}
+
+// TwoFactorHandler second step of the login
+//
+// swagger:operation POST /auth/login/2fa auth signInTwoFactor
+// Complete a login of a user with two-factor authentication, with the challenge of the login
+// and a TOTP code or an unused recovery code
+// ---
+// produces:
+// - application/json
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'400':
+//	  description: Invalid input
+//	'401':
+//	  description: Invalid code, or invalid or expired challenge
+//	'429':
+//	  description: Too many failed attempts, see Retry-After
+func (handler *AuthHandler) TwoFactorHandler(c *gin.Context) {
+
+	dto := dto.NewAuthTwoFactorDTO()
+	if err := c.ShouldBindJSON(dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	challenge, err := handler.twoFactor.FindChallenge(handler.ctx, dto.Challenge)
+	if err != nil {
+		_ = c.Error(err)
+		if errors.Is(err, service.ErrInvalidTwoFactorChallenge) {
+			audit(c, model.AuditEventLogin, model.AuditOutcomeFailure, model.AuditMethodTwoFactor, "", model.AuditReasonInvalidToken)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error verify two-factor code"})
+		return
+	}
+
+	if middleware.AbortThrottled(c, handler.throttle.Check(handler.ctx, challenge.Username, c.ClientIP())) {
+		audit(c, model.AuditEventLogin, model.AuditOutcomeFailure, model.AuditMethodTwoFactor, challenge.Username, model.AuditReasonThrottled)
+		return
+	}
+
+	user, err := handler.twoFactor.Verify(handler.ctx, dto.Challenge, dto.Code)
+	if err != nil {
+		_ = c.Error(err)
+		if errors.Is(err, service.ErrInvalidTwoFactorCode) {
+			if err := handler.throttle.Fail(handler.ctx, challenge.Username, c.ClientIP()); err != nil {
+				_ = c.Error(err)
+			}
+			audit(c, model.AuditEventLogin, model.AuditOutcomeFailure, model.AuditMethodTwoFactor, challenge.Username, model.AuditReasonInvalidTwoFactorCode)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		} else if errors.Is(err, service.ErrInvalidTwoFactorChallenge) {
+			audit(c, model.AuditEventLogin, model.AuditOutcomeFailure, model.AuditMethodTwoFactor, challenge.Username, model.AuditReasonInvalidToken)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error verify two-factor code"})
+		return
+	}
+	if err := handler.throttle.Succeed(handler.ctx, challenge.Username, c.ClientIP()); err != nil {
+		_ = c.Error(err)
+	}
+
+	handler.signIn(c, user, challenge.Tokens, model.AuditMethodTwoFactor)
+}
+
+// signIn issue bearer tokens or a session of the logged in user, method is the login method to audit
+func (handler *AuthHandler) signIn(c *gin.Context, user *model.User, tokens bool, method model.AuditMethod) {
+
+	if tokens {
+		pair, err := handler.tokens.Issue(handler.ctx, user.Username)
+		if err != nil {
+			_ = c.Error(err)
+			if errors.Is(err, service.ErrTokensDisabled) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error issue tokens"})
+			return
+		}
+		audit(c, model.AuditEventLogin, model.AuditOutcomeSuccess, method, user.Username, "")
+		c.JSON(http.StatusOK, gin.H{"data": handler.presenter.MakePair(pair)})
+		return
+	}
+
+	sessionTokenNew, sessionTokenModel, err := handler.sessions.Issue(handler.ctx, user.Username, device(c))
 	if err != nil {
 		_ = c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generate token"})
@@ -135,14 +330,16 @@ func (handler *AuthHandler) SignInHandler(c *gin.Context) {
 		return
 	}
 
-	data := handler.presenter.Make(sessionTokenNew)
+	audit(c, model.AuditEventLogin, model.AuditOutcomeSuccess, method, user.Username, "")
+	data := handler.presenter.Make(sessionTokenNew, sessionTokenModel.ExpiresAt)
 	c.JSON(http.StatusOK, gin.H{"data": data})
 }
 
 // RefreshHandler refresh token
 //
 // swagger:operation POST /auth/refresh-tokens auth refresh
-// Refresh token
+// Rotate session token, or exchange the refreshToken of the body for a new bearer token pair.
+// Refreshing an already rotated session token signs out every session of its login.
 // ---
 // produces:
 // - application/json
@@ -150,20 +347,41 @@ func (handler *AuthHandler) SignInHandler(c *gin.Context) {
 //
 //	'200':
 //	  description: Successful operation
+//	'400':
+//	  description: Invalid input
 //	'401':
 //	  description: Invalid credentials
 func (handler *AuthHandler) RefreshHandler(c *gin.Context) {
 
+	if c.Request.ContentLength != 0 {
+		handler.refreshTokens(c)
+		return
+	}
+
 	session := sessions.Default(c)
-	sessionToken := session.Get("token")
-	if sessionToken == nil {
+	sessionToken, ok := session.Get("token").(string)
+	if !ok {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid session cookie"})
 		return
 	}
+	username, _ := session.Get("username").(string)
 
-	sessionTokenNew, err := handler.service.GenerateToken()
+	sessionTokenNew, sessionTokenModel, err := handler.sessions.Rotate(handler.ctx, sessionToken, device(c))
 	if err != nil {
 		_ = c.Error(err)
+		if errors.Is(err, service.ErrInvalidSessionToken) || errors.Is(err, service.ErrSessionTokenReused) {
+			reason := model.AuditReasonInvalidToken
+			if errors.Is(err, service.ErrSessionTokenReused) {
+				reason = model.AuditReasonTokenReused
+			}
+			audit(c, model.AuditEventTokenRefresh, model.AuditOutcomeFailure, model.AuditMethodSession, username, reason)
+			session.Clear()
+			if err := session.Save(); err != nil {
+				_ = c.Error(err)
+			}
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid session cookie"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generate token"})
 		return
 	}
@@ -174,29 +392,115 @@ func (handler *AuthHandler) RefreshHandler(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "New session issued"})
+	audit(c, model.AuditEventTokenRefresh, model.AuditOutcomeSuccess, model.AuditMethodSession, sessionTokenModel.Username, "")
+	data := handler.presenter.Make(sessionTokenNew, sessionTokenModel.ExpiresAt)
+	c.JSON(http.StatusOK, gin.H{"message": "New session issued", "data": data})
+}
+
+func (handler *AuthHandler) refreshTokens(c *gin.Context) {
+
+	dto := dto.NewAuthRefreshDTO()
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	pair, err := handler.tokens.Refresh(handler.ctx, dto.RefreshToken)
+	if err != nil {
+		_ = c.Error(err)
+		if errors.Is(err, service.ErrInvalidToken) ||
+			errors.Is(err, service.ErrTokenRevoked) ||
+			errors.Is(err, service.ErrTokensDisabled) {
+			audit(c, model.AuditEventTokenRefresh, model.AuditOutcomeFailure, model.AuditMethodToken, "", model.AuditReasonInvalidToken)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error refresh tokens"})
+		return
+	}
+
+	audit(c, model.AuditEventTokenRefresh, model.AuditOutcomeSuccess, model.AuditMethodToken, pair.Username, "")
+	c.JSON(http.StatusOK, gin.H{"data": handler.presenter.MakePair(pair)})
 }
 
 // SignOutHandler logout
 //
 // swagger:operation POST /auth/logout auth signOut
-// Signing out
+// Signing out, revokes the bearer access token of the header and the refreshToken of the body
 // ---
 // responses:
 //
 //	'200':
 //	  description: Successful operation
 func (handler *AuthHandler) SignOutHandler(c *gin.Context) {
+
+	revoke := make([]string, 0, 2)
+	if token := middleware.BearerToken(c); token != "" {
+		revoke = append(revoke, token)
+	}
+	if c.Request.ContentLength != 0 {
+		dto := dto.NewAuthRefreshDTO()
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		revoke = append(revoke, dto.RefreshToken)
+	}
+	for _, token := range revoke {
+		// already invalid tokens need no revoking
+		err := handler.tokens.Revoke(handler.ctx, token)
+		if err != nil && !errors.Is(err, service.ErrInvalidToken) && !errors.Is(err, service.ErrTokensDisabled) {
+			_ = c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error revoke token"})
+			return
+		}
+	}
+
+	method := model.AuditMethod("")
+	if len(revoke) > 0 {
+		method = model.AuditMethodToken
+	}
 	session := sessions.Default(c)
+	username, _ := session.Get("username").(string)
+	if sessionToken, ok := session.Get("token").(string); ok {
+		if err := handler.sessions.Revoke(handler.ctx, sessionToken); err != nil {
+			_ = c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error revoke session"})
+			return
+		}
+		method = model.AuditMethodSession
+	}
 	session.Clear()
 	if err := session.Save(); err != nil {
 		_ = c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error session save"})
 		return
 	}
+	// a logout without credentials signs nobody out
+	if method != "" {
+		audit(c, model.AuditEventLogout, model.AuditOutcomeSuccess, method, username, "")
+	}
 	c.JSON(http.StatusOK, gin.H{"message": "Signed out..."})
 }
 
+// audit record a security event of the request
+func audit(c *gin.Context, eventType model.AuditEventType, outcome model.AuditOutcome, method model.AuditMethod, actor string, reason model.AuditReason) {
+	middleware.RecordAudit(c, &model.AuditEvent{
+		Type:    eventType,
+		Outcome: outcome,
+		Reason:  reason,
+		Method:  method,
+		Actor:   actor,
+	})
+}
+
+func device(c *gin.Context) model.Device {
+	return model.Device{
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	}
+}
+
 // Make ...
 func (handler *AuthHandler) Make() {
 	handler.MakeRoutes()
@@ -207,6 +511,7 @@ func (handler *AuthHandler) MakeRoutes() {
 
 	handler.router.POST("/auth/registration", handler.SignUpHandler)
 	handler.router.POST("/auth/login", handler.SignInHandler)
+	handler.router.POST("/auth/login/2fa", handler.TwoFactorHandler)
 	handler.router.POST("/auth/refresh-tokens", handler.RefreshHandler)
 	handler.router.POST("/auth/logout", handler.SignOutHandler)
 }
@@ -6,6 +6,7 @@ package mock
 
 import (
 	reflect "reflect"
+	time "time"
 	presenter "walk_backend/internal/app/api/presenter"
 	dto "walk_backend/internal/app/dto"
 	model "walk_backend/internal/app/model"
@@ -37,21 +38,6 @@ func (m *MockServiceInterface) EXPECT() *MockServiceInterfaceMockRecorder {
 	return m.recorder
 }
 
-// GenerateToken mocks base method.
-func (m *MockServiceInterface) GenerateToken() (string, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateToken")
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GenerateToken indicates an expected call of GenerateToken.
-func (mr *MockServiceInterfaceMockRecorder) GenerateToken() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockServiceInterface)(nil).GenerateToken))
-}
-
 // Login mocks base method.
 func (m *MockServiceInterface) Login(ctx context.Context, dto *dto.AuthLogin) (*model.User, error) {
 	m.ctrl.T.Helper()
@@ -68,7 +54,7 @@ func (mr *MockServiceInterfaceMockRecorder) Login(ctx, dto interface{}) *gomock.
 }
 
 // Registration mocks base method.
-func (m *MockServiceInterface) Registration(ctx context.Context, dto *dto.AuthLogin) (*model.User, error) {
+func (m *MockServiceInterface) Registration(ctx context.Context, dto *dto.AuthRegistration) (*model.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Registration", ctx, dto)
 	ret0, _ := ret[0].(*model.User)
@@ -82,6 +68,276 @@ func (mr *MockServiceInterfaceMockRecorder) Registration(ctx, dto interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Registration", reflect.TypeOf((*MockServiceInterface)(nil).Registration), ctx, dto)
 }
 
+// MockSessionTokenServiceInterface is a mock of SessionTokenServiceInterface interface.
+type MockSessionTokenServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionTokenServiceInterfaceMockRecorder
+}
+
+// MockSessionTokenServiceInterfaceMockRecorder is the mock recorder for MockSessionTokenServiceInterface.
+type MockSessionTokenServiceInterfaceMockRecorder struct {
+	mock *MockSessionTokenServiceInterface
+}
+
+// NewMockSessionTokenServiceInterface creates a new mock instance.
+func NewMockSessionTokenServiceInterface(ctrl *gomock.Controller) *MockSessionTokenServiceInterface {
+	mock := &MockSessionTokenServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockSessionTokenServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionTokenServiceInterface) EXPECT() *MockSessionTokenServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Issue mocks base method.
+func (m *MockSessionTokenServiceInterface) Issue(ctx context.Context, username string, device model.Device) (string, *model.SessionToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issue", ctx, username, device)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*model.SessionToken)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Issue indicates an expected call of Issue.
+func (mr *MockSessionTokenServiceInterfaceMockRecorder) Issue(ctx, username, device interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockSessionTokenServiceInterface)(nil).Issue), ctx, username, device)
+}
+
+// Revoke mocks base method.
+func (m *MockSessionTokenServiceInterface) Revoke(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockSessionTokenServiceInterfaceMockRecorder) Revoke(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockSessionTokenServiceInterface)(nil).Revoke), ctx, token)
+}
+
+// Rotate mocks base method.
+func (m *MockSessionTokenServiceInterface) Rotate(ctx context.Context, token string, device model.Device) (string, *model.SessionToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", ctx, token, device)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*model.SessionToken)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockSessionTokenServiceInterfaceMockRecorder) Rotate(ctx, token, device interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockSessionTokenServiceInterface)(nil).Rotate), ctx, token, device)
+}
+
+// MockLoginThrottleServiceInterface is a mock of LoginThrottleServiceInterface interface.
+type MockLoginThrottleServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginThrottleServiceInterfaceMockRecorder
+}
+
+// MockLoginThrottleServiceInterfaceMockRecorder is the mock recorder for MockLoginThrottleServiceInterface.
+type MockLoginThrottleServiceInterfaceMockRecorder struct {
+	mock *MockLoginThrottleServiceInterface
+}
+
+// NewMockLoginThrottleServiceInterface creates a new mock instance.
+func NewMockLoginThrottleServiceInterface(ctrl *gomock.Controller) *MockLoginThrottleServiceInterface {
+	mock := &MockLoginThrottleServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockLoginThrottleServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginThrottleServiceInterface) EXPECT() *MockLoginThrottleServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockLoginThrottleServiceInterface) Check(ctx context.Context, username, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, username, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockLoginThrottleServiceInterfaceMockRecorder) Check(ctx, username, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockLoginThrottleServiceInterface)(nil).Check), ctx, username, ip)
+}
+
+// Fail mocks base method.
+func (m *MockLoginThrottleServiceInterface) Fail(ctx context.Context, username, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", ctx, username, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockLoginThrottleServiceInterfaceMockRecorder) Fail(ctx, username, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockLoginThrottleServiceInterface)(nil).Fail), ctx, username, ip)
+}
+
+// Succeed mocks base method.
+func (m *MockLoginThrottleServiceInterface) Succeed(ctx context.Context, username, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Succeed", ctx, username, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Succeed indicates an expected call of Succeed.
+func (mr *MockLoginThrottleServiceInterfaceMockRecorder) Succeed(ctx, username, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Succeed", reflect.TypeOf((*MockLoginThrottleServiceInterface)(nil).Succeed), ctx, username, ip)
+}
+
+// MockTokenServiceInterface is a mock of TokenServiceInterface interface.
+type MockTokenServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenServiceInterfaceMockRecorder
+}
+
+// MockTokenServiceInterfaceMockRecorder is the mock recorder for MockTokenServiceInterface.
+type MockTokenServiceInterfaceMockRecorder struct {
+	mock *MockTokenServiceInterface
+}
+
+// NewMockTokenServiceInterface creates a new mock instance.
+func NewMockTokenServiceInterface(ctrl *gomock.Controller) *MockTokenServiceInterface {
+	mock := &MockTokenServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockTokenServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenServiceInterface) EXPECT() *MockTokenServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Issue mocks base method.
+func (m *MockTokenServiceInterface) Issue(ctx context.Context, username string) (*model.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issue", ctx, username)
+	ret0, _ := ret[0].(*model.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Issue indicates an expected call of Issue.
+func (mr *MockTokenServiceInterfaceMockRecorder) Issue(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockTokenServiceInterface)(nil).Issue), ctx, username)
+}
+
+// Refresh mocks base method.
+func (m *MockTokenServiceInterface) Refresh(ctx context.Context, refreshToken string) (*model.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, refreshToken)
+	ret0, _ := ret[0].(*model.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockTokenServiceInterfaceMockRecorder) Refresh(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockTokenServiceInterface)(nil).Refresh), ctx, refreshToken)
+}
+
+// Revoke mocks base method.
+func (m *MockTokenServiceInterface) Revoke(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockTokenServiceInterfaceMockRecorder) Revoke(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockTokenServiceInterface)(nil).Revoke), ctx, token)
+}
+
+// MockTwoFactorServiceInterface is a mock of TwoFactorServiceInterface interface.
+type MockTwoFactorServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorServiceInterfaceMockRecorder
+}
+
+// MockTwoFactorServiceInterfaceMockRecorder is the mock recorder for MockTwoFactorServiceInterface.
+type MockTwoFactorServiceInterfaceMockRecorder struct {
+	mock *MockTwoFactorServiceInterface
+}
+
+// NewMockTwoFactorServiceInterface creates a new mock instance.
+func NewMockTwoFactorServiceInterface(ctrl *gomock.Controller) *MockTwoFactorServiceInterface {
+	mock := &MockTwoFactorServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorServiceInterface) EXPECT() *MockTwoFactorServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Challenge mocks base method.
+func (m *MockTwoFactorServiceInterface) Challenge(ctx context.Context, user *model.User, tokens bool) (string, *model.TwoFactorChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Challenge", ctx, user, tokens)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*model.TwoFactorChallenge)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Challenge indicates an expected call of Challenge.
+func (mr *MockTwoFactorServiceInterfaceMockRecorder) Challenge(ctx, user, tokens interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Challenge", reflect.TypeOf((*MockTwoFactorServiceInterface)(nil).Challenge), ctx, user, tokens)
+}
+
+// FindChallenge mocks base method.
+func (m *MockTwoFactorServiceInterface) FindChallenge(ctx context.Context, challenge string) (*model.TwoFactorChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindChallenge", ctx, challenge)
+	ret0, _ := ret[0].(*model.TwoFactorChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindChallenge indicates an expected call of FindChallenge.
+func (mr *MockTwoFactorServiceInterfaceMockRecorder) FindChallenge(ctx, challenge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindChallenge", reflect.TypeOf((*MockTwoFactorServiceInterface)(nil).FindChallenge), ctx, challenge)
+}
+
+// Verify mocks base method.
+func (m *MockTwoFactorServiceInterface) Verify(ctx context.Context, challenge, code string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, challenge, code)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockTwoFactorServiceInterfaceMockRecorder) Verify(ctx, challenge, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockTwoFactorServiceInterface)(nil).Verify), ctx, challenge, code)
+}
+
 // MockTokenPresenterInterface is a mock of TokenPresenterInterface interface.
 type MockTokenPresenterInterface struct {
 	ctrl     *gomock.Controller
@@ -106,15 +362,43 @@ func (m *MockTokenPresenterInterface) EXPECT() *MockTokenPresenterInterfaceMockR
 }
 
 // Make mocks base method.
-func (m *MockTokenPresenterInterface) Make(token string) *presenter.Token {
+func (m *MockTokenPresenterInterface) Make(token string, expires time.Time) *presenter.Token {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Make", token)
+	ret := m.ctrl.Call(m, "Make", token, expires)
 	ret0, _ := ret[0].(*presenter.Token)
 	return ret0
 }
 
 // Make indicates an expected call of Make.
-func (mr *MockTokenPresenterInterfaceMockRecorder) Make(token interface{}) *gomock.Call {
+func (mr *MockTokenPresenterInterfaceMockRecorder) Make(token, expires interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Make", reflect.TypeOf((*MockTokenPresenterInterface)(nil).Make), token, expires)
+}
+
+// MakeChallenge mocks base method.
+func (m *MockTokenPresenterInterface) MakeChallenge(challenge string, expires time.Time) *presenter.Token {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MakeChallenge", challenge, expires)
+	ret0, _ := ret[0].(*presenter.Token)
+	return ret0
+}
+
+// MakeChallenge indicates an expected call of MakeChallenge.
+func (mr *MockTokenPresenterInterfaceMockRecorder) MakeChallenge(challenge, expires interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeChallenge", reflect.TypeOf((*MockTokenPresenterInterface)(nil).MakeChallenge), challenge, expires)
+}
+
+// MakePair mocks base method.
+func (m *MockTokenPresenterInterface) MakePair(pair *model.TokenPair) *presenter.Token {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MakePair", pair)
+	ret0, _ := ret[0].(*presenter.Token)
+	return ret0
+}
+
+// MakePair indicates an expected call of MakePair.
+func (mr *MockTokenPresenterInterfaceMockRecorder) MakePair(pair interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Make", reflect.TypeOf((*MockTokenPresenterInterface)(nil).Make), token)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakePair", reflect.TypeOf((*MockTokenPresenterInterface)(nil).MakePair), pair)
 }
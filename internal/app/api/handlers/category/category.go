@@ -3,10 +3,13 @@ package category
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
+	"walk_backend/internal/app/api/middleware"
 	"walk_backend/internal/app/api/presenter"
 	"walk_backend/internal/app/dto"
 	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
 	"walk_backend/internal/pkg/util"
 
 	"github.com/gin-gonic/gin"
@@ -16,15 +19,21 @@ import (
 // ServiceInterface ...
 type ServiceInterface interface {
 	ListCategories(ctx context.Context) (model.CategoryList, error)
+	PlaceCounts(ctx context.Context) (model.CategoryCounts, error)
 	Create(ctx context.Context, dto *dto.Category) (model.ID, error)
 	Update(ctx context.Context, dto *dto.Category) error
-	Delete(ctx context.Context, id model.ID) error
+	Delete(ctx context.Context, id model.ID, reassignTo *model.ID) error
+	Reorder(ctx context.Context, ids []model.ID) error
 	Find(ctx context.Context, id model.ID) (*model.Category, error)
+	FindBySlug(ctx context.Context, slug string) (*model.Category, error)
+	CategoryTree(ctx context.Context) (model.CategoryList, error)
 }
 
 type PresenterInterface interface {
 	Make(m *model.Category) *presenter.Category
 	MakeList(mList model.CategoryList) []*presenter.Category
+	MakeListWithCounts(mList model.CategoryList, counts model.CategoryCounts) []*presenter.Category
+	MakeTree(mList model.CategoryList) []*presenter.Category
 }
 
 // CategoriesHandler categories handler struct
@@ -60,12 +69,31 @@ func NewHandler(
 // ---
 // produces:
 // - application/json
+// parameters:
+//   - name: with_counts
+//     in: query
+//     description: add number of published places of every category
+//     required: false
+//     type: boolean
+//
 // responses:
 //
 //	'200':
 //	  description: Successful operation
+//	'400':
+//	  description: Invalid input
 func (handler *CategoriesHandler) ListCategoriesHandler(c *gin.Context) {
 
+	withCounts := false
+	if v := c.Query("with_counts"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid with_counts"})
+			return
+		}
+		withCounts = b
+	}
+
 	categoryList, err := handler.service.ListCategories(handler.ctx)
 	if err != nil {
 		_ = c.Error(err)
@@ -73,7 +101,20 @@ func (handler *CategoriesHandler) ListCategoriesHandler(c *gin.Context) {
 		return
 	}
 
-	data := handler.presenter.MakeList(categoryList)
+	if !withCounts {
+		data := handler.presenter.MakeList(categoryList)
+		c.JSON(http.StatusOK, gin.H{"data": data})
+		return
+	}
+
+	counts, err := handler.service.PlaceCounts(handler.ctx)
+	if err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	data := handler.presenter.MakeListWithCounts(categoryList, counts)
 	c.JSON(http.StatusOK, gin.H{"data": data})
 }
 
@@ -90,6 +131,8 @@ func (handler *CategoriesHandler) ListCategoriesHandler(c *gin.Context) {
 //	  description: Successful operation
 //	'400':
 //	  description: Invalid input
+//	'409':
+//	  description: Slug is already taken
 func (handler *CategoriesHandler) NewCategoryHandler(c *gin.Context) {
 
 	dto := dto.NewCategoryDTO()
@@ -102,6 +145,13 @@ func (handler *CategoriesHandler) NewCategoryHandler(c *gin.Context) {
 	id, err := handler.service.Create(handler.ctx, dto)
 	if err != nil {
 		_ = c.Error(err)
+		if isCategoryTreeError(err) || errors.Is(err, model.ErrInvalidModel) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		} else if errors.Is(err, service.ErrCategorySlugTaken) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -132,6 +182,8 @@ func (handler *CategoriesHandler) NewCategoryHandler(c *gin.Context) {
 //	  description: Invalid input
 //	'404':
 //	  description: Invalid category ID
+//	'409':
+//	  description: Slug is already taken
 func (handler *CategoriesHandler) UpdateCategryHandler(c *gin.Context) {
 
 	dto := dto.NewCategoryDTO()
@@ -146,9 +198,12 @@ func (handler *CategoriesHandler) UpdateCategryHandler(c *gin.Context) {
 		if errors.Is(err, model.ErrModelNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
-		} else if errors.Is(err, model.ErrModelUpdate) {
+		} else if errors.Is(err, model.ErrModelUpdate) || errors.Is(err, model.ErrInvalidModel) || isCategoryTreeError(err) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
+		} else if errors.Is(err, service.ErrCategorySlugTaken) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -170,6 +225,11 @@ func (handler *CategoriesHandler) UpdateCategryHandler(c *gin.Context) {
 //     description: ID of the category
 //     required: true
 //     type: string
+//   - name: reassign_to
+//     in: query
+//     description: ID of the category to move the places to
+//     required: false
+//     type: string
 //
 // responses:
 //
@@ -179,6 +239,8 @@ func (handler *CategoriesHandler) UpdateCategryHandler(c *gin.Context) {
 //	  description: Invalid input
 //	'404':
 //	  description: Invalid category ID
+//	'409':
+//	  description: Category has subcategories or is used by places
 func (handler *CategoriesHandler) DeleteCategoryHandler(c *gin.Context) {
 	id := c.Param("id")
 	categoryID, err := model.StringToID(id)
@@ -187,11 +249,31 @@ func (handler *CategoriesHandler) DeleteCategoryHandler(c *gin.Context) {
 		return
 	}
 
-	if err := handler.service.Delete(handler.ctx, categoryID); err != nil {
+	var reassignTo *model.ID
+	if v := c.Query("reassign_to"); v != "" {
+		reassignID, err := model.StringToID(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		reassignTo = &reassignID
+	}
+
+	if err := handler.service.Delete(handler.ctx, categoryID, reassignTo); err != nil {
 		_ = c.Error(err)
+		var inUseErr *service.CategoryInUseError
 		if errors.Is(err, model.ErrModelNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
+		} else if errors.As(err, &inUseErr) {
+			c.JSON(http.StatusConflict, gin.H{"error": service.ErrCategoryInUse.Error(), "places": inUseErr.Places})
+			return
+		} else if errors.Is(err, service.ErrCategoryHasChildren) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		} else if errors.Is(err, service.ErrInvalidReassignCategory) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -246,6 +328,116 @@ func (handler *CategoriesHandler) GetOneCategoryHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": data})
 }
 
+// GetCategoryBySlugHandler ...
+//
+// swagger:operation GET /categories/by-slug/{slug} categories findCategoryBySlug
+// Get one category by slug
+// ---
+// produces:
+// - application/json
+// parameters:
+//   - name: slug
+//     in: path
+//     description: category slug
+//     required: true
+//     type: string
+//
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'404':
+//	  description: Invalid category slug
+func (handler *CategoriesHandler) GetCategoryBySlugHandler(c *gin.Context) {
+
+	category, err := handler.service.FindBySlug(handler.ctx, c.Param("slug"))
+	if err != nil {
+		_ = c.Error(err)
+		if errors.Is(err, model.ErrModelNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	data := handler.presenter.Make(category)
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// ReorderCategoriesHandler ...
+//
+// swagger:operation PUT /categories/order categories reorderCategories
+// Set order of all categories at once
+// ---
+// produces:
+// - application/json
+// responses:
+//
+//	'204':
+//	  description: Successful operation
+//	'400':
+//	  description: Invalid input
+//	'409':
+//	  description: IDs do not match the stored categories
+func (handler *CategoriesHandler) ReorderCategoriesHandler(c *gin.Context) {
+
+	dto := dto.NewCategoryOrderDTO()
+	if err := c.ShouldBindJSON(dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ids := make([]model.ID, 0, len(dto.IDs))
+	for _, v := range dto.IDs {
+		id, err := model.StringToID(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	if err := handler.service.Reorder(handler.ctx, ids); err != nil {
+		_ = c.Error(err)
+		if errors.Is(err, service.ErrInvalidCategoryOrder) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		} else if errors.Is(err, model.ErrModelSetMismatch) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CategoryTreeHandler ...
+//
+// swagger:operation GET /categories/tree categories categoryTree
+// Returns nested categories, siblings sorted by order
+// ---
+// produces:
+// - application/json
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+func (handler *CategoriesHandler) CategoryTreeHandler(c *gin.Context) {
+
+	categoryList, err := handler.service.CategoryTree(handler.ctx)
+	if err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	data := handler.presenter.MakeTree(categoryList)
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
 // Make ...
 func (handler *CategoriesHandler) Make() {
 	handler.MakeRoutes()
@@ -255,9 +447,20 @@ func (handler *CategoriesHandler) Make() {
 func (handler *CategoriesHandler) MakeRoutes() {
 
 	handler.router.GET("/categories", handler.ListCategoriesHandler)
+	handler.router.GET("/categories/tree", handler.CategoryTreeHandler)
+	handler.router.GET("/categories/by-slug/:slug", handler.GetCategoryBySlugHandler)
 	handler.router.GET("/categories/:id", handler.GetOneCategoryHandler)
 
-	handler.routerAuth.POST("/categories", handler.NewCategoryHandler)
-	handler.routerAuth.PUT("/categories/:id", handler.UpdateCategryHandler)
-	handler.routerAuth.DELETE("/categories/:id", handler.DeleteCategoryHandler)
+	write := middleware.RequireScope(model.APIKeyScopeCategoriesWrite)
+	manage := middleware.RequirePermission(model.PermissionCategoryManage)
+	handler.routerAuth.POST("/categories", write, manage, handler.NewCategoryHandler)
+	handler.routerAuth.PUT("/categories/order", write, manage, handler.ReorderCategoriesHandler)
+	handler.routerAuth.PUT("/categories/:id", write, manage, handler.UpdateCategryHandler)
+	handler.routerAuth.DELETE("/categories/:id", write, manage, handler.DeleteCategoryHandler)
+}
+
+func isCategoryTreeError(err error) bool {
+	return errors.Is(err, service.ErrCategoryParentNotFound) ||
+		errors.Is(err, service.ErrCategoryCycle) ||
+		errors.Is(err, service.ErrCategoryDepth)
 }
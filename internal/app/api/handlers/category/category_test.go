@@ -1,9 +1,114 @@
 package category
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	categoryMock "walk_backend/internal/app/api/handlers/category/mock"
+	"walk_backend/internal/app/api/presenter"
+	"walk_backend/internal/app/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestCategoryHandler_ListCategories(t *testing.T) {
 
 }
+
+func TestCategoryHandler_CategoryTree(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	router := gin.Default()
+	apiV1 := router.Group("/api/v1")
+
+	mockCategoryService := categoryMock.NewMockServiceInterface(controller)
+
+	mh := NewHandler(context.Background(), apiV1, apiV1, mockCategoryService, presenter.NewCategoryPresenter())
+	mh.MakeRoutes()
+
+	parksID, _ := model.NewID()
+	gardensID, _ := model.NewID()
+	museumsID, _ := model.NewID()
+	categories := model.CategoryList{
+		{ID: museumsID, Name: "Museums", Order: 2},
+		{ID: gardensID, Name: "Botanical gardens", Order: 1, ParentID: &parksID},
+		{ID: parksID, Name: "Parks", Order: 1},
+	}
+
+	mockCategoryService.
+		EXPECT().
+		CategoryTree(context.Background()).
+		Return(categories, nil).
+		Times(1)
+
+	request, _ := http.NewRequest(http.MethodGet, "/api/v1/categories/tree", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var body struct {
+		Data []*presenter.Category `json:"data"`
+	}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	assert.Len(t, body.Data, 2)
+	assert.Equal(t, "Parks", body.Data[0].Name)
+	assert.Len(t, body.Data[0].Children, 1)
+	assert.Equal(t, "Botanical gardens", body.Data[0].Children[0].Name)
+	assert.Equal(t, parksID.String(), body.Data[0].Children[0].ParentID)
+	assert.Equal(t, "Museums", body.Data[1].Name)
+}
+
+func TestCategoryHandler_GetCategoryBySlug(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	router := gin.Default()
+	apiV1 := router.Group("/api/v1")
+
+	mockCategoryService := categoryMock.NewMockServiceInterface(controller)
+
+	mh := NewHandler(context.Background(), apiV1, apiV1, mockCategoryService, presenter.NewCategoryPresenter())
+	mh.MakeRoutes()
+
+	parksID, _ := model.NewID()
+	mockCategoryService.
+		EXPECT().
+		FindBySlug(context.Background(), "parks").
+		Return(&model.Category{ID: parksID, Name: "Parks", Slug: "parks", Order: 1, Icon: "tree", Color: "#2e7d32"}, nil).
+		Times(1)
+	mockCategoryService.
+		EXPECT().
+		FindBySlug(context.Background(), "zoos").
+		Return(nil, model.ErrModelNotFound).
+		Times(1)
+
+	request, _ := http.NewRequest(http.MethodGet, "/api/v1/categories/by-slug/parks", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var body struct {
+		Data *presenter.Category `json:"data"`
+	}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	assert.Equal(t, parksID.String(), body.Data.ID)
+	assert.Equal(t, "parks", body.Data.Slug)
+	assert.Equal(t, "tree", body.Data.Icon)
+	assert.Equal(t, "#2e7d32", body.Data.Color)
+
+	request, _ = http.NewRequest(http.MethodGet, "/api/v1/categories/by-slug/zoos", nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
@@ -37,6 +37,21 @@ func (m *MockServiceInterface) EXPECT() *MockServiceInterfaceMockRecorder {
 	return m.recorder
 }
 
+// CategoryTree mocks base method.
+func (m *MockServiceInterface) CategoryTree(ctx context.Context) (model.CategoryList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CategoryTree", ctx)
+	ret0, _ := ret[0].(model.CategoryList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CategoryTree indicates an expected call of CategoryTree.
+func (mr *MockServiceInterfaceMockRecorder) CategoryTree(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CategoryTree", reflect.TypeOf((*MockServiceInterface)(nil).CategoryTree), ctx)
+}
+
 // Create mocks base method.
 func (m *MockServiceInterface) Create(ctx context.Context, dto *dto.Category) (model.ID, error) {
 	m.ctrl.T.Helper()
@@ -53,17 +68,17 @@ func (mr *MockServiceInterfaceMockRecorder) Create(ctx, dto interface{}) *gomock
 }
 
 // Delete mocks base method.
-func (m *MockServiceInterface) Delete(ctx context.Context, id model.ID) error {
+func (m *MockServiceInterface) Delete(ctx context.Context, id model.ID, reassignTo *model.ID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, reassignTo)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockServiceInterfaceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+func (mr *MockServiceInterfaceMockRecorder) Delete(ctx, id, reassignTo interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockServiceInterface)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockServiceInterface)(nil).Delete), ctx, id, reassignTo)
 }
 
 // Find mocks base method.
@@ -81,6 +96,21 @@ func (mr *MockServiceInterfaceMockRecorder) Find(ctx, id interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockServiceInterface)(nil).Find), ctx, id)
 }
 
+// FindBySlug mocks base method.
+func (m *MockServiceInterface) FindBySlug(ctx context.Context, slug string) (*model.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySlug", ctx, slug)
+	ret0, _ := ret[0].(*model.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySlug indicates an expected call of FindBySlug.
+func (mr *MockServiceInterfaceMockRecorder) FindBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySlug", reflect.TypeOf((*MockServiceInterface)(nil).FindBySlug), ctx, slug)
+}
+
 // ListCategories mocks base method.
 func (m *MockServiceInterface) ListCategories(ctx context.Context) (model.CategoryList, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +126,35 @@ func (mr *MockServiceInterfaceMockRecorder) ListCategories(ctx interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockServiceInterface)(nil).ListCategories), ctx)
 }
 
+// PlaceCounts mocks base method.
+func (m *MockServiceInterface) PlaceCounts(ctx context.Context) (model.CategoryCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceCounts", ctx)
+	ret0, _ := ret[0].(model.CategoryCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceCounts indicates an expected call of PlaceCounts.
+func (mr *MockServiceInterfaceMockRecorder) PlaceCounts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceCounts", reflect.TypeOf((*MockServiceInterface)(nil).PlaceCounts), ctx)
+}
+
+// Reorder mocks base method.
+func (m *MockServiceInterface) Reorder(ctx context.Context, ids []model.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockServiceInterfaceMockRecorder) Reorder(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockServiceInterface)(nil).Reorder), ctx, ids)
+}
+
 // Update mocks base method.
 func (m *MockServiceInterface) Update(ctx context.Context, dto *dto.Category) error {
 	m.ctrl.T.Helper()
@@ -160,3 +219,31 @@ func (mr *MockPresenterInterfaceMockRecorder) MakeList(mList interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeList", reflect.TypeOf((*MockPresenterInterface)(nil).MakeList), mList)
 }
+
+// MakeListWithCounts mocks base method.
+func (m *MockPresenterInterface) MakeListWithCounts(mList model.CategoryList, counts model.CategoryCounts) []*presenter.Category {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MakeListWithCounts", mList, counts)
+	ret0, _ := ret[0].([]*presenter.Category)
+	return ret0
+}
+
+// MakeListWithCounts indicates an expected call of MakeListWithCounts.
+func (mr *MockPresenterInterfaceMockRecorder) MakeListWithCounts(mList, counts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeListWithCounts", reflect.TypeOf((*MockPresenterInterface)(nil).MakeListWithCounts), mList, counts)
+}
+
+// MakeTree mocks base method.
+func (m *MockPresenterInterface) MakeTree(mList model.CategoryList) []*presenter.Category {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MakeTree", mList)
+	ret0, _ := ret[0].([]*presenter.Category)
+	return ret0
+}
+
+// MakeTree indicates an expected call of MakeTree.
+func (mr *MockPresenterInterfaceMockRecorder) MakeTree(mList interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeTree", reflect.TypeOf((*MockPresenterInterface)(nil).MakeTree), mList)
+}
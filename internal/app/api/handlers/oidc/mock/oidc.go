@@ -0,0 +1,199 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/api/handlers/oidc/oidc.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	time "time"
+	presenter "walk_backend/internal/app/api/presenter"
+	model "walk_backend/internal/app/model"
+	service "walk_backend/internal/app/service"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockServiceInterface is a mock of ServiceInterface interface.
+type MockServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceInterfaceMockRecorder
+}
+
+// MockServiceInterfaceMockRecorder is the mock recorder for MockServiceInterface.
+type MockServiceInterfaceMockRecorder struct {
+	mock *MockServiceInterface
+}
+
+// NewMockServiceInterface creates a new mock instance.
+func NewMockServiceInterface(ctrl *gomock.Controller) *MockServiceInterface {
+	mock := &MockServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceInterface) EXPECT() *MockServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AuthCodeURL mocks base method.
+func (m *MockServiceInterface) AuthCodeURL(ctx context.Context, provider string) (string, *service.OIDCLogin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthCodeURL", ctx, provider)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*service.OIDCLogin)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AuthCodeURL indicates an expected call of AuthCodeURL.
+func (mr *MockServiceInterfaceMockRecorder) AuthCodeURL(ctx, provider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthCodeURL", reflect.TypeOf((*MockServiceInterface)(nil).AuthCodeURL), ctx, provider)
+}
+
+// Login mocks base method.
+func (m *MockServiceInterface) Login(ctx context.Context, provider, code string, login *service.OIDCLogin) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, provider, code, login)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockServiceInterfaceMockRecorder) Login(ctx, provider, code, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockServiceInterface)(nil).Login), ctx, provider, code, login)
+}
+
+// MockSessionTokenServiceInterface is a mock of SessionTokenServiceInterface interface.
+type MockSessionTokenServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionTokenServiceInterfaceMockRecorder
+}
+
+// MockSessionTokenServiceInterfaceMockRecorder is the mock recorder for MockSessionTokenServiceInterface.
+type MockSessionTokenServiceInterfaceMockRecorder struct {
+	mock *MockSessionTokenServiceInterface
+}
+
+// NewMockSessionTokenServiceInterface creates a new mock instance.
+func NewMockSessionTokenServiceInterface(ctrl *gomock.Controller) *MockSessionTokenServiceInterface {
+	mock := &MockSessionTokenServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockSessionTokenServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionTokenServiceInterface) EXPECT() *MockSessionTokenServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Issue mocks base method.
+func (m *MockSessionTokenServiceInterface) Issue(ctx context.Context, username string, device model.Device) (string, *model.SessionToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issue", ctx, username, device)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*model.SessionToken)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Issue indicates an expected call of Issue.
+func (mr *MockSessionTokenServiceInterfaceMockRecorder) Issue(ctx, username, device interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockSessionTokenServiceInterface)(nil).Issue), ctx, username, device)
+}
+
+// MockTwoFactorServiceInterface is a mock of TwoFactorServiceInterface interface.
+type MockTwoFactorServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorServiceInterfaceMockRecorder
+}
+
+// MockTwoFactorServiceInterfaceMockRecorder is the mock recorder for MockTwoFactorServiceInterface.
+type MockTwoFactorServiceInterfaceMockRecorder struct {
+	mock *MockTwoFactorServiceInterface
+}
+
+// NewMockTwoFactorServiceInterface creates a new mock instance.
+func NewMockTwoFactorServiceInterface(ctrl *gomock.Controller) *MockTwoFactorServiceInterface {
+	mock := &MockTwoFactorServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorServiceInterface) EXPECT() *MockTwoFactorServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Challenge mocks base method.
+func (m *MockTwoFactorServiceInterface) Challenge(ctx context.Context, user *model.User, tokens bool) (string, *model.TwoFactorChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Challenge", ctx, user, tokens)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*model.TwoFactorChallenge)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Challenge indicates an expected call of Challenge.
+func (mr *MockTwoFactorServiceInterfaceMockRecorder) Challenge(ctx, user, tokens interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Challenge", reflect.TypeOf((*MockTwoFactorServiceInterface)(nil).Challenge), ctx, user, tokens)
+}
+
+// MockTokenPresenterInterface is a mock of TokenPresenterInterface interface.
+type MockTokenPresenterInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenPresenterInterfaceMockRecorder
+}
+
+// MockTokenPresenterInterfaceMockRecorder is the mock recorder for MockTokenPresenterInterface.
+type MockTokenPresenterInterfaceMockRecorder struct {
+	mock *MockTokenPresenterInterface
+}
+
+// NewMockTokenPresenterInterface creates a new mock instance.
+func NewMockTokenPresenterInterface(ctrl *gomock.Controller) *MockTokenPresenterInterface {
+	mock := &MockTokenPresenterInterface{ctrl: ctrl}
+	mock.recorder = &MockTokenPresenterInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenPresenterInterface) EXPECT() *MockTokenPresenterInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Make mocks base method.
+func (m *MockTokenPresenterInterface) Make(token string, expires time.Time) *presenter.Token {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Make", token, expires)
+	ret0, _ := ret[0].(*presenter.Token)
+	return ret0
+}
+
+// Make indicates an expected call of Make.
+func (mr *MockTokenPresenterInterfaceMockRecorder) Make(token, expires interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Make", reflect.TypeOf((*MockTokenPresenterInterface)(nil).Make), token, expires)
+}
+
+// MakeChallenge mocks base method.
+func (m *MockTokenPresenterInterface) MakeChallenge(challenge string, expires time.Time) *presenter.Token {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MakeChallenge", challenge, expires)
+	ret0, _ := ret[0].(*presenter.Token)
+	return ret0
+}
+
+// MakeChallenge indicates an expected call of MakeChallenge.
+func (mr *MockTokenPresenterInterfaceMockRecorder) MakeChallenge(challenge, expires interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeChallenge", reflect.TypeOf((*MockTokenPresenterInterface)(nil).MakeChallenge), challenge, expires)
+}
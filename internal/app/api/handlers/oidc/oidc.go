@@ -0,0 +1,281 @@
+package oidc
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"time"
+
+	"walk_backend/internal/app/api/middleware"
+	"walk_backend/internal/app/api/presenter"
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/context"
+)
+
+// loginTTL time to sign in at the provider
+const loginTTL = 10 * time.Minute
+
+const (
+	sessionKeyProvider     = "oidc_provider"
+	sessionKeyState        = "oidc_state"
+	sessionKeyNonce        = "oidc_nonce"
+	sessionKeyCodeVerifier = "oidc_code_verifier"
+	sessionKeyExpires      = "oidc_expires"
+)
+
+// ServiceInterface ...
+type ServiceInterface interface {
+	AuthCodeURL(ctx context.Context, provider string) (string, *service.OIDCLogin, error)
+	Login(ctx context.Context, provider string, code string, login *service.OIDCLogin) (*model.User, error)
+}
+
+// SessionTokenServiceInterface ...
+type SessionTokenServiceInterface interface {
+	Issue(ctx context.Context, username string, device model.Device) (string, *model.SessionToken, error)
+}
+
+// TwoFactorServiceInterface ...
+type TwoFactorServiceInterface interface {
+	Challenge(ctx context.Context, user *model.User, tokens bool) (string, *model.TwoFactorChallenge, error)
+}
+
+// TokenPresenterInterface ...
+type TokenPresenterInterface interface {
+	Make(token string, expires time.Time) *presenter.Token
+	MakeChallenge(challenge string, expires time.Time) *presenter.Token
+}
+
+// OIDCHandler OpenID Connect login handler
+type OIDCHandler struct {
+	ctx       context.Context
+	router    *gin.RouterGroup
+	service   ServiceInterface
+	sessions  SessionTokenServiceInterface
+	twoFactor TwoFactorServiceInterface
+	presenter TokenPresenterInterface
+}
+
+// NewHandler create new OpenID Connect login handler
+func NewHandler(
+	ctx context.Context,
+	router *gin.RouterGroup,
+	service ServiceInterface,
+	sessions SessionTokenServiceInterface,
+	twoFactor TwoFactorServiceInterface,
+	presenter TokenPresenterInterface,
+) *OIDCHandler {
+	return &OIDCHandler{
+		ctx:       ctx,
+		router:    router,
+		service:   service,
+		sessions:  sessions,
+		twoFactor: twoFactor,
+		presenter: presenter,
+	}
+}
+
+// LoginHandler ...
+//
+// swagger:operation GET /auth/oidc/{provider}/login oidc oidcLogin
+// Redirect to the provider login, state, nonce and PKCE code verifier are kept in the session
+// ---
+// parameters:
+//   - name: provider
+//     in: path
+//     description: provider name, e.g. google
+//     type: string
+//     required: true
+//
+// responses:
+//
+//	'302':
+//	  description: Redirect to the provider
+//	'404':
+//	  description: Unknown provider
+//	'502':
+//	  description: Provider unavailable
+func (handler *OIDCHandler) LoginHandler(c *gin.Context) {
+
+	provider := c.Param("provider")
+	authURL, login, err := handler.service.AuthCodeURL(handler.ctx, provider)
+	if err != nil {
+		_ = c.Error(err)
+		if errors.Is(err, service.ErrUnknownOIDCProvider) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Provider unavailable"})
+		return
+	}
+
+	session := sessions.Default(c)
+	session.Set(sessionKeyProvider, provider)
+	session.Set(sessionKeyState, login.State)
+	session.Set(sessionKeyNonce, login.Nonce)
+	session.Set(sessionKeyCodeVerifier, login.CodeVerifier)
+	session.Set(sessionKeyExpires, time.Now().Add(loginTTL).Unix())
+	if err := session.Save(); err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error session save"})
+		return
+	}
+
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// CallbackHandler ...
+//
+// swagger:operation GET /auth/oidc/{provider}/callback oidc oidcCallback
+// Provider redirect back, signs in the user linked to the external identity, a new user is created on first login
+// ---
+// produces:
+// - application/json
+// parameters:
+//   - name: provider
+//     in: path
+//     description: provider name, e.g. google
+//     type: string
+//     required: true
+//   - name: code
+//     in: query
+//     description: authorization code
+//     type: string
+//   - name: state
+//     in: query
+//     description: login state
+//     type: string
+//
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'202':
+//	  description: Two-factor code required
+//	'400':
+//	  description: Invalid or expired login state, or the provider login failed
+//	'401':
+//	  description: Invalid code or ID token
+//	'404':
+//	  description: Unknown provider
+//	'409':
+//	  description: Email registered with another account
+func (handler *OIDCHandler) CallbackHandler(c *gin.Context) {
+
+	provider := c.Param("provider")
+	session := sessions.Default(c)
+	login, ok := handler.popLogin(session, provider)
+	if err := session.Save(); err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error session save"})
+		return
+	}
+
+	if providerErr := c.Query("error"); providerErr != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Provider login failed: " + providerErr})
+		return
+	}
+	// the login state is single use, a replayed or forged callback has none or another
+	if !ok || subtle.ConstantTimeCompare([]byte(c.Query("state")), []byte(login.State)) != 1 || c.Query("code") == "" {
+		audit(c, model.AuditOutcomeFailure, "", model.AuditReasonInvalidToken)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid login state"})
+		return
+	}
+
+	user, err := handler.service.Login(handler.ctx, provider, c.Query("code"), login)
+	if err != nil {
+		_ = c.Error(err)
+		switch {
+		case errors.Is(err, service.ErrUnknownOIDCProvider):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrOIDCLogin):
+			audit(c, model.AuditOutcomeFailure, "", model.AuditReasonInvalidCredentials)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": service.ErrOIDCLogin.Error()})
+		case errors.Is(err, service.ErrOIDCEmailTaken):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "OIDC login error"})
+		}
+		return
+	}
+
+	// the provider login is the first factor only, the code is verified by /auth/2fa like after a password
+	if user.IsTwoFactorEnabled() {
+		challenge, m, err := handler.twoFactor.Challenge(handler.ctx, user, false)
+		if err != nil {
+			_ = c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error create two-factor challenge"})
+			return
+		}
+		data := handler.presenter.MakeChallenge(challenge, m.ExpiresAt)
+		c.JSON(http.StatusAccepted, gin.H{"message": "Two-factor code required", "data": data})
+		return
+	}
+
+	sessionTokenNew, sessionTokenModel, err := handler.sessions.Issue(handler.ctx, user.Username, model.Device{
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	})
+	if err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generate token"})
+		return
+	}
+	session.Set("username", user.Username)
+	session.Set("token", sessionTokenNew)
+	if err := session.Save(); err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error session save"})
+		return
+	}
+
+	audit(c, model.AuditOutcomeSuccess, user.Username, "")
+	data := handler.presenter.Make(sessionTokenNew, sessionTokenModel.ExpiresAt)
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// audit record an OpenID Connect login of the request
+func audit(c *gin.Context, outcome model.AuditOutcome, actor string, reason model.AuditReason) {
+	middleware.RecordAudit(c, &model.AuditEvent{
+		Type:    model.AuditEventLogin,
+		Outcome: outcome,
+		Reason:  reason,
+		Method:  model.AuditMethodOIDC,
+		Actor:   actor,
+	})
+}
+
+// popLogin remove the login of the session, false when there is none for the provider or it expired
+func (handler *OIDCHandler) popLogin(session sessions.Session, provider string) (*service.OIDCLogin, bool) {
+
+	sessionProvider, _ := session.Get(sessionKeyProvider).(string)
+	expires, _ := session.Get(sessionKeyExpires).(int64)
+	login := &service.OIDCLogin{}
+	login.State, _ = session.Get(sessionKeyState).(string)
+	login.Nonce, _ = session.Get(sessionKeyNonce).(string)
+	login.CodeVerifier, _ = session.Get(sessionKeyCodeVerifier).(string)
+
+	for _, key := range []string{sessionKeyProvider, sessionKeyState, sessionKeyNonce, sessionKeyCodeVerifier, sessionKeyExpires} {
+		session.Delete(key)
+	}
+
+	if sessionProvider != provider || login.State == "" || time.Now().Unix() > expires {
+		return nil, false
+	}
+	return login, true
+}
+
+// Make ...
+func (handler *OIDCHandler) Make() {
+	handler.MakeRoutes()
+}
+
+// MakeRoutes make OpenID Connect login routes
+func (handler *OIDCHandler) MakeRoutes() {
+
+	handler.router.GET("/auth/oidc/:provider/login", handler.LoginHandler)
+	handler.router.GET("/auth/oidc/:provider/callback", handler.CallbackHandler)
+}
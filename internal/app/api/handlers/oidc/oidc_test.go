@@ -0,0 +1,126 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	oidcMock "walk_backend/internal/app/api/handlers/oidc/mock"
+	"walk_backend/internal/app/api/middleware"
+	"walk_backend/internal/app/api/presenter"
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDCHandler(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	router := gin.Default()
+	apiV1 := router.Group("/api/v1")
+	apiV1.Use(middleware.Session("session", cookie.NewStore([]byte("secret"))))
+
+	mockService := oidcMock.NewMockServiceInterface(controller)
+	mockSessionTokenService := oidcMock.NewMockSessionTokenServiceInterface(controller)
+	mockTwoFactorService := oidcMock.NewMockTwoFactorServiceInterface(controller)
+
+	mh := NewHandler(context.Background(), apiV1, mockService, mockSessionTokenService, mockTwoFactorService, presenter.NewTokenPresenter())
+	mh.MakeRoutes()
+
+	serve := func(path string, cookies []*http.Cookie) *httptest.ResponseRecorder {
+		request, _ := http.NewRequest(http.MethodGet, "/api/v1/auth/oidc/"+path, nil)
+		for _, cookie := range cookies {
+			request.AddCookie(cookie)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	login := &service.OIDCLogin{State: "state", Nonce: "nonce", CodeVerifier: "verifier"}
+	start := func(t *testing.T) []*http.Cookie {
+		mockService.EXPECT().AuthCodeURL(context.Background(), "test").Return("https://id.walk.local/authorize?state=state", login, nil)
+
+		recorder := serve("test/login", nil)
+		assert.Equal(t, http.StatusFound, recorder.Code)
+		assert.Equal(t, "https://id.walk.local/authorize?state=state", recorder.Header().Get("Location"))
+		return recorder.Result().Cookies()
+	}
+
+	t.Run("Unknown_provider", func(t *testing.T) {
+		mockService.EXPECT().AuthCodeURL(context.Background(), "facebook").Return("", nil, service.ErrUnknownOIDCProvider)
+
+		assert.Equal(t, http.StatusNotFound, serve("facebook/login", nil).Code)
+	})
+
+	t.Run("Callback_without_login", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve("test/callback?code=code&state=state", nil).Code)
+	})
+
+	t.Run("Callback_state_mismatch", func(t *testing.T) {
+		cookies := start(t)
+		assert.Equal(t, http.StatusBadRequest, serve("test/callback?code=code&state=forged", cookies).Code)
+		assert.Equal(t, http.StatusBadRequest, serve("other/callback?code=code&state=state", start(t)).Code)
+	})
+
+	t.Run("Callback_provider_error", func(t *testing.T) {
+		cookies := start(t)
+		recorder := serve("test/callback?error=access_denied&state=state", cookies)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "access_denied")
+	})
+
+	t.Run("Callback_email_taken", func(t *testing.T) {
+		cookies := start(t)
+		mockService.EXPECT().Login(context.Background(), "test", "code", login).Return(nil, service.ErrOIDCEmailTaken)
+
+		assert.Equal(t, http.StatusConflict, serve("test/callback?code=code&state=state", cookies).Code)
+	})
+
+	t.Run("Callback", func(t *testing.T) {
+		user, err := model.NewExternalUserModel("Wozniak", model.Identity{Provider: "test", Subject: "108"})
+		assert.Nil(t, err)
+
+		cookies := start(t)
+		mockService.EXPECT().Login(context.Background(), "test", "code", login).Return(user, nil)
+		mockSessionTokenService.
+			EXPECT().
+			Issue(context.Background(), "Wozniak", gomock.Any()).
+			Return("session", &model.SessionToken{Username: "Wozniak", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+		recorder := serve("test/callback?code=code&state=state", cookies)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"session"`)
+
+		// the login state is single use
+		assert.Equal(t, http.StatusBadRequest, serve("test/callback?code=code&state=state", recorder.Result().Cookies()).Code)
+	})
+
+	t.Run("Callback_two_factor", func(t *testing.T) {
+		user, err := model.NewUserModel("Wozniak", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+		assert.Nil(t, err)
+		enabledAt := time.Now()
+		user.TwoFactor = &model.TwoFactor{Secret: "GEZDGNBVGY3TQOJQ", EnabledAt: &enabledAt}
+
+		cookies := start(t)
+		mockService.EXPECT().Login(context.Background(), "test", "code", login).Return(user, nil)
+		mockTwoFactorService.
+			EXPECT().
+			Challenge(context.Background(), user, false).
+			Return("challenge", &model.TwoFactorChallenge{Username: "Wozniak", ExpiresAt: time.Now().Add(time.Minute)}, nil)
+		mockSessionTokenService.EXPECT().Issue(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		recorder := serve("test/callback?code=code&state=state", cookies)
+		assert.Equal(t, http.StatusAccepted, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"challenge"`)
+		assert.NotContains(t, recorder.Body.String(), `"session"`)
+	})
+}
@@ -0,0 +1,177 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/api/handlers/password/password.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockServiceInterface is a mock of ServiceInterface interface.
+type MockServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceInterfaceMockRecorder
+}
+
+// MockServiceInterfaceMockRecorder is the mock recorder for MockServiceInterface.
+type MockServiceInterfaceMockRecorder struct {
+	mock *MockServiceInterface
+}
+
+// NewMockServiceInterface creates a new mock instance.
+func NewMockServiceInterface(ctrl *gomock.Controller) *MockServiceInterface {
+	mock := &MockServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceInterface) EXPECT() *MockServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Forgot mocks base method.
+func (m *MockServiceInterface) Forgot(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Forgot", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Forgot indicates an expected call of Forgot.
+func (mr *MockServiceInterfaceMockRecorder) Forgot(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Forgot", reflect.TypeOf((*MockServiceInterface)(nil).Forgot), ctx, email)
+}
+
+// Reset mocks base method.
+func (m *MockServiceInterface) Reset(ctx context.Context, token, password string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, token, password)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockServiceInterfaceMockRecorder) Reset(ctx, token, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockServiceInterface)(nil).Reset), ctx, token, password)
+}
+
+// MockCredentialServiceInterface is a mock of CredentialServiceInterface interface.
+type MockCredentialServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCredentialServiceInterfaceMockRecorder
+}
+
+// MockCredentialServiceInterfaceMockRecorder is the mock recorder for MockCredentialServiceInterface.
+type MockCredentialServiceInterfaceMockRecorder struct {
+	mock *MockCredentialServiceInterface
+}
+
+// NewMockCredentialServiceInterface creates a new mock instance.
+func NewMockCredentialServiceInterface(ctrl *gomock.Controller) *MockCredentialServiceInterface {
+	mock := &MockCredentialServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockCredentialServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCredentialServiceInterface) EXPECT() *MockCredentialServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// RevokeAll mocks base method.
+func (m *MockCredentialServiceInterface) RevokeAll(ctx context.Context, username string, exceptSessionID model.ID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, username, exceptSessionID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockCredentialServiceInterfaceMockRecorder) RevokeAll(ctx, username, exceptSessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockCredentialServiceInterface)(nil).RevokeAll), ctx, username, exceptSessionID)
+}
+
+// MockLoginThrottleServiceInterface is a mock of LoginThrottleServiceInterface interface.
+type MockLoginThrottleServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginThrottleServiceInterfaceMockRecorder
+}
+
+// MockLoginThrottleServiceInterfaceMockRecorder is the mock recorder for MockLoginThrottleServiceInterface.
+type MockLoginThrottleServiceInterfaceMockRecorder struct {
+	mock *MockLoginThrottleServiceInterface
+}
+
+// NewMockLoginThrottleServiceInterface creates a new mock instance.
+func NewMockLoginThrottleServiceInterface(ctrl *gomock.Controller) *MockLoginThrottleServiceInterface {
+	mock := &MockLoginThrottleServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockLoginThrottleServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginThrottleServiceInterface) EXPECT() *MockLoginThrottleServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Succeed mocks base method.
+func (m *MockLoginThrottleServiceInterface) Succeed(ctx context.Context, username, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Succeed", ctx, username, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Succeed indicates an expected call of Succeed.
+func (mr *MockLoginThrottleServiceInterfaceMockRecorder) Succeed(ctx, username, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Succeed", reflect.TypeOf((*MockLoginThrottleServiceInterface)(nil).Succeed), ctx, username, ip)
+}
+
+// MockRateLimitServiceInterface is a mock of RateLimitServiceInterface interface.
+type MockRateLimitServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimitServiceInterfaceMockRecorder
+}
+
+// MockRateLimitServiceInterfaceMockRecorder is the mock recorder for MockRateLimitServiceInterface.
+type MockRateLimitServiceInterfaceMockRecorder struct {
+	mock *MockRateLimitServiceInterface
+}
+
+// NewMockRateLimitServiceInterface creates a new mock instance.
+func NewMockRateLimitServiceInterface(ctrl *gomock.Controller) *MockRateLimitServiceInterface {
+	mock := &MockRateLimitServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockRateLimitServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimitServiceInterface) EXPECT() *MockRateLimitServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockRateLimitServiceInterface) Allow(ctx context.Context, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", ctx, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockRateLimitServiceInterfaceMockRecorder) Allow(ctx, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockRateLimitServiceInterface)(nil).Allow), ctx, ip)
+}
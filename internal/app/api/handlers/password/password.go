@@ -0,0 +1,188 @@
+package password
+
+import (
+	"errors"
+	"net/http"
+
+	"walk_backend/internal/app/api/middleware"
+	"walk_backend/internal/app/dto"
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/context"
+)
+
+// ServiceInterface ...
+type ServiceInterface interface {
+	Forgot(ctx context.Context, email string) error
+	Reset(ctx context.Context, token string, password string) (*model.User, error)
+}
+
+// CredentialServiceInterface ...
+type CredentialServiceInterface interface {
+	RevokeAll(ctx context.Context, username string, exceptSessionID model.ID) (int64, error)
+}
+
+// LoginThrottleServiceInterface ...
+type LoginThrottleServiceInterface interface {
+	Succeed(ctx context.Context, username string, ip string) error
+}
+
+// RateLimitServiceInterface ...
+type RateLimitServiceInterface interface {
+	Allow(ctx context.Context, ip string) error
+}
+
+// PasswordHandler password reset handler struct
+type PasswordHandler struct {
+	ctx         context.Context
+	router      *gin.RouterGroup
+	service     ServiceInterface
+	credentials CredentialServiceInterface
+	throttle    LoginThrottleServiceInterface
+	rateLimit   RateLimitServiceInterface
+}
+
+// NewHandler create new password reset handler
+func NewHandler(
+	ctx context.Context,
+	router *gin.RouterGroup,
+	service ServiceInterface,
+	credentials CredentialServiceInterface,
+	throttle LoginThrottleServiceInterface,
+	rateLimit RateLimitServiceInterface,
+) *PasswordHandler {
+	return &PasswordHandler{
+		ctx:         ctx,
+		router:      router,
+		service:     service,
+		credentials: credentials,
+		throttle:    throttle,
+		rateLimit:   rateLimit,
+	}
+}
+
+// ForgotHandler ...
+//
+// swagger:operation POST /auth/password/forgot password forgotPassword
+// Email a password reset link, accepted whether the account exists or not
+// ---
+// produces:
+// - application/json
+// responses:
+//
+//	'202':
+//	  description: Accepted
+//	'400':
+//	  description: Invalid input
+//	'429':
+//	  description: Too many requests, see Retry-After
+func (handler *PasswordHandler) ForgotHandler(c *gin.Context) {
+
+	dto := dto.NewPasswordForgotDTO()
+	if err := c.ShouldBindJSON(dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// every request counts against the IP, so the endpoint can not be used to flood mailboxes
+	if middleware.AbortThrottled(c, handler.rateLimit.Allow(handler.ctx, c.ClientIP())) {
+		return
+	}
+
+	// errors are logged only, the response must not tell whether the account exists
+	if err := handler.service.Forgot(handler.ctx, dto.Email); err != nil {
+		_ = c.Error(err)
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "If the email belongs to an account, a password reset link was sent"})
+}
+
+// ResetHandler ...
+//
+// swagger:operation POST /auth/password/reset password resetPassword
+// Set a new password with the emailed reset token, revokes every session, bearer token and API key
+// ---
+// produces:
+// - application/json
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'400':
+//	  description: Invalid input, invalid token or the password violates the password policy
+//	'429':
+//	  description: Too many requests, see Retry-After
+func (handler *PasswordHandler) ResetHandler(c *gin.Context) {
+
+	dto := dto.NewPasswordResetDTO()
+	if err := c.ShouldBindJSON(dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// every request counts against the IP, guessed tokens included
+	if middleware.AbortThrottled(c, handler.rateLimit.Allow(handler.ctx, c.ClientIP())) {
+		middleware.RecordAudit(c, &model.AuditEvent{
+			Type:    model.AuditEventPasswordReset,
+			Outcome: model.AuditOutcomeFailure,
+			Reason:  model.AuditReasonThrottled,
+		})
+		return
+	}
+
+	user, err := handler.service.Reset(handler.ctx, dto.Token, dto.Password)
+	if err != nil {
+		_ = c.Error(err)
+		var validationErr *model.ValidationError
+		if errors.Is(err, service.ErrInvalidResetToken) {
+			middleware.RecordAudit(c, &model.AuditEvent{
+				Type:    model.AuditEventPasswordReset,
+				Outcome: model.AuditOutcomeFailure,
+				Reason:  model.AuditReasonInvalidToken,
+			})
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		} else if errors.As(err, &validationErr) {
+			middleware.RecordAudit(c, &model.AuditEvent{
+				Type:    model.AuditEventPasswordReset,
+				Outcome: model.AuditOutcomeFailure,
+				Reason:  model.AuditReasonInvalidInput,
+			})
+			c.JSON(http.StatusBadRequest, gin.H{"error": model.ErrInvalidModel.Error(), "fields": validationErr.Fields})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	middleware.RecordAudit(c, &model.AuditEvent{
+		Type:    model.AuditEventPasswordReset,
+		Outcome: model.AuditOutcomeSuccess,
+		Actor:   user.Username,
+	})
+	// the owner of the email is back in, lift a lockout of the username
+	if err := handler.throttle.Succeed(handler.ctx, user.Username, c.ClientIP()); err != nil {
+		_ = c.Error(err)
+	}
+	if _, err := handler.credentials.RevokeAll(handler.ctx, user.Username, model.NilID); err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Password reset, error revoke credentials"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset"})
+}
+
+// Make ...
+func (handler *PasswordHandler) Make() {
+	handler.MakeRoutes()
+}
+
+// MakeRoutes make password reset routes
+func (handler *PasswordHandler) MakeRoutes() {
+
+	handler.router.POST("/auth/password/forgot", handler.ForgotHandler)
+	handler.router.POST("/auth/password/reset", handler.ResetHandler)
+}
@@ -0,0 +1,98 @@
+package password
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	passwordMock "walk_backend/internal/app/api/handlers/password/mock"
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordHandler(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	router := gin.Default()
+	apiV1 := router.Group("/api/v1")
+
+	mockService := passwordMock.NewMockServiceInterface(controller)
+	mockCredentialService := passwordMock.NewMockCredentialServiceInterface(controller)
+	mockThrottle := passwordMock.NewMockLoginThrottleServiceInterface(controller)
+	mockRateLimit := passwordMock.NewMockRateLimitServiceInterface(controller)
+
+	mh := NewHandler(context.Background(), apiV1, mockService, mockCredentialService, mockThrottle, mockRateLimit)
+	mh.MakeRoutes()
+
+	serve := func(path string, body map[string]string) *httptest.ResponseRecorder {
+		b, _ := json.Marshal(body)
+		request, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/password/"+path, bytes.NewReader(b))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	t.Run("Forgot_invalid_input", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve("forgot", map[string]string{"email": "woz"}).Code)
+	})
+
+	t.Run("Forgot", func(t *testing.T) {
+		mockRateLimit.EXPECT().Allow(context.Background(), gomock.Any()).Return(nil).Times(2)
+		mockService.EXPECT().Forgot(context.Background(), "woz@apple.com").Return(nil)
+		mockService.EXPECT().Forgot(context.Background(), "jobs@apple.com").Return(errors.New("smtp down"))
+
+		// the same response whether the mail went out or not
+		assert.Equal(t, http.StatusAccepted, serve("forgot", map[string]string{"email": "woz@apple.com"}).Code)
+		assert.Equal(t, http.StatusAccepted, serve("forgot", map[string]string{"email": "jobs@apple.com"}).Code)
+	})
+
+	t.Run("Forgot_rate_limited", func(t *testing.T) {
+		mockRateLimit.EXPECT().Allow(context.Background(), gomock.Any()).Return(&service.ThrottledError{RetryAfter: time.Minute, Err: service.ErrRateLimited})
+
+		recorder := serve("forgot", map[string]string{"email": "woz@apple.com"})
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+		assert.Equal(t, "60", recorder.Header().Get("Retry-After"))
+	})
+
+	t.Run("Reset_invalid_token", func(t *testing.T) {
+		mockRateLimit.EXPECT().Allow(context.Background(), gomock.Any()).Return(nil)
+		mockService.EXPECT().Reset(context.Background(), "forged", "Apple-II-1977").Return(nil, service.ErrInvalidResetToken)
+
+		assert.Equal(t, http.StatusBadRequest, serve("reset", map[string]string{"token": "forged", "password": "Apple-II-1977"}).Code)
+	})
+
+	t.Run("Reset_policy", func(t *testing.T) {
+		validationErr := &model.ValidationError{}
+		validationErr.Add("password", "is too common")
+
+		mockRateLimit.EXPECT().Allow(context.Background(), gomock.Any()).Return(nil)
+		mockService.EXPECT().Reset(context.Background(), "token", "qwerty123").Return(nil, validationErr)
+
+		recorder := serve("reset", map[string]string{"token": "token", "password": "qwerty123"})
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "is too common")
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		user, err := model.NewUserModel("Wozniak", "Apple-II-1977", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+		assert.Nil(t, err)
+
+		mockRateLimit.EXPECT().Allow(context.Background(), gomock.Any()).Return(nil)
+		mockService.EXPECT().Reset(context.Background(), "token", "Apple-II-1977").Return(user, nil)
+		mockThrottle.EXPECT().Succeed(context.Background(), "Wozniak", gomock.Any()).Return(nil)
+		mockCredentialService.EXPECT().RevokeAll(context.Background(), "Wozniak", model.NilID).Return(int64(2), nil)
+
+		assert.Equal(t, http.StatusOK, serve("reset", map[string]string{"token": "token", "password": "Apple-II-1977"}).Code)
+	})
+}
@@ -6,6 +6,7 @@ package mock
 
 import (
 	reflect "reflect"
+	time "time"
 	presenter "walk_backend/internal/app/api/presenter"
 	dto "walk_backend/internal/app/dto"
 	model "walk_backend/internal/app/model"
@@ -126,19 +127,35 @@ func (mr *MockServiceInterfaceMockRecorder) ListPlaces(ctx interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlaces", reflect.TypeOf((*MockServiceInterface)(nil).ListPlaces), ctx)
 }
 
+// ListPlacesByCategory mocks base method.
+func (m *MockServiceInterface) ListPlacesByCategory(ctx context.Context, categoryID model.ID, offset, limit int64) (model.PlaceList, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlacesByCategory", ctx, categoryID, offset, limit)
+	ret0, _ := ret[0].(model.PlaceList)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListPlacesByCategory indicates an expected call of ListPlacesByCategory.
+func (mr *MockServiceInterfaceMockRecorder) ListPlacesByCategory(ctx, categoryID, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlacesByCategory", reflect.TypeOf((*MockServiceInterface)(nil).ListPlacesByCategory), ctx, categoryID, offset, limit)
+}
+
 // Search mocks base method.
-func (m *MockServiceInterface) Search(ctx context.Context, search string) (model.PlaceList, error) {
+func (m *MockServiceInterface) Search(ctx context.Context, search string, withSubcategories bool) (model.PlaceList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", ctx, search)
+	ret := m.ctrl.Call(m, "Search", ctx, search, withSubcategories)
 	ret0, _ := ret[0].(model.PlaceList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Search indicates an expected call of Search.
-func (mr *MockServiceInterfaceMockRecorder) Search(ctx, search interface{}) *gomock.Call {
+func (mr *MockServiceInterfaceMockRecorder) Search(ctx, search, withSubcategories interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockServiceInterface)(nil).Search), ctx, search)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockServiceInterface)(nil).Search), ctx, search, withSubcategories)
 }
 
 // Update mocks base method.
@@ -205,3 +222,40 @@ func (mr *MockPresenterInterfaceMockRecorder) MakeList(mList, cList interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeList", reflect.TypeOf((*MockPresenterInterface)(nil).MakeList), mList, cList)
 }
+
+// MockSearchAnalyticsInterface is a mock of SearchAnalyticsInterface interface.
+type MockSearchAnalyticsInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchAnalyticsInterfaceMockRecorder
+}
+
+// MockSearchAnalyticsInterfaceMockRecorder is the mock recorder for MockSearchAnalyticsInterface.
+type MockSearchAnalyticsInterfaceMockRecorder struct {
+	mock *MockSearchAnalyticsInterface
+}
+
+// NewMockSearchAnalyticsInterface creates a new mock instance.
+func NewMockSearchAnalyticsInterface(ctrl *gomock.Controller) *MockSearchAnalyticsInterface {
+	mock := &MockSearchAnalyticsInterface{ctrl: ctrl}
+	mock.recorder = &MockSearchAnalyticsInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchAnalyticsInterface) EXPECT() *MockSearchAnalyticsInterfaceMockRecorder {
+	return m.recorder
+}
+
+// RecordSearch mocks base method.
+func (m *MockSearchAnalyticsInterface) RecordSearch(search string, hits int, latency time.Duration, username string) model.ID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSearch", search, hits, latency, username)
+	ret0, _ := ret[0].(model.ID)
+	return ret0
+}
+
+// RecordSearch indicates an expected call of RecordSearch.
+func (mr *MockSearchAnalyticsInterfaceMockRecorder) RecordSearch(search, hits, latency, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSearch", reflect.TypeOf((*MockSearchAnalyticsInterface)(nil).RecordSearch), search, hits, latency, username)
+}
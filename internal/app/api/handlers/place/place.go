@@ -3,10 +3,15 @@ package place
 import (
 	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
+	"walk_backend/internal/app/api/middleware"
 	"walk_backend/internal/app/api/presenter"
 	"walk_backend/internal/app/dto"
 	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+	"walk_backend/internal/pkg/searchquery"
 	"walk_backend/internal/pkg/util"
 
 	"github.com/gin-gonic/gin"
@@ -22,7 +27,8 @@ type ServiceInterface interface {
 	Update(ctx context.Context, dto *dto.Place) error
 	Delete(ctx context.Context, id model.ID) error
 	Find(ctx context.Context, id model.ID) (*model.Place, error)
-	Search(ctx context.Context, search string) (model.PlaceList, error)
+	ListPlacesByCategory(ctx context.Context, categoryID model.ID, offset int64, limit int64) (model.PlaceList, int64, error)
+	Search(ctx context.Context, search string, withSubcategories bool) (model.PlaceList, error)
 	ListCategories(ctx context.Context) (model.CategoryList, error)
 	FindCategory(ctx context.Context, id model.ID) (*model.Category, error)
 }
@@ -33,29 +39,40 @@ type PresenterInterface interface {
 	MakeList(mList model.PlaceList, cList model.CategoryList) []*presenter.Place
 }
 
+// SearchAnalyticsInterface ...
+type SearchAnalyticsInterface interface {
+	RecordSearch(search string, hits int, latency time.Duration, username string) model.ID
+}
+
 // PlacesHandler ...
 type PlacesHandler struct {
-	ctx        context.Context
-	router     *gin.RouterGroup
-	routerAuth *gin.RouterGroup
-	service    ServiceInterface
-	presenter  PresenterInterface
+	ctx                context.Context
+	router             *gin.RouterGroup
+	routerAuth         *gin.RouterGroup
+	routerOptionalAuth *gin.RouterGroup
+	service            ServiceInterface
+	presenter          PresenterInterface
+	analytics          SearchAnalyticsInterface
 }
 
-// NewHandler ...
+// NewHandler routerOptionalAuth serves guests and signed in users, the user of a request with credentials is verified
 func NewHandler(
 	ctx context.Context,
 	router *gin.RouterGroup,
 	routerAuth *gin.RouterGroup,
+	routerOptionalAuth *gin.RouterGroup,
 	service ServiceInterface,
 	presenter PresenterInterface,
+	analytics SearchAnalyticsInterface,
 ) *PlacesHandler {
 	return &PlacesHandler{
-		ctx:        ctx,
-		router:     router,
-		routerAuth: routerAuth,
-		service:    service,
-		presenter:  presenter,
+		ctx:                ctx,
+		router:             router,
+		routerAuth:         routerAuth,
+		routerOptionalAuth: routerOptionalAuth,
+		service:            service,
+		presenter:          presenter,
+		analytics:          analytics,
 	}
 }
 
@@ -114,6 +131,9 @@ func (handler *PlacesHandler) NewPlaceHandler(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if user := middleware.UserFromContext(c); user != nil {
+		dto.AuthorID = user.ID.String()
+	}
 
 	id, err := handler.service.Create(handler.ctx, dto)
 	if err != nil {
@@ -272,6 +292,86 @@ func (handler *PlacesHandler) GetOnePlaceHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"data": data})
 }
 
+// ListCategoryPlacesHandler ...
+//
+// swagger:operation GET /categories/{id}/places places listCategoryPlaces
+// Returns page of published places of the category
+// ---
+// produces:
+// - application/json
+// parameters:
+//   - name: id
+//     in: path
+//     description: category ID
+//     required: true
+//     type: string
+//   - name: offset
+//     in: query
+//     description: number of places to skip
+//     required: false
+//     type: integer
+//   - name: limit
+//     in: query
+//     description: max number of places, up to 100
+//     required: false
+//     type: integer
+//
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'400':
+//	  description: Invalid input
+//	'404':
+//	  description: Invalid category ID
+func (handler *PlacesHandler) ListCategoryPlacesHandler(c *gin.Context) {
+	categoryID, err := model.StringToID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var offset int64
+	if v := c.Query("offset"); v != "" {
+		if offset, err = strconv.ParseInt(v, 10, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": service.ErrInvalidPaging.Error()})
+			return
+		}
+	}
+	limit := service.DefaultCategoryPlacesLimit
+	if v := c.Query("limit"); v != "" {
+		if limit, err = strconv.ParseInt(v, 10, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": service.ErrInvalidPaging.Error()})
+			return
+		}
+	}
+
+	category, err := handler.service.FindCategory(handler.ctx, categoryID)
+	if err != nil {
+		_ = c.Error(err)
+		if errors.Is(err, model.ErrModelNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	placeList, total, err := handler.service.ListPlacesByCategory(handler.ctx, categoryID, offset, limit)
+	if err != nil {
+		_ = c.Error(err)
+		if errors.Is(err, service.ErrInvalidPaging) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	data := handler.presenter.MakeList(placeList, model.CategoryList{category})
+	c.JSON(http.StatusOK, gin.H{"data": data, "total": total, "offset": offset, "limit": limit})
+}
+
 // SearchPlacesHandler ...
 //
 // swagger:operation GET /places/search places findPlace
@@ -282,19 +382,43 @@ func (handler *PlacesHandler) GetOnePlaceHandler(c *gin.Context) {
 // parameters:
 //   - name: q
 //     in: query
-//     description: place name, description and tags
+//     description: 'words, "quoted phrases", -exclusions, tag:park, category:name_or_id, near:lat,lng[,radius_km]'
 //     required: true
 //     type: string
+//   - name: subcategories
+//     in: query
+//     description: category filters match subcategories too
+//     required: false
+//     type: boolean
 //
 // responses:
 //
 //	'200':
 //	  description: Successful operation
+//	'400':
+//	  description: Invalid query syntax
 func (handler *PlacesHandler) SearchPlacesHandler(c *gin.Context) {
 	search := c.Query("q")
-	placeList, err := handler.service.Search(handler.ctx, search)
+
+	withSubcategories := false
+	if v := c.Query("subcategories"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid subcategories"})
+			return
+		}
+		withSubcategories = b
+	}
+
+	start := time.Now()
+	placeList, err := handler.service.Search(handler.ctx, search, withSubcategories)
 	if err != nil {
 		_ = c.Error(err)
+		var syntaxErr *searchquery.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": syntaxErr.Error(), "position": syntaxErr.Pos})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -309,8 +433,14 @@ func (handler *PlacesHandler) SearchPlacesHandler(c *gin.Context) {
 		return
 	}
 
+	searchID := handler.analytics.RecordSearch(search, len(placeList), time.Since(start), currentUsername(c))
+
 	data := handler.presenter.MakeList(placeList, categoryList)
-	c.JSON(http.StatusOK, gin.H{"data": data})
+	response := gin.H{"data": data}
+	if !searchID.IsNil() {
+		response["searchId"] = searchID
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // Make ...
@@ -324,11 +454,13 @@ func (handler *PlacesHandler) MakeRoutes() {
 
 	handler.router.GET("/places", handler.ListPlacesHandler)
 	handler.router.GET("/places/:id", handler.GetOnePlaceHandler)
-	handler.router.GET("/places/search", handler.SearchPlacesHandler)
+	handler.routerOptionalAuth.GET("/places/search", handler.SearchPlacesHandler)
+	handler.router.GET("/categories/:id/places", handler.ListCategoryPlacesHandler)
 
-	handler.routerAuth.POST("/places", handler.NewPlaceHandler)
-	handler.routerAuth.PUT("/places/:id", handler.UpdatePlaceHandler)
-	handler.routerAuth.DELETE("/places/:id", handler.DeletePlaceHandler)
+	write := middleware.RequireScope(model.APIKeyScopePlacesWrite)
+	handler.routerAuth.POST("/places", write, middleware.RequirePermission(model.PermissionPlaceCreate), handler.NewPlaceHandler)
+	handler.routerAuth.PUT("/places/:id", write, middleware.RequirePermission(model.PermissionPlaceUpdate), handler.UpdatePlaceHandler)
+	handler.routerAuth.DELETE("/places/:id", write, middleware.RequirePermission(model.PermissionPlaceDelete), handler.DeletePlaceHandler)
 }
 
 // MakeRequestValidation make request validation
@@ -337,3 +469,11 @@ func (handler *PlacesHandler) MakeRequestValidation() {
 		v.RegisterStructValidation(dto.ValidatePlaceDTO, dto.NewPlaceDTO())
 	}
 }
+
+// currentUsername username of the user verified by the CurrentUser middleware, empty for guests
+func currentUsername(c *gin.Context) string {
+	if user := middleware.UserFromContext(c); user != nil {
+		return user.Username
+	}
+	return ""
+}
@@ -1,9 +1,134 @@
 package place
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
+
+	placeMock "walk_backend/internal/app/api/handlers/place/mock"
+	"walk_backend/internal/app/api/presenter"
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+	"walk_backend/internal/pkg/searchquery"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestPlaceHandler_ListPlaces(t *testing.T) {
 
 }
+
+func TestPlaceHandler_Search(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	router := gin.Default()
+	apiV1 := router.Group("/api/v1")
+
+	mockPlaceService := placeMock.NewMockServiceInterface(controller)
+	mockSearchAnalytics := placeMock.NewMockSearchAnalyticsInterface(controller)
+
+	mh := NewHandler(context.Background(), apiV1, apiV1, apiV1, mockPlaceService, presenter.NewPlacePresenter(), mockSearchAnalytics)
+	mh.MakeRoutes()
+
+	t.Run("Syntax_error", func(t *testing.T) {
+
+		search := `tag:park "old town`
+		_, parseErr := searchquery.Parse(search)
+
+		mockPlaceService.
+			EXPECT().
+			Search(context.Background(), search, false).
+			Return(nil, parseErr).
+			Times(1)
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/v1/places/search?q="+url.QueryEscape(search), nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+		var body struct {
+			Error    string `json:"error"`
+			Position int    `json:"position"`
+		}
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.Equal(t, 9, body.Position)
+		assert.NotEmpty(t, body.Error)
+	})
+}
+
+func TestPlaceHandler_ListCategoryPlaces(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	router := gin.Default()
+	apiV1 := router.Group("/api/v1")
+
+	mockPlaceService := placeMock.NewMockServiceInterface(controller)
+	mockSearchAnalytics := placeMock.NewMockSearchAnalyticsInterface(controller)
+
+	mh := NewHandler(context.Background(), apiV1, apiV1, apiV1, mockPlaceService, presenter.NewPlacePresenter(), mockSearchAnalytics)
+	mh.MakeRoutes()
+
+	categoryID, _ := model.NewID()
+	placeID, _ := model.NewID()
+	category := &model.Category{ID: categoryID, Name: "Parks", Slug: "parks", Order: 1}
+
+	t.Run("Page", func(t *testing.T) {
+
+		mockPlaceService.EXPECT().FindCategory(context.Background(), categoryID).Return(category, nil)
+		mockPlaceService.
+			EXPECT().
+			ListPlacesByCategory(context.Background(), categoryID, int64(20), int64(10)).
+			Return(model.PlaceList{{ID: placeID, Name: "Gorky park", Category: categoryID}}, int64(21), nil)
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/v1/categories/"+categoryID.String()+"/places?offset=20&limit=10", nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		var body struct {
+			Data  []*presenter.Place `json:"data"`
+			Total int64              `json:"total"`
+		}
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.Equal(t, int64(21), body.Total)
+		assert.Len(t, body.Data, 1)
+		assert.Equal(t, "Parks", body.Data[0].Category.Name)
+	})
+
+	t.Run("Invalid_limit", func(t *testing.T) {
+
+		mockPlaceService.EXPECT().FindCategory(context.Background(), categoryID).Return(category, nil)
+		mockPlaceService.
+			EXPECT().
+			ListPlacesByCategory(context.Background(), categoryID, int64(0), int64(1000)).
+			Return(nil, int64(0), service.ErrInvalidPaging)
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/v1/categories/"+categoryID.String()+"/places?limit=1000", nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("Category_not_found", func(t *testing.T) {
+
+		mockPlaceService.EXPECT().FindCategory(context.Background(), categoryID).Return(nil, model.ErrModelNotFound)
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/v1/categories/"+categoryID.String()+"/places", nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
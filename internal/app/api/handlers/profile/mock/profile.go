@@ -0,0 +1,198 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/api/handlers/profile/profile.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	presenter "walk_backend/internal/app/api/presenter"
+	dto "walk_backend/internal/app/dto"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockServiceInterface is a mock of ServiceInterface interface.
+type MockServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceInterfaceMockRecorder
+}
+
+// MockServiceInterfaceMockRecorder is the mock recorder for MockServiceInterface.
+type MockServiceInterfaceMockRecorder struct {
+	mock *MockServiceInterface
+}
+
+// NewMockServiceInterface creates a new mock instance.
+func NewMockServiceInterface(ctrl *gomock.Controller) *MockServiceInterface {
+	mock := &MockServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceInterface) EXPECT() *MockServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockServiceInterface) Delete(ctx context.Context, user *model.User, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, user, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockServiceInterfaceMockRecorder) Delete(ctx, user, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockServiceInterface)(nil).Delete), ctx, user, password)
+}
+
+// Export mocks base method.
+func (m *MockServiceInterface) Export(ctx context.Context, id model.ID) (*model.UserExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, id)
+	ret0, _ := ret[0].(*model.UserExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockServiceInterfaceMockRecorder) Export(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockServiceInterface)(nil).Export), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockServiceInterface) Update(ctx context.Context, id model.ID, d *dto.Profile) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, d)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockServiceInterfaceMockRecorder) Update(ctx, id, d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockServiceInterface)(nil).Update), ctx, id, d)
+}
+
+// MockLoginThrottleServiceInterface is a mock of LoginThrottleServiceInterface interface.
+type MockLoginThrottleServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginThrottleServiceInterfaceMockRecorder
+}
+
+// MockLoginThrottleServiceInterfaceMockRecorder is the mock recorder for MockLoginThrottleServiceInterface.
+type MockLoginThrottleServiceInterfaceMockRecorder struct {
+	mock *MockLoginThrottleServiceInterface
+}
+
+// NewMockLoginThrottleServiceInterface creates a new mock instance.
+func NewMockLoginThrottleServiceInterface(ctrl *gomock.Controller) *MockLoginThrottleServiceInterface {
+	mock := &MockLoginThrottleServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockLoginThrottleServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginThrottleServiceInterface) EXPECT() *MockLoginThrottleServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockLoginThrottleServiceInterface) Check(ctx context.Context, username, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, username, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockLoginThrottleServiceInterfaceMockRecorder) Check(ctx, username, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockLoginThrottleServiceInterface)(nil).Check), ctx, username, ip)
+}
+
+// Fail mocks base method.
+func (m *MockLoginThrottleServiceInterface) Fail(ctx context.Context, username, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", ctx, username, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockLoginThrottleServiceInterfaceMockRecorder) Fail(ctx, username, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockLoginThrottleServiceInterface)(nil).Fail), ctx, username, ip)
+}
+
+// Succeed mocks base method.
+func (m *MockLoginThrottleServiceInterface) Succeed(ctx context.Context, username, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Succeed", ctx, username, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Succeed indicates an expected call of Succeed.
+func (mr *MockLoginThrottleServiceInterfaceMockRecorder) Succeed(ctx, username, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Succeed", reflect.TypeOf((*MockLoginThrottleServiceInterface)(nil).Succeed), ctx, username, ip)
+}
+
+// MockPresenterInterface is a mock of PresenterInterface interface.
+type MockPresenterInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenterInterfaceMockRecorder
+}
+
+// MockPresenterInterfaceMockRecorder is the mock recorder for MockPresenterInterface.
+type MockPresenterInterfaceMockRecorder struct {
+	mock *MockPresenterInterface
+}
+
+// NewMockPresenterInterface creates a new mock instance.
+func NewMockPresenterInterface(ctrl *gomock.Controller) *MockPresenterInterface {
+	mock := &MockPresenterInterface{ctrl: ctrl}
+	mock.recorder = &MockPresenterInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresenterInterface) EXPECT() *MockPresenterInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Make mocks base method.
+func (m_2 *MockPresenterInterface) Make(m *model.User) *presenter.Profile {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Make", m)
+	ret0, _ := ret[0].(*presenter.Profile)
+	return ret0
+}
+
+// Make indicates an expected call of Make.
+func (mr *MockPresenterInterfaceMockRecorder) Make(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Make", reflect.TypeOf((*MockPresenterInterface)(nil).Make), m)
+}
+
+// MakeExport mocks base method.
+func (m_2 *MockPresenterInterface) MakeExport(m *model.UserExport, currentSessionID model.ID) *presenter.UserExport {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "MakeExport", m, currentSessionID)
+	ret0, _ := ret[0].(*presenter.UserExport)
+	return ret0
+}
+
+// MakeExport indicates an expected call of MakeExport.
+func (mr *MockPresenterInterfaceMockRecorder) MakeExport(m, currentSessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeExport", reflect.TypeOf((*MockPresenterInterface)(nil).MakeExport), m, currentSessionID)
+}
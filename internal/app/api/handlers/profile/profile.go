@@ -0,0 +1,253 @@
+package profile
+
+import (
+	"errors"
+	"net/http"
+
+	"walk_backend/internal/app/api/middleware"
+	"walk_backend/internal/app/api/presenter"
+	"walk_backend/internal/app/dto"
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/context"
+)
+
+// ServiceInterface ...
+type ServiceInterface interface {
+	Update(ctx context.Context, id model.ID, d *dto.Profile) (*model.User, error)
+	Delete(ctx context.Context, user *model.User, password string) error
+	Export(ctx context.Context, id model.ID) (*model.UserExport, error)
+}
+
+// LoginThrottleServiceInterface ...
+type LoginThrottleServiceInterface interface {
+	Check(ctx context.Context, username string, ip string) error
+	Fail(ctx context.Context, username string, ip string) error
+	Succeed(ctx context.Context, username string, ip string) error
+}
+
+// PresenterInterface ...
+type PresenterInterface interface {
+	Make(m *model.User) *presenter.Profile
+	MakeExport(m *model.UserExport, currentSessionID model.ID) *presenter.UserExport
+}
+
+// ProfileHandler current user profile handler struct
+type ProfileHandler struct {
+	ctx        context.Context
+	routerAuth *gin.RouterGroup
+	service    ServiceInterface
+	throttle   LoginThrottleServiceInterface
+	presenter  PresenterInterface
+}
+
+// NewHandler create new profile handler
+func NewHandler(
+	ctx context.Context,
+	routerAuth *gin.RouterGroup,
+	service ServiceInterface,
+	throttle LoginThrottleServiceInterface,
+	presenter PresenterInterface,
+) *ProfileHandler {
+	return &ProfileHandler{
+		ctx:        ctx,
+		routerAuth: routerAuth,
+		service:    service,
+		throttle:   throttle,
+		presenter:  presenter,
+	}
+}
+
+// GetProfileHandler ...
+//
+// swagger:operation GET /me profile getProfile
+// Profile of the current user
+// ---
+// produces:
+// - application/json
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'401':
+//	  description: Invalid credentials
+func (handler *ProfileHandler) GetProfileHandler(c *gin.Context) {
+
+	data := handler.presenter.Make(middleware.UserFromContext(c))
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// UpdateProfileHandler ...
+//
+// swagger:operation PATCH /me profile updateProfile
+// Update display name, avatar URL and locale of the current user, missing fields are kept, empty ones cleared
+// ---
+// consumes:
+// - application/json
+// produces:
+// - application/json
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'400':
+//	  description: Invalid input
+//	'401':
+//	  description: Invalid credentials
+func (handler *ProfileHandler) UpdateProfileHandler(c *gin.Context) {
+
+	dto := dto.NewProfileDTO()
+	if err := c.ShouldBindJSON(dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := handler.service.Update(handler.ctx, middleware.UserFromContext(c).ID, dto)
+	if err != nil {
+		_ = c.Error(err)
+		var validationErr *model.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": model.ErrInvalidModel.Error(), "fields": validationErr.Fields})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	data := handler.presenter.Make(user)
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// DeleteProfileHandler ...
+//
+// swagger:operation DELETE /me profile deleteProfile
+// Delete the account of the current user, users with a password confirm it. Sessions and API keys are deleted,
+// created places are kept without the author
+// ---
+// consumes:
+// - application/json
+// produces:
+// - application/json
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'401':
+//	  description: Invalid credentials
+//	'403':
+//	  description: Invalid password
+//	'409':
+//	  description: The last admin can not be deleted
+//	'429':
+//	  description: Too many failed attempts, see Retry-After
+func (handler *ProfileHandler) DeleteProfileHandler(c *gin.Context) {
+
+	dto := dto.NewAccountDeleteDTO()
+	// users without a password send no body
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(dto); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	user := middleware.UserFromContext(c)
+	if middleware.AbortThrottled(c, handler.throttle.Check(handler.ctx, user.Username, c.ClientIP())) {
+		middleware.RecordAudit(c, &model.AuditEvent{
+			Type:    model.AuditEventAccountDelete,
+			Outcome: model.AuditOutcomeFailure,
+			Reason:  model.AuditReasonThrottled,
+		})
+		return
+	}
+
+	if err := handler.service.Delete(handler.ctx, user, dto.Password); err != nil {
+		_ = c.Error(err)
+		if errors.Is(err, service.ErrInvalidCurrentPassword) {
+			if err := handler.throttle.Fail(handler.ctx, user.Username, c.ClientIP()); err != nil {
+				_ = c.Error(err)
+			}
+			middleware.RecordAudit(c, &model.AuditEvent{
+				Type:    model.AuditEventAccountDelete,
+				Outcome: model.AuditOutcomeFailure,
+				Reason:  model.AuditReasonInvalidCredentials,
+			})
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		} else if errors.Is(err, service.ErrLastAdmin) {
+			middleware.RecordAudit(c, &model.AuditEvent{
+				Type:    model.AuditEventAccountDelete,
+				Outcome: model.AuditOutcomeFailure,
+				Reason:  model.AuditReasonForbidden,
+			})
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err := handler.throttle.Succeed(handler.ctx, user.Username, c.ClientIP()); err != nil {
+		_ = c.Error(err)
+	}
+	middleware.RecordAudit(c, &model.AuditEvent{
+		Type:    model.AuditEventAccountDelete,
+		Outcome: model.AuditOutcomeSuccess,
+	})
+
+	session := sessions.Default(c)
+	session.Clear()
+	if err := session.Save(); err != nil {
+		_ = c.Error(err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Account deleted"})
+}
+
+// ExportProfileHandler ...
+//
+// swagger:operation GET /me/export profile exportProfile
+// Personal data archive of the current user: profile, active sessions, API keys and created places
+// ---
+// produces:
+// - application/json
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'401':
+//	  description: Invalid credentials
+func (handler *ProfileHandler) ExportProfileHandler(c *gin.Context) {
+
+	m, err := handler.service.Export(handler.ctx, middleware.UserFromContext(c).ID)
+	if err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	current := model.NilID
+	if token := middleware.SessionTokenFromContext(c); token != nil {
+		current = token.FamilyID
+	}
+
+	// the ID, usernames may have characters unsafe in a header
+	c.Header("Content-Disposition", `attachment; filename="`+m.User.ID.String()+`-export.json"`)
+	c.JSON(http.StatusOK, gin.H{"data": handler.presenter.MakeExport(m, current)})
+}
+
+// Make ...
+func (handler *ProfileHandler) Make() {
+	handler.MakeRoutes()
+}
+
+// MakeRoutes make profile routes
+func (handler *ProfileHandler) MakeRoutes() {
+
+	handler.routerAuth.GET("/me", middleware.DenyAPIKey(), handler.GetProfileHandler)
+	handler.routerAuth.PATCH("/me", middleware.DenyAPIKey(), handler.UpdateProfileHandler)
+	handler.routerAuth.DELETE("/me", middleware.DenyAPIKey(), handler.DeleteProfileHandler)
+	handler.routerAuth.GET("/me/export", middleware.DenyAPIKey(), handler.ExportProfileHandler)
+}
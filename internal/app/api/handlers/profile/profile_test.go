@@ -0,0 +1,141 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	profileMock "walk_backend/internal/app/api/handlers/profile/mock"
+	"walk_backend/internal/app/api/middleware"
+	"walk_backend/internal/app/api/presenter"
+	"walk_backend/internal/app/dto"
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileHandler(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	user, err := model.NewUserModel("Wozniak", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+	assert.Nil(t, err)
+	assert.Nil(t, user.SetProfile("Woz", "", "en"))
+
+	router := gin.Default()
+	router.Use(middleware.Session("session", cookie.NewStore([]byte("secret"))))
+	apiV1auth := router.Group("/api/v1", func(c *gin.Context) {
+		c.Set(middleware.ContextUserKey, user)
+	})
+
+	mockService := profileMock.NewMockServiceInterface(controller)
+	mockLoginThrottleService := profileMock.NewMockLoginThrottleServiceInterface(controller)
+	mockLoginThrottleService.EXPECT().Check(context.Background(), "Wozniak", gomock.Any()).Return(nil).AnyTimes()
+
+	mh := NewHandler(context.Background(), apiV1auth, mockService, mockLoginThrottleService, presenter.NewProfilePresenter())
+	mh.MakeRoutes()
+
+	serve := func(method string, path string, body string) *httptest.ResponseRecorder {
+		request, _ := http.NewRequest(method, "/api/v1"+path, strings.NewReader(body))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	t.Run("Get", func(t *testing.T) {
+
+		var body struct {
+			Data *presenter.Profile `json:"data"`
+		}
+		recorder := serve(http.MethodGet, "/me", "")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.Equal(t, "Woz", body.Data.DisplayName)
+		assert.Equal(t, "en", body.Data.Locale)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+
+		mockService.
+			EXPECT().
+			Update(context.Background(), user.ID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ model.ID, d *dto.Profile) (*model.User, error) {
+				assert.Nil(t, d.DisplayName)
+				assert.Equal(t, "de-DE", *d.Locale)
+				updated := *user
+				return &updated, updated.SetProfile(updated.DisplayName, updated.AvatarURL, *d.Locale)
+			})
+		recorder := serve(http.MethodPatch, "/me", `{"locale":"de-DE"}`)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"locale":"de-DE"`)
+
+		validationErr := &model.ValidationError{}
+		validationErr.Add("avatarUrl", "must be an absolute http or https URL")
+		mockService.EXPECT().Update(context.Background(), user.ID, gomock.Any()).Return(nil, validationErr)
+		recorder = serve(http.MethodPatch, "/me", `{"avatarUrl":"javascript:alert(1)"}`)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "avatarUrl")
+	})
+
+	t.Run("Export", func(t *testing.T) {
+
+		mockService.EXPECT().Export(context.Background(), user.ID).Return(&model.UserExport{
+			User:       user,
+			Sessions:   []*model.SessionToken{},
+			APIKeys:    []*model.APIKey{},
+			Places:     model.PlaceList{},
+			ExportedAt: time.Now(),
+		}, nil)
+
+		var body struct {
+			Data *presenter.UserExport `json:"data"`
+		}
+		recorder := serve(http.MethodGet, "/me/export", "")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Header().Get("Content-Disposition"), "attachment")
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.Equal(t, "Wozniak", body.Data.Profile.Username)
+		assert.NotNil(t, body.Data.Places)
+	})
+
+	t.Run("Delete_invalid_password", func(t *testing.T) {
+
+		mockService.EXPECT().Delete(context.Background(), user, "guess").Return(service.ErrInvalidCurrentPassword)
+		mockLoginThrottleService.EXPECT().Fail(context.Background(), "Wozniak", gomock.Any()).Return(nil)
+
+		assert.Equal(t, http.StatusForbidden, serve(http.MethodDelete, "/me", `{"password":"guess"}`).Code)
+	})
+
+	t.Run("Delete_last_admin", func(t *testing.T) {
+
+		mockService.EXPECT().Delete(context.Background(), user, "password").Return(service.ErrLastAdmin)
+		mockLoginThrottleService.EXPECT().Succeed(context.Background(), "Wozniak", gomock.Any()).Times(0)
+
+		assert.Equal(t, http.StatusConflict, serve(http.MethodDelete, "/me", `{"password":"password"}`).Code)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+
+		mockService.EXPECT().Delete(context.Background(), user, "password").Return(nil)
+		mockLoginThrottleService.EXPECT().Succeed(context.Background(), "Wozniak", gomock.Any()).Return(nil)
+
+		assert.Equal(t, http.StatusOK, serve(http.MethodDelete, "/me", `{"password":"password"}`).Code)
+	})
+
+	t.Run("Delete_without_body", func(t *testing.T) {
+
+		mockService.EXPECT().Delete(context.Background(), user, "").Return(service.ErrInvalidCurrentPassword)
+		mockLoginThrottleService.EXPECT().Fail(context.Background(), "Wozniak", gomock.Any()).Return(nil)
+
+		assert.Equal(t, http.StatusForbidden, serve(http.MethodDelete, "/me", "").Code)
+	})
+}
@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/api/handlers/search/search.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	time "time"
+	presenter "walk_backend/internal/app/api/presenter"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockServiceInterface is a mock of ServiceInterface interface.
+type MockServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceInterfaceMockRecorder
+}
+
+// MockServiceInterfaceMockRecorder is the mock recorder for MockServiceInterface.
+type MockServiceInterfaceMockRecorder struct {
+	mock *MockServiceInterface
+}
+
+// NewMockServiceInterface creates a new mock instance.
+func NewMockServiceInterface(ctrl *gomock.Controller) *MockServiceInterface {
+	mock := &MockServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceInterface) EXPECT() *MockServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// RecordClick mocks base method.
+func (m *MockServiceInterface) RecordClick(ctx context.Context, searchID, placeID model.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordClick", ctx, searchID, placeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordClick indicates an expected call of RecordClick.
+func (mr *MockServiceInterfaceMockRecorder) RecordClick(ctx, searchID, placeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordClick", reflect.TypeOf((*MockServiceInterface)(nil).RecordClick), ctx, searchID, placeID)
+}
+
+// Stats mocks base method.
+func (m *MockServiceInterface) Stats(ctx context.Context, window time.Duration, limit int64) (*model.SearchStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx, window, limit)
+	ret0, _ := ret[0].(*model.SearchStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockServiceInterfaceMockRecorder) Stats(ctx, window, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockServiceInterface)(nil).Stats), ctx, window, limit)
+}
+
+// MockRateLimitServiceInterface is a mock of RateLimitServiceInterface interface.
+type MockRateLimitServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimitServiceInterfaceMockRecorder
+}
+
+// MockRateLimitServiceInterfaceMockRecorder is the mock recorder for MockRateLimitServiceInterface.
+type MockRateLimitServiceInterfaceMockRecorder struct {
+	mock *MockRateLimitServiceInterface
+}
+
+// NewMockRateLimitServiceInterface creates a new mock instance.
+func NewMockRateLimitServiceInterface(ctrl *gomock.Controller) *MockRateLimitServiceInterface {
+	mock := &MockRateLimitServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockRateLimitServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimitServiceInterface) EXPECT() *MockRateLimitServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockRateLimitServiceInterface) Allow(ctx context.Context, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", ctx, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockRateLimitServiceInterfaceMockRecorder) Allow(ctx, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockRateLimitServiceInterface)(nil).Allow), ctx, ip)
+}
+
+// MockPresenterInterface is a mock of PresenterInterface interface.
+type MockPresenterInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenterInterfaceMockRecorder
+}
+
+// MockPresenterInterfaceMockRecorder is the mock recorder for MockPresenterInterface.
+type MockPresenterInterfaceMockRecorder struct {
+	mock *MockPresenterInterface
+}
+
+// NewMockPresenterInterface creates a new mock instance.
+func NewMockPresenterInterface(ctrl *gomock.Controller) *MockPresenterInterface {
+	mock := &MockPresenterInterface{ctrl: ctrl}
+	mock.recorder = &MockPresenterInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresenterInterface) EXPECT() *MockPresenterInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Make mocks base method.
+func (m_2 *MockPresenterInterface) Make(m *model.SearchStats) *presenter.SearchStats {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Make", m)
+	ret0, _ := ret[0].(*presenter.SearchStats)
+	return ret0
+}
+
+// Make indicates an expected call of Make.
+func (mr *MockPresenterInterfaceMockRecorder) Make(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Make", reflect.TypeOf((*MockPresenterInterface)(nil).Make), m)
+}
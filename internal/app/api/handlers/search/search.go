@@ -0,0 +1,209 @@
+package search
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"walk_backend/internal/app/api/middleware"
+	"walk_backend/internal/app/api/presenter"
+	"walk_backend/internal/app/dto"
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/context"
+)
+
+const (
+	defaultStatsWindow time.Duration = 24 * time.Hour
+	defaultStatsLimit  int64         = 20
+)
+
+// ServiceInterface ...
+type ServiceInterface interface {
+	RecordClick(ctx context.Context, searchID model.ID, placeID model.ID) error
+	Stats(ctx context.Context, window time.Duration, limit int64) (*model.SearchStats, error)
+}
+
+// RateLimitServiceInterface ...
+type RateLimitServiceInterface interface {
+	Allow(ctx context.Context, ip string) error
+}
+
+// PresenterInterface ...
+type PresenterInterface interface {
+	Make(m *model.SearchStats) *presenter.SearchStats
+}
+
+// SearchHandler search analytics handler
+type SearchHandler struct {
+	ctx        context.Context
+	router     *gin.RouterGroup
+	routerAuth *gin.RouterGroup
+	service    ServiceInterface
+	rateLimit  RateLimitServiceInterface
+	presenter  PresenterInterface
+}
+
+// NewHandler create new search analytics handler
+func NewHandler(
+	ctx context.Context,
+	router *gin.RouterGroup,
+	routerAuth *gin.RouterGroup,
+	service ServiceInterface,
+	rateLimit RateLimitServiceInterface,
+	presenter PresenterInterface,
+) *SearchHandler {
+	return &SearchHandler{
+		ctx:        ctx,
+		router:     router,
+		routerAuth: routerAuth,
+		service:    service,
+		rateLimit:  rateLimit,
+		presenter:  presenter,
+	}
+}
+
+// ClickHandler ...
+//
+// swagger:operation POST /search/clicks search searchClick
+// Report the place selected from search results
+// ---
+// produces:
+// - application/json
+// responses:
+//
+//	'202':
+//	  description: Accepted
+//	'400':
+//	  description: Invalid input
+//	'404':
+//	  description: Unknown or expired search
+//	'429':
+//	  description: Too many requests, see Retry-After
+func (handler *SearchHandler) ClickHandler(c *gin.Context) {
+
+	dto := dto.NewSearchClickDTO()
+	if err := c.ShouldBindJSON(dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	searchID, err := model.StringToID(dto.SearchID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	placeID, err := model.StringToID(dto.PlaceID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// every click counts against the IP, the endpoint is public
+	if middleware.AbortThrottled(c, handler.rateLimit.Allow(handler.ctx, c.ClientIP())) {
+		return
+	}
+
+	if err := handler.service.RecordClick(handler.ctx, searchID, placeID); err != nil {
+		_ = c.Error(err)
+		if errors.Is(err, service.ErrUnknownSearch) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// StatsHandler ...
+//
+// swagger:operation GET /admin/search/stats search searchStats
+// Top queries, zero-result queries and click-through
+// ---
+// produces:
+// - application/json
+// parameters:
+//   - name: window
+//     in: query
+//     description: time window, e.g. 1h, 24h, 7d (default 24h, max 90d)
+//     required: false
+//     type: string
+//   - name: limit
+//     in: query
+//     description: queries per list (default 20, max 100)
+//     required: false
+//     type: integer
+//
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'400':
+//	  description: Invalid input
+func (handler *SearchHandler) StatsHandler(c *gin.Context) {
+
+	window := defaultStatsWindow
+	if v := c.Query("window"); v != "" {
+		w, err := parseWindow(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": service.ErrInvalidSearchStatsWindow.Error()})
+			return
+		}
+		window = w
+	}
+
+	limit := defaultStatsLimit
+	if v := c.Query("limit"); v != "" {
+		l, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": service.ErrInvalidSearchStatsLimit.Error()})
+			return
+		}
+		limit = l
+	}
+
+	stats, err := handler.service.Stats(handler.ctx, window, limit)
+	if err != nil {
+		_ = c.Error(err)
+		if errors.Is(err, service.ErrInvalidSearchStatsWindow) || errors.Is(err, service.ErrInvalidSearchStatsLimit) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	data := handler.presenter.Make(stats)
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// Make ...
+func (handler *SearchHandler) Make() {
+	handler.MakeRoutes()
+}
+
+// MakeRoutes make search analytics routes
+func (handler *SearchHandler) MakeRoutes() {
+
+	handler.router.POST("/search/clicks", handler.ClickHandler)
+
+	handler.routerAuth.GET("/admin/search/stats", middleware.RequirePermission(model.PermissionSearchStats), handler.StatsHandler)
+}
+
+// parseWindow time.ParseDuration with days support
+func parseWindow(v string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(v, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(v)
+}
@@ -0,0 +1,73 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	searchMock "walk_backend/internal/app/api/handlers/search/mock"
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchHandler_Click(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	router := gin.Default()
+	apiV1 := router.Group("/api/v1")
+
+	mockService := searchMock.NewMockServiceInterface(controller)
+	mockRateLimit := searchMock.NewMockRateLimitServiceInterface(controller)
+
+	mh := NewHandler(context.Background(), apiV1, apiV1, mockService, mockRateLimit, nil)
+	mh.MakeRoutes()
+
+	searchID, err := model.NewID()
+	assert.Nil(t, err)
+	placeID, err := model.NewID()
+	assert.Nil(t, err)
+	body := fmt.Sprintf(`{"searchId":"%s","placeId":"%s"}`, searchID, placeID)
+
+	serve := func(body string) *httptest.ResponseRecorder {
+		request, _ := http.NewRequest(http.MethodPost, "/api/v1/search/clicks", bytes.NewBufferString(body))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve(`{"searchId":"1"}`).Code)
+	})
+
+	t.Run("Throttled", func(t *testing.T) {
+		mockRateLimit.EXPECT().Allow(context.Background(), gomock.Any()).Return(&service.ThrottledError{RetryAfter: time.Minute, Err: service.ErrRateLimited})
+
+		recorder := serve(body)
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+		assert.Equal(t, "60", recorder.Header().Get("Retry-After"))
+	})
+
+	t.Run("Unknown_search", func(t *testing.T) {
+		mockRateLimit.EXPECT().Allow(context.Background(), gomock.Any()).Return(nil)
+		mockService.EXPECT().RecordClick(context.Background(), searchID, placeID).Return(service.ErrUnknownSearch)
+
+		assert.Equal(t, http.StatusNotFound, serve(body).Code)
+	})
+
+	t.Run("Click", func(t *testing.T) {
+		mockRateLimit.EXPECT().Allow(context.Background(), gomock.Any()).Return(nil)
+		mockService.EXPECT().RecordClick(context.Background(), searchID, placeID).Return(nil)
+
+		assert.Equal(t, http.StatusAccepted, serve(body).Code)
+	})
+}
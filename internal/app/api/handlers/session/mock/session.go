@@ -0,0 +1,170 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/api/handlers/session/session.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	presenter "walk_backend/internal/app/api/presenter"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockServiceInterface is a mock of ServiceInterface interface.
+type MockServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceInterfaceMockRecorder
+}
+
+// MockServiceInterfaceMockRecorder is the mock recorder for MockServiceInterface.
+type MockServiceInterfaceMockRecorder struct {
+	mock *MockServiceInterface
+}
+
+// NewMockServiceInterface creates a new mock instance.
+func NewMockServiceInterface(ctrl *gomock.Controller) *MockServiceInterface {
+	mock := &MockServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceInterface) EXPECT() *MockServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockServiceInterface) List(ctx context.Context, username string) ([]*model.SessionToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, username)
+	ret0, _ := ret[0].([]*model.SessionToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceInterfaceMockRecorder) List(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockServiceInterface)(nil).List), ctx, username)
+}
+
+// RevokeAll mocks base method.
+func (m *MockServiceInterface) RevokeAll(ctx context.Context, username string, exceptSessionID model.ID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, username, exceptSessionID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockServiceInterfaceMockRecorder) RevokeAll(ctx, username, exceptSessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockServiceInterface)(nil).RevokeAll), ctx, username, exceptSessionID)
+}
+
+// RevokeSession mocks base method.
+func (m *MockServiceInterface) RevokeSession(ctx context.Context, username string, sessionID model.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, username, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockServiceInterfaceMockRecorder) RevokeSession(ctx, username, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockServiceInterface)(nil).RevokeSession), ctx, username, sessionID)
+}
+
+// MockCredentialServiceInterface is a mock of CredentialServiceInterface interface.
+type MockCredentialServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCredentialServiceInterfaceMockRecorder
+}
+
+// MockCredentialServiceInterfaceMockRecorder is the mock recorder for MockCredentialServiceInterface.
+type MockCredentialServiceInterfaceMockRecorder struct {
+	mock *MockCredentialServiceInterface
+}
+
+// NewMockCredentialServiceInterface creates a new mock instance.
+func NewMockCredentialServiceInterface(ctrl *gomock.Controller) *MockCredentialServiceInterface {
+	mock := &MockCredentialServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockCredentialServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCredentialServiceInterface) EXPECT() *MockCredentialServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// RevokeAll mocks base method.
+func (m *MockCredentialServiceInterface) RevokeAll(ctx context.Context, username string, exceptSessionID model.ID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, username, exceptSessionID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockCredentialServiceInterfaceMockRecorder) RevokeAll(ctx, username, exceptSessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockCredentialServiceInterface)(nil).RevokeAll), ctx, username, exceptSessionID)
+}
+
+// MockPresenterInterface is a mock of PresenterInterface interface.
+type MockPresenterInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenterInterfaceMockRecorder
+}
+
+// MockPresenterInterfaceMockRecorder is the mock recorder for MockPresenterInterface.
+type MockPresenterInterfaceMockRecorder struct {
+	mock *MockPresenterInterface
+}
+
+// NewMockPresenterInterface creates a new mock instance.
+func NewMockPresenterInterface(ctrl *gomock.Controller) *MockPresenterInterface {
+	mock := &MockPresenterInterface{ctrl: ctrl}
+	mock.recorder = &MockPresenterInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresenterInterface) EXPECT() *MockPresenterInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Make mocks base method.
+func (m_2 *MockPresenterInterface) Make(m *model.SessionToken, current bool) *presenter.Session {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Make", m, current)
+	ret0, _ := ret[0].(*presenter.Session)
+	return ret0
+}
+
+// Make indicates an expected call of Make.
+func (mr *MockPresenterInterfaceMockRecorder) Make(m, current interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Make", reflect.TypeOf((*MockPresenterInterface)(nil).Make), m, current)
+}
+
+// MakeList mocks base method.
+func (m *MockPresenterInterface) MakeList(mList []*model.SessionToken, currentID model.ID) []*presenter.Session {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MakeList", mList, currentID)
+	ret0, _ := ret[0].([]*presenter.Session)
+	return ret0
+}
+
+// MakeList indicates an expected call of MakeList.
+func (mr *MockPresenterInterfaceMockRecorder) MakeList(mList, currentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeList", reflect.TypeOf((*MockPresenterInterface)(nil).MakeList), mList, currentID)
+}
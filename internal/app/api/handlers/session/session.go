@@ -0,0 +1,210 @@
+package session
+
+import (
+	"errors"
+	"net/http"
+
+	"walk_backend/internal/app/api/middleware"
+	"walk_backend/internal/app/api/presenter"
+	"walk_backend/internal/app/model"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/context"
+)
+
+// ServiceInterface ...
+type ServiceInterface interface {
+	List(ctx context.Context, username string) ([]*model.SessionToken, error)
+	RevokeSession(ctx context.Context, username string, sessionID model.ID) error
+	RevokeAll(ctx context.Context, username string, exceptSessionID model.ID) (int64, error)
+}
+
+// CredentialServiceInterface ...
+type CredentialServiceInterface interface {
+	RevokeAll(ctx context.Context, username string, exceptSessionID model.ID) (int64, error)
+}
+
+// PresenterInterface ...
+type PresenterInterface interface {
+	Make(m *model.SessionToken, current bool) *presenter.Session
+	MakeList(mList []*model.SessionToken, currentID model.ID) []*presenter.Session
+}
+
+// SessionsHandler active sessions handler struct
+type SessionsHandler struct {
+	ctx         context.Context
+	routerAuth  *gin.RouterGroup
+	service     ServiceInterface
+	credentials CredentialServiceInterface
+	presenter   PresenterInterface
+}
+
+// NewHandler create new sessions handler
+func NewHandler(
+	ctx context.Context,
+	routerAuth *gin.RouterGroup,
+	service ServiceInterface,
+	credentials CredentialServiceInterface,
+	presenter PresenterInterface,
+) *SessionsHandler {
+	return &SessionsHandler{
+		ctx:         ctx,
+		routerAuth:  routerAuth,
+		service:     service,
+		credentials: credentials,
+		presenter:   presenter,
+	}
+}
+
+// ListSessionsHandler ...
+//
+// swagger:operation GET /me/sessions sessions listSessions
+// Active sessions of the current user, most recently seen first
+// ---
+// produces:
+// - application/json
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'401':
+//	  description: Invalid credentials
+func (handler *SessionsHandler) ListSessionsHandler(c *gin.Context) {
+
+	list, err := handler.service.List(handler.ctx, middleware.UserFromContext(c).Username)
+	if err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	data := handler.presenter.MakeList(list, currentSessionID(c))
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// RevokeSessionHandler ...
+//
+// swagger:operation DELETE /me/sessions/{id} sessions revokeSession
+// Sign out a session of the current user
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     description: ID of the session
+//     required: true
+//     type: string
+//
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'400':
+//	  description: Invalid session ID
+//	'404':
+//	  description: Session not found
+func (handler *SessionsHandler) RevokeSessionHandler(c *gin.Context) {
+
+	sessionID, err := model.StringToID(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = handler.service.RevokeSession(handler.ctx, middleware.UserFromContext(c).Username, sessionID)
+	if err != nil {
+		_ = c.Error(err)
+		if errors.Is(err, model.ErrModelNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked"})
+}
+
+// RevokeSessionsHandler ...
+//
+// swagger:operation DELETE /me/sessions sessions revokeSessions
+// Sign out every session of the current user, others=true keeps the session of the request
+// ---
+// parameters:
+//   - name: others
+//     in: query
+//     description: keep the current session
+//     type: boolean
+//
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+func (handler *SessionsHandler) RevokeSessionsHandler(c *gin.Context) {
+
+	except := model.NilID
+	if c.Query("others") == "true" {
+		except = currentSessionID(c)
+	}
+
+	revoked, err := handler.service.RevokeAll(handler.ctx, middleware.UserFromContext(c).Username, except)
+	if err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revoked": revoked})
+}
+
+// RevokeUserSessionsHandler ...
+//
+// swagger:operation DELETE /admin/users/{username}/sessions sessions revokeUserSessions
+// Sign out every session of the user and revoke their bearer tokens and API keys
+// ---
+// parameters:
+//   - name: username
+//     in: path
+//     description: username
+//     required: true
+//     type: string
+//
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'403':
+//	  description: Access denied
+func (handler *SessionsHandler) RevokeUserSessionsHandler(c *gin.Context) {
+
+	revoked, err := handler.credentials.RevokeAll(handler.ctx, c.Param("username"), model.NilID)
+	if err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revoked": revoked})
+}
+
+// currentSessionID session of the request, NilID for bearer tokens
+func currentSessionID(c *gin.Context) model.ID {
+	if m := middleware.SessionTokenFromContext(c); m != nil {
+		return m.FamilyID
+	}
+	return model.NilID
+}
+
+// Make ...
+func (handler *SessionsHandler) Make() {
+	handler.MakeRoutes()
+}
+
+// MakeRoutes make sessions routes
+func (handler *SessionsHandler) MakeRoutes() {
+
+	denyAPIKey := middleware.DenyAPIKey()
+	handler.routerAuth.GET("/me/sessions", denyAPIKey, handler.ListSessionsHandler)
+	handler.routerAuth.DELETE("/me/sessions/:id", denyAPIKey, handler.RevokeSessionHandler)
+	handler.routerAuth.DELETE("/me/sessions", denyAPIKey, handler.RevokeSessionsHandler)
+	handler.routerAuth.DELETE("/admin/users/:username/sessions", middleware.RequirePermission(model.PermissionSessionRevoke), handler.RevokeUserSessionsHandler)
+}
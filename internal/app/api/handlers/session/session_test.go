@@ -0,0 +1,131 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sessionMock "walk_backend/internal/app/api/handlers/session/mock"
+	"walk_backend/internal/app/api/middleware"
+	"walk_backend/internal/app/api/presenter"
+	"walk_backend/internal/app/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionsHandler(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	user, err := model.NewUserModel("Wozniak", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+	assert.Nil(t, err)
+	currentID, _ := model.NewID()
+	otherID, _ := model.NewID()
+	current := &model.SessionToken{FamilyID: currentID, Username: "Wozniak", Device: model.Device{UserAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 16_0 like Mac OS X)"}}
+	other := &model.SessionToken{FamilyID: otherID, Username: "Wozniak"}
+
+	router := gin.Default()
+	apiV1auth := router.Group("/api/v1", func(c *gin.Context) {
+		c.Set(middleware.ContextUserKey, user)
+		c.Set(middleware.ContextSessionTokenKey, current)
+	})
+
+	mockSessionService := sessionMock.NewMockServiceInterface(controller)
+	mockCredentialService := sessionMock.NewMockCredentialServiceInterface(controller)
+
+	mh := NewHandler(context.Background(), apiV1auth, mockSessionService, mockCredentialService, presenter.NewSessionPresenter())
+	mh.MakeRoutes()
+
+	t.Run("List", func(t *testing.T) {
+
+		mockSessionService.EXPECT().List(context.Background(), "Wozniak").Return([]*model.SessionToken{current, other}, nil)
+
+		request, _ := http.NewRequest(http.MethodGet, "/api/v1/me/sessions", nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		var body struct {
+			Data []*presenter.Session `json:"data"`
+		}
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.Len(t, body.Data, 2)
+		assert.True(t, body.Data[0].Current)
+		assert.Equal(t, "iPhone", body.Data[0].Device)
+		assert.False(t, body.Data[1].Current)
+		assert.Contains(t, recorder.Body.String(), `"lastSeenAt":`)
+		assert.NotContains(t, recorder.Body.String(), `"last_seen_at":`)
+	})
+
+	t.Run("Revoke_others", func(t *testing.T) {
+
+		mockSessionService.EXPECT().RevokeAll(context.Background(), "Wozniak", currentID).Return(int64(1), nil)
+
+		request, _ := http.NewRequest(http.MethodDelete, "/api/v1/me/sessions?others=true", nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("Revoke_not_found", func(t *testing.T) {
+
+		mockSessionService.EXPECT().RevokeSession(context.Background(), "Wozniak", otherID).Return(model.ErrModelNotFound)
+
+		request, _ := http.NewRequest(http.MethodDelete, "/api/v1/me/sessions/"+otherID.String(), nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+
+	t.Run("Admin_only", func(t *testing.T) {
+
+		request, _ := http.NewRequest(http.MethodDelete, "/api/v1/admin/users/Jobs/sessions", nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+
+	t.Run("Admin_revoke", func(t *testing.T) {
+
+		admin, err := model.NewUserModel("admin", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+		assert.Nil(t, err)
+		admin.Roles = []model.Role{model.RoleAdmin}
+		saved := user
+		user = admin
+		defer func() { user = saved }()
+
+		mockCredentialService.EXPECT().RevokeAll(context.Background(), "Jobs", model.NilID).Return(int64(3), nil)
+
+		request, _ := http.NewRequest(http.MethodDelete, "/api/v1/admin/users/Jobs/sessions", nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"revoked":3`)
+	})
+
+	t.Run("Admin_revoke_forbidden", func(t *testing.T) {
+
+		editor, err := model.NewUserModel("editor", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+		assert.Nil(t, err)
+		editor.Roles = []model.Role{model.RoleEditor}
+		saved := user
+		user = editor
+		defer func() { user = saved }()
+
+		request, _ := http.NewRequest(http.MethodDelete, "/api/v1/admin/users/Jobs/sessions", nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+}
@@ -0,0 +1,196 @@
+package tag
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"walk_backend/internal/app/api/middleware"
+	"walk_backend/internal/app/api/presenter"
+	"walk_backend/internal/app/dto"
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/context"
+)
+
+// ServiceInterface ...
+type ServiceInterface interface {
+	ListTags(ctx context.Context, limit int64) (model.TagList, error)
+	Rename(ctx context.Context, tag string, name string) (int, error)
+	Merge(ctx context.Context, tags []string, into string) (int, error)
+}
+
+// PresenterInterface ...
+type PresenterInterface interface {
+	Make(m *model.Tag) *presenter.Tag
+	MakeList(mList model.TagList) []*presenter.Tag
+}
+
+// TagsHandler tags handler struct
+type TagsHandler struct {
+	ctx        context.Context
+	router     *gin.RouterGroup
+	routerAuth *gin.RouterGroup
+	service    ServiceInterface
+	presenter  PresenterInterface
+}
+
+// NewHandler create new tags handler
+func NewHandler(
+	ctx context.Context,
+	router *gin.RouterGroup,
+	routerAuth *gin.RouterGroup,
+	service ServiceInterface,
+	presenter PresenterInterface,
+) *TagsHandler {
+	return &TagsHandler{
+		ctx:        ctx,
+		router:     router,
+		routerAuth: routerAuth,
+		service:    service,
+		presenter:  presenter,
+	}
+}
+
+// ListTagsHandler ...
+//
+// swagger:operation GET /tags tags listTags
+// Returns tags, most used first
+// ---
+// produces:
+// - application/json
+// parameters:
+//   - name: limit
+//     in: query
+//     description: max number of tags
+//     required: false
+//     type: integer
+//
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'400':
+//	  description: Invalid input
+func (handler *TagsHandler) ListTagsHandler(c *gin.Context) {
+
+	var limit int64
+	if v := c.Query("limit"); v != "" {
+		l, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || l < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		limit = l
+	}
+
+	tagList, err := handler.service.ListTags(handler.ctx, limit)
+	if err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	data := handler.presenter.MakeList(tagList)
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// RenameTagHandler ...
+//
+// swagger:operation POST /tags/{tag}/rename tags renameTag
+// Rename tag on all places
+// ---
+// parameters:
+//   - name: tag
+//     in: path
+//     description: tag name
+//     required: true
+//     type: string
+//
+// produces:
+// - application/json
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'400':
+//	  description: Invalid input
+//	'404':
+//	  description: Tag not found
+func (handler *TagsHandler) RenameTagHandler(c *gin.Context) {
+
+	dto := dto.NewTagRenameDTO()
+	if err := c.ShouldBindJSON(dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := handler.service.Rename(handler.ctx, c.Param("tag"), dto.Name)
+	if err != nil {
+		handler.replaceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"updated": updated})
+}
+
+// MergeTagsHandler ...
+//
+// swagger:operation POST /tags/merge tags mergeTags
+// Replace tags with one tag on all places
+// ---
+// produces:
+// - application/json
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'400':
+//	  description: Invalid input
+//	'404':
+//	  description: Tags not found
+func (handler *TagsHandler) MergeTagsHandler(c *gin.Context) {
+
+	dto := dto.NewTagMergeDTO()
+	if err := c.ShouldBindJSON(dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := handler.service.Merge(handler.ctx, dto.Tags, dto.Into)
+	if err != nil {
+		handler.replaceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"updated": updated})
+}
+
+func (handler *TagsHandler) replaceError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	if errors.Is(err, service.ErrInvalidTag) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	} else if errors.Is(err, model.ErrModelNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// Make ...
+func (handler *TagsHandler) Make() {
+	handler.MakeRoutes()
+}
+
+// MakeRoutes make tags routes
+func (handler *TagsHandler) MakeRoutes() {
+
+	handler.router.GET("/tags", handler.ListTagsHandler)
+
+	manage := middleware.RequirePermission(model.PermissionTagManage)
+	handler.routerAuth.POST("/tags/merge", manage, handler.MergeTagsHandler)
+	handler.routerAuth.POST("/tags/:tag/rename", manage, handler.RenameTagHandler)
+}
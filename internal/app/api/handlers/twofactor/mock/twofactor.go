@@ -0,0 +1,241 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/api/handlers/twofactor/twofactor.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	presenter "walk_backend/internal/app/api/presenter"
+	model "walk_backend/internal/app/model"
+	service "walk_backend/internal/app/service"
+
+	gomock "github.com/golang/mock/gomock"
+	context "golang.org/x/net/context"
+)
+
+// MockServiceInterface is a mock of ServiceInterface interface.
+type MockServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceInterfaceMockRecorder
+}
+
+// MockServiceInterfaceMockRecorder is the mock recorder for MockServiceInterface.
+type MockServiceInterfaceMockRecorder struct {
+	mock *MockServiceInterface
+}
+
+// NewMockServiceInterface creates a new mock instance.
+func NewMockServiceInterface(ctrl *gomock.Controller) *MockServiceInterface {
+	mock := &MockServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceInterface) EXPECT() *MockServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockServiceInterface) Confirm(ctx context.Context, user *model.User, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", ctx, user, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockServiceInterfaceMockRecorder) Confirm(ctx, user, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockServiceInterface)(nil).Confirm), ctx, user, code)
+}
+
+// Disable mocks base method.
+func (m *MockServiceInterface) Disable(ctx context.Context, user *model.User, password, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", ctx, user, password, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockServiceInterfaceMockRecorder) Disable(ctx, user, password, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockServiceInterface)(nil).Disable), ctx, user, password, code)
+}
+
+// Enrol mocks base method.
+func (m *MockServiceInterface) Enrol(ctx context.Context, user *model.User, password string) (*service.TwoFactorEnrolment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enrol", ctx, user, password)
+	ret0, _ := ret[0].(*service.TwoFactorEnrolment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enrol indicates an expected call of Enrol.
+func (mr *MockServiceInterfaceMockRecorder) Enrol(ctx, user, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enrol", reflect.TypeOf((*MockServiceInterface)(nil).Enrol), ctx, user, password)
+}
+
+// RegenerateRecoveryCodes mocks base method.
+func (m *MockServiceInterface) RegenerateRecoveryCodes(ctx context.Context, user *model.User, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateRecoveryCodes", ctx, user, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegenerateRecoveryCodes indicates an expected call of RegenerateRecoveryCodes.
+func (mr *MockServiceInterfaceMockRecorder) RegenerateRecoveryCodes(ctx, user, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateRecoveryCodes", reflect.TypeOf((*MockServiceInterface)(nil).RegenerateRecoveryCodes), ctx, user, code)
+}
+
+// Reset mocks base method.
+func (m *MockServiceInterface) Reset(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockServiceInterfaceMockRecorder) Reset(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockServiceInterface)(nil).Reset), ctx, username)
+}
+
+// MockLoginThrottleServiceInterface is a mock of LoginThrottleServiceInterface interface.
+type MockLoginThrottleServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginThrottleServiceInterfaceMockRecorder
+}
+
+// MockLoginThrottleServiceInterfaceMockRecorder is the mock recorder for MockLoginThrottleServiceInterface.
+type MockLoginThrottleServiceInterfaceMockRecorder struct {
+	mock *MockLoginThrottleServiceInterface
+}
+
+// NewMockLoginThrottleServiceInterface creates a new mock instance.
+func NewMockLoginThrottleServiceInterface(ctrl *gomock.Controller) *MockLoginThrottleServiceInterface {
+	mock := &MockLoginThrottleServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockLoginThrottleServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginThrottleServiceInterface) EXPECT() *MockLoginThrottleServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockLoginThrottleServiceInterface) Check(ctx context.Context, username, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, username, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockLoginThrottleServiceInterfaceMockRecorder) Check(ctx, username, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockLoginThrottleServiceInterface)(nil).Check), ctx, username, ip)
+}
+
+// Fail mocks base method.
+func (m *MockLoginThrottleServiceInterface) Fail(ctx context.Context, username, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", ctx, username, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockLoginThrottleServiceInterfaceMockRecorder) Fail(ctx, username, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockLoginThrottleServiceInterface)(nil).Fail), ctx, username, ip)
+}
+
+// Succeed mocks base method.
+func (m *MockLoginThrottleServiceInterface) Succeed(ctx context.Context, username, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Succeed", ctx, username, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Succeed indicates an expected call of Succeed.
+func (mr *MockLoginThrottleServiceInterfaceMockRecorder) Succeed(ctx, username, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Succeed", reflect.TypeOf((*MockLoginThrottleServiceInterface)(nil).Succeed), ctx, username, ip)
+}
+
+// MockPresenterInterface is a mock of PresenterInterface interface.
+type MockPresenterInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenterInterfaceMockRecorder
+}
+
+// MockPresenterInterfaceMockRecorder is the mock recorder for MockPresenterInterface.
+type MockPresenterInterfaceMockRecorder struct {
+	mock *MockPresenterInterface
+}
+
+// NewMockPresenterInterface creates a new mock instance.
+func NewMockPresenterInterface(ctrl *gomock.Controller) *MockPresenterInterface {
+	mock := &MockPresenterInterface{ctrl: ctrl}
+	mock.recorder = &MockPresenterInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresenterInterface) EXPECT() *MockPresenterInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Make mocks base method.
+func (m_2 *MockPresenterInterface) Make(m *model.User) *presenter.TwoFactor {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Make", m)
+	ret0, _ := ret[0].(*presenter.TwoFactor)
+	return ret0
+}
+
+// Make indicates an expected call of Make.
+func (mr *MockPresenterInterfaceMockRecorder) Make(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Make", reflect.TypeOf((*MockPresenterInterface)(nil).Make), m)
+}
+
+// MakeEnrolment mocks base method.
+func (m_2 *MockPresenterInterface) MakeEnrolment(m *model.User, secret, uri string) *presenter.TwoFactor {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "MakeEnrolment", m, secret, uri)
+	ret0, _ := ret[0].(*presenter.TwoFactor)
+	return ret0
+}
+
+// MakeEnrolment indicates an expected call of MakeEnrolment.
+func (mr *MockPresenterInterfaceMockRecorder) MakeEnrolment(m, secret, uri interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeEnrolment", reflect.TypeOf((*MockPresenterInterface)(nil).MakeEnrolment), m, secret, uri)
+}
+
+// MakeRecoveryCodes mocks base method.
+func (m_2 *MockPresenterInterface) MakeRecoveryCodes(m *model.User, codes []string) *presenter.TwoFactor {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "MakeRecoveryCodes", m, codes)
+	ret0, _ := ret[0].(*presenter.TwoFactor)
+	return ret0
+}
+
+// MakeRecoveryCodes indicates an expected call of MakeRecoveryCodes.
+func (mr *MockPresenterInterfaceMockRecorder) MakeRecoveryCodes(m, codes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeRecoveryCodes", reflect.TypeOf((*MockPresenterInterface)(nil).MakeRecoveryCodes), m, codes)
+}
@@ -0,0 +1,326 @@
+package twofactor
+
+import (
+	"errors"
+	"net/http"
+
+	"walk_backend/internal/app/api/middleware"
+	"walk_backend/internal/app/api/presenter"
+	"walk_backend/internal/app/dto"
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/context"
+)
+
+// ServiceInterface ...
+type ServiceInterface interface {
+	Enrol(ctx context.Context, user *model.User, password string) (*service.TwoFactorEnrolment, error)
+	Confirm(ctx context.Context, user *model.User, code string) ([]string, error)
+	Disable(ctx context.Context, user *model.User, password string, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, user *model.User, code string) ([]string, error)
+	Reset(ctx context.Context, username string) error
+}
+
+// LoginThrottleServiceInterface ...
+type LoginThrottleServiceInterface interface {
+	Check(ctx context.Context, username string, ip string) error
+	Fail(ctx context.Context, username string, ip string) error
+	Succeed(ctx context.Context, username string, ip string) error
+}
+
+// PresenterInterface ...
+type PresenterInterface interface {
+	Make(m *model.User) *presenter.TwoFactor
+	MakeEnrolment(m *model.User, secret string, uri string) *presenter.TwoFactor
+	MakeRecoveryCodes(m *model.User, codes []string) *presenter.TwoFactor
+}
+
+// TwoFactorHandler two-factor authentication of the current user handler struct
+type TwoFactorHandler struct {
+	ctx        context.Context
+	routerAuth *gin.RouterGroup
+	service    ServiceInterface
+	throttle   LoginThrottleServiceInterface
+	presenter  PresenterInterface
+}
+
+// NewHandler create new two-factor handler
+func NewHandler(
+	ctx context.Context,
+	routerAuth *gin.RouterGroup,
+	service ServiceInterface,
+	throttle LoginThrottleServiceInterface,
+	presenter PresenterInterface,
+) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		ctx:        ctx,
+		routerAuth: routerAuth,
+		service:    service,
+		throttle:   throttle,
+		presenter:  presenter,
+	}
+}
+
+// GetTwoFactorHandler ...
+//
+// swagger:operation GET /me/2fa twoFactor getTwoFactor
+// Two-factor authentication state of the current user
+// ---
+// produces:
+// - application/json
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+func (handler *TwoFactorHandler) GetTwoFactorHandler(c *gin.Context) {
+
+	data := handler.presenter.Make(middleware.UserFromContext(c))
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// EnrolHandler ...
+//
+// swagger:operation POST /me/2fa twoFactor enrolTwoFactor
+// Start the enrolment with the current password, returns the TOTP secret and its otpauth URI to show as a QR code.
+// Two-factor authentication is enabled by confirming a code of the secret
+// ---
+// produces:
+// - application/json
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'403':
+//	  description: Invalid current password
+//	'409':
+//	  description: Two-factor authentication is enabled already
+//	'429':
+//	  description: Too many failed attempts, see Retry-After
+func (handler *TwoFactorHandler) EnrolHandler(c *gin.Context) {
+
+	dto := dto.NewTwoFactorEnrolDTO()
+	if err := c.ShouldBindJSON(dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user := middleware.UserFromContext(c)
+	if middleware.AbortThrottled(c, handler.throttle.Check(handler.ctx, user.Username, c.ClientIP())) {
+		return
+	}
+
+	enrolment, err := handler.service.Enrol(handler.ctx, user, dto.Password)
+	if err != nil {
+		handler.abort(c, user, err)
+		return
+	}
+	handler.succeed(c, user)
+
+	data := handler.presenter.MakeEnrolment(user, enrolment.Secret, enrolment.URI)
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// ConfirmHandler ...
+//
+// swagger:operation POST /me/2fa/confirm twoFactor confirmTwoFactor
+// Enable two-factor authentication with a code of the enrolled secret, returns the one-time recovery codes,
+// they are not shown again
+// ---
+// produces:
+// - application/json
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'400':
+//	  description: Invalid input or no enrolment started
+//	'401':
+//	  description: Invalid code
+//	'409':
+//	  description: Two-factor authentication is enabled already
+func (handler *TwoFactorHandler) ConfirmHandler(c *gin.Context) {
+
+	dto := dto.NewTwoFactorCodeDTO()
+	if err := c.ShouldBindJSON(dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user := middleware.UserFromContext(c)
+	if middleware.AbortThrottled(c, handler.throttle.Check(handler.ctx, user.Username, c.ClientIP())) {
+		return
+	}
+
+	codes, err := handler.service.Confirm(handler.ctx, user, dto.Code)
+	if err != nil {
+		handler.abort(c, user, err)
+		return
+	}
+	handler.succeed(c, user)
+
+	data := handler.presenter.MakeRecoveryCodes(user, codes)
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// DisableHandler ...
+//
+// swagger:operation POST /me/2fa/disable twoFactor disableTwoFactor
+// Disable two-factor authentication with the current password and a TOTP or recovery code
+// ---
+// produces:
+// - application/json
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'400':
+//	  description: Invalid input or two-factor authentication is not enabled
+//	'401':
+//	  description: Invalid code
+//	'403':
+//	  description: Invalid current password or required by the roles
+func (handler *TwoFactorHandler) DisableHandler(c *gin.Context) {
+
+	dto := dto.NewTwoFactorDisableDTO()
+	if err := c.ShouldBindJSON(dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user := middleware.UserFromContext(c)
+	if middleware.AbortThrottled(c, handler.throttle.Check(handler.ctx, user.Username, c.ClientIP())) {
+		return
+	}
+
+	if err := handler.service.Disable(handler.ctx, user, dto.Password, dto.Code); err != nil {
+		handler.abort(c, user, err)
+		return
+	}
+	handler.succeed(c, user)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+// RecoveryCodesHandler ...
+//
+// swagger:operation POST /me/2fa/recovery-codes twoFactor regenerateRecoveryCodes
+// Replace the recovery codes with a TOTP or recovery code, the new codes are not shown again
+// ---
+// produces:
+// - application/json
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'400':
+//	  description: Invalid input or two-factor authentication is not enabled
+//	'401':
+//	  description: Invalid code
+func (handler *TwoFactorHandler) RecoveryCodesHandler(c *gin.Context) {
+
+	dto := dto.NewTwoFactorCodeDTO()
+	if err := c.ShouldBindJSON(dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user := middleware.UserFromContext(c)
+	if middleware.AbortThrottled(c, handler.throttle.Check(handler.ctx, user.Username, c.ClientIP())) {
+		return
+	}
+
+	codes, err := handler.service.RegenerateRecoveryCodes(handler.ctx, user, dto.Code)
+	if err != nil {
+		handler.abort(c, user, err)
+		return
+	}
+	handler.succeed(c, user)
+
+	data := handler.presenter.MakeRecoveryCodes(user, codes)
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// ResetHandler ...
+//
+// swagger:operation DELETE /admin/users/{username}/2fa twoFactor resetTwoFactor
+// Remove two-factor authentication of the user who lost the device and the recovery codes, the user enrols again
+// ---
+// parameters:
+//   - name: username
+//     in: path
+//     description: username
+//     required: true
+//     type: string
+//
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'403':
+//	  description: Access denied
+//	'404':
+//	  description: User not found
+func (handler *TwoFactorHandler) ResetHandler(c *gin.Context) {
+
+	if err := handler.service.Reset(handler.ctx, c.Param("username")); err != nil {
+		_ = c.Error(err)
+		if errors.Is(err, model.ErrModelNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication reset"})
+}
+
+// abort respond the service error, wrong passwords and codes count as failed attempts
+func (handler *TwoFactorHandler) abort(c *gin.Context, user *model.User, err error) {
+
+	_ = c.Error(err)
+	switch {
+	case errors.Is(err, service.ErrInvalidCurrentPassword), errors.Is(err, service.ErrInvalidTwoFactorCode):
+		if err := handler.throttle.Fail(handler.ctx, user.Username, c.ClientIP()); err != nil {
+			_ = c.Error(err)
+		}
+		status := http.StatusUnauthorized
+		if errors.Is(err, service.ErrInvalidCurrentPassword) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrTwoFactorEnabled):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrTwoFactorRequired):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrTwoFactorNotEnabled), errors.Is(err, service.ErrTwoFactorNotPending):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+func (handler *TwoFactorHandler) succeed(c *gin.Context, user *model.User) {
+	if err := handler.throttle.Succeed(handler.ctx, user.Username, c.ClientIP()); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// Make ...
+func (handler *TwoFactorHandler) Make() {
+	handler.MakeRoutes()
+}
+
+// MakeRoutes make two-factor routes, API keys can not manage the second factor
+func (handler *TwoFactorHandler) MakeRoutes() {
+
+	denyAPIKey := middleware.DenyAPIKey()
+	handler.routerAuth.GET("/me/2fa", denyAPIKey, handler.GetTwoFactorHandler)
+	handler.routerAuth.POST("/me/2fa", denyAPIKey, handler.EnrolHandler)
+	handler.routerAuth.POST("/me/2fa/confirm", denyAPIKey, handler.ConfirmHandler)
+	handler.routerAuth.POST("/me/2fa/disable", denyAPIKey, handler.DisableHandler)
+	handler.routerAuth.POST("/me/2fa/recovery-codes", denyAPIKey, handler.RecoveryCodesHandler)
+	handler.routerAuth.DELETE("/admin/users/:username/2fa", middleware.RequirePermission(model.PermissionUserManage), handler.ResetHandler)
+}
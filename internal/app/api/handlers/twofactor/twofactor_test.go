@@ -0,0 +1,122 @@
+package twofactor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	twofactorMock "walk_backend/internal/app/api/handlers/twofactor/mock"
+	"walk_backend/internal/app/api/middleware"
+	"walk_backend/internal/app/api/presenter"
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwoFactorHandler(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	user, err := model.NewUserModel("Wozniak", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+	assert.Nil(t, err)
+
+	router := gin.Default()
+	apiV1auth := router.Group("/api/v1", func(c *gin.Context) {
+		c.Set(middleware.ContextUserKey, user)
+	})
+
+	mockService := twofactorMock.NewMockServiceInterface(controller)
+	mockLoginThrottleService := twofactorMock.NewMockLoginThrottleServiceInterface(controller)
+	mockLoginThrottleService.EXPECT().Check(context.Background(), "Wozniak", gomock.Any()).Return(nil).AnyTimes()
+	mockLoginThrottleService.EXPECT().Succeed(context.Background(), "Wozniak", gomock.Any()).Return(nil).AnyTimes()
+
+	mh := NewHandler(context.Background(), apiV1auth, mockService, mockLoginThrottleService, presenter.NewTwoFactorPresenter(model.NewAccessPolicy(nil, nil)))
+	mh.MakeRoutes()
+
+	serve := func(method string, path string, body string) *httptest.ResponseRecorder {
+		request, _ := http.NewRequest(method, "/api/v1"+path, strings.NewReader(body))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	var body struct {
+		Data *presenter.TwoFactor `json:"data"`
+	}
+
+	t.Run("Enrol", func(t *testing.T) {
+
+		mockService.
+			EXPECT().
+			Enrol(context.Background(), user, "password").
+			Return(&service.TwoFactorEnrolment{Secret: "GEZDGNBVGY3TQOJQ", URI: "otpauth://totp/Walk:Wozniak?secret=GEZDGNBVGY3TQOJQ"}, nil)
+
+		recorder := serve(http.MethodPost, "/me/2fa", `{"password":"password"}`)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.Equal(t, "GEZDGNBVGY3TQOJQ", body.Data.Secret)
+		assert.False(t, body.Data.Enabled)
+	})
+
+	t.Run("Enrol_invalid_password", func(t *testing.T) {
+
+		mockService.EXPECT().Enrol(context.Background(), user, "guess").Return(nil, service.ErrInvalidCurrentPassword)
+		mockLoginThrottleService.EXPECT().Fail(context.Background(), "Wozniak", gomock.Any()).Return(nil)
+
+		assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, "/me/2fa", `{"password":"guess"}`).Code)
+	})
+
+	t.Run("Confirm", func(t *testing.T) {
+
+		mockService.EXPECT().Confirm(context.Background(), user, "000000").Return(nil, service.ErrInvalidTwoFactorCode)
+		mockLoginThrottleService.EXPECT().Fail(context.Background(), "Wozniak", gomock.Any()).Return(nil)
+		assert.Equal(t, http.StatusUnauthorized, serve(http.MethodPost, "/me/2fa/confirm", `{"code":"000000"}`).Code)
+
+		mockService.EXPECT().Confirm(context.Background(), user, "123456").Return([]string{"01234-56789", "abcde-f0123"}, nil)
+		recorder := serve(http.MethodPost, "/me/2fa/confirm", `{"code":"123456"}`)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.True(t, body.Data.Enabled)
+		assert.Equal(t, []string{"01234-56789", "abcde-f0123"}, body.Data.RecoveryCodes)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+
+		enabledAt := time.Now()
+		user.TwoFactor = &model.TwoFactor{Secret: "GEZDGNBVGY3TQOJQ", EnabledAt: &enabledAt, RecoveryCodes: []string{"hash"}}
+		defer func() { user.TwoFactor = nil }()
+
+		recorder := serve(http.MethodGet, "/me/2fa", "")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.True(t, body.Data.Enabled)
+		assert.Equal(t, 1, body.Data.RecoveryCodesLeft)
+		assert.NotContains(t, recorder.Body.String(), "GEZDGNBVGY3TQOJQ")
+	})
+
+	t.Run("Disable_required", func(t *testing.T) {
+
+		mockService.EXPECT().Disable(context.Background(), user, "password", "123456").Return(service.ErrTwoFactorRequired)
+
+		assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, "/me/2fa/disable", `{"password":"password","code":"123456"}`).Code)
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+
+		// the current user is no admin
+		assert.Equal(t, http.StatusForbidden, serve(http.MethodDelete, "/admin/users/Jobs/2fa", "").Code)
+
+		user.Roles = []model.Role{model.RoleAdmin}
+		defer func() { user.Roles = []model.Role{model.RoleContributor} }()
+		mockService.EXPECT().Reset(context.Background(), "Jobs").Return(nil)
+		assert.Equal(t, http.StatusOK, serve(http.MethodDelete, "/admin/users/Jobs/2fa", "").Code)
+	})
+}
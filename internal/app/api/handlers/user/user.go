@@ -0,0 +1,161 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"walk_backend/internal/app/api/middleware"
+	"walk_backend/internal/app/api/presenter"
+	"walk_backend/internal/app/dto"
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/context"
+)
+
+// ServiceInterface ...
+type ServiceInterface interface {
+	Find(ctx context.Context, username string) (*model.User, error)
+	SetRoles(ctx context.Context, username string, roles []model.Role) (*model.User, error)
+}
+
+// PresenterInterface ...
+type PresenterInterface interface {
+	Make(m *model.User) *presenter.User
+}
+
+// UsersHandler users admin handler struct
+type UsersHandler struct {
+	ctx        context.Context
+	routerAuth *gin.RouterGroup
+	service    ServiceInterface
+	presenter  PresenterInterface
+}
+
+// NewHandler create new users handler
+func NewHandler(
+	ctx context.Context,
+	routerAuth *gin.RouterGroup,
+	service ServiceInterface,
+	presenter PresenterInterface,
+) *UsersHandler {
+	return &UsersHandler{
+		ctx:        ctx,
+		routerAuth: routerAuth,
+		service:    service,
+		presenter:  presenter,
+	}
+}
+
+// GetUserHandler ...
+//
+// swagger:operation GET /admin/users/{username} users findUser
+// Get user with roles
+// ---
+// produces:
+// - application/json
+// parameters:
+//   - name: username
+//     in: path
+//     description: username
+//     required: true
+//     type: string
+//
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'403':
+//	  description: Access denied
+//	'404':
+//	  description: User not found
+func (handler *UsersHandler) GetUserHandler(c *gin.Context) {
+
+	user, err := handler.service.Find(handler.ctx, c.Param("username"))
+	if err != nil {
+		_ = c.Error(err)
+		if errors.Is(err, model.ErrModelNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	data := handler.presenter.Make(user)
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// SetRolesHandler ...
+//
+// swagger:operation PUT /admin/users/{username}/roles users setUserRoles
+// Replace roles of the user
+// ---
+// produces:
+// - application/json
+// parameters:
+//   - name: username
+//     in: path
+//     description: username
+//     required: true
+//     type: string
+//
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'400':
+//	  description: Invalid input
+//	'403':
+//	  description: Access denied
+//	'404':
+//	  description: User not found
+//	'409':
+//	  description: The last admin can not lose the admin role
+func (handler *UsersHandler) SetRolesHandler(c *gin.Context) {
+
+	dto := dto.NewUserRolesDTO()
+	if err := c.ShouldBindJSON(dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	roles := make([]model.Role, 0, len(dto.Roles))
+	for _, role := range dto.Roles {
+		roles = append(roles, model.Role(role))
+	}
+
+	user, err := handler.service.SetRoles(handler.ctx, c.Param("username"), roles)
+	if err != nil {
+		_ = c.Error(err)
+		if errors.Is(err, model.ErrModelNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		} else if errors.Is(err, service.ErrInvalidRole) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		} else if errors.Is(err, service.ErrLastAdmin) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	data := handler.presenter.Make(user)
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// Make ...
+func (handler *UsersHandler) Make() {
+	handler.MakeRoutes()
+}
+
+// MakeRoutes make users routes
+func (handler *UsersHandler) MakeRoutes() {
+
+	admin := middleware.RequireRole(model.RoleAdmin)
+	handler.routerAuth.GET("/admin/users/:username", admin, handler.GetUserHandler)
+	handler.routerAuth.PUT("/admin/users/:username/roles", admin, handler.SetRolesHandler)
+}
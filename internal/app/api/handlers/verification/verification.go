@@ -0,0 +1,156 @@
+package verification
+
+import (
+	"errors"
+	"net/http"
+
+	"walk_backend/internal/app/api/middleware"
+	"walk_backend/internal/app/dto"
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/context"
+)
+
+// ServiceInterface ...
+type ServiceInterface interface {
+	Verify(ctx context.Context, token string) (*model.User, error)
+	Resend(ctx context.Context, email string) error
+}
+
+// LoginThrottleServiceInterface ...
+type LoginThrottleServiceInterface interface {
+	Check(ctx context.Context, username string, ip string) error
+	Fail(ctx context.Context, username string, ip string) error
+}
+
+// RateLimitServiceInterface ...
+type RateLimitServiceInterface interface {
+	Allow(ctx context.Context, ip string) error
+}
+
+// VerificationHandler email verification handler struct
+type VerificationHandler struct {
+	ctx       context.Context
+	router    *gin.RouterGroup
+	service   ServiceInterface
+	throttle  LoginThrottleServiceInterface
+	rateLimit RateLimitServiceInterface
+}
+
+// NewHandler create new email verification handler
+func NewHandler(
+	ctx context.Context,
+	router *gin.RouterGroup,
+	service ServiceInterface,
+	throttle LoginThrottleServiceInterface,
+	rateLimit RateLimitServiceInterface,
+) *VerificationHandler {
+	return &VerificationHandler{
+		ctx:       ctx,
+		router:    router,
+		service:   service,
+		throttle:  throttle,
+		rateLimit: rateLimit,
+	}
+}
+
+// VerifyHandler ...
+//
+// swagger:operation GET /auth/verify verification verifyEmail
+// Verify the email with the token of the emailed link
+// ---
+// produces:
+// - application/json
+// parameters:
+//   - name: token
+//     in: query
+//     description: verification token
+//     type: string
+//     required: true
+//
+// responses:
+//
+//	'200':
+//	  description: Successful operation
+//	'400':
+//	  description: Invalid or expired token
+//	'429':
+//	  description: Too many failed attempts, see Retry-After
+func (handler *VerificationHandler) VerifyHandler(c *gin.Context) {
+
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Token is required"})
+		return
+	}
+
+	if middleware.AbortThrottled(c, handler.throttle.Check(handler.ctx, "", c.ClientIP())) {
+		return
+	}
+
+	_, err := handler.service.Verify(handler.ctx, token)
+	if err != nil {
+		_ = c.Error(err)
+		if errors.Is(err, service.ErrInvalidVerificationToken) {
+			if err := handler.throttle.Fail(handler.ctx, "", c.ClientIP()); err != nil {
+				_ = c.Error(err)
+			}
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email verified"})
+}
+
+// ResendHandler ...
+//
+// swagger:operation POST /auth/verify/resend verification resendVerification
+// Email a new verification link, accepted whether the account exists or not
+// ---
+// produces:
+// - application/json
+// responses:
+//
+//	'202':
+//	  description: Accepted
+//	'400':
+//	  description: Invalid input
+//	'429':
+//	  description: Too many requests, see Retry-After
+func (handler *VerificationHandler) ResendHandler(c *gin.Context) {
+
+	dto := dto.NewEmailVerificationResendDTO()
+	if err := c.ShouldBindJSON(dto); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// every request counts against the IP, the service allows one email per user and resend interval
+	if middleware.AbortThrottled(c, handler.rateLimit.Allow(handler.ctx, c.ClientIP())) {
+		return
+	}
+
+	// errors are logged only, the response must not tell whether the account exists
+	if err := handler.service.Resend(handler.ctx, dto.Email); err != nil {
+		_ = c.Error(err)
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "If the email belongs to an unverified account, a verification link was sent"})
+}
+
+// Make ...
+func (handler *VerificationHandler) Make() {
+	handler.MakeRoutes()
+}
+
+// MakeRoutes make email verification routes
+func (handler *VerificationHandler) MakeRoutes() {
+
+	handler.router.GET("/auth/verify", handler.VerifyHandler)
+	handler.router.POST("/auth/verify/resend", handler.ResendHandler)
+}
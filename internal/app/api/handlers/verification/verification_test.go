@@ -0,0 +1,67 @@
+package verification
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	verificationMock "walk_backend/internal/app/api/handlers/verification/mock"
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerificationHandler(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	router := gin.Default()
+	apiV1 := router.Group("/api/v1")
+
+	mockService := verificationMock.NewMockServiceInterface(controller)
+	mockThrottle := verificationMock.NewMockLoginThrottleServiceInterface(controller)
+	mockRateLimit := verificationMock.NewMockRateLimitServiceInterface(controller)
+
+	mh := NewHandler(context.Background(), apiV1, mockService, mockThrottle, mockRateLimit)
+	mh.MakeRoutes()
+
+	serve := func(method string, path string, body string) int {
+		request, _ := http.NewRequest(method, "/api/v1/auth/verify"+path, bytes.NewBufferString(body))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	t.Run("Verify_missing_token", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, "", ""))
+	})
+
+	t.Run("Verify_invalid_token", func(t *testing.T) {
+		mockThrottle.EXPECT().Check(context.Background(), "", gomock.Any()).Return(nil)
+		mockService.EXPECT().Verify(context.Background(), "forged").Return(nil, service.ErrInvalidVerificationToken)
+		mockThrottle.EXPECT().Fail(context.Background(), "", gomock.Any()).Return(nil)
+
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, "?token=forged", ""))
+	})
+
+	t.Run("Verify", func(t *testing.T) {
+		mockThrottle.EXPECT().Check(context.Background(), "", gomock.Any()).Return(nil)
+		mockService.EXPECT().Verify(context.Background(), "token").Return(&model.User{Username: "Wozniak"}, nil)
+
+		assert.Equal(t, http.StatusOK, serve(http.MethodGet, "?token=token", ""))
+	})
+
+	t.Run("Resend", func(t *testing.T) {
+		mockRateLimit.EXPECT().Allow(context.Background(), gomock.Any()).Return(nil)
+		mockService.EXPECT().Resend(context.Background(), "woz@apple.com").Return(nil)
+
+		assert.Equal(t, http.StatusAccepted, serve(http.MethodPost, "/resend", `{"email":"woz@apple.com"}`))
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, "/resend", `{"email":"woz"}`))
+	})
+}
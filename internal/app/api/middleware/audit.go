@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"walk_backend/internal/app/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// ContextAuditRecorderKey key of the AuditRecorderInterface in the gin context
+	ContextAuditRecorderKey string = "audit_recorder"
+)
+
+// AuditRecorderInterface ...
+type AuditRecorderInterface interface {
+	Record(m *model.AuditEvent)
+}
+
+// Audit middleware make the recorder available to RecordAudit of the handlers and middleware after it
+func Audit(recorder AuditRecorderInterface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(ContextAuditRecorderKey, recorder)
+		c.Next()
+	}
+}
+
+// RecordAudit queue a security event of the request. IP, user agent and path are of the request,
+// actor and method default to the authenticated credentials. No-op without the Audit middleware
+func RecordAudit(c *gin.Context, m *model.AuditEvent) {
+
+	value, _ := c.Get(ContextAuditRecorderKey)
+	recorder, ok := value.(AuditRecorderInterface)
+	if !ok {
+		return
+	}
+
+	m.IP = c.ClientIP()
+	m.UserAgent = c.Request.UserAgent()
+	m.Path = c.FullPath()
+	if m.Actor == "" {
+		m.Actor = c.GetString(ContextUsernameKey)
+	}
+	if user := UserFromContext(c); m.Actor == "" && user != nil {
+		m.Actor = user.Username
+	}
+	if m.Method == "" {
+		m.Method = credentialMethod(c)
+	}
+
+	recorder.Record(m)
+}
+
+// credentialMethod credential the Auth middleware accepted, empty before it
+func credentialMethod(c *gin.Context) model.AuditMethod {
+	if APIKeyFromContext(c) != nil {
+		return model.AuditMethodAPIKey
+	} else if SessionTokenFromContext(c) != nil {
+		return model.AuditMethodSession
+	} else if _, ok := c.Get(ContextTokenClaimsKey); ok {
+		return model.AuditMethodToken
+	}
+	return ""
+}
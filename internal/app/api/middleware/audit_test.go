@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	middlewareMock "walk_backend/internal/app/api/middleware/mock"
+	"walk_backend/internal/app/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordAudit(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockRecorder := middlewareMock.NewMockAuditRecorderInterface(controller)
+
+	viewer, err := model.NewUserModel("viewer", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+	assert.Nil(t, err)
+
+	router := gin.New()
+	router.GET("/unaudited", func(c *gin.Context) {
+		RecordAudit(c, &model.AuditEvent{Type: model.AuditEventLogin, Outcome: model.AuditOutcomeSuccess})
+		c.Status(http.StatusNoContent)
+	})
+	audited := router.Group("", Audit(mockRecorder), func(c *gin.Context) {
+		c.Set(ContextUserKey, viewer)
+	})
+	audited.DELETE("/places/:id", RequirePermission(model.PermissionPlaceDelete), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	mockRecorder.EXPECT().Record(gomock.Any()).Do(func(m *model.AuditEvent) {
+		assert.Equal(t, model.AuditEventAccessDenied, m.Type)
+		assert.Equal(t, model.AuditOutcomeFailure, m.Outcome)
+		assert.Equal(t, model.AuditReasonForbidden, m.Reason)
+		assert.Equal(t, "viewer", m.Actor)
+		assert.Equal(t, "192.0.2.1", m.IP)
+		assert.Equal(t, "curl/8.0", m.UserAgent)
+		assert.Equal(t, "/places/:id", m.Path)
+	})
+
+	request, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/places/1", nil)
+	request.RemoteAddr = "192.0.2.1:1234"
+	request.Header.Set("User-Agent", "curl/8.0")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+
+	// without the Audit middleware nothing is recorded
+	request, _ = http.NewRequestWithContext(context.Background(), http.MethodGet, "/unaudited", nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+}
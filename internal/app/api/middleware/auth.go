@@ -1,21 +1,176 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"strings"
+
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+	"walk_backend/internal/pkg/jwt"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 )
 
-// Auth middleware
-func Auth() gin.HandlerFunc {
+const (
+	// ContextUsernameKey key of the authenticated username in the gin context
+	ContextUsernameKey string = "username"
+	// ContextTokenClaimsKey key of the bearer *jwt.Claims in the gin context, unset for sessions
+	ContextTokenClaimsKey string = "token_claims"
+	// ContextSessionTokenKey key of the verified *model.SessionToken in the gin context, unset for bearer tokens
+	ContextSessionTokenKey string = "session_token"
+	// ContextAPIKeyKey key of the verified *model.APIKey in the gin context, set for API key requests only
+	ContextAPIKeyKey string = "api_key"
+	// APIKeyHeader ...
+	APIKeyHeader string = "X-API-Key"
+)
+
+// TokenVerifierInterface ...
+type TokenVerifierInterface interface {
+	VerifyAccess(ctx context.Context, token string) (*jwt.Claims, error)
+}
+
+// SessionTokenVerifierInterface ...
+type SessionTokenVerifierInterface interface {
+	Verify(ctx context.Context, token string) (*model.SessionToken, error)
+}
+
+// APIKeyVerifierInterface ...
+type APIKeyVerifierInterface interface {
+	Verify(ctx context.Context, key string) (*model.APIKey, error)
+}
+
+// Auth middleware accept an API key, a bearer access token or a session with a stored active token.
+// API key requests are limited to the routes allowing one of the key scopes, see RequireScope
+func Auth(tokens TokenVerifierInterface, sessionTokens SessionTokenVerifierInterface, apiKeys APIKeyVerifierInterface) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
+		if key := c.GetHeader(APIKeyHeader); key != "" {
+			apiKey, err := apiKeys.Verify(c.Request.Context(), key)
+			if err != nil {
+				_ = c.Error(err)
+				if errors.Is(err, service.ErrInvalidAPIKey) {
+					auditAuthentication(c, model.AuditMethodAPIKey, "")
+					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid API key"})
+					return
+				}
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Error verify API key"})
+				return
+			}
+
+			c.Set(ContextUsernameKey, apiKey.Username)
+			c.Set(ContextAPIKeyKey, apiKey)
+			c.Next()
+			return
+		}
+
+		if c.GetHeader("Authorization") != "" {
+			token := BearerToken(c)
+			if token == "" {
+				auditAuthentication(c, model.AuditMethodToken, "")
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header"})
+				return
+			}
+
+			claims, err := tokens.VerifyAccess(c.Request.Context(), token)
+			if err != nil {
+				_ = c.Error(err)
+				if errors.Is(err, service.ErrInvalidToken) ||
+					errors.Is(err, service.ErrTokenRevoked) ||
+					errors.Is(err, service.ErrTokensDisabled) {
+					auditAuthentication(c, model.AuditMethodToken, "")
+					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+					return
+				}
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Error verify token"})
+				return
+			}
+
+			c.Set(ContextUsernameKey, claims.Subject)
+			c.Set(ContextTokenClaimsKey, claims)
+			c.Next()
+			return
+		}
+
 		session := sessions.Default(c)
-		sessionToken := session.Get("token")
-		if sessionToken == nil {
+		sessionToken, ok := session.Get("token").(string)
+		if !ok {
 			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		sessionTokenModel, err := sessionTokens.Verify(c.Request.Context(), sessionToken)
+		if err != nil {
+			_ = c.Error(err)
+			if errors.Is(err, service.ErrInvalidSessionToken) {
+				username, _ := session.Get("username").(string)
+				auditAuthentication(c, model.AuditMethodSession, username)
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid session cookie"})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Error verify session"})
+			return
 		}
+
+		c.Set(ContextUsernameKey, sessionTokenModel.Username)
+		c.Set(ContextSessionTokenKey, sessionTokenModel)
 		c.Next()
 	}
 }
+
+// Optional middleware run handler, e.g. Auth or CurrentUser, for requests with an API key, an authorization
+// header or a session token, requests without credentials pass as guests
+func Optional(handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !hasCredentials(c) {
+			c.Next()
+			return
+		}
+		handler(c)
+	}
+}
+
+// hasCredentials the request has credentials for Auth
+func hasCredentials(c *gin.Context) bool {
+	if c.GetHeader(APIKeyHeader) != "" || c.GetHeader("Authorization") != "" {
+		return true
+	}
+	_, ok := sessions.Default(c).Get("token").(string)
+	return ok
+}
+
+// auditAuthentication record rejected credentials, actor is the username the credentials claim, when known
+func auditAuthentication(c *gin.Context, method model.AuditMethod, actor string) {
+	RecordAudit(c, &model.AuditEvent{
+		Type:    model.AuditEventAuthentication,
+		Outcome: model.AuditOutcomeFailure,
+		Reason:  model.AuditReasonInvalidToken,
+		Method:  method,
+		Actor:   actor,
+	})
+}
+
+// SessionTokenFromContext session token of the request, nil for bearer tokens or without Auth middleware
+func SessionTokenFromContext(c *gin.Context) *model.SessionToken {
+	value, _ := c.Get(ContextSessionTokenKey)
+	m, _ := value.(*model.SessionToken)
+	return m
+}
+
+// APIKeyFromContext API key of the request, nil for sessions and bearer tokens or without Auth middleware
+func APIKeyFromContext(c *gin.Context) *model.APIKey {
+	value, _ := c.Get(ContextAPIKeyKey)
+	m, _ := value.(*model.APIKey)
+	return m
+}
+
+// BearerToken token of the Authorization: Bearer header, empty when missing
+func BearerToken(c *gin.Context) string {
+	scheme, token, found := strings.Cut(c.GetHeader("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	middlewareMock "walk_backend/internal/app/api/middleware/mock"
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
+	"walk_backend/internal/pkg/jwt"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuth(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockTokenVerifier := middlewareMock.NewMockTokenVerifierInterface(controller)
+	mockSessionTokenVerifier := middlewareMock.NewMockSessionTokenVerifierInterface(controller)
+	mockAPIKeyVerifier := middlewareMock.NewMockAPIKeyVerifierInterface(controller)
+	mockUserFinder := middlewareMock.NewMockUserFinderInterface(controller)
+
+	editor, err := model.NewUserModel("editor", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+	assert.Nil(t, err)
+	editor.Roles = []model.Role{model.RoleEditor}
+	mockUserFinder.EXPECT().FindByUsername(gomock.Any(), "editor").Return(editor, nil).AnyTimes()
+
+	mockTokenVerifier.EXPECT().VerifyAccess(gomock.Any(), "valid").Return(&jwt.Claims{ID: "1", Subject: "editor"}, nil).AnyTimes()
+	mockTokenVerifier.EXPECT().VerifyAccess(gomock.Any(), "revoked").Return(nil, service.ErrTokenRevoked).AnyTimes()
+	mockTokenVerifier.EXPECT().VerifyAccess(gomock.Any(), "down").Return(nil, errors.New("redis down")).AnyTimes()
+	mockSessionTokenVerifier.EXPECT().Verify(gomock.Any(), "active").Return(&model.SessionToken{Username: "editor"}, nil).AnyTimes()
+	mockSessionTokenVerifier.EXPECT().Verify(gomock.Any(), "rotated").Return(nil, service.ErrInvalidSessionToken).AnyTimes()
+	placesKey := &model.APIKey{Username: "editor", Scopes: []model.APIKeyScope{model.APIKeyScopePlacesWrite}}
+	readKey := &model.APIKey{Username: "editor", Scopes: []model.APIKeyScope{model.APIKeyScopePlacesRead}}
+	mockAPIKeyVerifier.EXPECT().Verify(gomock.Any(), "walk_places").Return(placesKey, nil).AnyTimes()
+	mockAPIKeyVerifier.EXPECT().Verify(gomock.Any(), "walk_read").Return(readKey, nil).AnyTimes()
+	mockAPIKeyVerifier.EXPECT().Verify(gomock.Any(), "walk_revoked").Return(nil, service.ErrInvalidAPIKey).AnyTimes()
+
+	router := gin.New()
+	router.Use(Session("session", cookie.NewStore([]byte("secret"))))
+	router.GET("/login/:token", func(c *gin.Context) {
+		session := sessions.Default(c)
+		// the stored token owner wins over the session username
+		session.Set("username", "admin")
+		session.Set("token", c.Param("token"))
+		_ = session.Save()
+	})
+	auth := router.Group("", Auth(mockTokenVerifier, mockSessionTokenVerifier, mockAPIKeyVerifier), CurrentUser(mockUserFinder, model.NewAccessPolicy(nil, nil)))
+	auth.DELETE("/places", RequireScope(model.APIKeyScopePlacesWrite), RequirePermission(model.PermissionPlaceDelete), func(c *gin.Context) {
+		c.String(http.StatusOK, UserFromContext(c).Username)
+	})
+	auth.DELETE("/categories", RequirePermission(model.PermissionCategoryManage), func(c *gin.Context) {
+		c.String(http.StatusOK, UserFromContext(c).Username)
+	})
+	auth.POST("/me/password", DenyAPIKey(), func(c *gin.Context) {
+		c.String(http.StatusOK, UserFromContext(c).Username)
+	})
+	optional := router.Group("",
+		Optional(Auth(mockTokenVerifier, mockSessionTokenVerifier, mockAPIKeyVerifier)),
+		Optional(CurrentUser(mockUserFinder, model.NewAccessPolicy(nil, nil))),
+	)
+	optional.GET("/places/search", func(c *gin.Context) {
+		if user := UserFromContext(c); user != nil {
+			c.String(http.StatusOK, user.Username)
+			return
+		}
+		c.String(http.StatusOK, "guest")
+	})
+
+	serve := func(authorization string) *httptest.ResponseRecorder {
+		request, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/places", nil)
+		if authorization != "" {
+			request.Header.Set("Authorization", authorization)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	serveAPIKey := func(method string, path string, key string) *httptest.ResponseRecorder {
+		request, _ := http.NewRequestWithContext(context.Background(), method, path, nil)
+		request.Header.Set(APIKeyHeader, key)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	serveSession := func(token string) *httptest.ResponseRecorder {
+		request, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/login/"+token, nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		cookies := recorder.Result().Cookies()
+
+		request, _ = http.NewRequestWithContext(context.Background(), http.MethodDelete, "/places", nil)
+		for _, cookie := range cookies {
+			request.AddCookie(cookie)
+		}
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	recorder := serve("Bearer valid")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "editor", recorder.Body.String())
+
+	assert.Equal(t, http.StatusOK, serve("bearer valid").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("Bearer revoked").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("Basic ZWRpdG9yOnBhc3N3b3Jk").Code)
+	assert.Equal(t, http.StatusInternalServerError, serve("Bearer down").Code)
+	assert.Equal(t, http.StatusForbidden, serve("").Code)
+
+	recorder = serveSession("active")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "editor", recorder.Body.String())
+	assert.Equal(t, http.StatusUnauthorized, serveSession("rotated").Code)
+
+	recorder = serveAPIKey(http.MethodDelete, "/places", "walk_places")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "editor", recorder.Body.String())
+	assert.Equal(t, http.StatusForbidden, serveAPIKey(http.MethodDelete, "/places", "walk_read").Code)
+	assert.Equal(t, http.StatusUnauthorized, serveAPIKey(http.MethodDelete, "/places", "walk_revoked").Code)
+	// routes without a scope and routes of people only deny every key
+	assert.Equal(t, http.StatusForbidden, serveAPIKey(http.MethodDelete, "/categories", "walk_places").Code)
+	assert.Equal(t, http.StatusForbidden, serveAPIKey(http.MethodPost, "/me/password", "walk_places").Code)
+
+	// optional routes verify credentials when there are any
+	request, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/places/search", nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "guest", recorder.Body.String())
+
+	request.Header.Set("Authorization", "Bearer valid")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, "editor", recorder.Body.String())
+
+	request.Header.Set("Authorization", "Bearer revoked")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
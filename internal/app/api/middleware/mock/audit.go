@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/api/middleware/audit.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuditRecorderInterface is a mock of AuditRecorderInterface interface.
+type MockAuditRecorderInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditRecorderInterfaceMockRecorder
+}
+
+// MockAuditRecorderInterfaceMockRecorder is the mock recorder for MockAuditRecorderInterface.
+type MockAuditRecorderInterfaceMockRecorder struct {
+	mock *MockAuditRecorderInterface
+}
+
+// NewMockAuditRecorderInterface creates a new mock instance.
+func NewMockAuditRecorderInterface(ctrl *gomock.Controller) *MockAuditRecorderInterface {
+	mock := &MockAuditRecorderInterface{ctrl: ctrl}
+	mock.recorder = &MockAuditRecorderInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRecorderInterface) EXPECT() *MockAuditRecorderInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m_2 *MockAuditRecorderInterface) Record(m *model.AuditEvent) {
+	m_2.ctrl.T.Helper()
+	m_2.ctrl.Call(m_2, "Record", m)
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditRecorderInterfaceMockRecorder) Record(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditRecorderInterface)(nil).Record), m)
+}
@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/api/middleware/auth.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	model "walk_backend/internal/app/model"
+	jwt "walk_backend/internal/pkg/jwt"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTokenVerifierInterface is a mock of TokenVerifierInterface interface.
+type MockTokenVerifierInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenVerifierInterfaceMockRecorder
+}
+
+// MockTokenVerifierInterfaceMockRecorder is the mock recorder for MockTokenVerifierInterface.
+type MockTokenVerifierInterfaceMockRecorder struct {
+	mock *MockTokenVerifierInterface
+}
+
+// NewMockTokenVerifierInterface creates a new mock instance.
+func NewMockTokenVerifierInterface(ctrl *gomock.Controller) *MockTokenVerifierInterface {
+	mock := &MockTokenVerifierInterface{ctrl: ctrl}
+	mock.recorder = &MockTokenVerifierInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenVerifierInterface) EXPECT() *MockTokenVerifierInterfaceMockRecorder {
+	return m.recorder
+}
+
+// VerifyAccess mocks base method.
+func (m *MockTokenVerifierInterface) VerifyAccess(ctx context.Context, token string) (*jwt.Claims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyAccess", ctx, token)
+	ret0, _ := ret[0].(*jwt.Claims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyAccess indicates an expected call of VerifyAccess.
+func (mr *MockTokenVerifierInterfaceMockRecorder) VerifyAccess(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAccess", reflect.TypeOf((*MockTokenVerifierInterface)(nil).VerifyAccess), ctx, token)
+}
+
+// MockSessionTokenVerifierInterface is a mock of SessionTokenVerifierInterface interface.
+type MockSessionTokenVerifierInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionTokenVerifierInterfaceMockRecorder
+}
+
+// MockSessionTokenVerifierInterfaceMockRecorder is the mock recorder for MockSessionTokenVerifierInterface.
+type MockSessionTokenVerifierInterfaceMockRecorder struct {
+	mock *MockSessionTokenVerifierInterface
+}
+
+// NewMockSessionTokenVerifierInterface creates a new mock instance.
+func NewMockSessionTokenVerifierInterface(ctrl *gomock.Controller) *MockSessionTokenVerifierInterface {
+	mock := &MockSessionTokenVerifierInterface{ctrl: ctrl}
+	mock.recorder = &MockSessionTokenVerifierInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionTokenVerifierInterface) EXPECT() *MockSessionTokenVerifierInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Verify mocks base method.
+func (m *MockSessionTokenVerifierInterface) Verify(ctx context.Context, token string) (*model.SessionToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, token)
+	ret0, _ := ret[0].(*model.SessionToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockSessionTokenVerifierInterfaceMockRecorder) Verify(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockSessionTokenVerifierInterface)(nil).Verify), ctx, token)
+}
+
+// MockAPIKeyVerifierInterface is a mock of APIKeyVerifierInterface interface.
+type MockAPIKeyVerifierInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyVerifierInterfaceMockRecorder
+}
+
+// MockAPIKeyVerifierInterfaceMockRecorder is the mock recorder for MockAPIKeyVerifierInterface.
+type MockAPIKeyVerifierInterfaceMockRecorder struct {
+	mock *MockAPIKeyVerifierInterface
+}
+
+// NewMockAPIKeyVerifierInterface creates a new mock instance.
+func NewMockAPIKeyVerifierInterface(ctrl *gomock.Controller) *MockAPIKeyVerifierInterface {
+	mock := &MockAPIKeyVerifierInterface{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyVerifierInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyVerifierInterface) EXPECT() *MockAPIKeyVerifierInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Verify mocks base method.
+func (m *MockAPIKeyVerifierInterface) Verify(ctx context.Context, key string) (*model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, key)
+	ret0, _ := ret[0].(*model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockAPIKeyVerifierInterfaceMockRecorder) Verify(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockAPIKeyVerifierInterface)(nil).Verify), ctx, key)
+}
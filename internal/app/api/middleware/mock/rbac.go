@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/api/middleware/rbac.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserFinderInterface is a mock of UserFinderInterface interface.
+type MockUserFinderInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserFinderInterfaceMockRecorder
+}
+
+// MockUserFinderInterfaceMockRecorder is the mock recorder for MockUserFinderInterface.
+type MockUserFinderInterfaceMockRecorder struct {
+	mock *MockUserFinderInterface
+}
+
+// NewMockUserFinderInterface creates a new mock instance.
+func NewMockUserFinderInterface(ctrl *gomock.Controller) *MockUserFinderInterface {
+	mock := &MockUserFinderInterface{ctrl: ctrl}
+	mock.recorder = &MockUserFinderInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserFinderInterface) EXPECT() *MockUserFinderInterfaceMockRecorder {
+	return m.recorder
+}
+
+// FindByUsername mocks base method.
+func (m *MockUserFinderInterface) FindByUsername(ctx context.Context, username string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUsername", ctx, username)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUsername indicates an expected call of FindByUsername.
+func (mr *MockUserFinderInterfaceMockRecorder) FindByUsername(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsername", reflect.TypeOf((*MockUserFinderInterface)(nil).FindByUsername), ctx, username)
+}
@@ -0,0 +1,189 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"walk_backend/internal/app/model"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// ContextUserKey key of the current *model.User in the gin context
+	ContextUserKey string = "user"
+	// contextScopeCheckedKey RequireScope allowed the API key of the request
+	contextScopeCheckedKey string = "api_key_scope_checked"
+	// contextAccessPolicyKey *model.AccessPolicy of RequireRole and RequirePermission
+	contextAccessPolicyKey string = "access_policy"
+)
+
+// UserFinderInterface ...
+type UserFinderInterface interface {
+	FindByUsername(ctx context.Context, username string) (*model.User, error)
+}
+
+// CurrentUser middleware load the bearer token or session user with roles into the request context, use after Auth.
+// RequireRole and RequirePermission check the user against access
+func CurrentUser(users UserFinderInterface, access *model.AccessPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		username := c.GetString(ContextUsernameKey)
+		if username == "" {
+			username, _ = sessions.Default(c).Get("username").(string)
+		}
+		if username == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid session cookie"})
+			return
+		}
+
+		user, err := users.FindByUsername(c.Request.Context(), username)
+		if err != nil {
+			_ = c.Error(err)
+			if errors.Is(err, model.ErrModelNotFound) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid session cookie"})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Error load user"})
+			return
+		}
+
+		c.Set(ContextUserKey, user)
+		c.Set(contextAccessPolicyKey, access)
+		c.Next()
+	}
+}
+
+// UserFromContext current user, nil without CurrentUser middleware
+func UserFromContext(c *gin.Context) *model.User {
+	value, _ := c.Get(ContextUserKey)
+	user, _ := value.(*model.User)
+	return user
+}
+
+// accessPolicyFromContext access policy of CurrentUser, a policy without checks otherwise
+func accessPolicyFromContext(c *gin.Context) *model.AccessPolicy {
+	value, _ := c.Get(contextAccessPolicyKey)
+	if access, ok := value.(*model.AccessPolicy); ok && access != nil {
+		return access
+	}
+	return model.NewAccessPolicy(nil, nil)
+}
+
+// RequireScope middleware API key requests need one of the scopes, other requests pass.
+// API keys are denied by RequireRole, RequirePermission and DenyAPIKey unless a RequireScope allowed them
+func RequireScope(scopes ...model.APIKeyScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		key := APIKeyFromContext(c)
+		if key == nil {
+			c.Next()
+			return
+		}
+
+		for _, scope := range scopes {
+			if key.HasScope(scope) {
+				c.Set(contextScopeCheckedKey, true)
+				c.Next()
+				return
+			}
+		}
+
+		auditAccessDenied(c, model.AuditReasonForbidden)
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "API key scope required"})
+	}
+}
+
+// DenyAPIKey middleware for routes of people only, e.g. password and API key management
+func DenyAPIKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if abortAPIKey(c) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// abortAPIKey abort API key requests not allowed by a RequireScope
+func abortAPIKey(c *gin.Context) bool {
+	if APIKeyFromContext(c) == nil || c.GetBool(contextScopeCheckedKey) {
+		return false
+	}
+	auditAccessDenied(c, model.AuditReasonForbidden)
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Not allowed with an API key"})
+	return true
+}
+
+// abortTwoFactorRequired abort requests of users who must enable two-factor authentication first
+func abortTwoFactorRequired(c *gin.Context, access *model.AccessPolicy, user *model.User) bool {
+	if user == nil || !access.TwoFactorRequired(user) {
+		return false
+	}
+	auditAccessDenied(c, model.AuditReasonTwoFactorRequired)
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Two-factor authentication required, enable it at /me/2fa"})
+	return true
+}
+
+// auditAccessDenied record the denied request of an authenticated user
+func auditAccessDenied(c *gin.Context, reason model.AuditReason) {
+	RecordAudit(c, &model.AuditEvent{
+		Type:    model.AuditEventAccessDenied,
+		Outcome: model.AuditOutcomeFailure,
+		Reason:  reason,
+	})
+}
+
+// RequireRole middleware current user must have one of the roles
+func RequireRole(roles ...model.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		if abortAPIKey(c) {
+			return
+		}
+
+		user := UserFromContext(c)
+		if abortTwoFactorRequired(c, accessPolicyFromContext(c), user) {
+			return
+		}
+		if user != nil {
+			for _, role := range roles {
+				if user.HasRole(role) {
+					c.Next()
+					return
+				}
+			}
+		}
+
+		auditAccessDenied(c, model.AuditReasonForbidden)
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+	}
+}
+
+// RequirePermission middleware a role of the current user must grant the permission
+func RequirePermission(permission model.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		if abortAPIKey(c) {
+			return
+		}
+		user := UserFromContext(c)
+		access := accessPolicyFromContext(c)
+		if abortTwoFactorRequired(c, access, user) {
+			return
+		}
+		if user != nil && !user.IsEmailVerified() && access.NeedsVerifiedEmail(permission) {
+			auditAccessDenied(c, model.AuditReasonEmailNotVerified)
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Email not verified"})
+			return
+		}
+		if user == nil || !access.Can(user, permission) {
+			auditAccessDenied(c, model.AuditReasonForbidden)
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+			return
+		}
+
+		c.Next()
+	}
+}
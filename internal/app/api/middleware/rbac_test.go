@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	middlewareMock "walk_backend/internal/app/api/middleware/mock"
+	"walk_backend/internal/app/model"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequirePermission(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockUserFinder := middlewareMock.NewMockUserFinderInterface(controller)
+
+	editor, err := model.NewUserModel("editor", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+	assert.Nil(t, err)
+	editor.Roles = []model.Role{model.RoleEditor}
+	mockUserFinder.EXPECT().FindByUsername(gomock.Any(), "editor").Return(editor, nil).AnyTimes()
+	contributor, err := model.NewUserModel("contributor", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+	assert.Nil(t, err)
+	now := time.Now()
+	contributor.EmailVerifiedAt = &now
+	mockUserFinder.EXPECT().FindByUsername(gomock.Any(), "contributor").Return(contributor, nil).AnyTimes()
+	mockUserFinder.EXPECT().FindByUsername(gomock.Any(), "gone").Return(nil, model.ErrModelNotFound).AnyTimes()
+
+	router := gin.New()
+	router.Use(Session("session", cookie.NewStore([]byte("secret"))))
+	router.GET("/login/:username", func(c *gin.Context) {
+		session := sessions.Default(c)
+		session.Set("username", c.Param("username"))
+		_ = session.Save()
+	})
+	access := model.NewAccessPolicy([]model.Permission{model.PermissionPlaceCreate}, nil)
+	auth := router.Group("", CurrentUser(mockUserFinder, access))
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	auth.POST("/places", RequirePermission(model.PermissionPlaceCreate), ok)
+	auth.DELETE("/places", RequirePermission(model.PermissionPlaceDelete), ok)
+	auth.DELETE("/categories", RequirePermission(model.PermissionCategoryManage), ok)
+	auth.GET("/admin", RequireRole(model.RoleAdmin), ok)
+
+	serve := func(method string, path string, username string) int {
+		cookies := make([]*http.Cookie, 0)
+		if username != "" {
+			request, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/login/"+username, nil)
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+			cookies = recorder.Result().Cookies()
+		}
+
+		request, _ := http.NewRequestWithContext(context.Background(), method, path, nil)
+		for _, cookie := range cookies {
+			request.AddCookie(cookie)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodDelete, "/places", "editor"))
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, "/places", "editor"))
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodPost, "/places", "contributor"))
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodDelete, "/places", "contributor"))
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodDelete, "/categories", "editor"))
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodGet, "/admin", "editor"))
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodDelete, "/places", "gone"))
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodDelete, "/places", ""))
+
+	*access = *model.NewAccessPolicy([]model.Permission{model.PermissionPlaceCreate}, []model.Role{model.RoleEditor})
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodDelete, "/places", "editor"))
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodPost, "/places", "contributor"))
+}
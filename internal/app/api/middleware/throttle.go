@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"walk_backend/internal/app/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AbortThrottled abort with 429 and Retry-After for a throttled attempt, 500 for other errors,
+// false when err is nil
+func AbortThrottled(c *gin.Context, err error) bool {
+
+	if err == nil {
+		return false
+	}
+	_ = c.Error(err)
+
+	var throttledErr *service.ThrottledError
+	if errors.As(err, &throttledErr) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttledErr.RetryAfter.Seconds()))))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": throttledErr.Unwrap().Error()})
+		return true
+	}
+
+	c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Error check login attempts"})
+	return true
+}
@@ -0,0 +1,57 @@
+package presenter
+
+import (
+	"time"
+
+	"walk_backend/internal/app/model"
+)
+
+// APIKey API key without the secret, Key is set once on creation
+type APIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Key        string     `json:"key,omitempty"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+}
+
+// NewAPIKeyPresenter create new API key presenter
+func NewAPIKeyPresenter() *APIKey {
+	return &APIKey{}
+}
+
+// Make make API key presenter
+func (p APIKey) Make(m *model.APIKey) *APIKey {
+	p.ID = m.ID.String()
+	p.Name = m.Name
+	p.Prefix = m.Prefix
+	p.Scopes = make([]string, 0, len(m.Scopes))
+	for _, scope := range m.Scopes {
+		p.Scopes = append(p.Scopes, string(scope))
+	}
+	p.CreatedAt = m.CreatedAt
+	p.ExpiresAt = m.ExpiresAt
+	p.LastUsedAt = m.LastUsedAt
+	return &p
+}
+
+// MakeCreated make API key presenter with the raw key, shown only once
+func (p APIKey) MakeCreated(key string, m *model.APIKey) *APIKey {
+	created := p.Make(m)
+	created.Key = key
+	return created
+}
+
+// MakeList make API key presenter list
+func (p APIKey) MakeList(mList []*model.APIKey) []*APIKey {
+
+	list := make([]*APIKey, 0, len(mList))
+	for _, m := range mList {
+		list = append(list, p.Make(m))
+	}
+
+	return list
+}
@@ -0,0 +1,52 @@
+package presenter
+
+import (
+	"time"
+
+	"walk_backend/internal/app/model"
+)
+
+// AuditEvent ...
+type AuditEvent struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Outcome   string    `json:"outcome"`
+	Reason    string    `json:"reason,omitempty"`
+	Method    string    `json:"method,omitempty"`
+	Actor     string    `json:"actor,omitempty"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"userAgent,omitempty"`
+	Path      string    `json:"path,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// NewAuditEventPresenter create new audit event presenter
+func NewAuditEventPresenter() *AuditEvent {
+	return &AuditEvent{}
+}
+
+// Make make audit event presenter
+func (p AuditEvent) Make(m *model.AuditEvent) *AuditEvent {
+	p.ID = m.ID.String()
+	p.Type = string(m.Type)
+	p.Outcome = string(m.Outcome)
+	p.Reason = string(m.Reason)
+	p.Method = string(m.Method)
+	p.Actor = m.Actor
+	p.IP = m.IP
+	p.UserAgent = m.UserAgent
+	p.Path = m.Path
+	p.CreatedAt = m.CreatedAt
+	return &p
+}
+
+// MakeList make audit event presenter list
+func (p AuditEvent) MakeList(mList []*model.AuditEvent) []*AuditEvent {
+
+	list := make([]*AuditEvent, 0, len(mList))
+	for _, m := range mList {
+		list = append(list, p.Make(m))
+	}
+
+	return list
+}
@@ -6,9 +6,16 @@ import (
 
 // Category ...
 type Category struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Order int8   `json:"order"`
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Slug        string      `json:"slug"`
+	Order       int32       `json:"order"`
+	ParentID    string      `json:"parentId,omitempty"`
+	Icon        string      `json:"icon,omitempty"`
+	Color       string      `json:"color,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Places      *int64      `json:"places,omitempty"`
+	Children    []*Category `json:"children,omitempty"`
 }
 
 // NewCategoryPresenter creaete new category presenter
@@ -20,7 +27,17 @@ func NewCategoryPresenter() *Category {
 func (p Category) Make(m *model.Category) *Category {
 	p.ID = m.ID.String()
 	p.Name = m.Name
+	p.Slug = m.Slug
 	p.Order = m.Order
+	p.ParentID = ""
+	if m.ParentID != nil {
+		p.ParentID = m.ParentID.String()
+	}
+	p.Icon = m.Icon
+	p.Color = m.Color
+	p.Description = m.Description
+	p.Places = nil
+	p.Children = nil
 	return &p
 }
 
@@ -34,3 +51,37 @@ func (p *Category) MakeList(mList model.CategoryList) []*Category {
 
 	return list
 }
+
+// MakeListWithCounts make category presenter list with number of places
+func (p *Category) MakeListWithCounts(mList model.CategoryList, counts model.CategoryCounts) []*Category {
+
+	list := p.MakeList(mList)
+	for i, m := range mList {
+		count := counts[m.ID]
+		list[i].Places = &count
+	}
+
+	return list
+}
+
+// MakeTree make nested category presenters, siblings sorted by order
+func (p *Category) MakeTree(mList model.CategoryList) []*Category {
+	return p.makeTreeLevel(mList, model.NilID, make(map[model.ID]struct{}))
+}
+
+func (p *Category) makeTreeLevel(mList model.CategoryList, parentID model.ID, seen map[model.ID]struct{}) []*Category {
+
+	children := mList.Children(parentID)
+	list := make([]*Category, 0, len(children))
+	for _, m := range children {
+		if _, ok := seen[m.ID]; ok {
+			continue
+		}
+		seen[m.ID] = struct{}{}
+		node := p.Make(m)
+		node.Children = p.makeTreeLevel(mList, m.ID, seen)
+		list = append(list, node)
+	}
+
+	return list
+}
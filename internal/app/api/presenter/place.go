@@ -6,11 +6,18 @@ import (
 
 // Place list data
 type Place struct {
-	ID          string   `json:"id"`
-	Name        string   `json:"name"`
-	Description string   `json:"description"`
-	Category    Category `json:"category"`
-	Tags        []string `json:"tags"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Category    Category  `json:"category"`
+	Tags        []string  `json:"tags"`
+	Location    *Location `json:"location,omitempty"`
+}
+
+// Location ...
+type Location struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
 }
 
 // NewPlacePresenter create new place presenter
@@ -23,8 +30,15 @@ func (p Place) Make(m *model.Place, c *model.Category) *Place {
 	p.ID = m.ID.String()
 	p.Name = m.Name
 	p.Description = m.Description
-	p.Category = *p.Category.Make(c)
+	p.Category = Category{}
+	if c != nil {
+		p.Category = *p.Category.Make(c)
+	}
 	p.Tags = m.Tags
+	p.Location = nil
+	if m.Location != nil {
+		p.Location = &Location{Lat: m.Location.Lat(), Lng: m.Location.Lng()}
+	}
 	return &p
 }
 
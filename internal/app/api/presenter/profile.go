@@ -0,0 +1,64 @@
+package presenter
+
+import (
+	"time"
+
+	"walk_backend/internal/app/model"
+)
+
+// Profile profile of the current user
+type Profile struct {
+	ID               string    `json:"id"`
+	Username         string    `json:"username"`
+	DisplayName      string    `json:"displayName"`
+	AvatarURL        string    `json:"avatarUrl"`
+	Locale           string    `json:"locale"`
+	Email            string    `json:"email,omitempty"`
+	EmailVerified    bool      `json:"emailVerified"`
+	Roles            []string  `json:"roles"`
+	TwoFactorEnabled bool      `json:"twoFactorEnabled"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+// UserExport personal data archive of the current user
+type UserExport struct {
+	ExportedAt time.Time  `json:"exportedAt"`
+	Profile    *Profile   `json:"profile"`
+	Sessions   []*Session `json:"sessions"`
+	APIKeys    []*APIKey  `json:"apiKeys"`
+	Places     []*Place   `json:"places"`
+}
+
+// NewProfilePresenter create new profile presenter
+func NewProfilePresenter() *Profile {
+	return &Profile{}
+}
+
+// Make make profile presenter
+func (p Profile) Make(m *model.User) *Profile {
+	p.ID = m.ID.String()
+	p.Username = m.Username
+	p.DisplayName = m.DisplayName
+	p.AvatarURL = m.AvatarURL
+	p.Locale = m.Locale
+	p.Email = m.Email
+	p.EmailVerified = m.IsEmailVerified()
+	p.Roles = make([]string, 0, len(m.Roles))
+	for _, role := range m.Roles {
+		p.Roles = append(p.Roles, string(role))
+	}
+	p.TwoFactorEnabled = m.IsTwoFactorEnabled()
+	p.CreatedAt = m.CreatedAt
+	return &p
+}
+
+// MakeExport make personal data archive presenter, currentSessionID is the session ID of the request
+func (p Profile) MakeExport(m *model.UserExport, currentSessionID model.ID) *UserExport {
+	return &UserExport{
+		ExportedAt: m.ExportedAt,
+		Profile:    p.Make(m.User),
+		Sessions:   NewSessionPresenter().MakeList(m.Sessions, currentSessionID),
+		APIKeys:    NewAPIKeyPresenter().MakeList(m.APIKeys),
+		Places:     NewPlacePresenter().MakeList(m.Places, m.Categories),
+	}
+}
@@ -0,0 +1,62 @@
+package presenter
+
+import (
+	"time"
+
+	"walk_backend/internal/app/model"
+)
+
+// SearchStats ...
+type SearchStats struct {
+	From              time.Time           `json:"from"`
+	To                time.Time           `json:"to"`
+	Searches          int64               `json:"searches"`
+	Clicked           int64               `json:"clicked"`
+	ClickThroughRate  float64             `json:"clickThroughRate"`
+	TopQueries        []*SearchQueryStats `json:"topQueries"`
+	ZeroResultQueries []*SearchQueryStats `json:"zeroResultQueries"`
+}
+
+// SearchQueryStats ...
+type SearchQueryStats struct {
+	Query            string  `json:"query"`
+	Count            int64   `json:"count"`
+	AvgHits          float64 `json:"avgHits"`
+	AvgLatencyMs     float64 `json:"avgLatencyMs"`
+	Clicked          int64   `json:"clicked"`
+	ClickThroughRate float64 `json:"clickThroughRate"`
+}
+
+// NewSearchStatsPresenter create new search stats presenter
+func NewSearchStatsPresenter() *SearchStats {
+	return &SearchStats{}
+}
+
+// Make make search stats presenter
+func (p SearchStats) Make(m *model.SearchStats) *SearchStats {
+	p.From = m.From
+	p.To = m.To
+	p.Searches = m.Searches
+	p.Clicked = m.Clicked
+	p.ClickThroughRate = m.ClickThroughRate
+	p.TopQueries = makeSearchQueryStatsList(m.TopQueries)
+	p.ZeroResultQueries = makeSearchQueryStatsList(m.ZeroResultQueries)
+	return &p
+}
+
+func makeSearchQueryStatsList(mList []*model.SearchQueryStats) []*SearchQueryStats {
+
+	list := make([]*SearchQueryStats, 0, len(mList))
+	for _, m := range mList {
+		list = append(list, &SearchQueryStats{
+			Query:            m.Query,
+			Count:            m.Count,
+			AvgHits:          m.AvgHits,
+			AvgLatencyMs:     m.AvgLatencyMs,
+			Clicked:          m.Clicked,
+			ClickThroughRate: m.ClickThroughRate,
+		})
+	}
+
+	return list
+}
@@ -0,0 +1,48 @@
+package presenter
+
+import (
+	"time"
+
+	"walk_backend/internal/app/model"
+)
+
+// Session active login session
+type Session struct {
+	ID         string    `json:"id"`
+	Device     string    `json:"device"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"userAgent"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	Current    bool      `json:"current"`
+}
+
+// NewSessionPresenter create new session presenter
+func NewSessionPresenter() *Session {
+	return &Session{}
+}
+
+// Make make session presenter, current is the session of the request
+func (p Session) Make(m *model.SessionToken, current bool) *Session {
+	p.ID = m.FamilyID.String()
+	p.Device = m.Device.Name()
+	p.IP = m.Device.IP
+	p.UserAgent = m.Device.UserAgent
+	p.CreatedAt = m.SessionCreatedAt
+	p.LastSeenAt = m.LastSeenAt
+	p.ExpiresAt = m.ExpiresAt
+	p.Current = current
+	return &p
+}
+
+// MakeList make session presenter list, currentID is the session ID of the request
+func (p Session) MakeList(mList []*model.SessionToken, currentID model.ID) []*Session {
+
+	list := make([]*Session, 0, len(mList))
+	for _, m := range mList {
+		list = append(list, p.Make(m, m.FamilyID == currentID))
+	}
+
+	return list
+}
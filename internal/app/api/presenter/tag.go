@@ -0,0 +1,34 @@
+package presenter
+
+import (
+	"walk_backend/internal/app/model"
+)
+
+// Tag ...
+type Tag struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// NewTagPresenter create new tag presenter
+func NewTagPresenter() *Tag {
+	return &Tag{}
+}
+
+// Make make tag presenter
+func (p Tag) Make(m *model.Tag) *Tag {
+	p.Name = m.Name
+	p.Count = m.Count
+	return &p
+}
+
+// MakeList make tag presenter list
+func (p *Tag) MakeList(mList model.TagList) []*Tag {
+
+	list := make([]*Tag, 0, len(mList))
+	for _, m := range mList {
+		list = append(list, p.Make(m))
+	}
+
+	return list
+}
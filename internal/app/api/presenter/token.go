@@ -1,9 +1,28 @@
 package presenter
 
-// Token ...
+import (
+	"time"
+
+	"walk_backend/internal/app/model"
+)
+
+const (
+	// TokenTypeBearer ...
+	TokenTypeBearer string = "Bearer"
+)
+
+// Token session token or bearer token pair
 type Token struct {
-	Token string `json:"token"`
-	// Expires time.Time `json:"expires"`
+	Token        string `json:"token,omitempty"`
+	AccessToken  string `json:"accessToken,omitempty"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+	TokenType    string `json:"tokenType,omitempty"`
+	// ExpiresIn access token lifetime in seconds
+	ExpiresIn int64 `json:"expiresIn,omitempty"`
+	// Expires session token or two-factor challenge expiry
+	Expires *time.Time `json:"expires,omitempty"`
+	// TwoFactorChallenge completes the login with a two-factor code
+	TwoFactorChallenge string `json:"twoFactorChallenge,omitempty"`
 }
 
 // NewTokenPresenter create new token presenter
@@ -11,8 +30,25 @@ func NewTokenPresenter() *Token {
 	return &Token{}
 }
 
-// Make make token presenter
-func (p Token) Make(token string) *Token {
+// Make make session token presenter
+func (p Token) Make(token string, expires time.Time) *Token {
 	p.Token = token
+	p.Expires = &expires
+	return &p
+}
+
+// MakeChallenge make two-factor challenge presenter of a login waiting for the code
+func (p Token) MakeChallenge(challenge string, expires time.Time) *Token {
+	p.TwoFactorChallenge = challenge
+	p.Expires = &expires
+	return &p
+}
+
+// MakePair make bearer token pair presenter
+func (p Token) MakePair(pair *model.TokenPair) *Token {
+	p.AccessToken = pair.AccessToken
+	p.RefreshToken = pair.RefreshToken
+	p.TokenType = TokenTypeBearer
+	p.ExpiresIn = int64(pair.AccessTTL.Seconds())
 	return &p
 }
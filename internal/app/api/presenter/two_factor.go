@@ -0,0 +1,49 @@
+package presenter
+
+import (
+	"walk_backend/internal/app/model"
+)
+
+// TwoFactor two-factor authentication state of the current user, the secret and the recovery codes are shown once
+type TwoFactor struct {
+	Enabled           bool     `json:"enabled"`
+	Required          bool     `json:"required"`
+	RecoveryCodesLeft int      `json:"recoveryCodesLeft"`
+	Secret            string   `json:"secret,omitempty"`
+	URI               string   `json:"uri,omitempty"`
+	RecoveryCodes     []string `json:"recoveryCodes,omitempty"`
+
+	access *model.AccessPolicy
+}
+
+// NewTwoFactorPresenter create new two-factor presenter, access tells which users require it
+func NewTwoFactorPresenter(access *model.AccessPolicy) *TwoFactor {
+	return &TwoFactor{access: access}
+}
+
+// Make make two-factor state presenter
+func (p TwoFactor) Make(m *model.User) *TwoFactor {
+	p.Enabled = m.IsTwoFactorEnabled()
+	p.Required = p.access.NeedsTwoFactor(m)
+	if p.Enabled {
+		p.RecoveryCodesLeft = len(m.TwoFactor.RecoveryCodes)
+	}
+	return &p
+}
+
+// MakeEnrolment make presenter of a started enrolment, the URI is shown as a QR code
+func (p TwoFactor) MakeEnrolment(m *model.User, secret string, uri string) *TwoFactor {
+	state := p.Make(m)
+	state.Secret = secret
+	state.URI = uri
+	return state
+}
+
+// MakeRecoveryCodes make presenter of new recovery codes, two-factor is enabled with them
+func (p TwoFactor) MakeRecoveryCodes(m *model.User, codes []string) *TwoFactor {
+	p.Enabled = true
+	p.Required = p.access.NeedsTwoFactor(m)
+	p.RecoveryCodesLeft = len(codes)
+	p.RecoveryCodes = codes
+	return &p
+}
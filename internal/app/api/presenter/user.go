@@ -0,0 +1,32 @@
+package presenter
+
+import (
+	"walk_backend/internal/app/model"
+)
+
+// User ...
+type User struct {
+	ID            string   `json:"id"`
+	Username      string   `json:"username"`
+	Email         string   `json:"email,omitempty"`
+	EmailVerified bool     `json:"emailVerified"`
+	Roles         []string `json:"roles"`
+}
+
+// NewUserPresenter create new user presenter
+func NewUserPresenter() *User {
+	return &User{}
+}
+
+// Make make user presenter
+func (p User) Make(m *model.User) *User {
+	p.ID = m.ID.String()
+	p.Username = m.Username
+	p.Email = m.Email
+	p.EmailVerified = m.IsEmailVerified()
+	p.Roles = make([]string, 0, len(m.Roles))
+	for _, role := range m.Roles {
+		p.Roles = append(p.Roles, string(role))
+	}
+	return &p
+}
@@ -0,0 +1,18 @@
+package dto
+
+import (
+	"time"
+)
+
+// NewAPIKeyCreateDTO create new API key create DTO
+func NewAPIKeyCreateDTO() *APIKeyCreate {
+	return &APIKeyCreate{}
+}
+
+// APIKeyCreate ...
+type APIKeyCreate struct {
+	Name   string   `json:"name"   binding:"required,max=100"`
+	Scopes []string `json:"scopes" binding:"required,min=1,dive,oneof=places:read places:write categories:write"`
+	// ExpiresAt the key never expires when empty
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
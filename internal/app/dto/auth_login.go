@@ -10,3 +10,15 @@ type AuthLogin struct {
 	Username string `json:"username" binding:"required"`
 	Password string `json:"password" binding:"required"`
 }
+
+// NewAuthRegistrationDTO create new auth registration DTO
+func NewAuthRegistrationDTO() *AuthRegistration {
+	return &AuthRegistration{}
+}
+
+// AuthRegistration ...
+type AuthRegistration struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Email    string `json:"email"    binding:"required,email"`
+}
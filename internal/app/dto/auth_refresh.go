@@ -0,0 +1,11 @@
+package dto
+
+// NewAuthRefreshDTO create new auth refresh DTO
+func NewAuthRefreshDTO() *AuthRefresh {
+	return &AuthRefresh{}
+}
+
+// AuthRefresh bearer refresh or revoke
+type AuthRefresh struct {
+	RefreshToken string `json:"refreshToken" binding:"required,jwt"`
+}
@@ -9,5 +9,22 @@ func NewCategoryDTO() *Category {
 type Category struct {
 	ID    string `json:"id" binding:"-"`
 	Name  string `json:"name" binding:"required"`
-	Order int8   `json:"order" binding:"required"`
+	Order int32  `json:"order" binding:"required"`
+	// ParentID empty for root category
+	ParentID string `json:"parentId" binding:"omitempty,uuid"`
+	// Slug generated from the name when empty, kept on update when empty
+	Slug        string `json:"slug" binding:"omitempty,max=100"`
+	Icon        string `json:"icon" binding:"omitempty,max=64"`
+	Color       string `json:"color" binding:"omitempty,hexcolor"`
+	Description string `json:"description" binding:"omitempty,max=1000"`
+}
+
+// NewCategoryOrderDTO create new category order DTO
+func NewCategoryOrderDTO() *CategoryOrder {
+	return &CategoryOrder{}
+}
+
+// CategoryOrder IDs of all categories in the new order
+type CategoryOrder struct {
+	IDs []string `json:"ids" binding:"required,min=1,dive,uuid"`
 }
@@ -11,11 +11,20 @@ func NewPlaceDTO() *Place {
 
 // Place ...
 type Place struct {
-	ID          string   `json:"id" binding:"-"`
-	Name        string   `json:"name" binding:"required"`
-	Description string   `json:"description"`
-	Category    string   `json:"category" binding:"required"`
-	Tags        []string `json:"tags"`
+	ID          string    `json:"id" binding:"-"`
+	Name        string    `json:"name" binding:"required"`
+	Description string    `json:"description"`
+	Category    string    `json:"category" binding:"required"`
+	Tags        []string  `json:"tags"`
+	Location    *Location `json:"location"`
+	// AuthorID ID of the signed in user creating the place, never bound from the request
+	AuthorID string `json:"-" binding:"-"`
+}
+
+// Location ...
+type Location struct {
+	Lat *float64 `json:"lat" binding:"required,min=-90,max=90"`
+	Lng *float64 `json:"lng" binding:"required,min=-180,max=180"`
 }
 
 // ValidatePlaceDTO validate place DTO
@@ -0,0 +1,11 @@
+package dto
+
+// NewEmailVerificationResendDTO create new email verification resend DTO
+func NewEmailVerificationResendDTO() *EmailVerificationResend {
+	return &EmailVerificationResend{}
+}
+
+// EmailVerificationResend ...
+type EmailVerificationResend struct {
+	Email string `json:"email" binding:"required,email"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+// NewPasswordChangeDTO create new password change DTO
+func NewPasswordChangeDTO() *PasswordChange {
+	return &PasswordChange{}
+}
+
+// PasswordChange ...
+type PasswordChange struct {
+	CurrentPassword string `json:"currentPassword" binding:"required"`
+	NewPassword     string `json:"newPassword"     binding:"required"`
+}
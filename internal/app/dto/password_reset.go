@@ -0,0 +1,22 @@
+package dto
+
+// NewPasswordForgotDTO create new password forgot DTO
+func NewPasswordForgotDTO() *PasswordForgot {
+	return &PasswordForgot{}
+}
+
+// PasswordForgot ...
+type PasswordForgot struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// NewPasswordResetDTO create new password reset DTO
+func NewPasswordResetDTO() *PasswordReset {
+	return &PasswordReset{}
+}
+
+// PasswordReset ...
+type PasswordReset struct {
+	Token    string `json:"token"    binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+// NewProfileDTO create new profile DTO
+func NewProfileDTO() *Profile {
+	return &Profile{}
+}
+
+// Profile profile update, missing fields are kept and empty ones cleared
+type Profile struct {
+	DisplayName *string `json:"displayName"`
+	AvatarURL   *string `json:"avatarUrl"`
+	Locale      *string `json:"locale"`
+}
+
+// NewAccountDeleteDTO create new account delete DTO
+func NewAccountDeleteDTO() *AccountDelete {
+	return &AccountDelete{}
+}
+
+// AccountDelete password confirming the deletion, users without a password send none
+type AccountDelete struct {
+	Password string `json:"password"`
+}
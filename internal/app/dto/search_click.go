@@ -0,0 +1,12 @@
+package dto
+
+// NewSearchClickDTO create new search click DTO
+func NewSearchClickDTO() *SearchClick {
+	return &SearchClick{}
+}
+
+// SearchClick place selected from search results
+type SearchClick struct {
+	SearchID string `json:"searchId" binding:"required,uuid"`
+	PlaceID  string `json:"placeId" binding:"required,uuid"`
+}
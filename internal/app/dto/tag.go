@@ -0,0 +1,22 @@
+package dto
+
+// NewTagRenameDTO create new tag rename DTO
+func NewTagRenameDTO() *TagRename {
+	return &TagRename{}
+}
+
+// TagRename ...
+type TagRename struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// NewTagMergeDTO create new tag merge DTO
+func NewTagMergeDTO() *TagMerge {
+	return &TagMerge{}
+}
+
+// TagMerge ...
+type TagMerge struct {
+	Tags []string `json:"tags" binding:"required,min=1,dive,required"`
+	Into string   `json:"into" binding:"required"`
+}
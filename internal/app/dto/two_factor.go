@@ -0,0 +1,44 @@
+package dto
+
+// NewAuthTwoFactorDTO create new two-factor login DTO
+func NewAuthTwoFactorDTO() *AuthTwoFactor {
+	return &AuthTwoFactor{}
+}
+
+// AuthTwoFactor second step of the password login
+type AuthTwoFactor struct {
+	Challenge string `json:"challenge" binding:"required,max=64"`
+	// Code TOTP code or recovery code
+	Code string `json:"code" binding:"required,max=32"`
+}
+
+// NewTwoFactorEnrolDTO create new two-factor enrol DTO
+func NewTwoFactorEnrolDTO() *TwoFactorEnrol {
+	return &TwoFactorEnrol{}
+}
+
+// TwoFactorEnrol ...
+type TwoFactorEnrol struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// NewTwoFactorCodeDTO create new two-factor code DTO
+func NewTwoFactorCodeDTO() *TwoFactorCode {
+	return &TwoFactorCode{}
+}
+
+// TwoFactorCode TOTP code, or a recovery code where accepted
+type TwoFactorCode struct {
+	Code string `json:"code" binding:"required,max=32"`
+}
+
+// NewTwoFactorDisableDTO create new two-factor disable DTO
+func NewTwoFactorDisableDTO() *TwoFactorDisable {
+	return &TwoFactorDisable{}
+}
+
+// TwoFactorDisable ...
+type TwoFactorDisable struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code"     binding:"required,max=32"`
+}
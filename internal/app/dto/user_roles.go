@@ -0,0 +1,11 @@
+package dto
+
+// NewUserRolesDTO create new user roles DTO
+func NewUserRolesDTO() *UserRoles {
+	return &UserRoles{}
+}
+
+// UserRoles all roles of the user, replaces the stored ones
+type UserRoles struct {
+	Roles []string `json:"roles" binding:"required,dive,oneof=admin editor contributor viewer"`
+}
@@ -0,0 +1,58 @@
+package model
+
+// AccessPolicy checks of the authorization on top of the role permissions, built from the config on start
+type AccessPolicy struct {
+	verifiedEmailPermissions map[Permission]bool
+	twoFactorRoles           map[Role]bool
+}
+
+// NewAccessPolicy users with an unverified email are denied verifiedEmailPermissions,
+// users of twoFactorRoles every permission until they enable two-factor authentication
+func NewAccessPolicy(verifiedEmailPermissions []Permission, twoFactorRoles []Role) *AccessPolicy {
+	p := &AccessPolicy{
+		verifiedEmailPermissions: make(map[Permission]bool, len(verifiedEmailPermissions)),
+		twoFactorRoles:           make(map[Role]bool, len(twoFactorRoles)),
+	}
+	for _, permission := range verifiedEmailPermissions {
+		p.verifiedEmailPermissions[permission] = true
+	}
+	for _, role := range twoFactorRoles {
+		p.twoFactorRoles[role] = true
+	}
+	return p
+}
+
+// NeedsVerifiedEmail users with an unverified email are denied the permission
+func (p *AccessPolicy) NeedsVerifiedEmail(permission Permission) bool {
+	return p.verifiedEmailPermissions[permission]
+}
+
+// NeedsTwoFactor a role of the user requires two-factor authentication.
+// Users without a password sign in with an external identity only and never need it
+func (p *AccessPolicy) NeedsTwoFactor(user *User) bool {
+	if user.Password == "" {
+		return false
+	}
+	for _, r := range user.Roles {
+		if p.twoFactorRoles[r] {
+			return true
+		}
+	}
+	return false
+}
+
+// TwoFactorRequired the user needs two-factor authentication and it is not enabled
+func (p *AccessPolicy) TwoFactorRequired(user *User) bool {
+	return p.NeedsTwoFactor(user) && !user.IsTwoFactorEnabled()
+}
+
+// Can a role of the user grants the permission and the user passes the email and two-factor checks
+func (p *AccessPolicy) Can(user *User, permission Permission) bool {
+	if !user.IsEmailVerified() && p.NeedsVerifiedEmail(permission) {
+		return false
+	}
+	if p.TwoFactorRequired(user) {
+		return false
+	}
+	return user.Can(permission)
+}
@@ -0,0 +1,102 @@
+package model
+
+import (
+	"time"
+)
+
+// APIKeyScope what requests with an API key may do, on top of the permissions of the key owner
+type APIKeyScope string
+
+const (
+	// APIKeyScopePlacesRead read only keys, place reads need no authentication
+	APIKeyScopePlacesRead APIKeyScope = "places:read"
+	// APIKeyScopePlacesWrite create, update and delete places
+	APIKeyScopePlacesWrite APIKeyScope = "places:write"
+	// APIKeyScopeCategoriesWrite create, update, reorder and delete categories
+	APIKeyScopeCategoriesWrite APIKeyScope = "categories:write"
+)
+
+// apiKeyScopePermissions permissions used by the routes of a scope, the owner roles must grant them
+var apiKeyScopePermissions = map[APIKeyScope][]Permission{
+	APIKeyScopePlacesRead: {},
+	APIKeyScopePlacesWrite: {
+		PermissionPlaceCreate,
+		PermissionPlaceUpdate,
+		PermissionPlaceDelete,
+	},
+	APIKeyScopeCategoriesWrite: {
+		PermissionCategoryManage,
+	},
+}
+
+// IsValid known scope
+func (s APIKeyScope) IsValid() bool {
+	_, ok := apiKeyScopePermissions[s]
+	return ok
+}
+
+// GrantedTo the user has a permission of the scope, read scopes are granted to everyone
+func (s APIKeyScope) GrantedTo(user *User) bool {
+	permissions := apiKeyScopePermissions[s]
+	if len(permissions) == 0 {
+		return true
+	}
+	for _, p := range permissions {
+		if user.Can(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// NormaliseAPIKeyScopes drop duplicate scopes, keep the order, ErrInvalidModel on unknown or no scopes
+func NormaliseAPIKeyScopes(scopes []APIKeyScope) ([]APIKeyScope, error) {
+
+	seen := make(map[APIKeyScope]struct{}, len(scopes))
+	normalised := make([]APIKeyScope, 0, len(scopes))
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return nil, ErrInvalidModel
+		}
+		if _, ok := seen[scope]; ok {
+			continue
+		}
+		seen[scope] = struct{}{}
+		normalised = append(normalised, scope)
+	}
+	if len(normalised) == 0 {
+		return nil, ErrInvalidModel
+	}
+
+	return normalised, nil
+}
+
+// APIKey long lived key of machine clients acting as the owner within the scopes, only the key hash is stored
+type APIKey struct {
+	ID      ID     `bson:"_id"`
+	KeyHash string `bson:"keyHash"`
+	// Prefix first characters of the key, shown to tell keys apart
+	Prefix     string        `bson:"prefix"`
+	Username   string        `bson:"username"`
+	Name       string        `bson:"name"`
+	Scopes     []APIKeyScope `bson:"scopes"`
+	CreatedAt  time.Time     `bson:"createdAt"`
+	ExpiresAt  *time.Time    `bson:"expiresAt,omitempty"`
+	LastUsedAt *time.Time    `bson:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time    `bson:"revokedAt,omitempty"`
+}
+
+// IsActive not revoked and not expired at now, keys without expiry never expire
+func (m *APIKey) IsActive(now time.Time) bool {
+	return m.RevokedAt == nil && (m.ExpiresAt == nil || now.Before(*m.ExpiresAt))
+}
+
+// HasScope ...
+func (m *APIKey) HasScope(scope APIKeyScope) bool {
+	for _, s := range m.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormaliseAPIKeyScopes(t *testing.T) {
+	scopes, err := NormaliseAPIKeyScopes([]APIKeyScope{APIKeyScopePlacesWrite, APIKeyScopePlacesRead, APIKeyScopePlacesWrite})
+	assert.Nil(t, err)
+	assert.Equal(t, []APIKeyScope{APIKeyScopePlacesWrite, APIKeyScopePlacesRead}, scopes)
+
+	_, err = NormaliseAPIKeyScopes([]APIKeyScope{APIKeyScopePlacesRead, "users:write"})
+	assert.ErrorIs(t, err, ErrInvalidModel)
+	_, err = NormaliseAPIKeyScopes(nil)
+	assert.ErrorIs(t, err, ErrInvalidModel)
+}
+
+func TestAPIKeyScopeGrantedTo(t *testing.T) {
+	u, err := NewUserModel("Wozniak", "password", &PasswordPolicy{}, DefaultPasswordHasher())
+	assert.Nil(t, err)
+	u.Roles = []Role{RoleContributor}
+
+	assert.True(t, APIKeyScopePlacesRead.GrantedTo(u))
+	assert.True(t, APIKeyScopePlacesWrite.GrantedTo(u))
+	assert.False(t, APIKeyScopeCategoriesWrite.GrantedTo(u))
+}
+
+func TestAPIKeyIsActive(t *testing.T) {
+	now := time.Now()
+	key := &APIKey{Scopes: []APIKeyScope{APIKeyScopePlacesRead}}
+	assert.True(t, key.IsActive(now))
+	assert.True(t, key.HasScope(APIKeyScopePlacesRead))
+	assert.False(t, key.HasScope(APIKeyScopePlacesWrite))
+
+	key.ExpiresAt = &now
+	assert.False(t, key.IsActive(now))
+
+	key.ExpiresAt = nil
+	key.RevokedAt = &now
+	assert.False(t, key.IsActive(now))
+}
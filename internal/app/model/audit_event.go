@@ -0,0 +1,128 @@
+package model
+
+import (
+	"time"
+)
+
+// AuditEventType ...
+type AuditEventType string
+
+// AuditOutcome ...
+type AuditOutcome string
+
+// AuditReason stable code of a failure, or of a notable success
+type AuditReason string
+
+// AuditMethod credential the event used
+type AuditMethod string
+
+const (
+	// AuditEventLogin password, two-factor or OpenID Connect login
+	AuditEventLogin AuditEventType = "login"
+	// AuditEventLogout ...
+	AuditEventLogout AuditEventType = "logout"
+	// AuditEventRegistration ...
+	AuditEventRegistration AuditEventType = "registration"
+	// AuditEventTokenRefresh session token rotation or bearer token pair refresh
+	AuditEventTokenRefresh AuditEventType = "token_refresh"
+	// AuditEventAuthentication rejected credentials of a request to a protected route
+	AuditEventAuthentication AuditEventType = "authentication"
+	// AuditEventAccessDenied authenticated request denied by a role, permission or API key scope
+	AuditEventAccessDenied AuditEventType = "access_denied"
+	// AuditEventPasswordChange ...
+	AuditEventPasswordChange AuditEventType = "password_change"
+	// AuditEventPasswordReset ...
+	AuditEventPasswordReset AuditEventType = "password_reset"
+	// AuditEventAccountDelete ...
+	AuditEventAccountDelete AuditEventType = "account_delete"
+)
+
+const (
+	// AuditOutcomeSuccess ...
+	AuditOutcomeSuccess AuditOutcome = "success"
+	// AuditOutcomeFailure ...
+	AuditOutcomeFailure AuditOutcome = "failure"
+)
+
+const (
+	// AuditReasonInvalidCredentials wrong username or password
+	AuditReasonInvalidCredentials AuditReason = "invalid_credentials"
+	// AuditReasonThrottled too many failed attempts
+	AuditReasonThrottled AuditReason = "throttled"
+	// AuditReasonEmailNotVerified ...
+	AuditReasonEmailNotVerified AuditReason = "email_not_verified"
+	// AuditReasonInvalidTwoFactorCode ...
+	AuditReasonInvalidTwoFactorCode AuditReason = "invalid_two_factor_code"
+	// AuditReasonInvalidToken invalid, expired or revoked session token, bearer token or API key
+	AuditReasonInvalidToken AuditReason = "invalid_token"
+	// AuditReasonTokenReused an already rotated session token was refreshed, its login is signed out
+	AuditReasonTokenReused AuditReason = "token_reused"
+	// AuditReasonInvalidInput rejected registration or password
+	AuditReasonInvalidInput AuditReason = "invalid_input"
+	// AuditReasonForbidden ...
+	AuditReasonForbidden AuditReason = "forbidden"
+	// AuditReasonTwoFactorRequired a role requires two-factor authentication the user has not enabled
+	AuditReasonTwoFactorRequired AuditReason = "two_factor_required"
+)
+
+const (
+	// AuditMethodPassword ...
+	AuditMethodPassword AuditMethod = "password"
+	// AuditMethodTwoFactor password and TOTP or recovery code
+	AuditMethodTwoFactor AuditMethod = "two_factor"
+	// AuditMethodOIDC ...
+	AuditMethodOIDC AuditMethod = "oidc"
+	// AuditMethodSession ...
+	AuditMethodSession AuditMethod = "session"
+	// AuditMethodToken bearer access or refresh token
+	AuditMethodToken AuditMethod = "token"
+	// AuditMethodAPIKey ...
+	AuditMethodAPIKey AuditMethod = "api_key"
+)
+
+var auditEventTypes = map[AuditEventType]bool{
+	AuditEventLogin:          true,
+	AuditEventLogout:         true,
+	AuditEventRegistration:   true,
+	AuditEventTokenRefresh:   true,
+	AuditEventAuthentication: true,
+	AuditEventAccessDenied:   true,
+	AuditEventPasswordChange: true,
+	AuditEventPasswordReset:  true,
+	AuditEventAccountDelete:  true,
+}
+
+// IsValid ...
+func (t AuditEventType) IsValid() bool {
+	return auditEventTypes[t]
+}
+
+// IsValid ...
+func (o AuditOutcome) IsValid() bool {
+	return o == AuditOutcomeSuccess || o == AuditOutcomeFailure
+}
+
+// AuditEvent security event, expires by TTL index on createdAt
+type AuditEvent struct {
+	ID      ID             `bson:"_id"`
+	Type    AuditEventType `bson:"type"`
+	Outcome AuditOutcome   `bson:"outcome"`
+	Reason  AuditReason    `bson:"reason,omitempty"`
+	Method  AuditMethod    `bson:"method,omitempty"`
+	// Actor username the event is about, empty when unknown
+	Actor     string    `bson:"actor,omitempty"`
+	IP        string    `bson:"ip"`
+	UserAgent string    `bson:"userAgent,omitempty"`
+	Path      string    `bson:"path,omitempty"`
+	CreatedAt time.Time `bson:"createdAt"`
+}
+
+// AuditEventFilter audit event query, zero fields match any event
+type AuditEventFilter struct {
+	Type    AuditEventType
+	Outcome AuditOutcome
+	Actor   string
+	IP      string
+	From    time.Time
+	To      time.Time
+}
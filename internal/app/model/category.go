@@ -1,21 +1,67 @@
 package model
 
+import (
+	"regexp"
+	"sort"
+	"unicode/utf8"
+)
+
+const (
+	// MaxCategoryDepth root categories have depth 1
+	MaxCategoryDepth int = 3
+	// MaxCategorySlugLength ...
+	MaxCategorySlugLength int = 100
+	// MaxCategoryIconLength ...
+	MaxCategoryIconLength int = 64
+	// MaxCategoryDescriptionLength in characters
+	MaxCategoryDescriptionLength int = 1000
+)
+
+var (
+	// categorySlugRegexp slugs and icon identifiers, lower case words joined by single dashes
+	categorySlugRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	// categoryColorRegexp hex colour, #rgb or #rrggbb
+	categoryColorRegexp = regexp.MustCompile(`^#([0-9a-f]{3}|[0-9a-f]{6})$`)
+)
+
 // Category ...
 type Category struct {
-	ID    ID     `bson:"_id"`
-	Name  string `bson:"name"`
-	Order int8   `bson:"order"`
+	ID          ID     `bson:"_id"`
+	Name        string `bson:"name"`
+	Slug        string `bson:"slug"`
+	Order       int32  `bson:"order"`
+	ParentID    *ID    `bson:"parentId,omitempty"`
+	Icon        string `bson:"icon,omitempty"`
+	Color       string `bson:"color,omitempty"`
+	Description string `bson:"description,omitempty"`
 }
 
 // CategoryList ...
 type CategoryList []*Category
 
+// CategoryCounts number of places by category ID
+type CategoryCounts map[ID]int64
+
 // NewCategoryModel create new category model
-func NewCategoryModel(id ID, name string, order int8) (*Category, error) {
+func NewCategoryModel(
+	id ID,
+	name string,
+	slug string,
+	order int32,
+	parentID *ID,
+	icon string,
+	color string,
+	description string,
+) (*Category, error) {
 	m := &Category{
-		ID:    id,
-		Name:  name,
-		Order: order,
+		ID:          id,
+		Name:        name,
+		Slug:        slug,
+		Order:       order,
+		ParentID:    parentID,
+		Icon:        icon,
+		Color:       color,
+		Description: description,
 	}
 	if err := m.Validate(); err != nil {
 		return nil, err
@@ -29,9 +75,29 @@ func (m *Category) Validate() error {
 	if m.Name == "" || m.Order == 0 {
 		return ErrInvalidModel
 	}
+	if m.ParentID != nil && *m.ParentID == m.ID {
+		return ErrInvalidModel
+	}
+	if len(m.Slug) > MaxCategorySlugLength || !categorySlugRegexp.MatchString(m.Slug) {
+		return ErrInvalidModel
+	}
+	if m.Icon != "" && (len(m.Icon) > MaxCategoryIconLength || !categorySlugRegexp.MatchString(m.Icon)) {
+		return ErrInvalidModel
+	}
+	if m.Color != "" && !categoryColorRegexp.MatchString(m.Color) {
+		return ErrInvalidModel
+	}
+	if utf8.RuneCountInString(m.Description) > MaxCategoryDescriptionLength {
+		return ErrInvalidModel
+	}
 	return nil
 }
 
+// IsRoot category has no parent
+func (m *Category) IsRoot() bool {
+	return m.ParentID == nil
+}
+
 // FindByID find by id
 func (mL CategoryList) FindByID(id ID) *Category {
 	for _, m := range mL {
@@ -42,3 +108,96 @@ func (mL CategoryList) FindByID(id ID) *Category {
 
 	return nil
 }
+
+// FindBySlug find by slug
+func (mL CategoryList) FindBySlug(slug string) *Category {
+	for _, m := range mL {
+		if m.Slug == slug {
+			return m
+		}
+	}
+
+	return nil
+}
+
+// Children direct children of the category sorted by order, NilID for root categories
+func (mL CategoryList) Children(id ID) CategoryList {
+
+	children := make(CategoryList, 0)
+	for _, m := range mL {
+		if (id == NilID && m.IsRoot()) || (m.ParentID != nil && *m.ParentID == id) {
+			children = append(children, m)
+		}
+	}
+	children.SortByOrder()
+
+	return children
+}
+
+// Descendants IDs of the category and all its descendants
+func (mL CategoryList) Descendants(id ID) []ID {
+
+	ids := []ID{id}
+	seen := map[ID]struct{}{id: {}}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range mL.Children(ids[i]) {
+			if _, ok := seen[child.ID]; !ok {
+				seen[child.ID] = struct{}{}
+				ids = append(ids, child.ID)
+			}
+		}
+	}
+
+	return ids
+}
+
+// Depth depth of the category, 1 for root, 0 when not found, stops on broken parent links
+func (mL CategoryList) Depth(id ID) int {
+
+	depth := 0
+	seen := make(map[ID]struct{})
+	for m := mL.FindByID(id); m != nil; {
+		if _, ok := seen[m.ID]; ok {
+			break
+		}
+		seen[m.ID] = struct{}{}
+		depth++
+		if m.IsRoot() {
+			break
+		}
+		m = mL.FindByID(*m.ParentID)
+	}
+
+	return depth
+}
+
+// Height levels in the subtree of the category, 1 for a leaf
+func (mL CategoryList) Height(id ID) int {
+
+	height := 0
+	seen := map[ID]struct{}{id: {}}
+	for level := []ID{id}; len(level) > 0; height++ {
+		next := make([]ID, 0)
+		for _, parentID := range level {
+			for _, child := range mL.Children(parentID) {
+				if _, ok := seen[child.ID]; !ok {
+					seen[child.ID] = struct{}{}
+					next = append(next, child.ID)
+				}
+			}
+		}
+		level = next
+	}
+
+	return height
+}
+
+// SortByOrder sort by order then name
+func (mL CategoryList) SortByOrder() {
+	sort.SliceStable(mL, func(i, j int) bool {
+		if mL[i].Order != mL[j].Order {
+			return mL[i].Order < mL[j].Order
+		}
+		return mL[i].Name < mL[j].Name
+	})
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func makeCategoryTree(t *testing.T) (CategoryList, []ID) {
+	ids := make([]ID, 0, 4)
+	for i := 0; i < 4; i++ {
+		id, err := NewID()
+		assert.Nil(t, err)
+		ids = append(ids, id)
+	}
+
+	// 0 -> 1 -> 2, 3 is root
+	return CategoryList{
+		{ID: ids[2], Name: "Botanical gardens", Order: 1, ParentID: &ids[1]},
+		{ID: ids[3], Name: "Museums", Order: 2},
+		{ID: ids[1], Name: "Gardens", Order: 1, ParentID: &ids[0]},
+		{ID: ids[0], Name: "Parks", Order: 1},
+	}, ids
+}
+
+func TestCategoryList_Tree(t *testing.T) {
+
+	categories, ids := makeCategoryTree(t)
+
+	roots := categories.Children(NilID)
+	assert.Len(t, roots, 2)
+	assert.Equal(t, ids[0], roots[0].ID)
+	assert.Equal(t, ids[3], roots[1].ID)
+
+	assert.Equal(t, []ID{ids[0], ids[1], ids[2]}, categories.Descendants(ids[0]))
+	assert.Equal(t, []ID{ids[3]}, categories.Descendants(ids[3]))
+
+	assert.Equal(t, 1, categories.Depth(ids[0]))
+	assert.Equal(t, 3, categories.Depth(ids[2]))
+	assert.Equal(t, 0, categories.Depth(NilID))
+
+	assert.Equal(t, 3, categories.Height(ids[0]))
+	assert.Equal(t, 1, categories.Height(ids[2]))
+}
+
+func TestCategoryValidate(t *testing.T) {
+	id, err := NewID()
+	assert.Nil(t, err)
+
+	_, err = NewCategoryModel(id, "Parks", "parks", 1, &id, "", "", "")
+	assert.ErrorIs(t, err, ErrInvalidModel)
+
+	_, err = NewCategoryModel(id, "Parks", "parks", 1, nil, "tree", "#2e7d32", "City parks")
+	assert.Nil(t, err)
+
+	for _, tt := range []struct {
+		name  string
+		slug  string
+		icon  string
+		color string
+	}{
+		{name: "Empty_slug", slug: ""},
+		{name: "Slug_upper_case", slug: "Parks"},
+		{name: "Slug_double_dash", slug: "city--parks"},
+		{name: "Slug_trailing_dash", slug: "parks-"},
+		{name: "Icon", slug: "parks", icon: "tree icon"},
+		{name: "Color_name", slug: "parks", color: "green"},
+		{name: "Color_length", slug: "parks", color: "#2e7d3"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCategoryModel(id, "Parks", tt.slug, 1, nil, tt.icon, tt.color, "")
+			assert.ErrorIs(t, err, ErrInvalidModel)
+		})
+	}
+}
@@ -2,6 +2,8 @@ package model
 
 import (
 	"errors"
+	"sort"
+	"strings"
 )
 
 var (
@@ -15,8 +17,50 @@ var (
 	ErrInvalidModel = errors.New("invalid model")
 	// ErrPassMismatched ...
 	ErrPassMismatched = errors.New("password mismatched")
+	// ErrModelDuplicate ...
+	ErrModelDuplicate = errors.New("model with the same unique key exists")
+	// ErrModelSetMismatch ...
+	ErrModelSetMismatch = errors.New("the provided IDs do not match the stored models")
 )
 
+// ValidationError invalid model with messages per field, is ErrInvalidModel
+type ValidationError struct {
+	Fields map[string][]string
+}
+
+// Add add a field message
+func (e *ValidationError) Add(field string, messages ...string) {
+	if e.Fields == nil {
+		e.Fields = make(map[string][]string)
+	}
+	e.Fields[field] = append(e.Fields[field], messages...)
+}
+
+// Empty no field messages
+func (e *ValidationError) Empty() bool {
+	return len(e.Fields) == 0
+}
+
+// Error ...
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for field := range e.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	messages := make([]string, 0, len(fields))
+	for _, field := range fields {
+		messages = append(messages, field+" "+strings.Join(e.Fields[field], ", "))
+	}
+	return ErrInvalidModel.Error() + ": " + strings.Join(messages, "; ")
+}
+
+// Unwrap ...
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidModel
+}
+
 // IsErrInvalidString check is a ErrInvalidString
 func IsErrInvalidString(err error) bool {
 	return errors.Is(err, ErrInvalidString)
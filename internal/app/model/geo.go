@@ -0,0 +1,37 @@
+package model
+
+// GeoPoint GeoJSON point, coordinates are [lng, lat]
+type GeoPoint struct {
+	Type        string    `bson:"type"`
+	Coordinates []float64 `bson:"coordinates"`
+}
+
+// NewGeoPoint create new GeoJSON point
+func NewGeoPoint(lat float64, lng float64) *GeoPoint {
+	return &GeoPoint{
+		Type:        "Point",
+		Coordinates: []float64{lng, lat},
+	}
+}
+
+// Lat latitude
+func (p *GeoPoint) Lat() float64 {
+	return p.Coordinates[1]
+}
+
+// Lng longitude
+func (p *GeoPoint) Lng() float64 {
+	return p.Coordinates[0]
+}
+
+// Validate validate point
+func (p *GeoPoint) Validate() error {
+
+	if p.Type != "Point" || len(p.Coordinates) != 2 {
+		return ErrInvalidModel
+	}
+	if p.Lat() < -90 || p.Lat() > 90 || p.Lng() < -180 || p.Lng() > 180 {
+		return ErrInvalidModel
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// PasswordHashArgon2id ...
+	PasswordHashArgon2id string = "argon2id"
+	// PasswordHashBcrypt ...
+	PasswordHashBcrypt string = "bcrypt"
+)
+
+// ErrUnknownPasswordHash the stored hash is of no supported algorithm
+var ErrUnknownPasswordHash = errors.New("unknown password hash")
+
+// PasswordHasherInterface ...
+type PasswordHasherInterface interface {
+	// Hash encode the password with the algorithm and the parameters in the hash
+	Hash(password string) (string, error)
+	// Verify ErrPassMismatched when the password does not match the hash
+	Verify(hash string, password string) error
+	// NeedsRehash the hash uses another algorithm or weaker parameters
+	NeedsRehash(hash string) bool
+}
+
+// Argon2idHasher argon2id hashes in the PHC string format $argon2id$v=19$m=65536,t=3,p=2$salt$key
+type Argon2idHasher struct {
+	// Memory in KiB
+	Memory  uint32
+	Time    uint32
+	Threads uint8
+	// KeyLength and SaltLength in bytes
+	KeyLength  uint32
+	SaltLength uint32
+}
+
+// NewArgon2idHasher create new argon2id hasher, 16 bytes salt and 32 bytes key
+func NewArgon2idHasher(memory uint32, time uint32, threads uint8) *Argon2idHasher {
+	return &Argon2idHasher{
+		Memory:     memory,
+		Time:       time,
+		Threads:    threads,
+		KeyLength:  32,
+		SaltLength: 16,
+	}
+}
+
+// DefaultArgon2idHasher 64 MiB, 3 passes, 2 threads
+func DefaultArgon2idHasher() *Argon2idHasher {
+	return NewArgon2idHasher(64*1024, 3, 2)
+}
+
+// Hash ...
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+
+	salt := make([]byte, h.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, h.Time, h.Memory, h.Threads, h.KeyLength)
+
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.Memory, h.Time, h.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify ...
+func (h *Argon2idHasher) Verify(hash string, password string) error {
+
+	params, salt, key, err := decodeArgon2id(hash)
+	if err != nil {
+		return err
+	}
+
+	other := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return ErrPassMismatched
+	}
+	return nil
+}
+
+// NeedsRehash ...
+func (h *Argon2idHasher) NeedsRehash(hash string) bool {
+
+	params, salt, key, err := decodeArgon2id(hash)
+	if err != nil {
+		return true
+	}
+	return params.Memory < h.Memory ||
+		params.Time < h.Time ||
+		params.Threads < h.Threads ||
+		uint32(len(key)) < h.KeyLength ||
+		uint32(len(salt)) < h.SaltLength
+}
+
+func decodeArgon2id(hash string) (*Argon2idHasher, []byte, []byte, error) {
+
+	// "", "argon2id", "v=19", "m=65536,t=3,p=2", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != PasswordHashArgon2id {
+		return nil, nil, nil, ErrUnknownPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, nil, nil, ErrUnknownPasswordHash
+	}
+
+	params := &Argon2idHasher{}
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads)
+	if err != nil || params.Memory == 0 || params.Time == 0 || params.Threads == 0 {
+		return nil, nil, nil, ErrUnknownPasswordHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, ErrUnknownPasswordHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return nil, nil, nil, ErrUnknownPasswordHash
+	}
+	params.KeyLength, params.SaltLength = uint32(len(key)), uint32(len(salt))
+
+	return params, salt, key, nil
+}
+
+// BcryptHasher ...
+type BcryptHasher struct {
+	Cost int
+}
+
+// NewBcryptHasher create new bcrypt hasher
+func NewBcryptHasher(cost int) *BcryptHasher {
+	return &BcryptHasher{
+		Cost: cost,
+	}
+}
+
+// Hash ...
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// Verify ...
+func (h *BcryptHasher) Verify(hash string, password string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if err != nil && errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return ErrPassMismatched
+	}
+	return err
+}
+
+// NeedsRehash ...
+func (h *BcryptHasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost < h.Cost
+}
+
+// PasswordHasher hashes with the current algorithm and verifies hashes of every supported algorithm
+type PasswordHasher struct {
+	current  PasswordHasherInterface
+	argon2id *Argon2idHasher
+	bcrypt   *BcryptHasher
+
+	dummyHash     string
+	dummyHashOnce sync.Once
+}
+
+// NewPasswordHasher create new password hasher of the PasswordHashArgon2id or the PasswordHashBcrypt algorithm
+func NewPasswordHasher(algorithm string, argon2id *Argon2idHasher, bcryptHasher *BcryptHasher) (*PasswordHasher, error) {
+
+	h := &PasswordHasher{
+		argon2id: argon2id,
+		bcrypt:   bcryptHasher,
+	}
+	switch algorithm {
+	case PasswordHashArgon2id:
+		h.current = argon2id
+	case PasswordHashBcrypt:
+		h.current = bcryptHasher
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPasswordHash, algorithm)
+	}
+
+	return h, nil
+}
+
+// Hash ...
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	return h.current.Hash(password)
+}
+
+// Verify ...
+func (h *PasswordHasher) Verify(hash string, password string) error {
+	return h.of(hash).Verify(hash, password)
+}
+
+// NeedsRehash the hash is not of the current algorithm or its parameters are weaker
+func (h *PasswordHasher) NeedsRehash(hash string) bool {
+	of := h.of(hash)
+	return of != h.current || of.NeedsRehash(hash)
+}
+
+// CompareDummy spend the time of a password check with the current algorithm
+func (h *PasswordHasher) CompareDummy(password string) {
+	h.dummyHashOnce.Do(func() {
+		h.dummyHash, _ = h.current.Hash("dummy password")
+	})
+	_ = h.current.Verify(h.dummyHash, password)
+}
+
+func (h *PasswordHasher) of(hash string) PasswordHasherInterface {
+	if strings.HasPrefix(hash, "$"+PasswordHashArgon2id+"$") {
+		return h.argon2id
+	}
+	return h.bcrypt
+}
+
+// DefaultPasswordHasher argon2id hasher of DefaultArgon2idHasher, verifies bcrypt hashes of the default cost
+func DefaultPasswordHasher() *PasswordHasher {
+	h, _ := NewPasswordHasher(PasswordHashArgon2id, DefaultArgon2idHasher(), NewBcryptHasher(bcrypt.DefaultCost))
+	return h
+}
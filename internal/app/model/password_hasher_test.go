@@ -0,0 +1,39 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestPasswordHasher(t *testing.T) {
+	weak := NewArgon2idHasher(8*1024, 1, 1)
+	h, err := NewPasswordHasher(PasswordHashArgon2id, NewArgon2idHasher(16*1024, 2, 1), NewBcryptHasher(bcrypt.MinCost))
+	assert.Nil(t, err)
+
+	hash, err := h.Hash("password")
+	assert.Nil(t, err)
+	assert.Regexp(t, `^\$argon2id\$v=19\$m=16384,t=2,p=1\$[^$]+\$[^$]+$`, hash)
+	assert.Nil(t, h.Verify(hash, "password"))
+	assert.ErrorIs(t, h.Verify(hash, "wrong_password"), ErrPassMismatched)
+	assert.False(t, h.NeedsRehash(hash))
+
+	// weaker parameters verify and need a rehash
+	weakHash, err := weak.Hash("password")
+	assert.Nil(t, err)
+	assert.Nil(t, h.Verify(weakHash, "password"))
+	assert.True(t, h.NeedsRehash(weakHash))
+
+	// legacy bcrypt hashes verify and need a rehash
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	assert.Nil(t, err)
+	assert.Nil(t, h.Verify(string(bcryptHash), "password"))
+	assert.ErrorIs(t, h.Verify(string(bcryptHash), "wrong_password"), ErrPassMismatched)
+	assert.True(t, h.NeedsRehash(string(bcryptHash)))
+
+	assert.ErrorIs(t, h.Verify("$argon2id$v=19$m=0,t=0,p=0$c2FsdA$a2V5", "password"), ErrUnknownPasswordHash)
+
+	_, err = NewPasswordHasher("md5", weak, NewBcryptHasher(bcrypt.MinCost))
+	assert.ErrorIs(t, err, ErrUnknownPasswordHash)
+}
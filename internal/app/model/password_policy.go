@@ -0,0 +1,98 @@
+package model
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxPasswordBytes bounds the hashing work, bcrypt ignores the rest after 72 bytes
+	MaxPasswordBytes int = 256
+)
+
+//go:embed common_passwords.txt
+var commonPasswords string
+
+// PasswordPolicy password rules, the zero policy only requires a non-empty password
+type PasswordPolicy struct {
+	// MinLength in characters
+	MinLength int
+	// MinClasses number of lowercase, uppercase, digit and symbol classes to use
+	MinClasses int
+	// RejectCommon reject passwords of the common breached passwords list
+	RejectCommon bool
+	common       map[string]struct{}
+}
+
+// NewPasswordPolicy create new password policy with the built-in common passwords list
+func NewPasswordPolicy(minLength int, minClasses int, rejectCommon bool) *PasswordPolicy {
+	p := &PasswordPolicy{
+		MinLength:    minLength,
+		MinClasses:   minClasses,
+		RejectCommon: rejectCommon,
+		common:       make(map[string]struct{}),
+	}
+	_ = p.AddCommon(strings.NewReader(commonPasswords))
+	return p
+}
+
+// AddCommon add passwords to the common list, one per line, # comments
+func (p *PasswordPolicy) AddCommon(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p.common[strings.ToLower(line)] = struct{}{}
+	}
+	return scanner.Err()
+}
+
+// Check password violations of the policy, empty when valid
+func (p *PasswordPolicy) Check(password string) []string {
+
+	if password == "" {
+		return []string{"is required"}
+	}
+
+	violations := make([]string, 0)
+	if utf8.RuneCountInString(password) < p.MinLength {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters", p.MinLength))
+	}
+	if len(password) > MaxPasswordBytes {
+		violations = append(violations, fmt.Sprintf("must be at most %d bytes", MaxPasswordBytes))
+	}
+	if p.MinClasses > 0 && passwordClasses(password) < p.MinClasses {
+		violations = append(violations, fmt.Sprintf("must use at least %d of: lowercase letters, uppercase letters, digits, symbols", p.MinClasses))
+	}
+	if p.RejectCommon {
+		if _, ok := p.common[strings.ToLower(password)]; ok {
+			violations = append(violations, "is too common")
+		}
+	}
+
+	return violations
+}
+
+func passwordClasses(password string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
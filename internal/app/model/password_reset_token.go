@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+)
+
+// PasswordResetToken single-use password reset token, only the token hash is stored
+type PasswordResetToken struct {
+	ID        ID         `bson:"_id"`
+	TokenHash string     `bson:"tokenHash"`
+	Username  string     `bson:"username"`
+	CreatedAt time.Time  `bson:"createdAt"`
+	ExpiresAt time.Time  `bson:"expiresAt"`
+	UsedAt    *time.Time `bson:"usedAt,omitempty"`
+}
+
+// IsActive not used and not expired
+func (m *PasswordResetToken) IsActive(now time.Time) bool {
+	return m.UsedAt == nil && now.Before(m.ExpiresAt)
+}
@@ -2,10 +2,12 @@ package model
 
 import (
 	"time"
+
+	"walk_backend/internal/pkg/translit"
 )
 
 // NewPlaceModel create new place model
-func NewPlaceModel(id ID, name string, nameSlug string, description string, category ID, tags []string) (*Place, error) {
+func NewPlaceModel(id ID, name string, nameSlug string, description string, category ID, tags []string, location *GeoPoint) (*Place, error) {
 	place := &Place{
 		ID:          id,
 		Name:        name,
@@ -13,6 +15,7 @@ func NewPlaceModel(id ID, name string, nameSlug string, description string, cate
 		Description: description,
 		Category:    category,
 		Tags:        tags,
+		Location:    location,
 	}
 	if err := place.Validate(); err != nil {
 		return nil, err
@@ -32,6 +35,14 @@ type Place struct {
 	Description string   `bson:"description"`
 	Category    ID       `bson:"category"`
 	Tags        []string `bson:"tags"`
+	// swagger:ignore
+	Location *GeoPoint `bson:"location,omitempty"`
+	// swagger:ignore
+	SearchTerms []string `bson:"searchTerms"`
+	// AuthorID user who created the place, nil for places of deleted users
+	//
+	// swagger:ignore
+	AuthorID *ID `bson:"authorId,omitempty"`
 
 	// swagger:ignore
 	CreatedAt time.Time `bson:"createdAt"`
@@ -50,5 +61,13 @@ func (m *Place) Validate() error {
 	if m.Name == "" || m.NameSlug == "" {
 		return ErrInvalidModel
 	}
+	if m.Location != nil {
+		return m.Location.Validate()
+	}
 	return nil
 }
+
+// UpdateSearchTerms make transliterated search terms from name and tags
+func (m *Place) UpdateSearchTerms() {
+	m.SearchTerms = translit.Keys(append([]string{m.Name}, m.Tags...)...)
+}
@@ -0,0 +1,25 @@
+package model
+
+// PlaceSearchCriteria place search filters
+type PlaceSearchCriteria struct {
+	Terms              []string
+	Phrases            []string
+	ExcludedTerms      []string
+	ExcludedPhrases    []string
+	Tags               []string
+	ExcludedTags       []string
+	Categories         []ID
+	ExcludedCategories []ID
+	Near               *GeoCircle
+}
+
+// GeoCircle ...
+type GeoCircle struct {
+	Center   *GeoPoint
+	RadiusKm float64
+}
+
+// HasText criteria has positive terms or phrases for the full text search
+func (c *PlaceSearchCriteria) HasText() bool {
+	return len(c.Terms) > 0 || len(c.Phrases) > 0
+}
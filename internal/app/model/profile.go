@@ -0,0 +1,76 @@
+package model
+
+import (
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+)
+
+const (
+	// DisplayNameMaxLength in characters
+	DisplayNameMaxLength int = 64
+	// AvatarURLMaxLength ...
+	AvatarURLMaxLength int = 2048
+)
+
+// SetProfile replace display name, avatar URL and locale, empty values clear them,
+// *ValidationError with displayName, avatarUrl and locale messages
+func (m *User) SetProfile(displayName string, avatarURL string, locale string) error {
+
+	displayName = strings.TrimSpace(displayName)
+	avatarURL = strings.TrimSpace(avatarURL)
+	locale = strings.TrimSpace(locale)
+
+	validationErr := &ValidationError{}
+	if utf8.RuneCountInString(displayName) > DisplayNameMaxLength {
+		validationErr.Add("displayName", "is too long")
+	}
+	if strings.IndexFunc(displayName, unicode.IsControl) >= 0 {
+		validationErr.Add("displayName", "must not contain control characters")
+	}
+
+	if avatarURL != "" {
+		u, err := url.Parse(avatarURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			validationErr.Add("avatarUrl", "must be an absolute http or https URL")
+		}
+		if len(avatarURL) > AvatarURLMaxLength {
+			validationErr.Add("avatarUrl", "is too long")
+		}
+	}
+
+	if locale != "" {
+		// stored in the canonical BCP 47 form, en_us becomes en-US
+		tag, err := language.Parse(strings.ReplaceAll(locale, "_", "-"))
+		if err != nil {
+			validationErr.Add("locale", "must be a BCP 47 language tag")
+		} else {
+			locale = tag.String()
+		}
+	}
+
+	if !validationErr.Empty() {
+		return validationErr
+	}
+
+	m.DisplayName = displayName
+	m.AvatarURL = avatarURL
+	m.Locale = locale
+
+	return nil
+}
+
+// UserExport personal data of the user, the account deletion anonymises the places instead of deleting them
+type UserExport struct {
+	User     *User
+	Sessions []*SessionToken
+	APIKeys  []*APIKey
+	Places   PlaceList
+	// Categories categories of the places
+	Categories CategoryList
+	ExportedAt time.Time
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+)
+
+// ReindexMode ...
+type ReindexMode string
+
+const (
+	// ReindexModeIndex write places to a new versioned index and switch alias
+	ReindexModeIndex ReindexMode = "index"
+	// ReindexModeQueue only publish reindex messages for the consumer
+	ReindexModeQueue ReindexMode = "queue"
+)
+
+// ReindexStatus ...
+type ReindexStatus string
+
+const (
+	// ReindexStatusRunning ...
+	ReindexStatusRunning ReindexStatus = "running"
+	// ReindexStatusDone ...
+	ReindexStatusDone ReindexStatus = "done"
+)
+
+// ReindexState reindex job checkpoint
+type ReindexState struct {
+	ID          ID            `bson:"_id"`
+	Mode        ReindexMode   `bson:"mode"`
+	Alias       string        `bson:"alias"`
+	Index       string        `bson:"index"`
+	LastPlaceID ID            `bson:"lastPlaceId"`
+	Processed   int64         `bson:"processed"`
+	Total       int64         `bson:"total"`
+	Status      ReindexStatus `bson:"status"`
+	StartedAt   time.Time     `bson:"startedAt"`
+	UpdatedAt   time.Time     `bson:"updatedAt"`
+	FinishedAt  time.Time     `bson:"finishedAt,omitempty"`
+}
+
+// NewReindexState create new reindex state
+func NewReindexState(mode ReindexMode, alias string, index string, total int64) (*ReindexState, error) {
+	id, err := NewID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &ReindexState{
+		ID:        id,
+		Mode:      mode,
+		Alias:     alias,
+		Index:     index,
+		Total:     total,
+		Status:    ReindexStatusRunning,
+		StartedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
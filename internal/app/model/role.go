@@ -0,0 +1,104 @@
+package model
+
+// Role ...
+type Role string
+
+// Permission ...
+type Permission string
+
+const (
+	// RoleAdmin manages users, categories and everything editors can do
+	RoleAdmin Role = "admin"
+	// RoleEditor edits and deletes any place and manages tags
+	RoleEditor Role = "editor"
+	// RoleContributor adds places, the role of self registered users
+	RoleContributor Role = "contributor"
+	// RoleViewer read only
+	RoleViewer Role = "viewer"
+)
+
+const (
+	// PermissionPlaceCreate ...
+	PermissionPlaceCreate Permission = "places:create"
+	// PermissionPlaceUpdate ...
+	PermissionPlaceUpdate Permission = "places:update"
+	// PermissionPlaceDelete ...
+	PermissionPlaceDelete Permission = "places:delete"
+	// PermissionTagManage rename and merge tags
+	PermissionTagManage Permission = "tags:manage"
+	// PermissionCategoryManage create, update, reorder and delete categories
+	PermissionCategoryManage Permission = "categories:manage"
+	// PermissionSearchStats ...
+	PermissionSearchStats Permission = "search:stats"
+	// PermissionUserManage assign roles
+	PermissionUserManage Permission = "users:manage"
+	// PermissionAPIKeyCreate create API keys for scripts and integrations
+	PermissionAPIKeyCreate Permission = "api_keys:create"
+	// PermissionAuditRead read the security audit log
+	PermissionAuditRead Permission = "audit:read"
+	// PermissionSessionRevoke revoke sessions, bearer tokens and API keys of any user
+	PermissionSessionRevoke Permission = "sessions:revoke"
+)
+
+// rolePermissions permissions granted by every role
+var rolePermissions = map[Role][]Permission{
+	RoleAdmin: {
+		PermissionPlaceCreate,
+		PermissionPlaceUpdate,
+		PermissionPlaceDelete,
+		PermissionTagManage,
+		PermissionCategoryManage,
+		PermissionSearchStats,
+		PermissionUserManage,
+		PermissionAPIKeyCreate,
+		PermissionAuditRead,
+		PermissionSessionRevoke,
+	},
+	RoleEditor: {
+		PermissionPlaceCreate,
+		PermissionPlaceUpdate,
+		PermissionPlaceDelete,
+		PermissionTagManage,
+		PermissionAPIKeyCreate,
+	},
+	RoleContributor: {
+		PermissionPlaceCreate,
+		PermissionAPIKeyCreate,
+	},
+	RoleViewer: {},
+}
+
+// IsValid known role
+func (r Role) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Can role grants the permission
+func (r Role) Can(permission Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// NormaliseRoles drop duplicate roles, keep the order, ErrInvalidModel on unknown roles
+func NormaliseRoles(roles []Role) ([]Role, error) {
+
+	seen := make(map[Role]struct{}, len(roles))
+	normalised := make([]Role, 0, len(roles))
+	for _, role := range roles {
+		if !role.IsValid() {
+			return nil, ErrInvalidModel
+		}
+		if _, ok := seen[role]; ok {
+			continue
+		}
+		seen[role] = struct{}{}
+		normalised = append(normalised, role)
+	}
+
+	return normalised, nil
+}
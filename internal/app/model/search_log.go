@@ -0,0 +1,68 @@
+package model
+
+import (
+	"time"
+)
+
+// NewSearchLog create new search log entry
+func NewSearchLog(id ID, query string, hits int, latency time.Duration, username string) *SearchLog {
+	return &SearchLog{
+		ID:            id,
+		Query:         query,
+		Hits:          hits,
+		LatencyMs:     float64(latency) / float64(time.Millisecond),
+		Username:      username,
+		ClickedPlaces: make([]ID, 0),
+		CreatedAt:     time.Now(),
+	}
+}
+
+// SearchLog one search request, expires by TTL index on createdAt
+type SearchLog struct {
+	ID            ID        `bson:"_id"`
+	Query         string    `bson:"query"`
+	Hits          int       `bson:"hits"`
+	LatencyMs     float64   `bson:"latencyMs"`
+	Username      string    `bson:"username,omitempty"`
+	ClickedPlaces []ID      `bson:"clickedPlaces"`
+	CreatedAt     time.Time `bson:"createdAt"`
+}
+
+// SearchQueryStats aggregated stats of one normalised query
+type SearchQueryStats struct {
+	Query            string  `bson:"_id"`
+	Count            int64   `bson:"count"`
+	AvgHits          float64 `bson:"avgHits"`
+	AvgLatencyMs     float64 `bson:"avgLatencyMs"`
+	Clicked          int64   `bson:"clicked"`
+	ClickThroughRate float64 `bson:"-"`
+}
+
+// SearchStats search analytics for a time window
+type SearchStats struct {
+	From              time.Time
+	To                time.Time
+	Searches          int64
+	Clicked           int64
+	ClickThroughRate  float64
+	TopQueries        []*SearchQueryStats
+	ZeroResultQueries []*SearchQueryStats
+}
+
+// CalcClickThroughRates fill click-through rates from counters
+func (m *SearchStats) CalcClickThroughRates() {
+	m.ClickThroughRate = clickThroughRate(m.Clicked, m.Searches)
+	for _, q := range m.TopQueries {
+		q.ClickThroughRate = clickThroughRate(q.Clicked, q.Count)
+	}
+	for _, q := range m.ZeroResultQueries {
+		q.ClickThroughRate = clickThroughRate(q.Clicked, q.Count)
+	}
+}
+
+func clickThroughRate(clicked int64, count int64) float64 {
+	if count == 0 {
+		return 0
+	}
+	return float64(clicked) / float64(count)
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// Device client of a session
+type Device struct {
+	UserAgent string `bson:"userAgent"`
+	IP        string `bson:"ip"`
+}
+
+// Name coarse device name from the user agent
+func (d Device) Name() string {
+	ua := strings.ToLower(d.UserAgent)
+	for _, device := range []struct{ marker, name string }{
+		{"iphone", "iPhone"},
+		{"ipad", "iPad"},
+		{"android", "Android"},
+		{"windows", "Windows"},
+		{"mac os", "macOS"},
+		{"cros", "ChromeOS"},
+		{"linux", "Linux"},
+	} {
+		if strings.Contains(ua, device.marker) {
+			return device.name
+		}
+	}
+	return "Unknown"
+}
+
+// SessionToken server-side session token, only the token hash is stored.
+// A refresh rotates the token, the new token joins the family of the old one,
+// the family is one session: its ID is the session ID shown to the user.
+type SessionToken struct {
+	ID        ID        `bson:"_id"`
+	TokenHash string    `bson:"tokenHash"`
+	FamilyID  ID        `bson:"familyId"`
+	Username  string    `bson:"username"`
+	Device    Device    `bson:"device"`
+	CreatedAt time.Time `bson:"createdAt"`
+	// SessionCreatedAt login time, kept across rotations
+	SessionCreatedAt time.Time  `bson:"sessionCreatedAt"`
+	LastSeenAt       time.Time  `bson:"lastSeenAt"`
+	ExpiresAt        time.Time  `bson:"expiresAt"`
+	RotatedAt        *time.Time `bson:"rotatedAt,omitempty"`
+	RevokedAt        *time.Time `bson:"revokedAt,omitempty"`
+}
+
+// IsActive not rotated, not revoked and not expired at now
+func (m *SessionToken) IsActive(now time.Time) bool {
+	return m.RotatedAt == nil && m.RevokedAt == nil && now.Before(m.ExpiresAt)
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// Tag tag usage counter, the name is the ID
+type Tag struct {
+	Name      string    `bson:"_id"`
+	Count     int64     `bson:"count"`
+	UpdatedAt time.Time `bson:"updatedAt"`
+}
+
+// TagList ...
+type TagList []*Tag
+
+// NormaliseTag lower case and single spaces, so "Park" and "park " are the same tag
+func NormaliseTag(tag string) string {
+	return strings.ToLower(strings.Join(strings.Fields(tag), " "))
+}
+
+// NormaliseTags normalise tags, drop empty and duplicate ones, keep the order
+func NormaliseTags(tags []string) []string {
+
+	seen := make(map[string]struct{}, len(tags))
+	normalised := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = NormaliseTag(tag)
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		normalised = append(normalised, tag)
+	}
+
+	return normalised
+}
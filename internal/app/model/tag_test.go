@@ -0,0 +1,13 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormaliseTags(t *testing.T) {
+	assert.Equal(t, "old town", NormaliseTag("  Old   Town "))
+	assert.Equal(t, []string{"park", "old town"}, NormaliseTags([]string{"Park", "park ", " ", "Old  town", "PARK"}))
+	assert.Equal(t, []string{}, NormaliseTags(nil))
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// TokenPair bearer access and refresh tokens
+type TokenPair struct {
+	// Username subject of the tokens
+	Username     string
+	AccessToken  string
+	RefreshToken string
+	// AccessTTL access token lifetime
+	AccessTTL time.Duration
+}
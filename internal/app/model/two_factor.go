@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+)
+
+// TwoFactor TOTP second factor of the password login, enabled once a code of the secret is confirmed
+type TwoFactor struct {
+	// Secret base32 TOTP secret, authenticator apps need it in clear
+	Secret    string     `bson:"secret"`
+	EnabledAt *time.Time `bson:"enabledAt,omitempty"`
+	// LastCounter time step of the last accepted code, a code is accepted once
+	LastCounter int64 `bson:"lastCounter"`
+	// RecoveryCodes hashes of the unused one-time recovery codes
+	RecoveryCodes []string `bson:"recoveryCodes,omitempty"`
+}
+
+// IsEnabled ...
+func (m *TwoFactor) IsEnabled() bool {
+	return m != nil && m.EnabledAt != nil
+}
+
+// TwoFactorChallenge password login waiting for the second factor
+type TwoFactorChallenge struct {
+	Username string `json:"username"`
+	// Tokens the login asked for bearer tokens instead of a session
+	Tokens    bool      `json:"tokens"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// IsTwoFactorEnabled ...
+func (m *User) IsTwoFactorEnabled() bool {
+	return m.TwoFactor.IsEnabled()
+}
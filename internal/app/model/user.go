@@ -1,14 +1,12 @@
 package model
 
 import (
-	"errors"
+	"strings"
 	"time"
-
-	"golang.org/x/crypto/bcrypt"
 )
 
-// NewUserModel create new user model
-func NewUserModel(username string, password string) (*User, error) {
+// NewUserModel create new user model, the password must satisfy the policy and is hashed by hasher
+func NewUserModel(username string, password string, policy *PasswordPolicy, hasher *PasswordHasher) (*User, error) {
 	id, err := NewID()
 	if err != nil {
 		return nil, err
@@ -17,20 +15,38 @@ func NewUserModel(username string, password string) (*User, error) {
 	m := &User{
 		ID:       id,
 		Username: username,
-		Password: password,
+		Roles:    []Role{RoleContributor},
 	}
 
-	if err := m.Validate(); err != nil {
+	if err := m.SetPassword(password, policy, hasher); err != nil {
 		return nil, err
 	}
 
-	pwd, err := hashPassword(password)
+	return m, nil
+}
+
+// NewExternalUserModel create new user signed in with an external identity, it has no password
+// until one is set through the password reset
+func NewExternalUserModel(username string, identity Identity) (*User, error) {
+	id, err := NewID()
 	if err != nil {
 		return nil, err
 	}
-	m.Password = pwd
 
-	return m, nil
+	return &User{
+		ID:         id,
+		Username:   username,
+		Identities: []Identity{identity},
+		Roles:      []Role{RoleContributor},
+	}, nil
+}
+
+// Identity external OpenID Connect account linked to the user, unique by provider and subject
+type Identity struct {
+	Provider string    `bson:"provider"`
+	Subject  string    `bson:"subject"`
+	Email    string    `bson:"email,omitempty"`
+	LinkedAt time.Time `bson:"linkedAt"`
 }
 
 // User ...
@@ -42,31 +58,106 @@ type User struct {
 	Username string `bson:"username"`
 	Password string `bson:"password"`
 	// swagger:ignore
+	Email string `bson:"email,omitempty"`
+	// swagger:ignore
+	EmailVerifiedAt *time.Time `bson:"emailVerifiedAt,omitempty"`
+	// swagger:ignore
+	DisplayName string `bson:"displayName,omitempty"`
+	// swagger:ignore
+	AvatarURL string `bson:"avatarUrl,omitempty"`
+	// swagger:ignore
+	Locale string `bson:"locale,omitempty"`
+	// swagger:ignore
+	Identities []Identity `bson:"identities,omitempty"`
+	// swagger:ignore
+	TwoFactor *TwoFactor `bson:"twoFactor,omitempty"`
+	// swagger:ignore
+	Roles []Role `bson:"roles"`
+	// swagger:ignore
 	CreatedAt time.Time `bson:"createdAt"`
 }
 
-// Validate calidate user model
+// Validate calidate user model, external users need no password
 func (m *User) Validate() error {
 
-	if m.Username == "" || m.Password == "" {
+	if m.Username == "" || (m.Password == "" && len(m.Identities) == 0) {
 		return ErrInvalidModel
 	}
 	return nil
 }
 
-// CheckPassword check user password
-func (m *User) CheckPassword(password string) error {
-	err := bcrypt.CompareHashAndPassword([]byte(m.Password), []byte(password))
-	if err != nil && errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
-		return ErrPassMismatched
+// SetPassword check the raw password against the policy and store its hash of hasher,
+// *ValidationError with username and password messages
+func (m *User) SetPassword(password string, policy *PasswordPolicy, hasher *PasswordHasher) error {
+
+	validationErr := &ValidationError{}
+	if m.Username == "" {
+		validationErr.Add("username", "is required")
+	}
+	if violations := policy.Check(password); len(violations) > 0 {
+		validationErr.Add("password", violations...)
+	}
+	if !validationErr.Empty() {
+		return validationErr
 	}
-	return err
+
+	return m.RehashPassword(password, hasher)
 }
 
-func hashPassword(raw string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(raw), bcrypt.DefaultCost)
+// RehashPassword store the hash of the raw password of hasher, no policy check,
+// use after CheckPassword when PasswordNeedsRehash
+func (m *User) RehashPassword(password string, hasher *PasswordHasher) error {
+
+	pwd, err := hasher.Hash(password)
 	if err != nil {
-		return "", err
+		return err
+	}
+	m.Password = pwd
+
+	return nil
+}
+
+// PasswordNeedsRehash the stored hash uses an older algorithm or weaker parameters than hasher
+func (m *User) PasswordNeedsRehash(hasher *PasswordHasher) bool {
+	return hasher.NeedsRehash(m.Password)
+}
+
+// NormaliseEmail trimmed lower case email, emails are unique and looked up in this form
+func NormaliseEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// IsEmailVerified ...
+func (m *User) IsEmailVerified() bool {
+	return m.EmailVerifiedAt != nil
+}
+
+// HasRole ...
+func (m *User) HasRole(role Role) bool {
+	for _, r := range m.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Can any role of the user grants the permission, AccessPolicy.Can adds the email and two-factor checks
+func (m *User) Can(permission Permission) bool {
+	for _, r := range m.Roles {
+		if r.Can(permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckPassword check user password, argon2id and bcrypt hashes, users without a password never match
+func (m *User) CheckPassword(password string, hasher *PasswordHasher) error {
+	if m.Password == "" {
+		// spend the time of a password check, the response time does not tell which users have one
+		hasher.CompareDummy(password)
+		return ErrPassMismatched
 	}
-	return string(bytes), err
+	return hasher.Verify(m.Password, password)
 }
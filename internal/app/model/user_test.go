@@ -1,27 +1,104 @@
 package model
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestNewUserModel(t *testing.T) {
-	u, err := NewUserModel("Wozniak", "password")
+	u, err := NewUserModel("Wozniak", "password", &PasswordPolicy{}, DefaultPasswordHasher())
 	assert.Nil(t, err)
 	assert.Equal(t, u.Username, "Wozniak")
 	assert.NotNil(t, u.ID)
 	assert.NotEqual(t, u.Password, "password")
 }
 
+func TestUserCan(t *testing.T) {
+	u, err := NewUserModel("Wozniak", "password", &PasswordPolicy{}, DefaultPasswordHasher())
+	assert.Nil(t, err)
+	assert.Equal(t, []Role{RoleContributor}, u.Roles)
+	assert.True(t, u.Can(PermissionPlaceCreate))
+	assert.False(t, u.Can(PermissionPlaceDelete))
+
+	u.Roles = []Role{RoleViewer}
+	assert.False(t, u.Can(PermissionPlaceCreate))
+
+	u.Roles = append(u.Roles, RoleAdmin)
+	assert.True(t, u.Can(PermissionUserManage))
+	assert.True(t, u.HasRole(RoleAdmin))
+
+	_, err = NormaliseRoles([]Role{RoleAdmin, "owner"})
+	assert.ErrorIs(t, err, ErrInvalidModel)
+}
+
+func TestAccessPolicyCanUnverified(t *testing.T) {
+	policy := NewAccessPolicy([]Permission{PermissionPlaceCreate}, nil)
+
+	u, err := NewUserModel("Wozniak", "password", &PasswordPolicy{}, DefaultPasswordHasher())
+	assert.Nil(t, err)
+	assert.False(t, policy.Can(u, PermissionPlaceCreate))
+	assert.True(t, NewAccessPolicy(nil, nil).Can(u, PermissionPlaceCreate))
+
+	u.Roles = []Role{RoleEditor}
+	assert.True(t, policy.Can(u, PermissionPlaceDelete))
+
+	now := time.Now()
+	u.EmailVerifiedAt = &now
+	assert.True(t, policy.Can(u, PermissionPlaceCreate))
+}
+
+func TestAccessPolicyCanTwoFactorRequired(t *testing.T) {
+	policy := NewAccessPolicy(nil, []Role{RoleEditor})
+
+	u, err := NewUserModel("Wozniak", "password", &PasswordPolicy{}, DefaultPasswordHasher())
+	assert.Nil(t, err)
+	u.Roles = []Role{RoleContributor}
+	assert.False(t, policy.TwoFactorRequired(u))
+	assert.True(t, policy.Can(u, PermissionPlaceCreate))
+
+	u.Roles = append(u.Roles, RoleEditor)
+	assert.True(t, policy.TwoFactorRequired(u))
+	assert.False(t, policy.Can(u, PermissionPlaceCreate))
+	assert.True(t, u.Can(PermissionPlaceCreate))
+
+	// a pending enrolment is not enabled yet
+	u.TwoFactor = &TwoFactor{Secret: "GEZDGNBVGY3TQOJQ"}
+	assert.False(t, policy.Can(u, PermissionPlaceCreate))
+
+	now := time.Now()
+	u.TwoFactor.EnabledAt = &now
+	assert.False(t, policy.TwoFactorRequired(u))
+	assert.True(t, policy.NeedsTwoFactor(u))
+	assert.True(t, policy.Can(u, PermissionPlaceDelete))
+
+	external, err := NewExternalUserModel("Jobs", Identity{Provider: "google", Subject: "109"})
+	assert.Nil(t, err)
+	external.Roles = []Role{RoleEditor}
+	assert.False(t, policy.TwoFactorRequired(external))
+}
+
 func TestValidatePassword(t *testing.T) {
-	u, _ := NewUserModel("Wozniak", "password")
-	err := u.CheckPassword("password")
+	u, _ := NewUserModel("Wozniak", "password", &PasswordPolicy{}, DefaultPasswordHasher())
+	err := u.CheckPassword("password", DefaultPasswordHasher())
 	assert.Nil(t, err)
-	err = u.CheckPassword("wrong_password")
+	err = u.CheckPassword("wrong_password", DefaultPasswordHasher())
 	assert.NotNil(t, err)
 }
 
+func TestNewExternalUserModel(t *testing.T) {
+	u, err := NewExternalUserModel("Wozniak", Identity{Provider: "google", Subject: "108"})
+	assert.Nil(t, err)
+	assert.Nil(t, u.Validate())
+	assert.Equal(t, []Role{RoleContributor}, u.Roles)
+	assert.ErrorIs(t, u.CheckPassword("", DefaultPasswordHasher()), ErrPassMismatched)
+
+	u.Identities = nil
+	assert.ErrorIs(t, u.Validate(), ErrInvalidModel)
+}
+
 func TestUserValidate(t *testing.T) {
 	type test struct {
 		username string
@@ -54,8 +131,74 @@ func TestUserValidate(t *testing.T) {
 
 	for _, tc := range tests {
 
-		_, err := NewUserModel(tc.username, tc.password)
-		assert.Equal(t, err, tc.want)
+		_, err := NewUserModel(tc.username, tc.password, &PasswordPolicy{}, DefaultPasswordHasher())
+		assert.ErrorIs(t, err, tc.want)
 	}
 
 }
+
+func TestPasswordPolicy(t *testing.T) {
+	type test struct {
+		password string
+		want     []string
+	}
+
+	p := NewPasswordPolicy(8, 3, true)
+
+	tests := []test{
+		{
+			password: "correct-Horse-battery",
+			want:     []string{},
+		},
+		{
+			password: "Sh0rt!",
+			want:     []string{"must be at least 8 characters"},
+		},
+		{
+			password: "lowercaseonly",
+			want:     []string{"must use at least 3 of: lowercase letters, uppercase letters, digits, symbols"},
+		},
+		{
+			password: "Password123",
+			want:     []string{"is too common"},
+		},
+		{
+			password: "пароль-Длинный",
+			want:     []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, p.Check(tc.password), tc.password)
+	}
+
+	_, err := NewUserModel("Wozniak", "password", p, DefaultPasswordHasher())
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Len(t, validationErr.Fields["password"], 2)
+	assert.ErrorIs(t, err, ErrInvalidModel)
+}
+
+func TestUser_SetProfile(t *testing.T) {
+
+	m, err := NewUserModel("Wozniak", "password", &PasswordPolicy{}, DefaultPasswordHasher())
+	assert.Nil(t, err)
+
+	assert.Nil(t, m.SetProfile("  Steve Wozniak ", "https://example.com/woz.png", "en_us"))
+	assert.Equal(t, "Steve Wozniak", m.DisplayName)
+	assert.Equal(t, "https://example.com/woz.png", m.AvatarURL)
+	assert.Equal(t, "en-US", m.Locale)
+
+	err = m.SetProfile(strings.Repeat("я", DisplayNameMaxLength+1), "javascript:alert(1)", "not a locale")
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, validationErr.Fields, "displayName")
+	assert.Contains(t, validationErr.Fields, "avatarUrl")
+	assert.Contains(t, validationErr.Fields, "locale")
+	assert.Equal(t, "Steve Wozniak", m.DisplayName)
+
+	assert.Nil(t, m.SetProfile("", "", ""))
+	assert.Empty(t, m.DisplayName)
+	assert.Empty(t, m.AvatarURL)
+	assert.Empty(t, m.Locale)
+}
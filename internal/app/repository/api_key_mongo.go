@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"walk_backend/internal/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/net/context"
+)
+
+// APIKeyMongoRepository API key mongodb repo
+type APIKeyMongoRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAPIKeyMongoRepository create new API key mongo repository
+func NewAPIKeyMongoRepository(collection *mongo.Collection) *APIKeyMongoRepository {
+	return &APIKeyMongoRepository{
+		collection: collection,
+	}
+}
+
+// Create ...
+func (r *APIKeyMongoRepository) Create(ctx context.Context, m *model.APIKey) (model.ID, error) {
+	if m.ID.IsNil() {
+		id, err := model.NewID()
+		if err != nil {
+			return model.NilID, err
+		}
+		m.ID = id
+	}
+
+	_, err := r.collection.InsertOne(ctx, m)
+
+	return m.ID, err
+}
+
+// FindByHash API key by key hash
+func (r *APIKeyMongoRepository) FindByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+
+	cur := r.collection.FindOne(ctx, bson.M{
+		"keyHash": keyHash,
+	})
+
+	if cur.Err() != nil {
+		if errors.Is(cur.Err(), mongo.ErrNoDocuments) {
+			return nil, model.ErrModelNotFound
+		}
+		return nil, cur.Err()
+	}
+
+	var m model.APIKey
+	if err := cur.Decode(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// FindActiveByUsername not revoked and not expired keys of the user, newest first
+func (r *APIKeyMongoRepository) FindActiveByUsername(ctx context.Context, username string, now time.Time) ([]*model.APIKey, error) {
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cur, err := r.collection.Find(ctx, bson.M{
+		"username":  username,
+		"revokedAt": bson.M{"$exists": false},
+		"$or": bson.A{
+			bson.M{"expiresAt": bson.M{"$exists": false}},
+			bson.M{"expiresAt": bson.M{"$gt": now}},
+		},
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*model.APIKey, 0)
+	if err := cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Revoke set revokedAt of a not yet revoked key of the user, ErrModelNotFound when there is none
+func (r *APIKeyMongoRepository) Revoke(ctx context.Context, id model.ID, username string, revokedAt time.Time) error {
+
+	updateResult, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id":       id,
+		"username":  username,
+		"revokedAt": bson.M{"$exists": false},
+	}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "revokedAt", Value: revokedAt},
+	}}})
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return model.ErrModelNotFound
+	}
+
+	return nil
+}
+
+// RevokeByUsername set revokedAt of every not yet revoked key of the user, returns the number of revoked keys
+func (r *APIKeyMongoRepository) RevokeByUsername(ctx context.Context, username string, revokedAt time.Time) (int64, error) {
+
+	updateResult, err := r.collection.UpdateMany(ctx, bson.M{
+		"username":  username,
+		"revokedAt": bson.M{"$exists": false},
+	}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "revokedAt", Value: revokedAt},
+	}}})
+	if err != nil {
+		return 0, err
+	}
+
+	return updateResult.ModifiedCount, nil
+}
+
+// Touch set lastUsedAt
+func (r *APIKeyMongoRepository) Touch(ctx context.Context, id model.ID, lastUsedAt time.Time) error {
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id": id,
+	}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "lastUsedAt", Value: lastUsedAt},
+	}}})
+
+	return err
+}
+
+// DeleteByUsername delete every key of the user, returns the number of deleted keys
+func (r *APIKeyMongoRepository) DeleteByUsername(ctx context.Context, username string) (int64, error) {
+
+	deleteResult, err := r.collection.DeleteMany(ctx, bson.M{"username": username})
+	if err != nil {
+		return 0, err
+	}
+
+	return deleteResult.DeletedCount, nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"walk_backend/internal/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/net/context"
+)
+
+// AuditEventMongoRepository audit event mongodb repo
+type AuditEventMongoRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAuditEventMongoRepository create new mongo audit event repository
+func NewAuditEventMongoRepository(collection *mongo.Collection) *AuditEventMongoRepository {
+	return &AuditEventMongoRepository{
+		collection: collection,
+	}
+}
+
+// CreateMany insert audit events
+func (r *AuditEventMongoRepository) CreateMany(ctx context.Context, events []*model.AuditEvent) error {
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	docs := make([]any, 0, len(events))
+	for _, e := range events {
+		docs = append(docs, e)
+	}
+
+	_, err := r.collection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	return err
+}
+
+// Find page of the events matching the filter, newest first
+func (r *AuditEventMongoRepository) Find(ctx context.Context, filter *model.AuditEventFilter, offset int64, limit int64) ([]*model.AuditEvent, error) {
+
+	query := bson.M{}
+	if filter.Type != "" {
+		query["type"] = filter.Type
+	}
+	if filter.Outcome != "" {
+		query["outcome"] = filter.Outcome
+	}
+	if filter.Actor != "" {
+		query["actor"] = filter.Actor
+	}
+	if filter.IP != "" {
+		query["ip"] = filter.IP
+	}
+	createdAt := bson.M{}
+	if !filter.From.IsZero() {
+		createdAt["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		createdAt["$lt"] = filter.To
+	}
+	if len(createdAt) > 0 {
+		query["createdAt"] = createdAt
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(offset).
+		SetLimit(limit)
+	cur, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*model.AuditEvent, 0)
+	if err := cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"walk_backend/internal/app/model"
+
+	"github.com/go-redis/redis/v9"
+	"golang.org/x/net/context"
+)
+
+const (
+	categoryCacheKey        string = "categories:all"
+	categoryCacheVersionKey string = "categories:version"
+)
+
+// CategoryRepository category repo to cache
+type CategoryRepository interface {
+	FindAll(ctx context.Context) (model.CategoryList, error)
+}
+
+// CategoryCacheRedisRepository read-through cache of all categories in process and in redis.
+// Writers call Invalidate after their write is committed, it drops both and notifies other instances
+// by redis pub/sub. The redis key carries a version bumped by Invalidate and the process cache a
+// generation, a fill that read the repo before an invalidation is not stored
+type CategoryCacheRedisRepository struct {
+	repo     CategoryRepository
+	client   *redis.Client
+	channel  string
+	localTTL time.Duration
+	redisTTL time.Duration
+
+	mu         sync.RWMutex
+	categories model.CategoryList
+	expires    time.Time
+	generation uint64
+}
+
+// NewCategoryCacheRedisRepository create new cached category repository
+func NewCategoryCacheRedisRepository(
+	repo CategoryRepository,
+	client *redis.Client,
+	channel string,
+	localTTL time.Duration,
+	redisTTL time.Duration,
+) *CategoryCacheRedisRepository {
+	return &CategoryCacheRedisRepository{
+		repo:     repo,
+		client:   client,
+		channel:  channel,
+		localTTL: localTTL,
+		redisTTL: redisTTL,
+	}
+}
+
+// Find category from the cached list
+func (r *CategoryCacheRedisRepository) Find(ctx context.Context, id model.ID) (*model.Category, error) {
+
+	categories, err := r.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m := categories.FindByID(id)
+	if m == nil {
+		return nil, model.ErrModelNotFound
+	}
+
+	return m, nil
+}
+
+// FindBySlug category from the cached list
+func (r *CategoryCacheRedisRepository) FindBySlug(ctx context.Context, slug string) (*model.Category, error) {
+
+	categories, err := r.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m := categories.FindBySlug(slug)
+	if m == nil {
+		return nil, model.ErrModelNotFound
+	}
+
+	return m, nil
+}
+
+// FindAll categories from process cache, then redis, then the repo
+func (r *CategoryCacheRedisRepository) FindAll(ctx context.Context) (model.CategoryList, error) {
+
+	categories, generation := r.getLocal()
+	if categories != nil {
+		return categories, nil
+	}
+
+	// redis errors are not fatal for reads, the repo is the source of truth
+	version, err := r.client.Get(ctx, categoryCacheVersionKey).Int64()
+	redisOK := err == nil || err == redis.Nil
+	key := categoryCacheKey + ":" + strconv.FormatInt(version, 10)
+	if redisOK {
+		if result, err := r.client.Get(ctx, key).Result(); err == nil {
+			categories := make(model.CategoryList, 0)
+			if err := json.Unmarshal([]byte(result), &categories); err == nil {
+				r.setLocal(categories, generation)
+				return copyCategories(categories), nil
+			}
+		}
+	}
+
+	categories, err = r.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// after an Invalidate the key of the read version is not read any more
+	if data, err := json.Marshal(categories); redisOK && err == nil {
+		r.client.Set(ctx, key, string(data), r.redisTTL)
+	}
+	r.setLocal(categories, generation)
+
+	return copyCategories(categories), nil
+}
+
+// Invalidate drop cached categories here, in redis and on the other instances
+func (r *CategoryCacheRedisRepository) Invalidate(ctx context.Context) error {
+
+	r.clearLocal()
+
+	if err := r.client.Incr(ctx, categoryCacheVersionKey).Err(); err != nil {
+		return err
+	}
+
+	return r.client.Publish(ctx, r.channel, categoryCacheKey).Err()
+}
+
+// Subscribe drop the process cache on invalidation messages until ctx is done
+func (r *CategoryCacheRedisRepository) Subscribe(ctx context.Context) error {
+
+	pubsub := r.client.Subscribe(ctx, r.channel)
+	defer pubsub.Close()
+
+	// wait for subscription confirmation, so no message is missed after return of Receive
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return err
+	}
+	// the cache could be filled before the subscription
+	r.clearLocal()
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			r.clearLocal()
+		}
+	}
+}
+
+// getLocal cached categories, nil on a miss, and the generation a fill after the miss is stored for
+func (r *CategoryCacheRedisRepository) getLocal() (model.CategoryList, uint64) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.categories == nil || time.Now().After(r.expires) {
+		return nil, r.generation
+	}
+
+	return copyCategories(r.categories), r.generation
+}
+
+// setLocal store categories read in the generation, dropped when cleared since
+func (r *CategoryCacheRedisRepository) setLocal(categories model.CategoryList, generation uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if generation != r.generation {
+		return
+	}
+
+	r.categories = categories
+	r.expires = time.Now().Add(r.localTTL)
+}
+
+func (r *CategoryCacheRedisRepository) clearLocal() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.categories = nil
+	r.generation++
+}
+
+// copyCategories callers may reorder the list and change the categories
+func copyCategories(categories model.CategoryList) model.CategoryList {
+	c := make(model.CategoryList, 0, len(categories))
+	for _, m := range categories {
+		category := *m
+		if m.ParentID != nil {
+			parentID := *m.ParentID
+			category.ParentID = &parentID
+		}
+		c = append(c, &category)
+	}
+	return c
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"encoding/json"
+	"time"
+
+	"walk_backend/internal/app/model"
+
+	"github.com/go-redis/redis/v9"
+	"golang.org/x/net/context"
+)
+
+const (
+	categoryCountCacheKey string = "categories:place-counts"
+)
+
+// CategoryCountCacheRedisRepository place counts by category redis cache
+type CategoryCountCacheRedisRepository struct {
+	client *redis.Client
+}
+
+// NewCategoryCountCacheRedisRepository create new redis category count cache repository
+func NewCategoryCountCacheRedisRepository(client *redis.Client) *CategoryCountCacheRedisRepository {
+	return &CategoryCountCacheRedisRepository{
+		client: client,
+	}
+}
+
+// Get cached counts, nil when not cached
+func (r *CategoryCountCacheRedisRepository) Get(ctx context.Context) (model.CategoryCounts, error) {
+
+	result, err := r.client.Get(ctx, categoryCountCacheKey).Result()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	counts := make(model.CategoryCounts)
+	if err = json.Unmarshal([]byte(result), &counts); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// Set cache counts
+func (r *CategoryCountCacheRedisRepository) Set(ctx context.Context, counts model.CategoryCounts, expiration time.Duration) error {
+
+	data, err := json.Marshal(counts)
+	if err != nil {
+		return err
+	}
+
+	return r.client.Set(ctx, categoryCountCacheKey, string(data), expiration).Err()
+}
+
+// Del delete cached counts
+func (r *CategoryCountCacheRedisRepository) Del(ctx context.Context) error {
+	return r.client.Del(ctx, categoryCountCacheKey).Err()
+}
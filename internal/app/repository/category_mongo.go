@@ -2,7 +2,6 @@ package repository
 
 import (
 	"errors"
-	"fmt"
 
 	"walk_backend/internal/app/model"
 
@@ -45,6 +44,28 @@ func (r *CategoryMongoRepository) Find(ctx context.Context, id model.ID) (*model
 	return &m, nil
 }
 
+// FindBySlug category
+func (r *CategoryMongoRepository) FindBySlug(ctx context.Context, slug string) (*model.Category, error) {
+
+	cur := r.collection.FindOne(ctx, bson.M{
+		"slug": slug,
+	})
+
+	if cur.Err() != nil {
+		if errors.Is(cur.Err(), mongo.ErrNoDocuments) {
+			return nil, model.ErrModelNotFound
+		}
+		return nil, cur.Err()
+	}
+
+	var m model.Category
+	if err := cur.Decode(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
 // FindAll categories
 func (r *CategoryMongoRepository) FindAll(ctx context.Context) (model.CategoryList, error) {
 
@@ -78,6 +99,9 @@ func (r *CategoryMongoRepository) Create(ctx context.Context, m *model.Category)
 	}
 
 	_, err := r.collection.InsertOne(ctx, m)
+	if mongo.IsDuplicateKeyError(err) {
+		return model.NilID, model.ErrModelDuplicate
+	}
 
 	return m.ID, err
 }
@@ -85,13 +109,42 @@ func (r *CategoryMongoRepository) Create(ctx context.Context, m *model.Category)
 // Update ...
 func (r *CategoryMongoRepository) Update(ctx context.Context, m *model.Category) error {
 
-	fmt.Println(m)
-	updateResult, err := r.collection.UpdateOne(ctx, bson.M{
-		"_id": m.ID,
-	}, bson.D{{Key: "$set", Value: bson.D{
+	set := bson.D{
 		{Key: "name", Value: m.Name},
+		{Key: "slug", Value: m.Slug},
 		{Key: "order", Value: m.Order},
-	}}})
+	}
+	unset := bson.D{}
+	optional := []bson.E{
+		{Key: "icon", Value: m.Icon},
+		{Key: "color", Value: m.Color},
+		{Key: "description", Value: m.Description},
+	}
+	for _, e := range optional {
+		if e.Value != "" {
+			set = append(set, e)
+		} else {
+			unset = append(unset, bson.E{Key: e.Key, Value: ""})
+		}
+	}
+	if m.ParentID != nil {
+		set = append(set, bson.E{Key: "parentId", Value: m.ParentID})
+	} else {
+		unset = append(unset, bson.E{Key: "parentId", Value: ""})
+	}
+	update := bson.D{{Key: "$set", Value: set}}
+	if len(unset) > 0 {
+		update = append(update, bson.E{Key: "$unset", Value: unset})
+	}
+
+	updateResult, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id": m.ID,
+	}, update)
+	if mongo.IsDuplicateKeyError(err) {
+		return model.ErrModelDuplicate
+	} else if err != nil {
+		return err
+	}
 
 	if updateResult.MatchedCount == 0 {
 		return model.ErrModelNotFound
@@ -99,7 +152,37 @@ func (r *CategoryMongoRepository) Update(ctx context.Context, m *model.Category)
 		return model.ErrModelUpdate
 	}
 
-	return err
+	return nil
+}
+
+// UpdateOrders set order by position in ids, ids must be all stored categories,
+// run it in a transaction so a mismatch leaves orders untouched
+func (r *CategoryMongoRepository) UpdateOrders(ctx context.Context, ids []model.ID) error {
+
+	total, err := r.collection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+	if total != int64(len(ids)) {
+		return model.ErrModelSetMismatch
+	}
+
+	models := make([]mongo.WriteModel, 0, len(ids))
+	for i, id := range ids {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": id}).
+			SetUpdate(bson.M{"$set": bson.M{"order": int32(i + 1)}}))
+	}
+
+	result, err := r.collection.BulkWrite(ctx, models)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount != int64(len(ids)) {
+		return model.ErrModelSetMismatch
+	}
+
+	return nil
 }
 
 // Delete ...
@@ -107,10 +190,13 @@ func (r *CategoryMongoRepository) Delete(ctx context.Context, id model.ID) error
 	deleteResult, err := r.collection.DeleteOne(ctx, bson.M{
 		"_id": id,
 	})
+	if err != nil {
+		return err
+	}
 
 	if deleteResult.DeletedCount == 0 {
 		return model.ErrModelNotFound
 	}
 
-	return err
+	return nil
 }
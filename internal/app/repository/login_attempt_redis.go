@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/go-redis/redis/v9"
+	"golang.org/x/net/context"
+)
+
+const (
+	loginAttemptKeyPrefix string = "auth:attempts:"
+	loginBlockKeyPrefix   string = "auth:blocked:"
+)
+
+// LoginAttemptRedisRepository failed login counters and temporary blocks
+type LoginAttemptRedisRepository struct {
+	client *redis.Client
+}
+
+// NewLoginAttemptRedisRepository create new redis login attempt repository
+func NewLoginAttemptRedisRepository(client *redis.Client) *LoginAttemptRedisRepository {
+	return &LoginAttemptRedisRepository{
+		client: client,
+	}
+}
+
+// Incr count a failure, the counter expires window after the first failure
+func (r *LoginAttemptRedisRepository) Incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+
+	pipe := r.client.TxPipeline()
+	incr := pipe.Incr(ctx, loginAttemptKeyPrefix+key)
+	pipe.ExpireNX(ctx, loginAttemptKeyPrefix+key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
+// Block block the key for duration
+func (r *LoginAttemptRedisRepository) Block(ctx context.Context, key string, duration time.Duration) error {
+	return r.client.Set(ctx, loginBlockKeyPrefix+key, 1, duration).Err()
+}
+
+// BlockedFor remaining block time, 0 when not blocked
+func (r *LoginAttemptRedisRepository) BlockedFor(ctx context.Context, key string) (time.Duration, error) {
+
+	ttl, err := r.client.PTTL(ctx, loginBlockKeyPrefix+key).Result()
+	if err != nil {
+		return 0, err
+	}
+	// -2 missing key, -1 no expiry which Block never sets
+	if ttl < 0 {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
+
+// Reset delete the counter and the block of the key
+func (r *LoginAttemptRedisRepository) Reset(ctx context.Context, key string) error {
+	return r.client.Del(ctx, loginAttemptKeyPrefix+key, loginBlockKeyPrefix+key).Err()
+}
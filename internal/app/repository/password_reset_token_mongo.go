@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"walk_backend/internal/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/net/context"
+)
+
+// PasswordResetTokenMongoRepository password reset token mongodb repo
+type PasswordResetTokenMongoRepository struct {
+	collection *mongo.Collection
+}
+
+// NewPasswordResetTokenMongoRepository create new password reset token mongo repository
+func NewPasswordResetTokenMongoRepository(collection *mongo.Collection) *PasswordResetTokenMongoRepository {
+	return &PasswordResetTokenMongoRepository{
+		collection: collection,
+	}
+}
+
+// Create ...
+func (r *PasswordResetTokenMongoRepository) Create(ctx context.Context, m *model.PasswordResetToken) (model.ID, error) {
+	if m.ID.IsNil() {
+		id, err := model.NewID()
+		if err != nil {
+			return model.NilID, err
+		}
+		m.ID = id
+	}
+
+	_, err := r.collection.InsertOne(ctx, m)
+
+	return m.ID, err
+}
+
+// FindByHash password reset token by token hash
+func (r *PasswordResetTokenMongoRepository) FindByHash(ctx context.Context, tokenHash string) (*model.PasswordResetToken, error) {
+
+	cur := r.collection.FindOne(ctx, bson.M{
+		"tokenHash": tokenHash,
+	})
+
+	if cur.Err() != nil {
+		if errors.Is(cur.Err(), mongo.ErrNoDocuments) {
+			return nil, model.ErrModelNotFound
+		}
+		return nil, cur.Err()
+	}
+
+	var m model.PasswordResetToken
+	if err := cur.Decode(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// MarkUsed set usedAt of a not yet used token, ErrModelUpdate when it is already used
+func (r *PasswordResetTokenMongoRepository) MarkUsed(ctx context.Context, id model.ID, usedAt time.Time) error {
+
+	updateResult, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id":    id,
+		"usedAt": bson.M{"$exists": false},
+	}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "usedAt", Value: usedAt},
+	}}})
+	if err != nil {
+		return err
+	}
+
+	if updateResult.ModifiedCount == 0 {
+		return model.ErrModelUpdate
+	}
+
+	return nil
+}
+
+// InvalidateByUsername mark every not yet used token of the user used
+func (r *PasswordResetTokenMongoRepository) InvalidateByUsername(ctx context.Context, username string, usedAt time.Time) error {
+
+	_, err := r.collection.UpdateMany(ctx, bson.M{
+		"username": username,
+		"usedAt":   bson.M{"$exists": false},
+	}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "usedAt", Value: usedAt},
+	}}})
+
+	return err
+}
@@ -54,3 +54,21 @@ func (r *PlaceCacheRedisRepository) Set(ctx context.Context, key string, places
 func (r *PlaceCacheRedisRepository) Del(ctx context.Context, keys ...string) error {
 	return r.сlient.Del(ctx, keys...).Err()
 }
+
+// DelByPrefix Delete cache places with keys starting with prefix
+func (r *PlaceCacheRedisRepository) DelByPrefix(ctx context.Context, prefix string) error {
+
+	iter := r.сlient.Scan(ctx, 0, prefix+"*", 100).Iterator()
+	keys := make([]string, 0)
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return r.сlient.Del(ctx, keys...).Err()
+}
@@ -2,16 +2,23 @@ package repository
 
 import (
 	"errors"
+	"regexp"
+	"strings"
 	"time"
 
 	"walk_backend/internal/app/model"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"golang.org/x/net/context"
 )
 
+const (
+	earthRadiusKm float64 = 6378.1
+)
+
 // PlaceMongoRepository place mongodb repo
 type PlaceMongoRepository struct {
 	collection *mongo.Collection
@@ -67,6 +74,86 @@ func (r *PlaceMongoRepository) FindAll(ctx context.Context) (model.PlaceList, er
 	return mList, nil
 }
 
+// FindBatch places sorted by id after the given id
+func (r *PlaceMongoRepository) FindBatch(ctx context.Context, after model.ID, limit int64) (model.PlaceList, error) {
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$gt": after}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	mList := make(model.PlaceList, 0, limit)
+	for cursor.Next(ctx) {
+		var place model.Place
+		if err := cursor.Decode(&place); err != nil {
+			return nil, err
+		}
+		mList = append(mList, &place)
+	}
+
+	return mList, cursor.Err()
+}
+
+// FindChangedSince places created or updated at or after since, sorted by id after the given id
+func (r *PlaceMongoRepository) FindChangedSince(ctx context.Context, since time.Time, after model.ID, limit int64) (model.PlaceList, error) {
+
+	filter := bson.M{
+		"_id": bson.M{"$gt": after},
+		"$or": bson.A{
+			bson.M{"createdAt": bson.M{"$gte": since}},
+			bson.M{"updatedAt": bson.M{"$gte": since}},
+		},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	mList := make(model.PlaceList, 0, limit)
+	for cursor.Next(ctx) {
+		var place model.Place
+		if err := cursor.Decode(&place); err != nil {
+			return nil, err
+		}
+		mList = append(mList, &place)
+	}
+
+	return mList, cursor.Err()
+}
+
+// ExistingIDs the ids of places still stored
+func (r *PlaceMongoRepository) ExistingIDs(ctx context.Context, ids []model.ID) ([]model.ID, error) {
+
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	existing := make([]model.ID, 0, len(ids))
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID model.ID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		existing = append(existing, doc.ID)
+	}
+
+	return existing, cursor.Err()
+}
+
+// Count places
+func (r *PlaceMongoRepository) Count(ctx context.Context) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{})
+}
+
 // Create ...
 func (r *PlaceMongoRepository) Create(ctx context.Context, place *model.Place) (model.ID, error) {
 
@@ -96,6 +183,8 @@ func (r *PlaceMongoRepository) Update(ctx context.Context, place *model.Place) e
 		{Key: "description", Value: place.Description},
 		{Key: "category", Value: place.Category},
 		{Key: "tags", Value: place.Tags},
+		{Key: "location", Value: place.Location},
+		{Key: "searchTerms", Value: place.SearchTerms},
 		{Key: "updatedAt", Value: place.UpdatedAt},
 	}}})
 
@@ -108,6 +197,235 @@ func (r *PlaceMongoRepository) Update(ctx context.Context, place *model.Place) e
 	return err
 }
 
+// FindByTags places having any of the tags
+func (r *PlaceMongoRepository) FindByTags(ctx context.Context, tags []string) (model.PlaceList, error) {
+
+	cursor, err := r.collection.Find(ctx, bson.M{"tags": bson.M{"$in": tags}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	mList := make(model.PlaceList, 0)
+	for cursor.Next(ctx) {
+		var place model.Place
+		if err := cursor.Decode(&place); err != nil {
+			return nil, err
+		}
+		mList = append(mList, &place)
+	}
+
+	return mList, cursor.Err()
+}
+
+// FindByAuthor places created by the user, oldest first
+func (r *PlaceMongoRepository) FindByAuthor(ctx context.Context, authorID model.ID) (model.PlaceList, error) {
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"authorId": authorID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	mList := make(model.PlaceList, 0)
+	for cursor.Next(ctx) {
+		var place model.Place
+		if err := cursor.Decode(&place); err != nil {
+			return nil, err
+		}
+		mList = append(mList, &place)
+	}
+
+	return mList, cursor.Err()
+}
+
+// AnonymiseAuthor unset the author of the places created by the user, returns the number of places
+func (r *PlaceMongoRepository) AnonymiseAuthor(ctx context.Context, authorID model.ID) (int64, error) {
+
+	updateResult, err := r.collection.UpdateMany(ctx, bson.M{
+		"authorId": authorID,
+	}, bson.D{{Key: "$unset", Value: bson.D{
+		{Key: "authorId", Value: ""},
+	}}})
+	if err != nil {
+		return 0, err
+	}
+
+	return updateResult.ModifiedCount, nil
+}
+
+// FindByIDs ...
+func (r *PlaceMongoRepository) FindByIDs(ctx context.Context, ids []model.ID) (model.PlaceList, error) {
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	mList := make(model.PlaceList, 0, len(ids))
+	for cursor.Next(ctx) {
+		var place model.Place
+		if err := cursor.Decode(&place); err != nil {
+			return nil, err
+		}
+		mList = append(mList, &place)
+	}
+
+	return mList, cursor.Err()
+}
+
+// ReplaceTags replace the from tags with into on the places in place, a concurrent update of the
+// other tags is not lost. $addToSet and $pull of the same array conflict in one update, run it in a transaction
+func (r *PlaceMongoRepository) ReplaceTags(ctx context.Context, ids []model.ID, from []string, into string) error {
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	filter := bson.M{"_id": bson.M{"$in": ids}, "tags": bson.M{"$in": from}}
+	_, err := r.collection.UpdateMany(ctx, filter, bson.M{"$addToSet": bson.M{"tags": into}})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateMany(ctx, filter, bson.D{
+		{Key: "$pull", Value: bson.M{"tags": bson.M{"$in": from}}},
+		{Key: "$set", Value: bson.M{"updatedAt": time.Now()}},
+	})
+	return err
+}
+
+// UpdateSearchTerms bulk update search terms of places
+func (r *PlaceMongoRepository) UpdateSearchTerms(ctx context.Context, places model.PlaceList) error {
+
+	if len(places) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(places))
+	for _, place := range places {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": place.ID}).
+			SetUpdate(bson.D{{Key: "$set", Value: bson.D{
+				{Key: "searchTerms", Value: place.SearchTerms},
+			}}}))
+	}
+
+	_, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// CountByCategory ...
+func (r *PlaceMongoRepository) CountByCategory(ctx context.Context, categoryID model.ID) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"category": categoryID})
+}
+
+// FindPublishedByCategory page of published places of the category, oldest first
+func (r *PlaceMongoRepository) FindPublishedByCategory(ctx context.Context, categoryID model.ID, offset int64, limit int64) (model.PlaceList, error) {
+
+	filter := publishedPlacesFilter()
+	filter = append(filter, bson.E{Key: "category", Value: categoryID})
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetSkip(offset).SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	mList := make(model.PlaceList, 0, limit)
+	for cursor.Next(ctx) {
+		var place model.Place
+		if err := cursor.Decode(&place); err != nil {
+			return nil, err
+		}
+		mList = append(mList, &place)
+	}
+
+	return mList, cursor.Err()
+}
+
+// CountPublishedByCategory ...
+func (r *PlaceMongoRepository) CountPublishedByCategory(ctx context.Context, categoryID model.ID) (int64, error) {
+
+	filter := publishedPlacesFilter()
+	filter = append(filter, bson.E{Key: "category", Value: categoryID})
+
+	return r.collection.CountDocuments(ctx, filter)
+}
+
+// CountPublishedByCategories number of published places of every used category
+func (r *PlaceMongoRepository) CountPublishedByCategories(ctx context.Context) (model.CategoryCounts, error) {
+
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: publishedPlacesFilter()}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$category"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	counts := make(model.CategoryCounts)
+	for cursor.Next(ctx) {
+		var row struct {
+			Category model.ID `bson:"_id"`
+			Count    int64    `bson:"count"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, err
+		}
+		counts[row.Category] = row.Count
+	}
+
+	return counts, cursor.Err()
+}
+
+// ReassignCategory move places to another category, returns IDs of moved places
+func (r *PlaceMongoRepository) ReassignCategory(ctx context.Context, from model.ID, to model.ID) ([]model.ID, error) {
+
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+	cursor, err := r.collection.Find(ctx, bson.M{"category": from}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	ids := make([]model.ID, 0)
+	for cursor.Next(ctx) {
+		var place struct {
+			ID model.ID `bson:"_id"`
+		}
+		if err := cursor.Decode(&place); err != nil {
+			return nil, err
+		}
+		ids = append(ids, place.ID)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	_, err = r.collection.UpdateMany(ctx, bson.M{
+		"_id": bson.M{"$in": ids},
+	}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "category", Value: to},
+		{Key: "updatedAt", Value: time.Now()},
+	}}})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
 // Delete ...
 func (r *PlaceMongoRepository) Delete(ctx context.Context, id model.ID) error {
 	deleteResult, err := r.collection.DeleteOne(ctx, bson.M{
@@ -121,12 +439,60 @@ func (r *PlaceMongoRepository) Delete(ctx context.Context, id model.ID) error {
 	return err
 }
 
-// Search ...
-func (r *PlaceMongoRepository) Search(ctx context.Context, search string) (model.PlaceList, error) {
+// Search places by criteria, the text search is combined with the other filters
+func (r *PlaceMongoRepository) Search(ctx context.Context, criteria *model.PlaceSearchCriteria) (model.PlaceList, error) {
+
+	opts := options.Find()
+	filter := bson.D{}
+
+	if criteria.HasText() {
+		filter = append(filter, bson.E{Key: "$text", Value: bson.D{{Key: "$search", Value: makeTextSearch(criteria)}}})
+		opts.SetSort(bson.D{{Key: "score", Value: bson.D{{Key: "$meta", Value: "textScore"}}}})
+	} else if len(criteria.ExcludedTerms) > 0 || len(criteria.ExcludedPhrases) > 0 {
+		// $text can not match only negated terms
+		excluded := make([]string, 0, len(criteria.ExcludedTerms)+len(criteria.ExcludedPhrases))
+		excluded = append(excluded, criteria.ExcludedTerms...)
+		excluded = append(excluded, criteria.ExcludedPhrases...)
+
+		nor := bson.A{}
+		for _, term := range excluded {
+			regex := primitive.Regex{Pattern: regexp.QuoteMeta(term), Options: "i"}
+			nor = append(nor, bson.M{"name": regex}, bson.M{"description": regex}, bson.M{"tags": regex}, bson.M{"searchTerms": regex})
+		}
+		filter = append(filter, bson.E{Key: "$nor", Value: nor})
+	}
+
+	tags := bson.D{}
+	if len(criteria.Tags) > 0 {
+		tags = append(tags, bson.E{Key: "$all", Value: criteria.Tags})
+	}
+	if len(criteria.ExcludedTags) > 0 {
+		tags = append(tags, bson.E{Key: "$nin", Value: criteria.ExcludedTags})
+	}
+	if len(tags) > 0 {
+		filter = append(filter, bson.E{Key: "tags", Value: tags})
+	}
+
+	categories := bson.D{}
+	if len(criteria.Categories) > 0 {
+		categories = append(categories, bson.E{Key: "$in", Value: criteria.Categories})
+	}
+	if len(criteria.ExcludedCategories) > 0 {
+		categories = append(categories, bson.E{Key: "$nin", Value: criteria.ExcludedCategories})
+	}
+	if len(categories) > 0 {
+		filter = append(filter, bson.E{Key: "category", Value: categories})
+	}
+
+	if criteria.Near != nil {
+		// $near can not be used together with $text
+		center := bson.A{criteria.Near.Center.Lng(), criteria.Near.Center.Lat()}
+		filter = append(filter, bson.E{Key: "location", Value: bson.D{{Key: "$geoWithin", Value: bson.D{
+			{Key: "$centerSphere", Value: bson.A{center, criteria.Near.RadiusKm / earthRadiusKm}},
+		}}}})
+	}
 
-	sort := options.Find()
-	sort.SetSort(bson.D{{Key: "score", Value: bson.D{{Key: "$meta", Value: "textScore"}}}})
-	cursor, err := r.collection.Find(ctx, bson.D{{Key: "$text", Value: bson.D{{Key: "$search", Value: search}}}}, sort)
+	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -143,3 +509,28 @@ func (r *PlaceMongoRepository) Search(ctx context.Context, search string) (model
 
 	return places, nil
 }
+
+// publishedPlacesFilter places not marked as deleted
+func publishedPlacesFilter() bson.D {
+	return bson.D{{Key: "deletedAt", Value: bson.D{{Key: "$exists", Value: false}}}}
+}
+
+// makeTextSearch make $text $search string, terms are combined with OR, phrases with AND
+func makeTextSearch(criteria *model.PlaceSearchCriteria) string {
+
+	parts := make([]string, 0, len(criteria.Terms)+len(criteria.Phrases)+len(criteria.ExcludedTerms)+len(criteria.ExcludedPhrases))
+	for _, term := range criteria.Terms {
+		parts = append(parts, strings.ReplaceAll(term, `"`, ""))
+	}
+	for _, phrase := range criteria.Phrases {
+		parts = append(parts, `"`+strings.ReplaceAll(phrase, `"`, "")+`"`)
+	}
+	for _, term := range criteria.ExcludedTerms {
+		parts = append(parts, "-"+strings.ReplaceAll(term, `"`, ""))
+	}
+	for _, phrase := range criteria.ExcludedPhrases {
+		parts = append(parts, `-"`+strings.ReplaceAll(phrase, `"`, "")+`"`)
+	}
+
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/pkg/elastic"
+
+	"golang.org/x/net/context"
+)
+
+// placeScrollKeepAlive keeps the scroll of ScanIDs open between batches
+const placeScrollKeepAlive string = "1m"
+
+// placeIndexBody settings and mappings for versioned place index
+var placeIndexBody = map[string]any{
+	"settings": map[string]any{
+		"number_of_replicas": 0,
+		"refresh_interval":   "-1",
+	},
+	"mappings": map[string]any{
+		"dynamic": "strict",
+		"properties": map[string]any{
+			"name": map[string]any{
+				"type":     "text",
+				"analyzer": "russian",
+				"fields": map[string]any{
+					"keyword": map[string]any{"type": "keyword"},
+				},
+			},
+			"nameSlug":    map[string]any{"type": "keyword"},
+			"description": map[string]any{"type": "text", "analyzer": "russian"},
+			"category":    map[string]any{"type": "keyword"},
+			"tags":        map[string]any{"type": "keyword"},
+			"location":    map[string]any{"type": "geo_point"},
+			"createdAt":   map[string]any{"type": "date"},
+			"updatedAt":   map[string]any{"type": "date"},
+		},
+	},
+}
+
+// placeDocument elasticsearch place document
+type placeDocument struct {
+	Name        string     `json:"name"`
+	NameSlug    string     `json:"nameSlug"`
+	Description string     `json:"description"`
+	Category    string     `json:"category"`
+	Tags        []string   `json:"tags"`
+	Location    []float64  `json:"location,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
+}
+
+// BulkError ...
+type BulkError struct {
+	Failed int
+	First  string
+}
+
+// Error ...
+func (e *BulkError) Error() string {
+	return fmt.Sprintf("bulk request failed for %d documents, first error: %s", e.Failed, e.First)
+}
+
+// PlaceSearchElasticRepository place elasticsearch repo
+type PlaceSearchElasticRepository struct {
+	client *elastic.Client
+}
+
+// NewPlaceSearchElasticRepository create new elasticsearch place repository
+func NewPlaceSearchElasticRepository(client *elastic.Client) *PlaceSearchElasticRepository {
+	return &PlaceSearchElasticRepository{
+		client: client,
+	}
+}
+
+// CreateIndex create new place index with mapping
+func (r *PlaceSearchElasticRepository) CreateIndex(ctx context.Context, index string) error {
+	return r.client.DoJSON(ctx, http.MethodPut, "/"+url.PathEscape(index), placeIndexBody, nil)
+}
+
+// BulkIndex index places into index
+func (r *PlaceSearchElasticRepository) BulkIndex(ctx context.Context, index string, places model.PlaceList) error {
+
+	if len(places) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, place := range places {
+		action := map[string]any{"index": map[string]any{"_index": index, "_id": place.ID.String()}}
+		if err := enc.Encode(action); err != nil {
+			return err
+		}
+
+		doc := placeDocument{
+			Name:        place.Name,
+			NameSlug:    place.NameSlug,
+			Description: place.Description,
+			Category:    place.Category.String(),
+			Tags:        place.Tags,
+			CreatedAt:   place.CreatedAt,
+		}
+		if place.Location != nil {
+			doc.Location = []float64{place.Location.Lng(), place.Location.Lat()}
+		}
+		if !place.UpdatedAt.IsZero() {
+			doc.UpdatedAt = &place.UpdatedAt
+		}
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+	}
+
+	return r.bulk(ctx, &body)
+}
+
+// BulkDelete delete places from index, missing documents are skipped
+func (r *PlaceSearchElasticRepository) BulkDelete(ctx context.Context, index string, ids []model.ID) error {
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, id := range ids {
+		action := map[string]any{"delete": map[string]any{"_index": index, "_id": id.String()}}
+		if err := enc.Encode(action); err != nil {
+			return err
+		}
+	}
+
+	return r.bulk(ctx, &body)
+}
+
+// ScanIDs call fn with the ids of the documents of index in batches of size, refreshed documents only
+func (r *PlaceSearchElasticRepository) ScanIDs(ctx context.Context, index string, size int64, fn func(ids []model.ID) error) error {
+
+	type scrollResponse struct {
+		ScrollID string `json:"_scroll_id"`
+		Hits     struct {
+			Hits []struct {
+				ID string `json:"_id"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+
+	var resp scrollResponse
+	query := map[string]any{"size": size, "_source": false, "sort": []string{"_doc"}}
+	path := "/" + url.PathEscape(index) + "/_search?scroll=" + placeScrollKeepAlive
+	if err := r.client.DoJSON(ctx, http.MethodPost, path, query, &resp); err != nil {
+		return err
+	}
+
+	scrollID := resp.ScrollID
+	defer func() {
+		// the scroll expires after the keep alive anyway
+		_ = r.client.DoJSON(ctx, http.MethodDelete, "/_search/scroll", map[string]any{"scroll_id": scrollID}, nil)
+	}()
+
+	for len(resp.Hits.Hits) > 0 {
+		ids := make([]model.ID, 0, len(resp.Hits.Hits))
+		for _, hit := range resp.Hits.Hits {
+			id, err := model.StringToID(hit.ID)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+		if err := fn(ids); err != nil {
+			return err
+		}
+
+		resp = scrollResponse{}
+		next := map[string]any{"scroll": placeScrollKeepAlive, "scroll_id": scrollID}
+		if err := r.client.DoJSON(ctx, http.MethodPost, "/_search/scroll", next, &resp); err != nil {
+			return err
+		}
+		scrollID = resp.ScrollID
+	}
+
+	return nil
+}
+
+// bulk send the ndjson body to the bulk API, *BulkError when documents failed
+func (r *PlaceSearchElasticRepository) bulk(ctx context.Context, body *bytes.Buffer) error {
+
+	var resp struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int `json:"status"`
+			Error  *struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := r.client.Do(ctx, http.MethodPost, "/_bulk", body, "application/x-ndjson", &resp); err != nil {
+		return err
+	}
+	if !resp.Errors {
+		return nil
+	}
+
+	bulkErr := &BulkError{}
+	for _, item := range resp.Items {
+		for _, result := range item {
+			if result.Error != nil {
+				if bulkErr.Failed == 0 {
+					bulkErr.First = result.Error.Type + ": " + result.Error.Reason
+				}
+				bulkErr.Failed++
+			}
+		}
+	}
+	return bulkErr
+}
+
+// RefreshIndex restore refresh interval and refresh index
+func (r *PlaceSearchElasticRepository) RefreshIndex(ctx context.Context, index string) error {
+
+	settings := map[string]any{"index": map[string]any{"refresh_interval": "1s"}}
+	if err := r.client.DoJSON(ctx, http.MethodPut, "/"+url.PathEscape(index)+"/_settings", settings, nil); err != nil {
+		return err
+	}
+	return r.client.DoJSON(ctx, http.MethodPost, "/"+url.PathEscape(index)+"/_refresh", nil, nil)
+}
+
+// AliasIndices return indices the alias points to
+func (r *PlaceSearchElasticRepository) AliasIndices(ctx context.Context, alias string) ([]string, error) {
+
+	resp := make(map[string]any)
+	err := r.client.DoJSON(ctx, http.MethodGet, "/_alias/"+url.PathEscape(alias), nil, &resp)
+	if err != nil {
+		if elastic.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	indices := make([]string, 0, len(resp))
+	for index := range resp {
+		indices = append(indices, index)
+	}
+	return indices, nil
+}
+
+// SwapAlias atomically point alias to index and remove it from old indices
+func (r *PlaceSearchElasticRepository) SwapAlias(ctx context.Context, alias string, index string, oldIndices []string) error {
+
+	actions := make([]map[string]any, 0, len(oldIndices)+1)
+	for _, old := range oldIndices {
+		if old == index {
+			continue
+		}
+		actions = append(actions, map[string]any{"remove": map[string]any{"index": old, "alias": alias}})
+	}
+	actions = append(actions, map[string]any{"add": map[string]any{"index": index, "alias": alias}})
+
+	return r.client.DoJSON(ctx, http.MethodPost, "/_aliases", map[string]any{"actions": actions}, nil)
+}
+
+// IsIndex name is a concrete index, not an alias
+func (r *PlaceSearchElasticRepository) IsIndex(ctx context.Context, name string) (bool, error) {
+
+	resp := make(map[string]any)
+	err := r.client.DoJSON(ctx, http.MethodGet, "/"+url.PathEscape(name)+"/_settings", nil, &resp)
+	if err != nil {
+		if elastic.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// an alias resolves to the indices it points to
+	_, ok := resp[name]
+	return ok, nil
+}
+
+// ReplaceIndexWithAlias atomically delete the concrete index named like the alias and point the alias to index
+func (r *PlaceSearchElasticRepository) ReplaceIndexWithAlias(ctx context.Context, alias string, index string) error {
+
+	actions := []map[string]any{
+		{"add": map[string]any{"index": index, "alias": alias}},
+		{"remove_index": map[string]any{"index": alias}},
+	}
+
+	return r.client.DoJSON(ctx, http.MethodPost, "/_aliases", map[string]any{"actions": actions}, nil)
+}
+
+// DeleteIndex ...
+func (r *PlaceSearchElasticRepository) DeleteIndex(ctx context.Context, index string) error {
+	err := r.client.DoJSON(ctx, http.MethodDelete, "/"+url.PathEscape(index), nil, nil)
+	if elastic.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
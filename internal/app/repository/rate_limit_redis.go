@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/go-redis/redis/v9"
+	"golang.org/x/net/context"
+)
+
+const rateLimitKeyPrefix string = "ratelimit:"
+
+// RateLimitRedisRepository fixed window request counters
+type RateLimitRedisRepository struct {
+	client *redis.Client
+}
+
+// NewRateLimitRedisRepository create new redis rate limit repository
+func NewRateLimitRedisRepository(client *redis.Client) *RateLimitRedisRepository {
+	return &RateLimitRedisRepository{
+		client: client,
+	}
+}
+
+// Incr count a request, the counter expires window after the first request, returns the count and the rest of the window
+func (r *RateLimitRedisRepository) Incr(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+
+	pipe := r.client.TxPipeline()
+	incr := pipe.Incr(ctx, rateLimitKeyPrefix+key)
+	pipe.ExpireNX(ctx, rateLimitKeyPrefix+key, window)
+	ttl := pipe.PTTL(ctx, rateLimitKeyPrefix+key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, 0, err
+	}
+
+	rest := ttl.Val()
+	if rest < 0 {
+		rest = window
+	}
+
+	return incr.Val(), rest, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"walk_backend/internal/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/net/context"
+)
+
+// ReindexStateMongoRepository reindex checkpoint mongodb repo
+type ReindexStateMongoRepository struct {
+	collection *mongo.Collection
+}
+
+// NewReindexStateMongoRepository create new mongo reindex state repository
+func NewReindexStateMongoRepository(collection *mongo.Collection) *ReindexStateMongoRepository {
+	return &ReindexStateMongoRepository{
+		collection: collection,
+	}
+}
+
+// FindUnfinished find last running job for alias and mode
+func (r *ReindexStateMongoRepository) FindUnfinished(ctx context.Context, mode model.ReindexMode, alias string) (*model.ReindexState, error) {
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "startedAt", Value: -1}})
+	cur := r.collection.FindOne(ctx, bson.M{
+		"mode":   mode,
+		"alias":  alias,
+		"status": model.ReindexStatusRunning,
+	}, opts)
+
+	if cur.Err() != nil {
+		if errors.Is(cur.Err(), mongo.ErrNoDocuments) {
+			return nil, model.ErrModelNotFound
+		}
+		return nil, cur.Err()
+	}
+
+	var m model.ReindexState
+	if err := cur.Decode(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// Save insert or replace state
+func (r *ReindexStateMongoRepository) Save(ctx context.Context, m *model.ReindexState) error {
+
+	m.UpdatedAt = time.Now()
+	_, err := r.collection.ReplaceOne(ctx, bson.M{
+		"_id": m.ID,
+	}, m, options.Replace().SetUpsert(true))
+
+	return err
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"time"
+
+	"walk_backend/internal/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/net/context"
+)
+
+// SearchLogMongoRepository search log mongodb repo
+type SearchLogMongoRepository struct {
+	collection *mongo.Collection
+}
+
+// NewSearchLogMongoRepository create new mongo search log repository
+func NewSearchLogMongoRepository(collection *mongo.Collection) *SearchLogMongoRepository {
+	return &SearchLogMongoRepository{
+		collection: collection,
+	}
+}
+
+// CreateMany insert search log entries
+func (r *SearchLogMongoRepository) CreateMany(ctx context.Context, logs []*model.SearchLog) error {
+
+	if len(logs) == 0 {
+		return nil
+	}
+
+	docs := make([]any, 0, len(logs))
+	for _, l := range logs {
+		docs = append(docs, l)
+	}
+
+	_, err := r.collection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	return err
+}
+
+// AddClick add selected place to the search log entry
+func (r *SearchLogMongoRepository) AddClick(ctx context.Context, searchID model.ID, placeID model.ID) error {
+
+	updateResult, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id": searchID,
+	}, bson.M{"$addToSet": bson.M{"clickedPlaces": placeID}})
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return model.ErrModelNotFound
+	}
+
+	return nil
+}
+
+// Exists search log entry exists, an expired entry is removed by the TTL index
+func (r *SearchLogMongoRepository) Exists(ctx context.Context, id model.ID) (bool, error) {
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": id}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// AnonymiseUsername unset the username of the search log entries of the user
+func (r *SearchLogMongoRepository) AnonymiseUsername(ctx context.Context, username string) error {
+
+	_, err := r.collection.UpdateMany(ctx, bson.M{
+		"username": username,
+	}, bson.D{{Key: "$unset", Value: bson.D{
+		{Key: "username", Value: ""},
+	}}})
+
+	return err
+}
+
+// Stats aggregate search log entries created in [from, to)
+func (r *SearchLogMongoRepository) Stats(ctx context.Context, from time.Time, to time.Time, limit int64) (*model.SearchStats, error) {
+
+	clicked := bson.M{"$cond": bson.A{
+		bson.M{"$gt": bson.A{bson.M{"$size": bson.M{"$ifNull": bson.A{"$clickedPlaces", bson.A{}}}}, 0}},
+		1,
+		0,
+	}}
+	groupByQuery := bson.D{{Key: "$group", Value: bson.M{
+		"_id":          "$query",
+		"count":        bson.M{"$sum": 1},
+		"avgHits":      bson.M{"$avg": "$hits"},
+		"avgLatencyMs": bson.M{"$avg": "$latencyMs"},
+		"clicked":      bson.M{"$sum": clicked},
+	}}}
+	sortByCount := bson.D{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"createdAt": bson.M{"$gte": from, "$lt": to}}}},
+		{{Key: "$facet", Value: bson.M{
+			"total": bson.A{
+				bson.D{{Key: "$group", Value: bson.M{
+					"_id":      nil,
+					"searches": bson.M{"$sum": 1},
+					"clicked":  bson.M{"$sum": clicked},
+				}}},
+			},
+			"top": bson.A{
+				groupByQuery,
+				sortByCount,
+				bson.D{{Key: "$limit", Value: limit}},
+			},
+			"zero": bson.A{
+				bson.D{{Key: "$match", Value: bson.M{"hits": 0}}},
+				groupByQuery,
+				sortByCount,
+				bson.D{{Key: "$limit", Value: limit}},
+			},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var result struct {
+		Total []struct {
+			Searches int64 `bson:"searches"`
+			Clicked  int64 `bson:"clicked"`
+		} `bson:"total"`
+		Top  []*model.SearchQueryStats `bson:"top"`
+		Zero []*model.SearchQueryStats `bson:"zero"`
+	}
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	stats := &model.SearchStats{
+		From:              from,
+		To:                to,
+		TopQueries:        make([]*model.SearchQueryStats, 0, len(result.Top)),
+		ZeroResultQueries: make([]*model.SearchQueryStats, 0, len(result.Zero)),
+	}
+	if len(result.Total) > 0 {
+		stats.Searches = result.Total[0].Searches
+		stats.Clicked = result.Total[0].Clicked
+	}
+	stats.TopQueries = append(stats.TopQueries, result.Top...)
+	stats.ZeroResultQueries = append(stats.ZeroResultQueries, result.Zero...)
+	stats.CalcClickThroughRates()
+
+	return stats, nil
+}
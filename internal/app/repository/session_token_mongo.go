@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"walk_backend/internal/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/net/context"
+)
+
+// SessionTokenMongoRepository session token mongodb repo
+type SessionTokenMongoRepository struct {
+	collection *mongo.Collection
+}
+
+// NewSessionTokenMongoRepository create new session token mongo repository
+func NewSessionTokenMongoRepository(collection *mongo.Collection) *SessionTokenMongoRepository {
+	return &SessionTokenMongoRepository{
+		collection: collection,
+	}
+}
+
+// Create ...
+func (r *SessionTokenMongoRepository) Create(ctx context.Context, m *model.SessionToken) (model.ID, error) {
+	if m.ID.IsNil() {
+		id, err := model.NewID()
+		if err != nil {
+			return model.NilID, err
+		}
+		m.ID = id
+	}
+
+	_, err := r.collection.InsertOne(ctx, m)
+
+	return m.ID, err
+}
+
+// FindByHash session token by token hash
+func (r *SessionTokenMongoRepository) FindByHash(ctx context.Context, tokenHash string) (*model.SessionToken, error) {
+
+	cur := r.collection.FindOne(ctx, bson.M{
+		"tokenHash": tokenHash,
+	})
+
+	if cur.Err() != nil {
+		if errors.Is(cur.Err(), mongo.ErrNoDocuments) {
+			return nil, model.ErrModelNotFound
+		}
+		return nil, cur.Err()
+	}
+
+	var m model.SessionToken
+	if err := cur.Decode(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// MarkRotated set rotatedAt of an active token, ErrModelUpdate when it is already rotated or revoked
+func (r *SessionTokenMongoRepository) MarkRotated(ctx context.Context, id model.ID, rotatedAt time.Time) error {
+
+	updateResult, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id":       id,
+		"rotatedAt": bson.M{"$exists": false},
+		"revokedAt": bson.M{"$exists": false},
+	}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "rotatedAt", Value: rotatedAt},
+	}}})
+	if err != nil {
+		return err
+	}
+
+	if updateResult.ModifiedCount == 0 {
+		return model.ErrModelUpdate
+	}
+
+	return nil
+}
+
+// RevokeFamily revoke every not yet revoked token of the family
+func (r *SessionTokenMongoRepository) RevokeFamily(ctx context.Context, familyID model.ID, revokedAt time.Time) error {
+
+	_, err := r.collection.UpdateMany(ctx, bson.M{
+		"familyId":  familyID,
+		"revokedAt": bson.M{"$exists": false},
+	}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "revokedAt", Value: revokedAt},
+	}}})
+
+	return err
+}
+
+// FindActiveByUsername not rotated, not revoked and not expired tokens of the user, one per session
+func (r *SessionTokenMongoRepository) FindActiveByUsername(ctx context.Context, username string, now time.Time) ([]*model.SessionToken, error) {
+
+	opts := options.Find().SetSort(bson.D{{Key: "lastSeenAt", Value: -1}})
+	cur, err := r.collection.Find(ctx, bson.M{
+		"username":  username,
+		"rotatedAt": bson.M{"$exists": false},
+		"revokedAt": bson.M{"$exists": false},
+		"expiresAt": bson.M{"$gt": now},
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*model.SessionToken, 0)
+	if err := cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// Touch set lastSeenAt
+func (r *SessionTokenMongoRepository) Touch(ctx context.Context, id model.ID, lastSeenAt time.Time) error {
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id": id,
+	}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "lastSeenAt", Value: lastSeenAt},
+	}}})
+
+	return err
+}
+
+// RevokeByUsername revoke every session of the user except the exceptFamilyID session, NilID for none,
+// returns the number of revoked sessions
+func (r *SessionTokenMongoRepository) RevokeByUsername(ctx context.Context, username string, exceptFamilyID model.ID, revokedAt time.Time) (int64, error) {
+
+	// rotated tokens are already unusable, revoking the active token of each family is enough
+	filter := bson.M{
+		"username":  username,
+		"rotatedAt": bson.M{"$exists": false},
+		"revokedAt": bson.M{"$exists": false},
+	}
+	if !exceptFamilyID.IsNil() {
+		filter["familyId"] = bson.M{"$ne": exceptFamilyID}
+	}
+
+	updateResult, err := r.collection.UpdateMany(ctx, filter, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "revokedAt", Value: revokedAt},
+	}}})
+	if err != nil {
+		return 0, err
+	}
+
+	return updateResult.ModifiedCount, nil
+}
+
+// DeleteByUsername delete every token of the user, returns the number of deleted tokens
+func (r *SessionTokenMongoRepository) DeleteByUsername(ctx context.Context, username string) (int64, error) {
+
+	deleteResult, err := r.collection.DeleteMany(ctx, bson.M{"username": username})
+	if err != nil {
+		return 0, err
+	}
+
+	return deleteResult.DeletedCount, nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"walk_backend/internal/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/net/context"
+)
+
+// TagMongoRepository tag mongodb repo, counters are calculated from places
+type TagMongoRepository struct {
+	collection       *mongo.Collection
+	placesCollection *mongo.Collection
+}
+
+// NewTagMongoRepository create new mongo tag repository
+func NewTagMongoRepository(collection *mongo.Collection, placesCollection *mongo.Collection) *TagMongoRepository {
+	return &TagMongoRepository{
+		collection:       collection,
+		placesCollection: placesCollection,
+	}
+}
+
+// Find tag
+func (r *TagMongoRepository) Find(ctx context.Context, name string) (*model.Tag, error) {
+
+	cur := r.collection.FindOne(ctx, bson.M{
+		"_id": name,
+	})
+
+	if cur.Err() != nil {
+		if errors.Is(cur.Err(), mongo.ErrNoDocuments) {
+			return nil, model.ErrModelNotFound
+		}
+		return nil, cur.Err()
+	}
+
+	var m model.Tag
+	if err := cur.Decode(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// FindAll tags, most used first, limit 0 is no limit
+func (r *TagMongoRepository) FindAll(ctx context.Context, limit int64) (model.TagList, error) {
+
+	opts := options.Find().SetSort(bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	mList := make(model.TagList, 0)
+	for cursor.Next(ctx) {
+		var m model.Tag
+		if err := cursor.Decode(&m); err != nil {
+			return nil, err
+		}
+		mList = append(mList, &m)
+	}
+
+	return mList, cursor.Err()
+}
+
+// Recount count places of the tags, unused tags are deleted
+func (r *TagMongoRepository) Recount(ctx context.Context, names []string) error {
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	cursor, err := r.placesCollection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"tags": bson.M{"$in": names}}}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$match", Value: bson.M{"tags": bson.M{"$in": names}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	counts := make(map[string]int64, len(names))
+	for cursor.Next(ctx) {
+		var result struct {
+			Name  string `bson:"_id"`
+			Count int64  `bson:"count"`
+		}
+		if err := cursor.Decode(&result); err != nil {
+			return err
+		}
+		counts[result.Name] = result.Count
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	models := make([]mongo.WriteModel, 0, len(names))
+	for _, name := range names {
+		if count := counts[name]; count > 0 {
+			models = append(models, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": name}).
+				SetUpdate(bson.M{"$set": bson.M{"count": count, "updatedAt": now}}).
+				SetUpsert(true))
+			continue
+		}
+		models = append(models, mongo.NewDeleteOneModel().SetFilter(bson.M{"_id": name}))
+	}
+
+	_, err = r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
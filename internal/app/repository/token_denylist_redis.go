@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v9"
+	"golang.org/x/net/context"
+)
+
+const (
+	tokenDenyListKeyPrefix   string = "auth:revoked:"
+	tokenValidAfterKeyPrefix string = "auth:valid_after:"
+)
+
+// TokenDenyListRedisRepository revoked token IDs, kept until the token expires
+type TokenDenyListRedisRepository struct {
+	client *redis.Client
+}
+
+// NewTokenDenyListRedisRepository create new redis token deny-list repository
+func NewTokenDenyListRedisRepository(client *redis.Client) *TokenDenyListRedisRepository {
+	return &TokenDenyListRedisRepository{
+		client: client,
+	}
+}
+
+// Add deny the token ID until expiration, false when already denied
+func (r *TokenDenyListRedisRepository) Add(ctx context.Context, id string, expiration time.Duration) (bool, error) {
+	// zero expiration is no expiration in redis
+	if expiration < time.Second {
+		expiration = time.Second
+	}
+	return r.client.SetNX(ctx, tokenDenyListKeyPrefix+id, 1, expiration).Result()
+}
+
+// Has the token ID is denied
+func (r *TokenDenyListRedisRepository) Has(ctx context.Context, id string) (bool, error) {
+	count, err := r.client.Exists(ctx, tokenDenyListKeyPrefix+id).Result()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// SetTokensValidAfter deny every token of the username issued at or before validAfter, kept for expiration
+func (r *TokenDenyListRedisRepository) SetTokensValidAfter(ctx context.Context, username string, validAfter time.Time, expiration time.Duration) error {
+	if expiration < time.Second {
+		expiration = time.Second
+	}
+	return r.client.Set(ctx, tokenValidAfterKeyPrefix+username, validAfter.Unix(), expiration).Err()
+}
+
+// TokensValidAfter time set by SetTokensValidAfter, zero when there is none
+func (r *TokenDenyListRedisRepository) TokensValidAfter(ctx context.Context, username string) (time.Time, error) {
+	value, err := r.client.Get(ctx, tokenValidAfterKeyPrefix+username).Result()
+	if err == redis.Nil {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, err
+	}
+	unix, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(unix, 0), nil
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/net/context"
+)
+
+// MongoTransaction run repository calls in one mongodb transaction,
+// repositories must use the ctx passed to fn
+type MongoTransaction struct {
+	client *mongo.Client
+}
+
+// NewMongoTransaction create new mongo transaction runner
+func NewMongoTransaction(client *mongo.Client) *MongoTransaction {
+	return &MongoTransaction{
+		client: client,
+	}
+}
+
+// WithTransaction run fn in a transaction, the transaction is aborted when fn returns an error
+func (t *MongoTransaction) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+
+	session, err := t.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (any, error) {
+		return nil, fn(sessCtx)
+	})
+
+	return err
+}
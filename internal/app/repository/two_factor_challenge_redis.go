@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"walk_backend/internal/app/model"
+
+	"github.com/go-redis/redis/v9"
+	"golang.org/x/net/context"
+)
+
+const (
+	twoFactorChallengeKeyPrefix string = "auth:2fa:"
+)
+
+// TwoFactorChallengeRedisRepository password logins waiting for the second factor by challenge hash, expire with the challenge
+type TwoFactorChallengeRedisRepository struct {
+	client *redis.Client
+}
+
+// NewTwoFactorChallengeRedisRepository create new redis two-factor challenge repository
+func NewTwoFactorChallengeRedisRepository(client *redis.Client) *TwoFactorChallengeRedisRepository {
+	return &TwoFactorChallengeRedisRepository{
+		client: client,
+	}
+}
+
+// Create ...
+func (r *TwoFactorChallengeRedisRepository) Create(ctx context.Context, hash string, m *model.TwoFactorChallenge) error {
+
+	value, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	expiration := time.Until(m.ExpiresAt)
+	// zero expiration is no expiration in redis
+	if expiration < time.Second {
+		expiration = time.Second
+	}
+
+	return r.client.Set(ctx, twoFactorChallengeKeyPrefix+hash, value, expiration).Err()
+}
+
+// Find challenge by hash, ErrModelNotFound when unknown or expired
+func (r *TwoFactorChallengeRedisRepository) Find(ctx context.Context, hash string) (*model.TwoFactorChallenge, error) {
+
+	value, err := r.client.Get(ctx, twoFactorChallengeKeyPrefix+hash).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, model.ErrModelNotFound
+		}
+		return nil, err
+	}
+
+	var m model.TwoFactorChallenge
+	if err := json.Unmarshal(value, &m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// Delete ...
+func (r *TwoFactorChallengeRedisRepository) Delete(ctx context.Context, hash string) error {
+	return r.client.Del(ctx, twoFactorChallengeKeyPrefix+hash).Err()
+}
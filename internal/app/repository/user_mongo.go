@@ -35,10 +35,35 @@ func (r *UserMongoRepository) Create(ctx context.Context, m *model.User) (model.
 
 	m.CreatedAt = time.Now()
 	_, err := r.collection.InsertOne(ctx, m)
+	if mongo.IsDuplicateKeyError(err) {
+		return model.NilID, model.ErrModelDuplicate
+	}
 
 	return m.ID, err
 }
 
+// FindByID ...
+func (r *UserMongoRepository) FindByID(ctx context.Context, id model.ID) (*model.User, error) {
+
+	cur := r.collection.FindOne(ctx, bson.M{
+		"_id": id,
+	})
+
+	if cur.Err() != nil {
+		if errors.Is(cur.Err(), mongo.ErrNoDocuments) {
+			return nil, model.ErrModelNotFound
+		}
+		return nil, cur.Err()
+	}
+
+	var m model.User
+	if err := cur.Decode(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
 // FindByUsername user bu username
 func (r *UserMongoRepository) FindByUsername(ctx context.Context, username string) (*model.User, error) {
 
@@ -60,3 +85,294 @@ func (r *UserMongoRepository) FindByUsername(ctx context.Context, username strin
 
 	return &m, nil
 }
+
+// FindByEmail user by normalised email
+func (r *UserMongoRepository) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+
+	cur := r.collection.FindOne(ctx, bson.M{
+		"email": model.NormaliseEmail(email),
+	})
+
+	if cur.Err() != nil {
+		if errors.Is(cur.Err(), mongo.ErrNoDocuments) {
+			return nil, model.ErrModelNotFound
+		}
+		return nil, cur.Err()
+	}
+
+	var m model.User
+	if err := cur.Decode(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// Update set the profile fields, credentials, roles and identities have their own updates
+func (r *UserMongoRepository) Update(ctx context.Context, m *model.User) error {
+
+	updateResult, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id": m.ID,
+	}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "displayName", Value: m.DisplayName},
+		{Key: "avatarUrl", Value: m.AvatarURL},
+		{Key: "locale", Value: m.Locale},
+	}}})
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return model.ErrModelNotFound
+	}
+
+	return nil
+}
+
+// Delete ...
+func (r *UserMongoRepository) Delete(ctx context.Context, id model.ID) error {
+
+	deleteResult, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if deleteResult.DeletedCount == 0 {
+		return model.ErrModelNotFound
+	}
+
+	return nil
+}
+
+// UpdateRoles ...
+func (r *UserMongoRepository) UpdateRoles(ctx context.Context, id model.ID, roles []model.Role) error {
+
+	updateResult, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id": id,
+	}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "roles", Value: roles},
+	}}})
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return model.ErrModelNotFound
+	}
+
+	return nil
+}
+
+// CountByRole number of users having the role
+func (r *UserMongoRepository) CountByRole(ctx context.Context, role model.Role) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"roles": role})
+}
+
+// UpdatePassword ...
+func (r *UserMongoRepository) UpdatePassword(ctx context.Context, id model.ID, passwordHash string) error {
+
+	updateResult, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id": id,
+	}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "password", Value: passwordHash},
+	}}})
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return model.ErrModelNotFound
+	}
+
+	return nil
+}
+
+// MarkEmailVerified set emailVerifiedAt, ErrModelNotFound when the user email changed in between
+func (r *UserMongoRepository) MarkEmailVerified(ctx context.Context, id model.ID, email string, verifiedAt time.Time) error {
+
+	updateResult, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id":   id,
+		"email": email,
+	}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "emailVerifiedAt", Value: verifiedAt},
+	}}})
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return model.ErrModelNotFound
+	}
+
+	return nil
+}
+
+// FindByIdentity user linked to the external identity
+func (r *UserMongoRepository) FindByIdentity(ctx context.Context, provider string, subject string) (*model.User, error) {
+
+	cur := r.collection.FindOne(ctx, bson.M{
+		"identities": bson.M{"$elemMatch": bson.M{
+			"provider": provider,
+			"subject":  subject,
+		}},
+	})
+
+	if cur.Err() != nil {
+		if errors.Is(cur.Err(), mongo.ErrNoDocuments) {
+			return nil, model.ErrModelNotFound
+		}
+		return nil, cur.Err()
+	}
+
+	var m model.User
+	if err := cur.Decode(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// AddIdentity link the external identity, ErrModelDuplicate when it is linked to a user already
+func (r *UserMongoRepository) AddIdentity(ctx context.Context, id model.ID, identity model.Identity) error {
+
+	updateResult, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id": id,
+	}, bson.D{{Key: "$push", Value: bson.D{
+		{Key: "identities", Value: identity},
+	}}})
+	if mongo.IsDuplicateKeyError(err) {
+		return model.ErrModelDuplicate
+	} else if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return model.ErrModelNotFound
+	}
+
+	return nil
+}
+
+// SetPendingTwoFactor store a new TOTP secret to confirm, ErrModelNotFound when two-factor is enabled already
+func (r *UserMongoRepository) SetPendingTwoFactor(ctx context.Context, id model.ID, secret string) error {
+
+	updateResult, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id":                 id,
+		"twoFactor.enabledAt": bson.M{"$exists": false},
+	}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "twoFactor", Value: model.TwoFactor{Secret: secret}},
+	}}})
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return model.ErrModelNotFound
+	}
+
+	return nil
+}
+
+// EnableTwoFactor enable the pending secret, ErrModelNotFound when the pending secret changed or is enabled already
+func (r *UserMongoRepository) EnableTwoFactor(ctx context.Context, id model.ID, secret string, counter int64, recoveryCodes []string, enabledAt time.Time) error {
+
+	updateResult, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id":                 id,
+		"twoFactor.secret":    secret,
+		"twoFactor.enabledAt": bson.M{"$exists": false},
+	}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "twoFactor.enabledAt", Value: enabledAt},
+		{Key: "twoFactor.lastCounter", Value: counter},
+		{Key: "twoFactor.recoveryCodes", Value: recoveryCodes},
+	}}})
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return model.ErrModelNotFound
+	}
+
+	return nil
+}
+
+// DeleteTwoFactor ...
+func (r *UserMongoRepository) DeleteTwoFactor(ctx context.Context, id model.ID) error {
+
+	updateResult, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id": id,
+	}, bson.D{{Key: "$unset", Value: bson.D{
+		{Key: "twoFactor", Value: ""},
+	}}})
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return model.ErrModelNotFound
+	}
+
+	return nil
+}
+
+// UseTwoFactorCounter accept a code of the time step once, ErrModelNotFound when a code of the step or a later one was accepted
+func (r *UserMongoRepository) UseTwoFactorCounter(ctx context.Context, id model.ID, counter int64) error {
+
+	updateResult, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id":                   id,
+		"twoFactor.enabledAt":   bson.M{"$exists": true},
+		"twoFactor.lastCounter": bson.M{"$lt": counter},
+	}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "twoFactor.lastCounter", Value: counter},
+	}}})
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return model.ErrModelNotFound
+	}
+
+	return nil
+}
+
+// UseRecoveryCode remove the recovery code hash, ErrModelNotFound when it is used already
+func (r *UserMongoRepository) UseRecoveryCode(ctx context.Context, id model.ID, codeHash string) error {
+
+	updateResult, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id":                     id,
+		"twoFactor.enabledAt":     bson.M{"$exists": true},
+		"twoFactor.recoveryCodes": codeHash,
+	}, bson.D{{Key: "$pull", Value: bson.D{
+		{Key: "twoFactor.recoveryCodes", Value: codeHash},
+	}}})
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return model.ErrModelNotFound
+	}
+
+	return nil
+}
+
+// SetRecoveryCodes replace the recovery code hashes, ErrModelNotFound when two-factor is not enabled
+func (r *UserMongoRepository) SetRecoveryCodes(ctx context.Context, id model.ID, recoveryCodes []string) error {
+
+	updateResult, err := r.collection.UpdateOne(ctx, bson.M{
+		"_id":                 id,
+		"twoFactor.enabledAt": bson.M{"$exists": true},
+	}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "twoFactor.recoveryCodes", Value: recoveryCodes},
+	}}})
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return model.ErrModelNotFound
+	}
+
+	return nil
+}
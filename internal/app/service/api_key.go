@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"walk_backend/internal/app/model"
+)
+
+const (
+	// apiKeyPrefix tells API keys apart from other secrets, e.g. in secret scanners
+	apiKeyPrefix string = "walk_"
+	// apiKeyShownPrefixLength characters of the key kept to tell keys apart
+	apiKeyShownPrefixLength int = len(apiKeyPrefix) + 8
+	// apiKeyTouchInterval lastUsedAt is written at most once per interval
+	apiKeyTouchInterval = time.Minute
+)
+
+var (
+	// ErrInvalidAPIKey unknown, expired or revoked API key
+	ErrInvalidAPIKey = errors.New("invalid api key")
+)
+
+// APIKeyRepositoryInterface ...
+type APIKeyRepositoryInterface interface {
+	Create(ctx context.Context, m *model.APIKey) (model.ID, error)
+	FindByHash(ctx context.Context, keyHash string) (*model.APIKey, error)
+	FindActiveByUsername(ctx context.Context, username string, now time.Time) ([]*model.APIKey, error)
+	Revoke(ctx context.Context, id model.ID, username string, revokedAt time.Time) error
+	RevokeByUsername(ctx context.Context, username string, revokedAt time.Time) (int64, error)
+	Touch(ctx context.Context, id model.ID, lastUsedAt time.Time) error
+}
+
+// DefaultAPIKeyService ...
+type DefaultAPIKeyService struct {
+	keyRepo APIKeyRepositoryInterface
+	now     func() time.Time
+}
+
+// NewDefaultAPIKeyService create new default API key service
+func NewDefaultAPIKeyService(keyRepo APIKeyRepositoryInterface) *DefaultAPIKeyService {
+	return &DefaultAPIKeyService{
+		keyRepo: keyRepo,
+		now:     time.Now,
+	}
+}
+
+// Create store a new key of the user, returns the raw key, it is not stored and can not be shown again.
+// *ValidationError when the user roles do not grant a scope or the expiry is not in the future
+func (s *DefaultAPIKeyService) Create(
+	ctx context.Context,
+	user *model.User,
+	name string,
+	scopes []model.APIKeyScope,
+	expiresAt *time.Time,
+) (string, *model.APIKey, error) {
+
+	now := s.now()
+	validationErr := &model.ValidationError{}
+	scopes, err := model.NormaliseAPIKeyScopes(scopes)
+	if err != nil {
+		validationErr.Add("scopes", "must be one or more of places:read, places:write, categories:write")
+	}
+	for _, scope := range scopes {
+		if !scope.GrantedTo(user) {
+			validationErr.Add("scopes", string(scope)+" is not granted by your roles")
+		}
+	}
+	if expiresAt != nil && !now.Before(*expiresAt) {
+		validationErr.Add("expiresAt", "must be in the future")
+	}
+	if !validationErr.Empty() {
+		return "", nil, validationErr
+	}
+
+	token, err := newOpaqueToken()
+	if err != nil {
+		return "", nil, err
+	}
+	key := apiKeyPrefix + token
+
+	m := &model.APIKey{
+		KeyHash:   hashOpaqueToken(key),
+		Prefix:    key[:apiKeyShownPrefixLength],
+		Username:  user.Username,
+		Name:      name,
+		Scopes:    scopes,
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	}
+	if _, err := s.keyRepo.Create(ctx, m); err != nil {
+		return "", nil, err
+	}
+
+	return key, m, nil
+}
+
+// List active keys of the user, newest first
+func (s *DefaultAPIKeyService) List(ctx context.Context, username string) ([]*model.APIKey, error) {
+	return s.keyRepo.FindActiveByUsername(ctx, username, s.now())
+}
+
+// Revoke revoke the key of the user, ErrModelNotFound when the user has no such active key
+func (s *DefaultAPIKeyService) Revoke(ctx context.Context, username string, id model.ID) error {
+	return s.keyRepo.Revoke(ctx, id, username, s.now())
+}
+
+// RevokeAll revoke every key of the user, returns the number of revoked keys
+func (s *DefaultAPIKeyService) RevokeAll(ctx context.Context, username string) (int64, error) {
+	return s.keyRepo.RevokeByUsername(ctx, username, s.now())
+}
+
+// Verify the raw key is stored and active, updates last used
+func (s *DefaultAPIKeyService) Verify(ctx context.Context, key string) (*model.APIKey, error) {
+
+	if len(key) <= apiKeyShownPrefixLength || key[:len(apiKeyPrefix)] != apiKeyPrefix {
+		return nil, ErrInvalidAPIKey
+	}
+
+	m, err := s.keyRepo.FindByHash(ctx, hashOpaqueToken(key))
+	if err != nil {
+		if errors.Is(err, model.ErrModelNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	now := s.now()
+	if !m.IsActive(now) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if m.LastUsedAt == nil || now.Sub(*m.LastUsedAt) >= apiKeyTouchInterval {
+		if err := s.keyRepo.Touch(ctx, m.ID, now); err != nil {
+			return nil, err
+		}
+		m.LastUsedAt = &now
+	}
+
+	return m, nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"walk_backend/internal/app/model"
+	mockService "walk_backend/internal/app/service/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyService(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockKeyRepository := mockService.NewMockAPIKeyRepositoryInterface(controller)
+
+	ctx := context.Background()
+	now := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+	s := NewDefaultAPIKeyService(mockKeyRepository)
+	s.now = func() time.Time { return now }
+
+	user, err := model.NewUserModel("Wozniak", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+	assert.Nil(t, err)
+	user.Roles = []model.Role{model.RoleContributor}
+
+	t.Run("Create_invalid", func(t *testing.T) {
+		past := now.Add(-time.Hour)
+		_, _, err := s.Create(ctx, user, "import", []model.APIKeyScope{model.APIKeyScopeCategoriesWrite}, &past)
+
+		var validationErr *model.ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		assert.Contains(t, validationErr.Fields, "scopes")
+		assert.Contains(t, validationErr.Fields, "expiresAt")
+	})
+
+	var key string
+	var stored *model.APIKey
+	t.Run("Create", func(t *testing.T) {
+		mockKeyRepository.
+			EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, m *model.APIKey) (model.ID, error) {
+				m.ID, _ = model.NewID()
+				stored = m
+				return m.ID, nil
+			})
+
+		var m *model.APIKey
+		key, m, err = s.Create(ctx, user, "import", []model.APIKeyScope{model.APIKeyScopePlacesWrite, model.APIKeyScopePlacesWrite}, nil)
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(key, "walk_"))
+		assert.True(t, strings.HasPrefix(key, m.Prefix))
+		assert.Equal(t, hashOpaqueToken(key), m.KeyHash)
+		assert.Equal(t, []model.APIKeyScope{model.APIKeyScopePlacesWrite}, m.Scopes)
+	})
+
+	t.Run("Verify_invalid", func(t *testing.T) {
+		mockKeyRepository.EXPECT().FindByHash(ctx, hashOpaqueToken("walk_forged-key")).Return(nil, model.ErrModelNotFound)
+
+		for _, key := range []string{"", "walk_", "other_forged-key", "walk_forged-key"} {
+			_, err := s.Verify(ctx, key)
+			assert.ErrorIs(t, err, ErrInvalidAPIKey, key)
+		}
+	})
+
+	t.Run("Verify", func(t *testing.T) {
+		mockKeyRepository.EXPECT().FindByHash(ctx, hashOpaqueToken(key)).Return(stored, nil).Times(2)
+		mockKeyRepository.EXPECT().Touch(ctx, stored.ID, now).Return(nil)
+
+		m, err := s.Verify(ctx, key)
+		assert.Nil(t, err)
+		assert.Equal(t, now, *m.LastUsedAt)
+
+		// last used is written at most once per interval
+		_, err = s.Verify(ctx, key)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Verify_revoked", func(t *testing.T) {
+		revoked := *stored
+		revoked.RevokedAt = &now
+		mockKeyRepository.EXPECT().FindByHash(ctx, hashOpaqueToken(key)).Return(&revoked, nil)
+
+		_, err := s.Verify(ctx, key)
+		assert.ErrorIs(t, err, ErrInvalidAPIKey)
+	})
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/pkg/logger"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	auditBufferSize    int           = 1024
+	auditBatchSize     int           = 100
+	auditFlushInterval time.Duration = time.Second
+	auditFlushTimeout  time.Duration = 5 * time.Second
+
+	// DefaultAuditEventsLimit ...
+	DefaultAuditEventsLimit int64 = 50
+	// MaxAuditEventsLimit ...
+	MaxAuditEventsLimit int64 = 200
+)
+
+var (
+	// ErrInvalidAuditFilter unknown event type or outcome, or an empty time range
+	ErrInvalidAuditFilter = errors.New("invalid audit event filter")
+)
+
+// AuditEventRepositoryInterface ...
+type AuditEventRepositoryInterface interface {
+	CreateMany(ctx context.Context, events []*model.AuditEvent) error
+	Find(ctx context.Context, filter *model.AuditEventFilter, offset int64, limit int64) ([]*model.AuditEvent, error)
+}
+
+// DefaultAuditService records security events in background and mirrors them to the log,
+// the request never waits for the database
+type DefaultAuditService struct {
+	eventRepo AuditEventRepositoryInterface
+	events    chan *model.AuditEvent
+	dropped   atomic.Int64
+	now       func() time.Time
+}
+
+// NewDefaultAuditService create new audit service
+func NewDefaultAuditService(eventRepo AuditEventRepositoryInterface) *DefaultAuditService {
+	return &DefaultAuditService{
+		eventRepo: eventRepo,
+		events:    make(chan *model.AuditEvent, auditBufferSize),
+		now:       time.Now,
+	}
+}
+
+// Record queue the event, ID and creation time are set when missing. The event is dropped when the buffer is full
+func (s *DefaultAuditService) Record(m *model.AuditEvent) {
+
+	if m.ID.IsNil() {
+		id, err := model.NewID()
+		if err != nil {
+			s.dropped.Add(1)
+			return
+		}
+		m.ID = id
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = s.now()
+	}
+
+	select {
+	case s.events <- m:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// Run log and write queued events in batches until ctx is done, then flush what is left
+func (s *DefaultAuditService) Run(ctx context.Context) {
+
+	log := logger.LoggerFromContext(ctx)
+
+	ticker := time.NewTicker(auditFlushInterval)
+	defer ticker.Stop()
+
+	pending := make([]*model.AuditEvent, 0, auditBatchSize)
+	flush := func(ctx context.Context) {
+		if dropped := s.dropped.Swap(0); dropped > 0 {
+			log.Warn().Int64("dropped", dropped).Msg("audit buffer is full, events dropped")
+		}
+		if len(pending) == 0 {
+			return
+		}
+		if err := s.eventRepo.CreateMany(ctx, pending); err != nil {
+			log.Error().Err(err).Int("count", len(pending)).Msg("audit write error")
+		}
+		pending = make([]*model.AuditEvent, 0, auditBatchSize)
+	}
+	handle := func(ctx context.Context, m *model.AuditEvent) {
+		logAuditEvent(log, m)
+		pending = append(pending, m)
+		if len(pending) >= auditBatchSize {
+			flush(ctx)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), auditFlushTimeout)
+			defer cancel()
+			for {
+				select {
+				case m := <-s.events:
+					handle(flushCtx, m)
+				default:
+					flush(flushCtx)
+					return
+				}
+			}
+		case m := <-s.events:
+			handle(ctx, m)
+		case <-ticker.C:
+			flush(ctx)
+		}
+	}
+}
+
+// Find page of the events matching the filter, newest first
+func (s *DefaultAuditService) Find(ctx context.Context, filter *model.AuditEventFilter, offset int64, limit int64) ([]*model.AuditEvent, error) {
+
+	if offset < 0 || limit < 1 || limit > MaxAuditEventsLimit {
+		return nil, ErrInvalidPaging
+	}
+	if (filter.Type != "" && !filter.Type.IsValid()) ||
+		(filter.Outcome != "" && !filter.Outcome.IsValid()) ||
+		(!filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To)) {
+		return nil, ErrInvalidAuditFilter
+	}
+
+	return s.eventRepo.Find(ctx, filter, offset, limit)
+}
+
+// logAuditEvent mirror the event to the log for log shipping. The "audit" object keeps the same keys
+// for every event, empty values included, change it only together with the log pipeline
+func logAuditEvent(log *zerolog.Logger, m *model.AuditEvent) {
+
+	level := zerolog.InfoLevel
+	if m.Outcome != model.AuditOutcomeSuccess {
+		level = zerolog.WarnLevel
+	}
+
+	log.WithLevel(level).
+		Dict("audit", zerolog.Dict().
+			Str("id", m.ID.String()).
+			Str("type", string(m.Type)).
+			Str("outcome", string(m.Outcome)).
+			Str("reason", string(m.Reason)).
+			Str("method", string(m.Method)).
+			Str("actor", m.Actor).
+			Str("ip", m.IP).
+			Str("user_agent", m.UserAgent).
+			Str("path", m.Path).
+			Time("created_at", m.CreatedAt)).
+		Msg("security event")
+}
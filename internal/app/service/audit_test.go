@@ -0,0 +1,96 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"walk_backend/internal/app/model"
+	mockService "walk_backend/internal/app/service/mock"
+	"walk_backend/internal/pkg/logger"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditService_Run(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockAuditEventRepository := mockService.NewMockAuditEventRepositoryInterface(controller)
+
+	var out bytes.Buffer
+	log := zerolog.New(&out)
+	ctx, cancel := context.WithCancel(logger.ContextWithLogger(context.Background(), &log))
+
+	s := NewDefaultAuditService(mockAuditEventRepository)
+	s.Record(&model.AuditEvent{
+		Type:    model.AuditEventLogin,
+		Outcome: model.AuditOutcomeFailure,
+		Reason:  model.AuditReasonInvalidCredentials,
+		Method:  model.AuditMethodPassword,
+		Actor:   "user",
+		IP:      "192.0.2.1",
+	})
+
+	mockAuditEventRepository.EXPECT().CreateMany(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, events []*model.AuditEvent) error {
+			assert.Len(t, events, 1)
+			assert.False(t, events[0].ID.IsNil())
+			assert.False(t, events[0].CreatedAt.IsZero())
+			assert.Equal(t, "user", events[0].Actor)
+			return nil
+		})
+
+	cancel()
+	s.Run(ctx)
+
+	var entry struct {
+		Level string            `json:"level"`
+		Audit map[string]string `json:"audit"`
+	}
+	assert.Nil(t, json.Unmarshal(out.Bytes(), &entry))
+	assert.Equal(t, "warn", entry.Level)
+	assert.Equal(t, "login", entry.Audit["type"])
+	assert.Equal(t, "failure", entry.Audit["outcome"])
+	assert.Equal(t, "invalid_credentials", entry.Audit["reason"])
+	assert.Equal(t, "192.0.2.1", entry.Audit["ip"])
+	// the log schema keeps empty keys
+	assert.Contains(t, entry.Audit, "user_agent")
+	assert.Len(t, entry.Audit, 10)
+}
+
+func TestAuditService_Find(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockAuditEventRepository := mockService.NewMockAuditEventRepositoryInterface(controller)
+
+	ctx := context.Background()
+	s := NewDefaultAuditService(mockAuditEventRepository)
+
+	_, err := s.Find(ctx, &model.AuditEventFilter{}, -1, 10)
+	assert.ErrorIs(t, err, ErrInvalidPaging)
+	_, err = s.Find(ctx, &model.AuditEventFilter{}, 0, MaxAuditEventsLimit+1)
+	assert.ErrorIs(t, err, ErrInvalidPaging)
+	_, err = s.Find(ctx, &model.AuditEventFilter{Type: "unknown"}, 0, 10)
+	assert.ErrorIs(t, err, ErrInvalidAuditFilter)
+	_, err = s.Find(ctx, &model.AuditEventFilter{Outcome: "maybe"}, 0, 10)
+	assert.ErrorIs(t, err, ErrInvalidAuditFilter)
+	now := time.Now()
+	_, err = s.Find(ctx, &model.AuditEventFilter{From: now, To: now}, 0, 10)
+	assert.ErrorIs(t, err, ErrInvalidAuditFilter)
+
+	filter := &model.AuditEventFilter{Type: model.AuditEventLogin, Actor: "user"}
+	events := []*model.AuditEvent{{Type: model.AuditEventLogin}}
+	mockAuditEventRepository.EXPECT().Find(ctx, filter, int64(0), int64(10)).Return(events, nil)
+
+	result, err := s.Find(ctx, filter, 0, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, events, result)
+}
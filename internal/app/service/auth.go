@@ -6,8 +6,7 @@ import (
 
 	"walk_backend/internal/app/dto"
 	"walk_backend/internal/app/model"
-
-	"github.com/gofrs/uuid"
+	"walk_backend/internal/pkg/logger"
 )
 
 var (
@@ -19,22 +18,45 @@ var (
 type UserRepositoryInterface interface {
 	Create(ctx context.Context, m *model.User) (model.ID, error)
 	FindByUsername(ctx context.Context, username string) (*model.User, error)
+	FindByEmail(ctx context.Context, email string) (*model.User, error)
+	UpdatePassword(ctx context.Context, id model.ID, password string) error
+}
+
+// EmailVerificationSenderInterface ...
+type EmailVerificationSenderInterface interface {
+	Send(ctx context.Context, user *model.User) error
 }
 
 // DefaultAuthService ...
 type DefaultAuthService struct {
-	userRepo UserRepositoryInterface
+	userRepo             UserRepositoryInterface
+	verification         EmailVerificationSenderInterface
+	passwordPolicy       *model.PasswordPolicy
+	passwordHasher       *model.PasswordHasher
+	allowUnverifiedLogin bool
 }
 
-// NewDefaultAuthService create new default auth service
-func NewDefaultAuthService(userRepo UserRepositoryInterface) *DefaultAuthService {
+// NewDefaultAuthService create new default auth service, registered passwords must satisfy passwordPolicy,
+// allowUnverifiedLogin lets users log in before verifying the email
+func NewDefaultAuthService(
+	userRepo UserRepositoryInterface,
+	verification EmailVerificationSenderInterface,
+	passwordPolicy *model.PasswordPolicy,
+	passwordHasher *model.PasswordHasher,
+	allowUnverifiedLogin bool,
+) *DefaultAuthService {
 	return &DefaultAuthService{
-		userRepo: userRepo,
+		userRepo:             userRepo,
+		verification:         verification,
+		passwordPolicy:       passwordPolicy,
+		passwordHasher:       passwordHasher,
+		allowUnverifiedLogin: allowUnverifiedLogin,
 	}
 }
 
-// Registration ...
-func (s *DefaultAuthService) Registration(ctx context.Context, dto *dto.AuthLogin) (*model.User, error) {
+// Registration create an unverified user and email the verification link,
+// a failed email is logged only, the user can ask for a resend
+func (s *DefaultAuthService) Registration(ctx context.Context, dto *dto.AuthRegistration) (*model.User, error) {
 
 	user, err := s.userRepo.FindByUsername(ctx, dto.Username)
 	if err != nil && !errors.Is(err, model.ErrModelNotFound) {
@@ -43,15 +65,30 @@ func (s *DefaultAuthService) Registration(ctx context.Context, dto *dto.AuthLogi
 		return nil, ErrInvalidUsernameOrPassword
 	}
 
-	m, err := model.NewUserModel(dto.Username, dto.Password)
+	user, err = s.userRepo.FindByEmail(ctx, dto.Email)
+	if err != nil && !errors.Is(err, model.ErrModelNotFound) {
+		return nil, err
+	} else if user != nil {
+		return nil, ErrInvalidUsernameOrPassword
+	}
+
+	m, err := model.NewUserModel(dto.Username, dto.Password, s.passwordPolicy, s.passwordHasher)
 	if err != nil {
 		return nil, err
 	}
+	m.Email = model.NormaliseEmail(dto.Email)
 
 	if _, err := s.userRepo.Create(ctx, m); err != nil {
+		if errors.Is(err, model.ErrModelDuplicate) {
+			return nil, ErrInvalidUsernameOrPassword
+		}
 		return nil, err
 	}
 
+	if err := s.verification.Send(ctx, m); err != nil {
+		logger.LoggerFromContext(ctx).Warn().Err(err).Str("username", m.Username).Msg("send verification email")
+	}
+
 	return m, nil
 }
 
@@ -61,12 +98,13 @@ func (s *DefaultAuthService) Login(ctx context.Context, dto *dto.AuthLogin) (*mo
 	user, err := s.userRepo.FindByUsername(ctx, dto.Username)
 	if err != nil {
 		if errors.Is(err, model.ErrModelNotFound) {
+			s.passwordHasher.CompareDummy(dto.Password)
 			return nil, ErrInvalidUsernameOrPassword
 		}
 		return nil, err
 	}
 
-	err = user.CheckPassword(dto.Password)
+	err = user.CheckPassword(dto.Password, s.passwordHasher)
 	if err != nil {
 		if errors.Is(err, model.ErrPassMismatched) {
 			return nil, ErrInvalidUsernameOrPassword
@@ -74,14 +112,26 @@ func (s *DefaultAuthService) Login(ctx context.Context, dto *dto.AuthLogin) (*mo
 		return nil, err
 	}
 
+	if user.PasswordNeedsRehash(s.passwordHasher) {
+		s.rehashPassword(ctx, user, dto.Password)
+	}
+	if !s.allowUnverifiedLogin && !user.IsEmailVerified() {
+		return nil, ErrEmailNotVerified
+	}
+
 	return user, nil
 }
 
-// GenerateToken ...
-func (s *DefaultAuthService) GenerateToken() (string, error) {
-	token, err := uuid.NewV7()
+// rehashPassword upgrade the stored hash to the hasher, the login succeeds on errors
+func (s *DefaultAuthService) rehashPassword(ctx context.Context, user *model.User, password string) {
+
+	previous := user.Password
+	err := user.RehashPassword(password, s.passwordHasher)
+	if err == nil {
+		err = s.userRepo.UpdatePassword(ctx, user.ID, user.Password)
+	}
 	if err != nil {
-		return "", err
+		user.Password = previous
+		logger.LoggerFromContext(ctx).Warn().Err(err).Str("username", user.Username).Msg("rehash password")
 	}
-	return token.String(), nil
 }
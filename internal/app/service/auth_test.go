@@ -2,15 +2,21 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"walk_backend/internal/app/dto"
 
 	"walk_backend/internal/app/model"
 	mockAuth "walk_backend/internal/app/service/mock"
+	"walk_backend/internal/pkg/logger"
 
 	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 )
 
 type testCase struct {
@@ -18,34 +24,64 @@ type testCase struct {
 	err         error
 }
 
+func newVerifiedUserModel(t *testing.T, username string, password string) *model.User {
+	user, err := model.NewUserModel(username, password, &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+	assert.Nil(t, err)
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	return user
+}
+
 func TestAuthService_Registration(t *testing.T) {
 
 	controller := gomock.NewController(t)
 	defer controller.Finish()
 
 	mockUserRepository := mockAuth.NewMockUserRepositoryInterface(controller)
+	mockVerification := mockAuth.NewMockEmailVerificationSenderInterface(controller)
 
-	t.Run("ErrInvalidUsernameOrPassword", func(t *testing.T) {
+	nop := zerolog.Nop()
+	ctx := logger.ContextWithLogger(context.Background(), &nop)
+	credentials := dto.AuthRegistration{Username: "test", Password: "test", Email: "Test@Walk.local"}
+	das := NewDefaultAuthService(mockUserRepository, mockVerification, &model.PasswordPolicy{}, model.DefaultPasswordHasher(), false)
 
-		testCase := testCase{
-			credentials: dto.AuthLogin{Username: "test", Password: "test"},
-			err:         ErrInvalidUsernameOrPassword,
-		}
+	t.Run("ErrInvalidUsernameOrPassword", func(t *testing.T) {
 
-		userModel, _ := model.NewUserModel(
-			testCase.credentials.Username,
-			testCase.credentials.Password,
-		)
+		userModel, _ := model.NewUserModel(credentials.Username, credentials.Password, &model.PasswordPolicy{}, model.DefaultPasswordHasher())
 
 		mockUserRepository.
 			EXPECT().
-			FindByUsername(context.Background(), testCase.credentials.Username).
+			FindByUsername(ctx, credentials.Username).
 			Return(userModel, nil).
 			Times(1)
 
-		das := NewDefaultAuthService(mockUserRepository)
-		_, err := das.Registration(context.Background(), &testCase.credentials)
-		assert.ErrorIs(t, err, testCase.err)
+		_, err := das.Registration(ctx, &credentials)
+		assert.ErrorIs(t, err, ErrInvalidUsernameOrPassword)
+	})
+
+	t.Run("Email_taken", func(t *testing.T) {
+
+		userModel, _ := model.NewUserModel("other", "test", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+
+		mockUserRepository.EXPECT().FindByUsername(ctx, credentials.Username).Return(nil, model.ErrModelNotFound)
+		mockUserRepository.EXPECT().FindByEmail(ctx, credentials.Email).Return(userModel, nil)
+
+		_, err := das.Registration(ctx, &credentials)
+		assert.ErrorIs(t, err, ErrInvalidUsernameOrPassword)
+	})
+
+	t.Run("Unverified", func(t *testing.T) {
+
+		mockUserRepository.EXPECT().FindByUsername(ctx, credentials.Username).Return(nil, model.ErrModelNotFound)
+		mockUserRepository.EXPECT().FindByEmail(ctx, credentials.Email).Return(nil, model.ErrModelNotFound)
+		mockUserRepository.EXPECT().Create(ctx, gomock.Any()).Return(model.NilID, nil)
+		// the user is created when the email fails, a resend is possible
+		mockVerification.EXPECT().Send(ctx, gomock.Any()).Return(errors.New("smtp down"))
+
+		user, err := das.Registration(ctx, &credentials)
+		assert.Nil(t, err)
+		assert.Equal(t, "test@walk.local", user.Email)
+		assert.False(t, user.IsEmailVerified())
 	})
 }
 
@@ -73,9 +109,49 @@ func TestAuthService_Login(t *testing.T) {
 				Return(nil, model.ErrModelNotFound).
 				Times(1)
 
-			das := NewDefaultAuthService(mockUserRepository)
+			das := NewDefaultAuthService(mockUserRepository, nil, &model.PasswordPolicy{}, model.DefaultPasswordHasher(), false)
 			_, err := das.Login(context.Background(), &testCase.credentials)
 			assert.ErrorIs(t, err, testCase.err)
 		}
 	})
+
+	t.Run("Rehash", func(t *testing.T) {
+
+		user := newVerifiedUserModel(t, "test", "test")
+		var err error
+		user.Password, err = model.NewBcryptHasher(bcrypt.MinCost).Hash("test")
+		assert.Nil(t, err)
+
+		mockUserRepository.EXPECT().FindByUsername(context.Background(), "test").Return(user, nil).Times(2)
+		mockUserRepository.
+			EXPECT().
+			UpdatePassword(context.Background(), user.ID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ model.ID, password string) error {
+				assert.True(t, strings.HasPrefix(password, "$argon2id$"))
+				return nil
+			}).
+			Times(1)
+
+		das := NewDefaultAuthService(mockUserRepository, nil, &model.PasswordPolicy{}, model.DefaultPasswordHasher(), false)
+		_, err = das.Login(context.Background(), &dto.AuthLogin{Username: "test", Password: "test"})
+		assert.Nil(t, err)
+		assert.False(t, user.PasswordNeedsRehash(model.DefaultPasswordHasher()))
+
+		// up to date hash, no write
+		_, err = das.Login(context.Background(), &dto.AuthLogin{Username: "test", Password: "test"})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Unverified", func(t *testing.T) {
+
+		user, err := model.NewUserModel("test", "test", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+		assert.Nil(t, err)
+		mockUserRepository.EXPECT().FindByUsername(context.Background(), "test").Return(user, nil).Times(2)
+
+		_, err = NewDefaultAuthService(mockUserRepository, nil, &model.PasswordPolicy{}, model.DefaultPasswordHasher(), false).Login(context.Background(), &dto.AuthLogin{Username: "test", Password: "test"})
+		assert.ErrorIs(t, err, ErrEmailNotVerified)
+
+		_, err = NewDefaultAuthService(mockUserRepository, nil, &model.PasswordPolicy{}, model.DefaultPasswordHasher(), true).Login(context.Background(), &dto.AuthLogin{Username: "test", Password: "test"})
+		assert.Nil(t, err)
+	})
 }
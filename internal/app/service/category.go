@@ -2,69 +2,342 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
 	"walk_backend/internal/app/dto"
 	"walk_backend/internal/app/model"
+	"walk_backend/internal/pkg/logger"
+
+	"github.com/gosimple/slug"
+)
+
+const (
+	// defaultCategorySlug for names without a single letter or digit
+	defaultCategorySlug string = "category"
+
+	categoryCountsCacheDuration time.Duration = 5 * time.Minute
+)
+
+var (
+	// ErrCategoryParentNotFound ...
+	ErrCategoryParentNotFound = errors.New("parent category not found")
+	// ErrCategoryCycle ...
+	ErrCategoryCycle = errors.New("category can not be moved into itself or its descendant")
+	// ErrCategoryDepth ...
+	ErrCategoryDepth = errors.New("category depth limit exceeded")
+	// ErrCategoryHasChildren ...
+	ErrCategoryHasChildren = errors.New("category has subcategories")
+	// ErrCategoryInUse ...
+	ErrCategoryInUse = errors.New("category is used by places")
+	// ErrInvalidCategoryOrder ...
+	ErrInvalidCategoryOrder = errors.New("category order must list every category once")
+	// ErrCategorySlugTaken ...
+	ErrCategorySlugTaken = errors.New("category slug is already taken")
+	// ErrInvalidReassignCategory ...
+	ErrInvalidReassignCategory = errors.New("invalid category to reassign places to")
 )
 
+// CategoryInUseError category is referenced by places
+type CategoryInUseError struct {
+	Places int64
+}
+
+// Error ...
+func (e *CategoryInUseError) Error() string {
+	return fmt.Sprintf("%s: %d", ErrCategoryInUse.Error(), e.Places)
+}
+
+// Is ...
+func (e *CategoryInUseError) Is(target error) bool {
+	return target == ErrCategoryInUse
+}
+
 // CategoryRepositoryInterface ...
 type CategoryRepositoryInterface interface {
-	Find(ctx context.Context, id model.ID) (*model.Category, error)
-	FindAll(ctx context.Context) (model.CategoryList, error)
 	Create(ctx context.Context, m *model.Category) (model.ID, error)
 	Update(ctx context.Context, m *model.Category) error
+	UpdateOrders(ctx context.Context, ids []model.ID) error
 	Delete(ctx context.Context, id model.ID) error
+	FindAll(ctx context.Context) (model.CategoryList, error)
+}
+
+// CategoryCacheRepositoryInterface read-through category cache, invalidated after every committed write
+type CategoryCacheRepositoryInterface interface {
+	Find(ctx context.Context, id model.ID) (*model.Category, error)
+	FindBySlug(ctx context.Context, slug string) (*model.Category, error)
+	FindAll(ctx context.Context) (model.CategoryList, error)
+	Invalidate(ctx context.Context) error
+}
+
+// CategoryPlaceRepositoryInterface ...
+type CategoryPlaceRepositoryInterface interface {
+	CountByCategory(ctx context.Context, categoryID model.ID) (int64, error)
+	ReassignCategory(ctx context.Context, from model.ID, to model.ID) ([]model.ID, error)
+	CountPublishedByCategories(ctx context.Context) (model.CategoryCounts, error)
+}
+
+// CategoryCountCacheRepositoryInterface ...
+type CategoryCountCacheRepositoryInterface interface {
+	Get(ctx context.Context) (model.CategoryCounts, error)
+	Set(ctx context.Context, counts model.CategoryCounts, expiration time.Duration) error
+	Del(ctx context.Context) error
+}
+
+// TransactionInterface ...
+type TransactionInterface interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 // DefaultCategoryService ...
 type DefaultCategoryService struct {
-	categoryRepo CategoryRepositoryInterface
+	categoryRepo  CategoryRepositoryInterface
+	categoryCache CategoryCacheRepositoryInterface
+	placeRepo     CategoryPlaceRepositoryInterface
+	transaction   TransactionInterface
+	placeQueue    PlaceQueueRepositoryInterface
+	placeCache    PlaceCacheRepositoryInterface
+	countCache    CategoryCountCacheRepositoryInterface
 }
 
 // NewDefaultCategoryService create new default category service
-func NewDefaultCategoryService(categoryRepo CategoryRepositoryInterface) *DefaultCategoryService {
+func NewDefaultCategoryService(
+	categoryRepo CategoryRepositoryInterface,
+	categoryCache CategoryCacheRepositoryInterface,
+	placeRepo CategoryPlaceRepositoryInterface,
+	transaction TransactionInterface,
+	placeQueue PlaceQueueRepositoryInterface,
+	placeCache PlaceCacheRepositoryInterface,
+	countCache CategoryCountCacheRepositoryInterface,
+) *DefaultCategoryService {
 	return &DefaultCategoryService{
-		categoryRepo: categoryRepo,
+		categoryRepo:  categoryRepo,
+		categoryCache: categoryCache,
+		placeRepo:     placeRepo,
+		transaction:   transaction,
+		placeQueue:    placeQueue,
+		placeCache:    placeCache,
+		countCache:    countCache,
 	}
 }
 
 // ListCategories ...
 func (s *DefaultCategoryService) ListCategories(ctx context.Context) (model.CategoryList, error) {
-	return s.categoryRepo.FindAll(ctx)
+	return s.categoryCache.FindAll(ctx)
+}
+
+// PlaceCounts number of published places by category, categories without places are missing
+func (s *DefaultCategoryService) PlaceCounts(ctx context.Context) (model.CategoryCounts, error) {
+
+	counts, err := s.countCache.Get(ctx)
+	if err != nil {
+		return nil, err
+	} else if counts != nil {
+		return counts, nil
+	}
+
+	counts, err = s.placeRepo.CountPublishedByCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.countCache.Set(ctx, counts, categoryCountsCacheDuration); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
 }
 
-// Create ...
+// Create create category, the slug is generated from the name when not given
 func (s *DefaultCategoryService) Create(ctx context.Context, d *dto.Category) (model.ID, error) {
 
-	m, err := s.makeModelFromCategoryDTO(d)
+	categories, err := s.categoryCache.FindAll(ctx)
+	if err != nil {
+		return model.NilID, err
+	}
+
+	m, err := s.makeModelFromCategoryDTO(categories, d)
 	if err != nil {
 		return model.NilID, err
 	}
 
-	return s.categoryRepo.Create(ctx, m)
+	if m.ParentID != nil {
+		if err := checkCategoryParent(categories, m); err != nil {
+			return model.NilID, err
+		}
+	}
+
+	id, err := s.categoryRepo.Create(ctx, m)
+	if errors.Is(err, model.ErrModelDuplicate) {
+		return model.NilID, ErrCategorySlugTaken
+	} else if err != nil {
+		return model.NilID, err
+	}
+
+	s.invalidateCache(ctx)
+
+	return id, nil
 }
 
-// Update ...
+// Update update and move category, the whole subtree is moved with it,
+// the slug is kept when not given
 func (s *DefaultCategoryService) Update(ctx context.Context, d *dto.Category) error {
 
-	m, err := s.makeModelFromCategoryDTO(d)
+	categories, err := s.categoryCache.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	m, err := s.makeModelFromCategoryDTO(categories, d)
 	if err != nil {
 		return err
 	}
+	if categories.FindByID(m.ID) == nil {
+		return model.ErrModelNotFound
+	}
 
-	return s.categoryRepo.Update(ctx, m)
+	if m.ParentID != nil {
+		if err := checkCategoryParent(categories, m); err != nil {
+			return err
+		}
+	}
+
+	err = s.categoryRepo.Update(ctx, m)
+	if errors.Is(err, model.ErrModelDuplicate) {
+		return ErrCategorySlugTaken
+	} else if err != nil {
+		return err
+	}
+
+	s.invalidateCache(ctx)
+
+	return nil
 }
 
-// Delete ...
-func (s *DefaultCategoryService) Delete(ctx context.Context, id model.ID) error {
-	return s.categoryRepo.Delete(ctx, id)
+// Reorder set orders of all categories by their position in ids in one transaction,
+// model.ErrModelSetMismatch when ids do not match the stored categories
+func (s *DefaultCategoryService) Reorder(ctx context.Context, ids []model.ID) error {
+
+	if len(ids) == 0 {
+		return ErrInvalidCategoryOrder
+	}
+
+	seen := make(map[model.ID]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			return ErrInvalidCategoryOrder
+		}
+		seen[id] = struct{}{}
+	}
+
+	err := s.transaction.WithTransaction(ctx, func(ctx context.Context) error {
+		return s.categoryRepo.UpdateOrders(ctx, ids)
+	})
+	if err != nil {
+		return err
+	}
+
+	// after the commit, a read during the transaction could cache the old orders again
+	s.invalidateCache(ctx)
+
+	return nil
+}
+
+// Delete delete category without subcategories, places of the category are moved to reassignTo
+// in the same transaction, without reassignTo a used category is not deleted
+func (s *DefaultCategoryService) Delete(ctx context.Context, id model.ID, reassignTo *model.ID) error {
+
+	var moved []model.ID
+	err := s.transaction.WithTransaction(ctx, func(ctx context.Context) error {
+
+		moved = nil
+
+		// the stored categories, a child or the reassign target created or deleted meanwhile is not missed
+		categories, err := s.categoryRepo.FindAll(ctx)
+		if err != nil {
+			return err
+		}
+		if categories.FindByID(id) == nil {
+			return model.ErrModelNotFound
+		}
+		if len(categories.Children(id)) > 0 {
+			return ErrCategoryHasChildren
+		}
+		if reassignTo != nil && (*reassignTo == id || categories.FindByID(*reassignTo) == nil) {
+			return ErrInvalidReassignCategory
+		}
+
+		if reassignTo == nil {
+			count, err := s.placeRepo.CountByCategory(ctx, id)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return &CategoryInUseError{Places: count}
+			}
+		} else {
+			ids, err := s.placeRepo.ReassignCategory(ctx, id, *reassignTo)
+			if err != nil {
+				return err
+			}
+			moved = ids
+		}
+
+		return s.categoryRepo.Delete(ctx, id)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.invalidateCache(ctx)
+
+	if len(moved) == 0 {
+		return nil
+	}
+
+	if err := s.placeCache.Del(ctx, listPlacesCacheKey); err != nil {
+		return err
+	}
+
+	if err := s.countCache.Del(ctx); err != nil {
+		return err
+	}
+
+	for _, placeID := range moved {
+		if err := s.placeQueue.PublishReIndex(placeID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// invalidateCache after a committed write, an error does not fail the write, the process caches
+// of the instances expire after the local TTL
+func (s *DefaultCategoryService) invalidateCache(ctx context.Context) {
+	if err := s.categoryCache.Invalidate(ctx); err != nil {
+		logger.LoggerFromContext(ctx).Error().Err(err).Msg("invalidate category cache")
+	}
+}
+
+// CategoryTree all categories, use CategoryList.Children to walk the tree
+func (s *DefaultCategoryService) CategoryTree(ctx context.Context) (model.CategoryList, error) {
+	return s.categoryCache.FindAll(ctx)
 }
 
 // Find ...
 func (s *DefaultCategoryService) Find(ctx context.Context, id model.ID) (*model.Category, error) {
-	return s.categoryRepo.Find(ctx, id)
+	return s.categoryCache.Find(ctx, id)
+}
+
+// FindBySlug ...
+func (s *DefaultCategoryService) FindBySlug(ctx context.Context, slug string) (*model.Category, error) {
+	return s.categoryCache.FindBySlug(ctx, slug)
 }
 
-func (s *DefaultCategoryService) makeModelFromCategoryDTO(d *dto.Category) (*model.Category, error) {
+func (s *DefaultCategoryService) makeModelFromCategoryDTO(categories model.CategoryList, d *dto.Category) (*model.Category, error) {
 
 	var id model.ID
 	var err error
@@ -78,14 +351,88 @@ func (s *DefaultCategoryService) makeModelFromCategoryDTO(d *dto.Category) (*mod
 		return nil, err
 	}
 
+	var parentID *model.ID
+	if d.ParentID != "" {
+		pID, err := model.StringToID(d.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		parentID = &pID
+	}
+
+	categorySlug := d.Slug
+	if categorySlug == "" {
+		if current := categories.FindByID(id); current != nil {
+			categorySlug = current.Slug
+		}
+	}
+	if categorySlug == "" {
+		categorySlug = uniqueCategorySlug(categories, id, d.Name)
+	} else if other := categories.FindBySlug(categorySlug); other != nil && other.ID != id {
+		return nil, ErrCategorySlugTaken
+	}
+
 	m, err := model.NewCategoryModel(
 		id,
 		d.Name,
+		categorySlug,
 		d.Order,
+		parentID,
+		d.Icon,
+		strings.ToLower(d.Color),
+		d.Description,
 	)
+	if errors.Is(err, model.ErrInvalidModel) && parentID != nil && *parentID == id {
+		return nil, ErrCategoryCycle
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	return m, nil
 }
+
+// uniqueCategorySlug slug of the name, numbered when another category has it
+func uniqueCategorySlug(categories model.CategoryList, id model.ID, name string) string {
+
+	base := slug.Make(name)
+	// leave room for the number suffix
+	if len(base) > model.MaxCategorySlugLength-10 {
+		base = strings.Trim(base[:model.MaxCategorySlugLength-10], "-")
+	}
+	if base == "" {
+		base = defaultCategorySlug
+	}
+
+	categorySlug := base
+	for i := 2; ; i++ {
+		if other := categories.FindBySlug(categorySlug); other == nil || other.ID == id {
+			return categorySlug
+		}
+		categorySlug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// checkCategoryParent parent must exist, must not be in the subtree of the category and the subtree must fit the depth limit
+func checkCategoryParent(categories model.CategoryList, m *model.Category) error {
+
+	if categories.FindByID(*m.ParentID) == nil {
+		return ErrCategoryParentNotFound
+	}
+
+	for _, id := range categories.Descendants(m.ID) {
+		if id == *m.ParentID {
+			return ErrCategoryCycle
+		}
+	}
+
+	height := 1
+	if categories.FindByID(m.ID) != nil {
+		height = categories.Height(m.ID)
+	}
+	if categories.Depth(*m.ParentID)+height > model.MaxCategoryDepth {
+		return ErrCategoryDepth
+	}
+
+	return nil
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"walk_backend/internal/app/dto"
+	"walk_backend/internal/app/model"
+	mockService "walk_backend/internal/app/service/mock"
+	"walk_backend/internal/pkg/logger"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeServiceCategoryTree(t *testing.T) (model.CategoryList, []model.ID) {
+	ids := make([]model.ID, 0, 4)
+	for i := 0; i < 4; i++ {
+		id, err := model.NewID()
+		assert.Nil(t, err)
+		ids = append(ids, id)
+	}
+
+	// 0 -> 1 -> 2, 3 is root
+	return model.CategoryList{
+		{ID: ids[0], Name: "Parks", Order: 1},
+		{ID: ids[1], Name: "Gardens", Order: 1, ParentID: &ids[0]},
+		{ID: ids[2], Name: "Botanical gardens", Order: 1, ParentID: &ids[1]},
+		{ID: ids[3], Name: "Museums", Order: 2},
+	}, ids
+}
+
+func TestCategoryService_Update(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockCategoryRepository := mockService.NewMockCategoryRepositoryInterface(controller)
+	mockCategoryCache := mockService.NewMockCategoryCacheRepositoryInterface(controller)
+
+	ctx := context.Background()
+	categories, ids := makeServiceCategoryTree(t)
+	mockCategoryCache.EXPECT().FindAll(ctx).Return(categories, nil).AnyTimes()
+	mockCategoryCache.EXPECT().Invalidate(ctx).Return(nil).AnyTimes()
+
+	s := NewDefaultCategoryService(mockCategoryRepository, mockCategoryCache, nil, nil, nil, nil, nil)
+
+	t.Run("Cycle", func(t *testing.T) {
+		err := s.Update(ctx, &dto.Category{ID: ids[0].String(), Name: "Parks", Order: 1, ParentID: ids[2].String()})
+		assert.ErrorIs(t, err, ErrCategoryCycle)
+	})
+
+	t.Run("Self", func(t *testing.T) {
+		err := s.Update(ctx, &dto.Category{ID: ids[0].String(), Name: "Parks", Order: 1, ParentID: ids[0].String()})
+		assert.ErrorIs(t, err, ErrCategoryCycle)
+	})
+
+	t.Run("Depth", func(t *testing.T) {
+		mockCategoryRepository.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+		err := s.Update(ctx, &dto.Category{ID: ids[1].String(), Name: "Gardens", Order: 1, ParentID: ids[3].String()})
+		assert.Nil(t, err)
+
+		err = s.Update(ctx, &dto.Category{ID: ids[0].String(), Name: "Parks", Order: 1, ParentID: ids[3].String()})
+		assert.ErrorIs(t, err, ErrCategoryDepth)
+	})
+
+	t.Run("Parent_not_found", func(t *testing.T) {
+		parentID, err := model.NewID()
+		assert.Nil(t, err)
+		err = s.Update(ctx, &dto.Category{ID: ids[3].String(), Name: "Museums", Order: 2, ParentID: parentID.String()})
+		assert.ErrorIs(t, err, ErrCategoryParentNotFound)
+	})
+}
+
+func TestCategoryService_Delete(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockCategoryRepository := mockService.NewMockCategoryRepositoryInterface(controller)
+	mockCategoryCache := mockService.NewMockCategoryCacheRepositoryInterface(controller)
+	mockPlaceRepository := mockService.NewMockCategoryPlaceRepositoryInterface(controller)
+	mockTransaction := mockService.NewMockTransactionInterface(controller)
+	mockPlaceQueue := mockService.NewMockPlaceQueueRepositoryInterface(controller)
+	mockPlaceCache := mockService.NewMockPlaceCacheRepositoryInterface(controller)
+	mockCountCache := mockService.NewMockCategoryCountCacheRepositoryInterface(controller)
+
+	nop := zerolog.Nop()
+	ctx := logger.ContextWithLogger(context.Background(), &nop)
+	categories, ids := makeServiceCategoryTree(t)
+	// the checks read the stored categories in the transaction, never the cache
+	mockCategoryRepository.EXPECT().FindAll(ctx).Return(categories, nil).AnyTimes()
+	mockTransaction.EXPECT().WithTransaction(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	s := NewDefaultCategoryService(mockCategoryRepository, mockCategoryCache, mockPlaceRepository, mockTransaction, mockPlaceQueue, mockPlaceCache, mockCountCache)
+
+	t.Run("Not_found", func(t *testing.T) {
+		id, err := model.NewID()
+		assert.Nil(t, err)
+		assert.ErrorIs(t, s.Delete(ctx, id, nil), model.ErrModelNotFound)
+	})
+
+	t.Run("Has_children", func(t *testing.T) {
+		assert.ErrorIs(t, s.Delete(ctx, ids[1], nil), ErrCategoryHasChildren)
+	})
+
+	t.Run("In_use", func(t *testing.T) {
+		mockPlaceRepository.EXPECT().CountByCategory(ctx, ids[2]).Return(int64(3), nil)
+
+		err := s.Delete(ctx, ids[2], nil)
+		assert.ErrorIs(t, err, ErrCategoryInUse)
+		var inUseErr *CategoryInUseError
+		assert.ErrorAs(t, err, &inUseErr)
+		assert.Equal(t, int64(3), inUseErr.Places)
+	})
+
+	t.Run("Invalid_reassign", func(t *testing.T) {
+		assert.ErrorIs(t, s.Delete(ctx, ids[2], &ids[2]), ErrInvalidReassignCategory)
+	})
+
+	t.Run("Reassign", func(t *testing.T) {
+		placeID, err := model.NewID()
+		assert.Nil(t, err)
+
+		mockPlaceRepository.EXPECT().ReassignCategory(ctx, ids[2], ids[3]).Return([]model.ID{placeID}, nil)
+		mockCategoryRepository.EXPECT().Delete(ctx, ids[2]).Return(nil)
+		mockCategoryCache.EXPECT().Invalidate(ctx).Return(nil)
+		mockPlaceCache.EXPECT().Del(ctx, listPlacesCacheKey).Return(nil)
+		mockCountCache.EXPECT().Del(ctx).Return(nil)
+		mockPlaceQueue.EXPECT().PublishReIndex(placeID).Return(nil)
+
+		assert.Nil(t, s.Delete(ctx, ids[2], &ids[3]))
+	})
+
+	t.Run("Unused", func(t *testing.T) {
+		mockPlaceRepository.EXPECT().CountByCategory(ctx, ids[3]).Return(int64(0), nil)
+		mockCategoryRepository.EXPECT().Delete(ctx, ids[3]).Return(nil)
+		mockCategoryCache.EXPECT().Invalidate(ctx).Return(nil)
+
+		assert.Nil(t, s.Delete(ctx, ids[3], nil))
+	})
+
+	t.Run("Invalidate_error", func(t *testing.T) {
+		mockPlaceRepository.EXPECT().CountByCategory(ctx, ids[3]).Return(int64(0), nil)
+		mockCategoryRepository.EXPECT().Delete(ctx, ids[3]).Return(nil)
+		mockCategoryCache.EXPECT().Invalidate(ctx).Return(errors.New("redis down"))
+
+		// the category is deleted, the caches expire
+		assert.Nil(t, s.Delete(ctx, ids[3], nil))
+	})
+}
+
+func TestCategoryService_Reorder(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockCategoryRepository := mockService.NewMockCategoryRepositoryInterface(controller)
+	mockCategoryCache := mockService.NewMockCategoryCacheRepositoryInterface(controller)
+	mockTransaction := mockService.NewMockTransactionInterface(controller)
+
+	ctx := context.Background()
+	_, ids := makeServiceCategoryTree(t)
+	committed := false
+	mockTransaction.EXPECT().WithTransaction(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			committed = false
+			if err := fn(ctx); err != nil {
+				return err
+			}
+			committed = true
+			return nil
+		}).
+		AnyTimes()
+
+	s := NewDefaultCategoryService(mockCategoryRepository, mockCategoryCache, nil, mockTransaction, nil, nil, nil)
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.ErrorIs(t, s.Reorder(ctx, nil), ErrInvalidCategoryOrder)
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		assert.ErrorIs(t, s.Reorder(ctx, []model.ID{ids[0], ids[1], ids[0]}), ErrInvalidCategoryOrder)
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		order := []model.ID{ids[3], ids[2]}
+		mockCategoryRepository.EXPECT().UpdateOrders(ctx, order).Return(model.ErrModelSetMismatch)
+
+		assert.ErrorIs(t, s.Reorder(ctx, order), model.ErrModelSetMismatch)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		order := []model.ID{ids[3], ids[2], ids[1], ids[0]}
+		mockCategoryRepository.EXPECT().UpdateOrders(ctx, order).Return(nil)
+		// a read during the transaction would cache the old orders again
+		mockCategoryCache.EXPECT().Invalidate(ctx).Do(func(context.Context) {
+			assert.True(t, committed)
+		}).Return(nil)
+
+		assert.Nil(t, s.Reorder(ctx, order))
+	})
+}
+
+func TestCategoryService_Slug(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockCategoryRepository := mockService.NewMockCategoryRepositoryInterface(controller)
+	mockCategoryCache := mockService.NewMockCategoryCacheRepositoryInterface(controller)
+
+	ctx := context.Background()
+	categories, ids := makeServiceCategoryTree(t)
+	categories[0].Slug = "parks"
+	categories[3].Slug = "museums"
+	mockCategoryCache.EXPECT().FindAll(ctx).Return(categories, nil).AnyTimes()
+	mockCategoryCache.EXPECT().Invalidate(ctx).Return(nil).AnyTimes()
+
+	s := NewDefaultCategoryService(mockCategoryRepository, mockCategoryCache, nil, nil, nil, nil, nil)
+
+	t.Run("Generated", func(t *testing.T) {
+		mockCategoryRepository.EXPECT().Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, m *model.Category) (model.ID, error) {
+				assert.Equal(t, "park-gor-kogo", m.Slug)
+				assert.Equal(t, "#2e7d32", m.Color)
+				return m.ID, nil
+			})
+
+		_, err := s.Create(ctx, &dto.Category{Name: "Парк Горького", Order: 3, Color: "#2E7D32"})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Numbered", func(t *testing.T) {
+		mockCategoryRepository.EXPECT().Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, m *model.Category) (model.ID, error) {
+				assert.Equal(t, "parks-2", m.Slug)
+				return m.ID, nil
+			})
+
+		_, err := s.Create(ctx, &dto.Category{Name: "Parks", Order: 3})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Taken", func(t *testing.T) {
+		_, err := s.Create(ctx, &dto.Category{Name: "City parks", Slug: "parks", Order: 3})
+		assert.ErrorIs(t, err, ErrCategorySlugTaken)
+	})
+
+	t.Run("Kept_on_update", func(t *testing.T) {
+		mockCategoryRepository.EXPECT().Update(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, m *model.Category) error {
+				assert.Equal(t, "museums", m.Slug)
+				return nil
+			})
+
+		assert.Nil(t, s.Update(ctx, &dto.Category{ID: ids[3].String(), Name: "Art museums", Order: 2}))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := s.Create(ctx, &dto.Category{Name: "Zoos", Slug: "Zoos", Order: 3})
+		assert.ErrorIs(t, err, model.ErrInvalidModel)
+	})
+}
+
+func TestCategoryService_PlaceCounts(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockPlaceRepository := mockService.NewMockCategoryPlaceRepositoryInterface(controller)
+	mockCountCache := mockService.NewMockCategoryCountCacheRepositoryInterface(controller)
+
+	ctx := context.Background()
+	_, ids := makeServiceCategoryTree(t)
+	counts := model.CategoryCounts{ids[0]: 3, ids[3]: 1}
+
+	s := NewDefaultCategoryService(nil, nil, mockPlaceRepository, nil, nil, nil, mockCountCache)
+
+	t.Run("Miss", func(t *testing.T) {
+		mockCountCache.EXPECT().Get(ctx).Return(nil, nil)
+		mockPlaceRepository.EXPECT().CountPublishedByCategories(ctx).Return(counts, nil)
+		mockCountCache.EXPECT().Set(ctx, counts, categoryCountsCacheDuration).Return(nil)
+
+		result, err := s.PlaceCounts(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, counts, result)
+	})
+
+	t.Run("Hit", func(t *testing.T) {
+		mockCountCache.EXPECT().Get(ctx).Return(counts, nil)
+
+		result, err := s.PlaceCounts(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, counts, result)
+	})
+}
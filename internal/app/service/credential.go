@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+
+	"walk_backend/internal/app/model"
+)
+
+// CredentialSessionServiceInterface ...
+type CredentialSessionServiceInterface interface {
+	RevokeAll(ctx context.Context, username string, exceptSessionID model.ID) (int64, error)
+}
+
+// CredentialTokenServiceInterface ...
+type CredentialTokenServiceInterface interface {
+	RevokeAll(ctx context.Context, username string) error
+}
+
+// CredentialAPIKeyServiceInterface ...
+type CredentialAPIKeyServiceInterface interface {
+	RevokeAll(ctx context.Context, username string) (int64, error)
+}
+
+// DefaultCredentialService signs a user out of everything at once: sessions, bearer tokens and API keys
+type DefaultCredentialService struct {
+	sessions CredentialSessionServiceInterface
+	tokens   CredentialTokenServiceInterface
+	apiKeys  CredentialAPIKeyServiceInterface
+}
+
+// NewDefaultCredentialService create new default credential service
+func NewDefaultCredentialService(
+	sessions CredentialSessionServiceInterface,
+	tokens CredentialTokenServiceInterface,
+	apiKeys CredentialAPIKeyServiceInterface,
+) *DefaultCredentialService {
+	return &DefaultCredentialService{
+		sessions: sessions,
+		tokens:   tokens,
+		apiKeys:  apiKeys,
+	}
+}
+
+// RevokeAll revoke every credential of the user except the exceptSessionID session, NilID for none.
+// Returns the number of revoked sessions and API keys, bearer tokens are not counted
+func (s *DefaultCredentialService) RevokeAll(ctx context.Context, username string, exceptSessionID model.ID) (int64, error) {
+
+	// bearer tokens first, a stolen refresh token is the credential that survives the longest unnoticed
+	if err := s.tokens.RevokeAll(ctx, username); err != nil {
+		return 0, err
+	}
+
+	keys, err := s.apiKeys.RevokeAll(ctx, username)
+	if err != nil {
+		return 0, err
+	}
+
+	sessions, err := s.sessions.RevokeAll(ctx, username, exceptSessionID)
+	if err != nil {
+		return 0, err
+	}
+
+	return sessions + keys, nil
+}
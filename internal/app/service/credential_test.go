@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"walk_backend/internal/app/model"
+	mockService "walk_backend/internal/app/service/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentialService_RevokeAll(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockSessionService := mockService.NewMockCredentialSessionServiceInterface(controller)
+	mockTokenService := mockService.NewMockCredentialTokenServiceInterface(controller)
+	mockAPIKeyService := mockService.NewMockCredentialAPIKeyServiceInterface(controller)
+
+	ctx := context.Background()
+	s := NewDefaultCredentialService(mockSessionService, mockTokenService, mockAPIKeyService)
+	currentID, err := model.NewID()
+	assert.Nil(t, err)
+
+	gomock.InOrder(
+		mockTokenService.EXPECT().RevokeAll(ctx, "Wozniak").Return(nil),
+		mockAPIKeyService.EXPECT().RevokeAll(ctx, "Wozniak").Return(int64(1), nil),
+		mockSessionService.EXPECT().RevokeAll(ctx, "Wozniak", currentID).Return(int64(2), nil),
+	)
+	revoked, err := s.RevokeAll(ctx, "Wozniak", currentID)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), revoked)
+
+	// nothing is reported revoked while bearer tokens still work
+	errRedis := errors.New("redis down")
+	mockTokenService.EXPECT().RevokeAll(ctx, "Wozniak").Return(errRedis)
+	_, err = s.RevokeAll(ctx, "Wozniak", model.NilID)
+	assert.ErrorIs(t, err, errRedis)
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/pkg/jwt"
+	"walk_backend/internal/pkg/mailer"
+)
+
+const (
+	// TokenTypeEmailVerification signed link token of the verification email
+	TokenTypeEmailVerification   string = "email_verification"
+	emailVerificationCooldownKey string = "verify:"
+)
+
+var (
+	// ErrInvalidVerificationToken malformed, expired or for another email
+	ErrInvalidVerificationToken = errors.New("invalid verification token")
+	// ErrEmailNotVerified ...
+	ErrEmailNotVerified = errors.New("email not verified")
+)
+
+// EmailVerificationUserRepositoryInterface ...
+type EmailVerificationUserRepositoryInterface interface {
+	FindByUsername(ctx context.Context, username string) (*model.User, error)
+	FindByEmail(ctx context.Context, email string) (*model.User, error)
+	MarkEmailVerified(ctx context.Context, id model.ID, email string, verifiedAt time.Time) error
+}
+
+// EmailVerificationCooldownRepositoryInterface ...
+type EmailVerificationCooldownRepositoryInterface interface {
+	Block(ctx context.Context, key string, duration time.Duration) error
+	BlockedFor(ctx context.Context, key string) (time.Duration, error)
+}
+
+// DefaultEmailVerificationService stateless signed verification links, the token is bound to the email
+type DefaultEmailVerificationService struct {
+	userRepo       EmailVerificationUserRepositoryInterface
+	cooldownRepo   EmailVerificationCooldownRepositoryInterface
+	signer         TokenSignerInterface
+	mailer         MailerInterface
+	issuer         string
+	ttl            time.Duration
+	verifyURL      string
+	resendInterval time.Duration
+	now            func() time.Time
+}
+
+// NewDefaultEmailVerificationService create new default email verification service,
+// the emailed link is verifyURL with the token query parameter, one resend per resendInterval and user
+func NewDefaultEmailVerificationService(
+	userRepo EmailVerificationUserRepositoryInterface,
+	cooldownRepo EmailVerificationCooldownRepositoryInterface,
+	signer TokenSignerInterface,
+	mailer MailerInterface,
+	issuer string,
+	ttl time.Duration,
+	verifyURL string,
+	resendInterval time.Duration,
+) *DefaultEmailVerificationService {
+	return &DefaultEmailVerificationService{
+		userRepo:       userRepo,
+		cooldownRepo:   cooldownRepo,
+		signer:         signer,
+		mailer:         mailer,
+		issuer:         issuer,
+		ttl:            ttl,
+		verifyURL:      verifyURL,
+		resendInterval: resendInterval,
+		now:            time.Now,
+	}
+}
+
+// Send email a verification link to the user
+func (s *DefaultEmailVerificationService) Send(ctx context.Context, user *model.User) error {
+
+	now := s.now()
+	token, err := s.signer.Sign(&jwt.Claims{
+		ID:        emailHash(user.Email),
+		Subject:   user.Username,
+		Issuer:    s.issuer,
+		Type:      TokenTypeEmailVerification,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(s.ttl).Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	link, err := url.Parse(s.verifyURL)
+	if err != nil {
+		return err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	return s.mailer.Send(ctx, &mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nopen the link to verify your email, it expires in %s:\n\n%s\n\n"+
+				"If you did not sign up, ignore this email.\n",
+			user.Username, s.ttl, link,
+		),
+	})
+}
+
+// Resend email a new verification link, unknown and verified emails and resends within
+// the resend interval are ignored so the caller can not tell which accounts exist
+func (s *DefaultEmailVerificationService) Resend(ctx context.Context, email string) error {
+
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, model.ErrModelNotFound) {
+			return nil
+		}
+		return err
+	}
+	if user.IsEmailVerified() {
+		return nil
+	}
+
+	key := emailVerificationCooldownKey + user.ID.String()
+	blockedFor, err := s.cooldownRepo.BlockedFor(ctx, key)
+	if err != nil {
+		return err
+	} else if blockedFor > 0 {
+		return nil
+	}
+	if err := s.cooldownRepo.Block(ctx, key, s.resendInterval); err != nil {
+		return err
+	}
+
+	return s.Send(ctx, user)
+}
+
+// Verify mark the email of the token verified, verifying twice is no error
+func (s *DefaultEmailVerificationService) Verify(ctx context.Context, token string) (*model.User, error) {
+
+	claims, err := s.signer.Verify(token)
+	if err != nil || claims.Type != TokenTypeEmailVerification || claims.Issuer != s.issuer {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	user, err := s.userRepo.FindByUsername(ctx, claims.Subject)
+	if err != nil {
+		if errors.Is(err, model.ErrModelNotFound) {
+			return nil, ErrInvalidVerificationToken
+		}
+		return nil, err
+	}
+	// the email changed since the link was sent
+	if user.Email == "" || emailHash(user.Email) != claims.ID {
+		return nil, ErrInvalidVerificationToken
+	}
+	if user.IsEmailVerified() {
+		return user, nil
+	}
+
+	now := s.now()
+	if err := s.userRepo.MarkEmailVerified(ctx, user.ID, user.Email, now); err != nil {
+		if errors.Is(err, model.ErrModelNotFound) {
+			return nil, ErrInvalidVerificationToken
+		}
+		return nil, err
+	}
+	user.EmailVerifiedAt = &now
+
+	return user, nil
+}
+
+func emailHash(email string) string {
+	sum := sha256.Sum256([]byte(model.NormaliseEmail(email)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"walk_backend/internal/app/model"
+	mockService "walk_backend/internal/app/service/mock"
+	"walk_backend/internal/pkg/jwt"
+	"walk_backend/internal/pkg/mailer"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailVerificationService(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockUserRepository := mockService.NewMockEmailVerificationUserRepositoryInterface(controller)
+	mockCooldownRepository := mockService.NewMockEmailVerificationCooldownRepositoryInterface(controller)
+	mockMailer := mockService.NewMockMailerInterface(controller)
+
+	key, err := jwt.NewHS256Key("email", []byte("0123456789abcdef0123456789abcdef"))
+	assert.Nil(t, err)
+	keySet, err := jwt.NewKeySet("email", key)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	s := NewDefaultEmailVerificationService(
+		mockUserRepository,
+		mockCooldownRepository,
+		keySet,
+		mockMailer,
+		"walk",
+		time.Hour,
+		"https://walk.local/api/v1/auth/verify",
+		time.Minute,
+	)
+
+	user, err := model.NewUserModel("Wozniak", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+	assert.Nil(t, err)
+	user.Email = "woz@apple.com"
+
+	var token string
+	mockMailer.
+		EXPECT().
+		Send(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, m *mailer.Message) error {
+			assert.Equal(t, "woz@apple.com", m.To)
+			link, err := url.Parse(regexp.MustCompile(`https://\S+`).FindString(m.Body))
+			assert.Nil(t, err)
+			token = link.Query().Get("token")
+			return nil
+		}).
+		AnyTimes()
+
+	t.Run("Resend_cooldown", func(t *testing.T) {
+		mockUserRepository.EXPECT().FindByEmail(ctx, "woz@apple.com").Return(user, nil).Times(2)
+		mockCooldownRepository.EXPECT().BlockedFor(ctx, "verify:"+user.ID.String()).Return(time.Duration(0), nil)
+		mockCooldownRepository.EXPECT().Block(ctx, "verify:"+user.ID.String(), time.Minute).Return(nil)
+		mockCooldownRepository.EXPECT().BlockedFor(ctx, "verify:"+user.ID.String()).Return(time.Minute, nil)
+
+		assert.Nil(t, s.Resend(ctx, "woz@apple.com"))
+		assert.NotEmpty(t, token)
+		token = ""
+		assert.Nil(t, s.Resend(ctx, "woz@apple.com"))
+		assert.Empty(t, token)
+	})
+
+	t.Run("Resend_unknown", func(t *testing.T) {
+		mockUserRepository.EXPECT().FindByEmail(ctx, "jobs@apple.com").Return(nil, model.ErrModelNotFound)
+
+		assert.Nil(t, s.Resend(ctx, "jobs@apple.com"))
+	})
+
+	t.Run("Verify_invalid", func(t *testing.T) {
+		_, err := s.Verify(ctx, "forged")
+		assert.ErrorIs(t, err, ErrInvalidVerificationToken)
+
+		// an access token of the same key is no verification token
+		access, err := keySet.Sign(&jwt.Claims{ID: "1", Subject: "Wozniak", Issuer: "walk", Type: TokenTypeAccess, ExpiresAt: time.Now().Add(time.Hour).Unix()})
+		assert.Nil(t, err)
+		_, err = s.Verify(ctx, access)
+		assert.ErrorIs(t, err, ErrInvalidVerificationToken)
+	})
+
+	t.Run("Verify_email_changed", func(t *testing.T) {
+		assert.Nil(t, s.Send(ctx, user))
+
+		changed := *user
+		changed.Email = "steve@apple.com"
+		mockUserRepository.EXPECT().FindByUsername(ctx, "Wozniak").Return(&changed, nil)
+
+		_, err := s.Verify(ctx, token)
+		assert.ErrorIs(t, err, ErrInvalidVerificationToken)
+	})
+
+	t.Run("Verify", func(t *testing.T) {
+		assert.Nil(t, s.Send(ctx, user))
+
+		mockUserRepository.EXPECT().FindByUsername(ctx, "Wozniak").Return(user, nil)
+		mockUserRepository.EXPECT().MarkEmailVerified(ctx, user.ID, "woz@apple.com", gomock.Any()).Return(nil)
+
+		m, err := s.Verify(ctx, token)
+		assert.Nil(t, err)
+		assert.True(t, m.IsEmailVerified())
+	})
+}
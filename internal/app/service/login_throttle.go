@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	loginThrottleScopeUser string = "user"
+	loginThrottleScopeIP   string = "ip"
+)
+
+// ErrLoginThrottled ...
+var ErrLoginThrottled = errors.New("too many failed attempts")
+
+var loginLockouts = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "auth_login_lockouts_total",
+		Help: "Number of temporary lockouts after repeated failed attempts",
+	},
+	[]string{"scope"},
+)
+
+var loginThrottled = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "auth_login_throttled_total",
+		Help: "Number of attempts rejected while delayed or locked out",
+	},
+	[]string{"scope"},
+)
+
+func init() {
+	prometheus.MustRegister(loginLockouts, loginThrottled)
+}
+
+// LoginAttemptRepositoryInterface ...
+type LoginAttemptRepositoryInterface interface {
+	Incr(ctx context.Context, key string, window time.Duration) (int64, error)
+	Block(ctx context.Context, key string, duration time.Duration) error
+	BlockedFor(ctx context.Context, key string) (time.Duration, error)
+	Reset(ctx context.Context, key string) error
+}
+
+// LoginThrottleLimits failures allowed per key before the delay and the lockout
+type LoginThrottleLimits struct {
+	// FreeAttempts failures without delay
+	FreeAttempts int64
+	// MaxAttempts failures before the lockout
+	MaxAttempts int64
+}
+
+// LoginThrottleConfig ...
+type LoginThrottleConfig struct {
+	User LoginThrottleLimits
+	IP   LoginThrottleLimits
+	// BaseDelay delay after the first failure over FreeAttempts, doubled by every next failure
+	BaseDelay time.Duration
+	Lockout   time.Duration
+	// Window failures are counted within
+	Window time.Duration
+	// Allowlist IPs and CIDRs never throttled
+	Allowlist []string
+}
+
+// ThrottledError attempt rejected, retry after RetryAfter
+type ThrottledError struct {
+	RetryAfter time.Duration
+	// Err ErrLoginThrottled when nil
+	Err error
+}
+
+// Error ...
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", e.Unwrap(), e.RetryAfter.Round(time.Second))
+}
+
+// Unwrap ...
+func (e *ThrottledError) Unwrap() error {
+	if e.Err == nil {
+		return ErrLoginThrottled
+	}
+	return e.Err
+}
+
+// DefaultLoginThrottleService progressive delays and temporary lockouts per username and per IP
+type DefaultLoginThrottleService struct {
+	attemptRepo LoginAttemptRepositoryInterface
+	cfg         LoginThrottleConfig
+	allowlist   []*net.IPNet
+}
+
+// NewDefaultLoginThrottleService create new default login throttle service
+func NewDefaultLoginThrottleService(attemptRepo LoginAttemptRepositoryInterface, cfg LoginThrottleConfig) (*DefaultLoginThrottleService, error) {
+
+	allowlist, err := parseAllowlist(cfg.Allowlist)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DefaultLoginThrottleService{
+		attemptRepo: attemptRepo,
+		cfg:         cfg,
+		allowlist:   allowlist,
+	}, nil
+}
+
+// Check *ThrottledError while the username or the IP is delayed or locked out, empty username checks the IP only
+func (s *DefaultLoginThrottleService) Check(ctx context.Context, username string, ip string) error {
+
+	if s.allowed(ip) {
+		return nil
+	}
+
+	var retryAfter time.Duration
+	for _, key := range s.keys(username, ip) {
+		blockedFor, err := s.attemptRepo.BlockedFor(ctx, key.key)
+		if err != nil {
+			return err
+		}
+		if blockedFor > 0 {
+			loginThrottled.WithLabelValues(key.scope).Inc()
+		}
+		if blockedFor > retryAfter {
+			retryAfter = blockedFor
+		}
+	}
+
+	if retryAfter > 0 {
+		return &ThrottledError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// Fail count a failed attempt and delay or lock out the username and the IP
+func (s *DefaultLoginThrottleService) Fail(ctx context.Context, username string, ip string) error {
+
+	if s.allowed(ip) {
+		return nil
+	}
+
+	for _, key := range s.keys(username, ip) {
+		failures, err := s.attemptRepo.Incr(ctx, key.key, s.cfg.Window)
+		if err != nil {
+			return err
+		}
+
+		delay := s.delay(failures, key.limits)
+		if delay == 0 {
+			continue
+		}
+		if delay == s.cfg.Lockout {
+			loginLockouts.WithLabelValues(key.scope).Inc()
+		}
+		if err := s.attemptRepo.Block(ctx, key.key, delay); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Succeed reset the username counter, the IP counter keeps counting
+func (s *DefaultLoginThrottleService) Succeed(ctx context.Context, username string, ip string) error {
+
+	if username == "" {
+		return nil
+	}
+	return s.attemptRepo.Reset(ctx, s.keys(username, "")[0].key)
+}
+
+// delay after the failures, Lockout from MaxAttempts on
+func (s *DefaultLoginThrottleService) delay(failures int64, limits LoginThrottleLimits) time.Duration {
+
+	if failures >= limits.MaxAttempts {
+		return s.cfg.Lockout
+	}
+	if failures <= limits.FreeAttempts {
+		return 0
+	}
+
+	delay := s.cfg.BaseDelay
+	for i := limits.FreeAttempts + 1; i < failures && delay < s.cfg.Lockout; i++ {
+		delay *= 2
+	}
+	if delay > s.cfg.Lockout {
+		return s.cfg.Lockout
+	}
+	return delay
+}
+
+type loginThrottleKey struct {
+	scope  string
+	key    string
+	limits LoginThrottleLimits
+}
+
+func (s *DefaultLoginThrottleService) keys(username string, ip string) []loginThrottleKey {
+
+	keys := make([]loginThrottleKey, 0, 2)
+	if username != "" {
+		keys = append(keys, loginThrottleKey{
+			scope:  loginThrottleScopeUser,
+			key:    loginThrottleScopeUser + ":" + strings.ToLower(username),
+			limits: s.cfg.User,
+		})
+	}
+	if ip != "" {
+		keys = append(keys, loginThrottleKey{
+			scope:  loginThrottleScopeIP,
+			key:    loginThrottleScopeIP + ":" + ip,
+			limits: s.cfg.IP,
+		})
+	}
+	return keys
+}
+
+func (s *DefaultLoginThrottleService) allowed(ip string) bool {
+	return allowlisted(s.allowlist, ip)
+}
+
+// parseAllowlist IPs and CIDRs, an IP is a single address network
+func parseAllowlist(values []string) ([]*net.IPNet, error) {
+
+	allowlist := make([]*net.IPNet, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if !strings.Contains(v, "/") {
+			if ip := net.ParseIP(v); ip != nil && ip.To4() != nil {
+				v += "/32"
+			} else {
+				v += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(v)
+		if err != nil {
+			return nil, err
+		}
+		allowlist = append(allowlist, ipNet)
+	}
+
+	return allowlist, nil
+}
+
+func allowlisted(allowlist []*net.IPNet, ip string) bool {
+
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, ipNet := range allowlist {
+		if ipNet.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockService "walk_backend/internal/app/service/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginThrottleService(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockAttemptRepository := mockService.NewMockLoginAttemptRepositoryInterface(controller)
+
+	ctx := context.Background()
+	s, err := NewDefaultLoginThrottleService(mockAttemptRepository, LoginThrottleConfig{
+		User:      LoginThrottleLimits{FreeAttempts: 3, MaxAttempts: 10},
+		IP:        LoginThrottleLimits{FreeAttempts: 10, MaxAttempts: 100},
+		BaseDelay: time.Second,
+		Lockout:   15 * time.Minute,
+		Window:    15 * time.Minute,
+		Allowlist: []string{"10.0.0.0/8", "127.0.0.1"},
+	})
+	assert.Nil(t, err)
+
+	t.Run("Delays", func(t *testing.T) {
+		limits := LoginThrottleLimits{FreeAttempts: 3, MaxAttempts: 10}
+		assert.Equal(t, time.Duration(0), s.delay(3, limits))
+		assert.Equal(t, time.Second, s.delay(4, limits))
+		assert.Equal(t, 2*time.Second, s.delay(5, limits))
+		assert.Equal(t, 32*time.Second, s.delay(9, limits))
+		assert.Equal(t, 15*time.Minute, s.delay(10, limits))
+	})
+
+	t.Run("Fail_blocks", func(t *testing.T) {
+		mockAttemptRepository.EXPECT().Incr(ctx, "user:wozniak", 15*time.Minute).Return(int64(10), nil)
+		mockAttemptRepository.EXPECT().Block(ctx, "user:wozniak", 15*time.Minute).Return(nil)
+		mockAttemptRepository.EXPECT().Incr(ctx, "ip:192.0.2.1", 15*time.Minute).Return(int64(4), nil)
+
+		assert.Nil(t, s.Fail(ctx, "Wozniak", "192.0.2.1"))
+	})
+
+	t.Run("Check_longest_block", func(t *testing.T) {
+		mockAttemptRepository.EXPECT().BlockedFor(ctx, "user:wozniak").Return(time.Minute, nil)
+		mockAttemptRepository.EXPECT().BlockedFor(ctx, "ip:192.0.2.1").Return(time.Second, nil)
+
+		err := s.Check(ctx, "Wozniak", "192.0.2.1")
+		assert.ErrorIs(t, err, ErrLoginThrottled)
+		assert.Equal(t, time.Minute, err.(*ThrottledError).RetryAfter)
+	})
+
+	t.Run("Allowlist", func(t *testing.T) {
+		// no repository calls
+		assert.Nil(t, s.Check(ctx, "Wozniak", "10.1.2.3"))
+		assert.Nil(t, s.Fail(ctx, "Wozniak", "127.0.0.1"))
+	})
+
+	t.Run("Invalid_allowlist", func(t *testing.T) {
+		_, err := NewDefaultLoginThrottleService(mockAttemptRepository, LoginThrottleConfig{Allowlist: []string{"internal"}})
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/api_key.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAPIKeyRepositoryInterface is a mock of APIKeyRepositoryInterface interface.
+type MockAPIKeyRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyRepositoryInterfaceMockRecorder
+}
+
+// MockAPIKeyRepositoryInterfaceMockRecorder is the mock recorder for MockAPIKeyRepositoryInterface.
+type MockAPIKeyRepositoryInterfaceMockRecorder struct {
+	mock *MockAPIKeyRepositoryInterface
+}
+
+// NewMockAPIKeyRepositoryInterface creates a new mock instance.
+func NewMockAPIKeyRepositoryInterface(ctrl *gomock.Controller) *MockAPIKeyRepositoryInterface {
+	mock := &MockAPIKeyRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyRepositoryInterface) EXPECT() *MockAPIKeyRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m_2 *MockAPIKeyRepositoryInterface) Create(ctx context.Context, m *model.APIKey) (model.ID, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Create", ctx, m)
+	ret0, _ := ret[0].(model.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPIKeyRepositoryInterfaceMockRecorder) Create(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIKeyRepositoryInterface)(nil).Create), ctx, m)
+}
+
+// FindActiveByUsername mocks base method.
+func (m *MockAPIKeyRepositoryInterface) FindActiveByUsername(ctx context.Context, username string, now time.Time) ([]*model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveByUsername", ctx, username, now)
+	ret0, _ := ret[0].([]*model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveByUsername indicates an expected call of FindActiveByUsername.
+func (mr *MockAPIKeyRepositoryInterfaceMockRecorder) FindActiveByUsername(ctx, username, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveByUsername", reflect.TypeOf((*MockAPIKeyRepositoryInterface)(nil).FindActiveByUsername), ctx, username, now)
+}
+
+// FindByHash mocks base method.
+func (m *MockAPIKeyRepositoryInterface) FindByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByHash", ctx, keyHash)
+	ret0, _ := ret[0].(*model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByHash indicates an expected call of FindByHash.
+func (mr *MockAPIKeyRepositoryInterfaceMockRecorder) FindByHash(ctx, keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHash", reflect.TypeOf((*MockAPIKeyRepositoryInterface)(nil).FindByHash), ctx, keyHash)
+}
+
+// Revoke mocks base method.
+func (m *MockAPIKeyRepositoryInterface) Revoke(ctx context.Context, id model.ID, username string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id, username, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAPIKeyRepositoryInterfaceMockRecorder) Revoke(ctx, id, username, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAPIKeyRepositoryInterface)(nil).Revoke), ctx, id, username, revokedAt)
+}
+
+// RevokeByUsername mocks base method.
+func (m *MockAPIKeyRepositoryInterface) RevokeByUsername(ctx context.Context, username string, revokedAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeByUsername", ctx, username, revokedAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeByUsername indicates an expected call of RevokeByUsername.
+func (mr *MockAPIKeyRepositoryInterfaceMockRecorder) RevokeByUsername(ctx, username, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeByUsername", reflect.TypeOf((*MockAPIKeyRepositoryInterface)(nil).RevokeByUsername), ctx, username, revokedAt)
+}
+
+// Touch mocks base method.
+func (m *MockAPIKeyRepositoryInterface) Touch(ctx context.Context, id model.ID, lastUsedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", ctx, id, lastUsedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockAPIKeyRepositoryInterfaceMockRecorder) Touch(ctx, id, lastUsedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockAPIKeyRepositoryInterface)(nil).Touch), ctx, id, lastUsedAt)
+}
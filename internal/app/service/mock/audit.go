@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/audit.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuditEventRepositoryInterface is a mock of AuditEventRepositoryInterface interface.
+type MockAuditEventRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditEventRepositoryInterfaceMockRecorder
+}
+
+// MockAuditEventRepositoryInterfaceMockRecorder is the mock recorder for MockAuditEventRepositoryInterface.
+type MockAuditEventRepositoryInterfaceMockRecorder struct {
+	mock *MockAuditEventRepositoryInterface
+}
+
+// NewMockAuditEventRepositoryInterface creates a new mock instance.
+func NewMockAuditEventRepositoryInterface(ctrl *gomock.Controller) *MockAuditEventRepositoryInterface {
+	mock := &MockAuditEventRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockAuditEventRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditEventRepositoryInterface) EXPECT() *MockAuditEventRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateMany mocks base method.
+func (m *MockAuditEventRepositoryInterface) CreateMany(ctx context.Context, events []*model.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMany", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMany indicates an expected call of CreateMany.
+func (mr *MockAuditEventRepositoryInterfaceMockRecorder) CreateMany(ctx, events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMany", reflect.TypeOf((*MockAuditEventRepositoryInterface)(nil).CreateMany), ctx, events)
+}
+
+// Find mocks base method.
+func (m *MockAuditEventRepositoryInterface) Find(ctx context.Context, filter *model.AuditEventFilter, offset, limit int64) ([]*model.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, filter, offset, limit)
+	ret0, _ := ret[0].([]*model.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockAuditEventRepositoryInterfaceMockRecorder) Find(ctx, filter, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockAuditEventRepositoryInterface)(nil).Find), ctx, filter, offset, limit)
+}
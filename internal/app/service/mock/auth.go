@@ -50,6 +50,21 @@ func (mr *MockUserRepositoryInterfaceMockRecorder) Create(ctx, m interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserRepositoryInterface)(nil).Create), ctx, m)
 }
 
+// FindByEmail mocks base method.
+func (m *MockUserRepositoryInterface) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEmail", ctx, email)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByEmail indicates an expected call of FindByEmail.
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindByEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByEmail), ctx, email)
+}
+
 // FindByUsername mocks base method.
 func (m *MockUserRepositoryInterface) FindByUsername(ctx context.Context, username string) (*model.User, error) {
 	m.ctrl.T.Helper()
@@ -64,3 +79,54 @@ func (mr *MockUserRepositoryInterfaceMockRecorder) FindByUsername(ctx, username
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsername", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByUsername), ctx, username)
 }
+
+// UpdatePassword mocks base method.
+func (m *MockUserRepositoryInterface) UpdatePassword(ctx context.Context, id model.ID, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, id, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserRepositoryInterfaceMockRecorder) UpdatePassword(ctx, id, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserRepositoryInterface)(nil).UpdatePassword), ctx, id, password)
+}
+
+// MockEmailVerificationSenderInterface is a mock of EmailVerificationSenderInterface interface.
+type MockEmailVerificationSenderInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailVerificationSenderInterfaceMockRecorder
+}
+
+// MockEmailVerificationSenderInterfaceMockRecorder is the mock recorder for MockEmailVerificationSenderInterface.
+type MockEmailVerificationSenderInterfaceMockRecorder struct {
+	mock *MockEmailVerificationSenderInterface
+}
+
+// NewMockEmailVerificationSenderInterface creates a new mock instance.
+func NewMockEmailVerificationSenderInterface(ctrl *gomock.Controller) *MockEmailVerificationSenderInterface {
+	mock := &MockEmailVerificationSenderInterface{ctrl: ctrl}
+	mock.recorder = &MockEmailVerificationSenderInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailVerificationSenderInterface) EXPECT() *MockEmailVerificationSenderInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockEmailVerificationSenderInterface) Send(ctx context.Context, user *model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockEmailVerificationSenderInterfaceMockRecorder) Send(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockEmailVerificationSenderInterface)(nil).Send), ctx, user)
+}
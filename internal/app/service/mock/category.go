@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 	model "walk_backend/internal/app/model"
 
 	gomock "github.com/golang/mock/gomock"
@@ -64,8 +65,74 @@ func (mr *MockCategoryRepositoryInterfaceMockRecorder) Delete(ctx, id interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryRepositoryInterface)(nil).Delete), ctx, id)
 }
 
+// FindAll mocks base method.
+func (m *MockCategoryRepositoryInterface) FindAll(ctx context.Context) (model.CategoryList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].(model.CategoryList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockCategoryRepositoryInterfaceMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCategoryRepositoryInterface)(nil).FindAll), ctx)
+}
+
+// Update mocks base method.
+func (m_2 *MockCategoryRepositoryInterface) Update(ctx context.Context, m *model.Category) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Update", ctx, m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCategoryRepositoryInterfaceMockRecorder) Update(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCategoryRepositoryInterface)(nil).Update), ctx, m)
+}
+
+// UpdateOrders mocks base method.
+func (m *MockCategoryRepositoryInterface) UpdateOrders(ctx context.Context, ids []model.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrders", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrders indicates an expected call of UpdateOrders.
+func (mr *MockCategoryRepositoryInterfaceMockRecorder) UpdateOrders(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrders", reflect.TypeOf((*MockCategoryRepositoryInterface)(nil).UpdateOrders), ctx, ids)
+}
+
+// MockCategoryCacheRepositoryInterface is a mock of CategoryCacheRepositoryInterface interface.
+type MockCategoryCacheRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryCacheRepositoryInterfaceMockRecorder
+}
+
+// MockCategoryCacheRepositoryInterfaceMockRecorder is the mock recorder for MockCategoryCacheRepositoryInterface.
+type MockCategoryCacheRepositoryInterfaceMockRecorder struct {
+	mock *MockCategoryCacheRepositoryInterface
+}
+
+// NewMockCategoryCacheRepositoryInterface creates a new mock instance.
+func NewMockCategoryCacheRepositoryInterface(ctrl *gomock.Controller) *MockCategoryCacheRepositoryInterface {
+	mock := &MockCategoryCacheRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockCategoryCacheRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryCacheRepositoryInterface) EXPECT() *MockCategoryCacheRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
 // Find mocks base method.
-func (m *MockCategoryRepositoryInterface) Find(ctx context.Context, id model.ID) (*model.Category, error) {
+func (m *MockCategoryCacheRepositoryInterface) Find(ctx context.Context, id model.ID) (*model.Category, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Find", ctx, id)
 	ret0, _ := ret[0].(*model.Category)
@@ -74,13 +141,13 @@ func (m *MockCategoryRepositoryInterface) Find(ctx context.Context, id model.ID)
 }
 
 // Find indicates an expected call of Find.
-func (mr *MockCategoryRepositoryInterfaceMockRecorder) Find(ctx, id interface{}) *gomock.Call {
+func (mr *MockCategoryCacheRepositoryInterfaceMockRecorder) Find(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockCategoryRepositoryInterface)(nil).Find), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockCategoryCacheRepositoryInterface)(nil).Find), ctx, id)
 }
 
 // FindAll mocks base method.
-func (m *MockCategoryRepositoryInterface) FindAll(ctx context.Context) (model.CategoryList, error) {
+func (m *MockCategoryCacheRepositoryInterface) FindAll(ctx context.Context) (model.CategoryList, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindAll", ctx)
 	ret0, _ := ret[0].(model.CategoryList)
@@ -89,21 +156,207 @@ func (m *MockCategoryRepositoryInterface) FindAll(ctx context.Context) (model.Ca
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockCategoryRepositoryInterfaceMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+func (mr *MockCategoryCacheRepositoryInterfaceMockRecorder) FindAll(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCategoryRepositoryInterface)(nil).FindAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCategoryCacheRepositoryInterface)(nil).FindAll), ctx)
 }
 
-// Update mocks base method.
-func (m_2 *MockCategoryRepositoryInterface) Update(ctx context.Context, m *model.Category) error {
-	m_2.ctrl.T.Helper()
-	ret := m_2.ctrl.Call(m_2, "Update", ctx, m)
+// FindBySlug mocks base method.
+func (m *MockCategoryCacheRepositoryInterface) FindBySlug(ctx context.Context, slug string) (*model.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySlug", ctx, slug)
+	ret0, _ := ret[0].(*model.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySlug indicates an expected call of FindBySlug.
+func (mr *MockCategoryCacheRepositoryInterfaceMockRecorder) FindBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySlug", reflect.TypeOf((*MockCategoryCacheRepositoryInterface)(nil).FindBySlug), ctx, slug)
+}
+
+// Invalidate mocks base method.
+func (m *MockCategoryCacheRepositoryInterface) Invalidate(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invalidate", ctx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockCategoryRepositoryInterfaceMockRecorder) Update(ctx, m interface{}) *gomock.Call {
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockCategoryCacheRepositoryInterfaceMockRecorder) Invalidate(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCategoryRepositoryInterface)(nil).Update), ctx, m)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockCategoryCacheRepositoryInterface)(nil).Invalidate), ctx)
+}
+
+// MockCategoryPlaceRepositoryInterface is a mock of CategoryPlaceRepositoryInterface interface.
+type MockCategoryPlaceRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryPlaceRepositoryInterfaceMockRecorder
+}
+
+// MockCategoryPlaceRepositoryInterfaceMockRecorder is the mock recorder for MockCategoryPlaceRepositoryInterface.
+type MockCategoryPlaceRepositoryInterfaceMockRecorder struct {
+	mock *MockCategoryPlaceRepositoryInterface
+}
+
+// NewMockCategoryPlaceRepositoryInterface creates a new mock instance.
+func NewMockCategoryPlaceRepositoryInterface(ctrl *gomock.Controller) *MockCategoryPlaceRepositoryInterface {
+	mock := &MockCategoryPlaceRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockCategoryPlaceRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryPlaceRepositoryInterface) EXPECT() *MockCategoryPlaceRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CountByCategory mocks base method.
+func (m *MockCategoryPlaceRepositoryInterface) CountByCategory(ctx context.Context, categoryID model.ID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByCategory", ctx, categoryID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByCategory indicates an expected call of CountByCategory.
+func (mr *MockCategoryPlaceRepositoryInterfaceMockRecorder) CountByCategory(ctx, categoryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByCategory", reflect.TypeOf((*MockCategoryPlaceRepositoryInterface)(nil).CountByCategory), ctx, categoryID)
+}
+
+// CountPublishedByCategories mocks base method.
+func (m *MockCategoryPlaceRepositoryInterface) CountPublishedByCategories(ctx context.Context) (model.CategoryCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPublishedByCategories", ctx)
+	ret0, _ := ret[0].(model.CategoryCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPublishedByCategories indicates an expected call of CountPublishedByCategories.
+func (mr *MockCategoryPlaceRepositoryInterfaceMockRecorder) CountPublishedByCategories(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPublishedByCategories", reflect.TypeOf((*MockCategoryPlaceRepositoryInterface)(nil).CountPublishedByCategories), ctx)
+}
+
+// ReassignCategory mocks base method.
+func (m *MockCategoryPlaceRepositoryInterface) ReassignCategory(ctx context.Context, from, to model.ID) ([]model.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignCategory", ctx, from, to)
+	ret0, _ := ret[0].([]model.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignCategory indicates an expected call of ReassignCategory.
+func (mr *MockCategoryPlaceRepositoryInterfaceMockRecorder) ReassignCategory(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignCategory", reflect.TypeOf((*MockCategoryPlaceRepositoryInterface)(nil).ReassignCategory), ctx, from, to)
+}
+
+// MockCategoryCountCacheRepositoryInterface is a mock of CategoryCountCacheRepositoryInterface interface.
+type MockCategoryCountCacheRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryCountCacheRepositoryInterfaceMockRecorder
+}
+
+// MockCategoryCountCacheRepositoryInterfaceMockRecorder is the mock recorder for MockCategoryCountCacheRepositoryInterface.
+type MockCategoryCountCacheRepositoryInterfaceMockRecorder struct {
+	mock *MockCategoryCountCacheRepositoryInterface
+}
+
+// NewMockCategoryCountCacheRepositoryInterface creates a new mock instance.
+func NewMockCategoryCountCacheRepositoryInterface(ctrl *gomock.Controller) *MockCategoryCountCacheRepositoryInterface {
+	mock := &MockCategoryCountCacheRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockCategoryCountCacheRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryCountCacheRepositoryInterface) EXPECT() *MockCategoryCountCacheRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Del mocks base method.
+func (m *MockCategoryCountCacheRepositoryInterface) Del(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Del", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Del indicates an expected call of Del.
+func (mr *MockCategoryCountCacheRepositoryInterfaceMockRecorder) Del(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockCategoryCountCacheRepositoryInterface)(nil).Del), ctx)
+}
+
+// Get mocks base method.
+func (m *MockCategoryCountCacheRepositoryInterface) Get(ctx context.Context) (model.CategoryCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx)
+	ret0, _ := ret[0].(model.CategoryCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCategoryCountCacheRepositoryInterfaceMockRecorder) Get(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCategoryCountCacheRepositoryInterface)(nil).Get), ctx)
+}
+
+// Set mocks base method.
+func (m *MockCategoryCountCacheRepositoryInterface) Set(ctx context.Context, counts model.CategoryCounts, expiration time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, counts, expiration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockCategoryCountCacheRepositoryInterfaceMockRecorder) Set(ctx, counts, expiration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCategoryCountCacheRepositoryInterface)(nil).Set), ctx, counts, expiration)
+}
+
+// MockTransactionInterface is a mock of TransactionInterface interface.
+type MockTransactionInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionInterfaceMockRecorder
+}
+
+// MockTransactionInterfaceMockRecorder is the mock recorder for MockTransactionInterface.
+type MockTransactionInterfaceMockRecorder struct {
+	mock *MockTransactionInterface
+}
+
+// NewMockTransactionInterface creates a new mock instance.
+func NewMockTransactionInterface(ctrl *gomock.Controller) *MockTransactionInterface {
+	mock := &MockTransactionInterface{ctrl: ctrl}
+	mock.recorder = &MockTransactionInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionInterface) EXPECT() *MockTransactionInterfaceMockRecorder {
+	return m.recorder
+}
+
+// WithTransaction mocks base method.
+func (m *MockTransactionInterface) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTransaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTransaction indicates an expected call of WithTransaction.
+func (mr *MockTransactionInterfaceMockRecorder) WithTransaction(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTransaction", reflect.TypeOf((*MockTransactionInterface)(nil).WithTransaction), ctx, fn)
 }
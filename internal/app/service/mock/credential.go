@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/credential.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCredentialSessionServiceInterface is a mock of CredentialSessionServiceInterface interface.
+type MockCredentialSessionServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCredentialSessionServiceInterfaceMockRecorder
+}
+
+// MockCredentialSessionServiceInterfaceMockRecorder is the mock recorder for MockCredentialSessionServiceInterface.
+type MockCredentialSessionServiceInterfaceMockRecorder struct {
+	mock *MockCredentialSessionServiceInterface
+}
+
+// NewMockCredentialSessionServiceInterface creates a new mock instance.
+func NewMockCredentialSessionServiceInterface(ctrl *gomock.Controller) *MockCredentialSessionServiceInterface {
+	mock := &MockCredentialSessionServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockCredentialSessionServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCredentialSessionServiceInterface) EXPECT() *MockCredentialSessionServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// RevokeAll mocks base method.
+func (m *MockCredentialSessionServiceInterface) RevokeAll(ctx context.Context, username string, exceptSessionID model.ID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, username, exceptSessionID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockCredentialSessionServiceInterfaceMockRecorder) RevokeAll(ctx, username, exceptSessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockCredentialSessionServiceInterface)(nil).RevokeAll), ctx, username, exceptSessionID)
+}
+
+// MockCredentialTokenServiceInterface is a mock of CredentialTokenServiceInterface interface.
+type MockCredentialTokenServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCredentialTokenServiceInterfaceMockRecorder
+}
+
+// MockCredentialTokenServiceInterfaceMockRecorder is the mock recorder for MockCredentialTokenServiceInterface.
+type MockCredentialTokenServiceInterfaceMockRecorder struct {
+	mock *MockCredentialTokenServiceInterface
+}
+
+// NewMockCredentialTokenServiceInterface creates a new mock instance.
+func NewMockCredentialTokenServiceInterface(ctrl *gomock.Controller) *MockCredentialTokenServiceInterface {
+	mock := &MockCredentialTokenServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockCredentialTokenServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCredentialTokenServiceInterface) EXPECT() *MockCredentialTokenServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// RevokeAll mocks base method.
+func (m *MockCredentialTokenServiceInterface) RevokeAll(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockCredentialTokenServiceInterfaceMockRecorder) RevokeAll(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockCredentialTokenServiceInterface)(nil).RevokeAll), ctx, username)
+}
+
+// MockCredentialAPIKeyServiceInterface is a mock of CredentialAPIKeyServiceInterface interface.
+type MockCredentialAPIKeyServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCredentialAPIKeyServiceInterfaceMockRecorder
+}
+
+// MockCredentialAPIKeyServiceInterfaceMockRecorder is the mock recorder for MockCredentialAPIKeyServiceInterface.
+type MockCredentialAPIKeyServiceInterfaceMockRecorder struct {
+	mock *MockCredentialAPIKeyServiceInterface
+}
+
+// NewMockCredentialAPIKeyServiceInterface creates a new mock instance.
+func NewMockCredentialAPIKeyServiceInterface(ctrl *gomock.Controller) *MockCredentialAPIKeyServiceInterface {
+	mock := &MockCredentialAPIKeyServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockCredentialAPIKeyServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCredentialAPIKeyServiceInterface) EXPECT() *MockCredentialAPIKeyServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// RevokeAll mocks base method.
+func (m *MockCredentialAPIKeyServiceInterface) RevokeAll(ctx context.Context, username string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, username)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockCredentialAPIKeyServiceInterfaceMockRecorder) RevokeAll(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockCredentialAPIKeyServiceInterface)(nil).RevokeAll), ctx, username)
+}
@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/email_verification.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEmailVerificationUserRepositoryInterface is a mock of EmailVerificationUserRepositoryInterface interface.
+type MockEmailVerificationUserRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailVerificationUserRepositoryInterfaceMockRecorder
+}
+
+// MockEmailVerificationUserRepositoryInterfaceMockRecorder is the mock recorder for MockEmailVerificationUserRepositoryInterface.
+type MockEmailVerificationUserRepositoryInterfaceMockRecorder struct {
+	mock *MockEmailVerificationUserRepositoryInterface
+}
+
+// NewMockEmailVerificationUserRepositoryInterface creates a new mock instance.
+func NewMockEmailVerificationUserRepositoryInterface(ctrl *gomock.Controller) *MockEmailVerificationUserRepositoryInterface {
+	mock := &MockEmailVerificationUserRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockEmailVerificationUserRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailVerificationUserRepositoryInterface) EXPECT() *MockEmailVerificationUserRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// FindByEmail mocks base method.
+func (m *MockEmailVerificationUserRepositoryInterface) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEmail", ctx, email)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByEmail indicates an expected call of FindByEmail.
+func (mr *MockEmailVerificationUserRepositoryInterfaceMockRecorder) FindByEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockEmailVerificationUserRepositoryInterface)(nil).FindByEmail), ctx, email)
+}
+
+// FindByUsername mocks base method.
+func (m *MockEmailVerificationUserRepositoryInterface) FindByUsername(ctx context.Context, username string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUsername", ctx, username)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUsername indicates an expected call of FindByUsername.
+func (mr *MockEmailVerificationUserRepositoryInterfaceMockRecorder) FindByUsername(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsername", reflect.TypeOf((*MockEmailVerificationUserRepositoryInterface)(nil).FindByUsername), ctx, username)
+}
+
+// MarkEmailVerified mocks base method.
+func (m *MockEmailVerificationUserRepositoryInterface) MarkEmailVerified(ctx context.Context, id model.ID, email string, verifiedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEmailVerified", ctx, id, email, verifiedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEmailVerified indicates an expected call of MarkEmailVerified.
+func (mr *MockEmailVerificationUserRepositoryInterfaceMockRecorder) MarkEmailVerified(ctx, id, email, verifiedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailVerified", reflect.TypeOf((*MockEmailVerificationUserRepositoryInterface)(nil).MarkEmailVerified), ctx, id, email, verifiedAt)
+}
+
+// MockEmailVerificationCooldownRepositoryInterface is a mock of EmailVerificationCooldownRepositoryInterface interface.
+type MockEmailVerificationCooldownRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailVerificationCooldownRepositoryInterfaceMockRecorder
+}
+
+// MockEmailVerificationCooldownRepositoryInterfaceMockRecorder is the mock recorder for MockEmailVerificationCooldownRepositoryInterface.
+type MockEmailVerificationCooldownRepositoryInterfaceMockRecorder struct {
+	mock *MockEmailVerificationCooldownRepositoryInterface
+}
+
+// NewMockEmailVerificationCooldownRepositoryInterface creates a new mock instance.
+func NewMockEmailVerificationCooldownRepositoryInterface(ctrl *gomock.Controller) *MockEmailVerificationCooldownRepositoryInterface {
+	mock := &MockEmailVerificationCooldownRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockEmailVerificationCooldownRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailVerificationCooldownRepositoryInterface) EXPECT() *MockEmailVerificationCooldownRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Block mocks base method.
+func (m *MockEmailVerificationCooldownRepositoryInterface) Block(ctx context.Context, key string, duration time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Block", ctx, key, duration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Block indicates an expected call of Block.
+func (mr *MockEmailVerificationCooldownRepositoryInterfaceMockRecorder) Block(ctx, key, duration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Block", reflect.TypeOf((*MockEmailVerificationCooldownRepositoryInterface)(nil).Block), ctx, key, duration)
+}
+
+// BlockedFor mocks base method.
+func (m *MockEmailVerificationCooldownRepositoryInterface) BlockedFor(ctx context.Context, key string) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockedFor", ctx, key)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockedFor indicates an expected call of BlockedFor.
+func (mr *MockEmailVerificationCooldownRepositoryInterfaceMockRecorder) BlockedFor(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockedFor", reflect.TypeOf((*MockEmailVerificationCooldownRepositoryInterface)(nil).BlockedFor), ctx, key)
+}
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/login_throttle.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLoginAttemptRepositoryInterface is a mock of LoginAttemptRepositoryInterface interface.
+type MockLoginAttemptRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginAttemptRepositoryInterfaceMockRecorder
+}
+
+// MockLoginAttemptRepositoryInterfaceMockRecorder is the mock recorder for MockLoginAttemptRepositoryInterface.
+type MockLoginAttemptRepositoryInterfaceMockRecorder struct {
+	mock *MockLoginAttemptRepositoryInterface
+}
+
+// NewMockLoginAttemptRepositoryInterface creates a new mock instance.
+func NewMockLoginAttemptRepositoryInterface(ctrl *gomock.Controller) *MockLoginAttemptRepositoryInterface {
+	mock := &MockLoginAttemptRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockLoginAttemptRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginAttemptRepositoryInterface) EXPECT() *MockLoginAttemptRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Block mocks base method.
+func (m *MockLoginAttemptRepositoryInterface) Block(ctx context.Context, key string, duration time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Block", ctx, key, duration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Block indicates an expected call of Block.
+func (mr *MockLoginAttemptRepositoryInterfaceMockRecorder) Block(ctx, key, duration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Block", reflect.TypeOf((*MockLoginAttemptRepositoryInterface)(nil).Block), ctx, key, duration)
+}
+
+// BlockedFor mocks base method.
+func (m *MockLoginAttemptRepositoryInterface) BlockedFor(ctx context.Context, key string) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockedFor", ctx, key)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockedFor indicates an expected call of BlockedFor.
+func (mr *MockLoginAttemptRepositoryInterfaceMockRecorder) BlockedFor(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockedFor", reflect.TypeOf((*MockLoginAttemptRepositoryInterface)(nil).BlockedFor), ctx, key)
+}
+
+// Incr mocks base method.
+func (m *MockLoginAttemptRepositoryInterface) Incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Incr", ctx, key, window)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Incr indicates an expected call of Incr.
+func (mr *MockLoginAttemptRepositoryInterfaceMockRecorder) Incr(ctx, key, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Incr", reflect.TypeOf((*MockLoginAttemptRepositoryInterface)(nil).Incr), ctx, key, window)
+}
+
+// Reset mocks base method.
+func (m *MockLoginAttemptRepositoryInterface) Reset(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockLoginAttemptRepositoryInterfaceMockRecorder) Reset(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockLoginAttemptRepositoryInterface)(nil).Reset), ctx, key)
+}
@@ -0,0 +1,179 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/oidc.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	model "walk_backend/internal/app/model"
+	oidc "walk_backend/internal/pkg/oidc"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOIDCProviderInterface is a mock of OIDCProviderInterface interface.
+type MockOIDCProviderInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOIDCProviderInterfaceMockRecorder
+}
+
+// MockOIDCProviderInterfaceMockRecorder is the mock recorder for MockOIDCProviderInterface.
+type MockOIDCProviderInterfaceMockRecorder struct {
+	mock *MockOIDCProviderInterface
+}
+
+// NewMockOIDCProviderInterface creates a new mock instance.
+func NewMockOIDCProviderInterface(ctrl *gomock.Controller) *MockOIDCProviderInterface {
+	mock := &MockOIDCProviderInterface{ctrl: ctrl}
+	mock.recorder = &MockOIDCProviderInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOIDCProviderInterface) EXPECT() *MockOIDCProviderInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AuthCodeURL mocks base method.
+func (m *MockOIDCProviderInterface) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthCodeURL", ctx, state, nonce, codeVerifier)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthCodeURL indicates an expected call of AuthCodeURL.
+func (mr *MockOIDCProviderInterfaceMockRecorder) AuthCodeURL(ctx, state, nonce, codeVerifier interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthCodeURL", reflect.TypeOf((*MockOIDCProviderInterface)(nil).AuthCodeURL), ctx, state, nonce, codeVerifier)
+}
+
+// Exchange mocks base method.
+func (m *MockOIDCProviderInterface) Exchange(ctx context.Context, code, codeVerifier string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exchange", ctx, code, codeVerifier)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exchange indicates an expected call of Exchange.
+func (mr *MockOIDCProviderInterfaceMockRecorder) Exchange(ctx, code, codeVerifier interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exchange", reflect.TypeOf((*MockOIDCProviderInterface)(nil).Exchange), ctx, code, codeVerifier)
+}
+
+// VerifyIDToken mocks base method.
+func (m *MockOIDCProviderInterface) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*oidc.IDToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyIDToken", ctx, rawIDToken, nonce)
+	ret0, _ := ret[0].(*oidc.IDToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyIDToken indicates an expected call of VerifyIDToken.
+func (mr *MockOIDCProviderInterfaceMockRecorder) VerifyIDToken(ctx, rawIDToken, nonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyIDToken", reflect.TypeOf((*MockOIDCProviderInterface)(nil).VerifyIDToken), ctx, rawIDToken, nonce)
+}
+
+// MockOIDCUserRepositoryInterface is a mock of OIDCUserRepositoryInterface interface.
+type MockOIDCUserRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOIDCUserRepositoryInterfaceMockRecorder
+}
+
+// MockOIDCUserRepositoryInterfaceMockRecorder is the mock recorder for MockOIDCUserRepositoryInterface.
+type MockOIDCUserRepositoryInterfaceMockRecorder struct {
+	mock *MockOIDCUserRepositoryInterface
+}
+
+// NewMockOIDCUserRepositoryInterface creates a new mock instance.
+func NewMockOIDCUserRepositoryInterface(ctrl *gomock.Controller) *MockOIDCUserRepositoryInterface {
+	mock := &MockOIDCUserRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockOIDCUserRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOIDCUserRepositoryInterface) EXPECT() *MockOIDCUserRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddIdentity mocks base method.
+func (m *MockOIDCUserRepositoryInterface) AddIdentity(ctx context.Context, id model.ID, identity model.Identity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddIdentity", ctx, id, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddIdentity indicates an expected call of AddIdentity.
+func (mr *MockOIDCUserRepositoryInterfaceMockRecorder) AddIdentity(ctx, id, identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddIdentity", reflect.TypeOf((*MockOIDCUserRepositoryInterface)(nil).AddIdentity), ctx, id, identity)
+}
+
+// Create mocks base method.
+func (m_2 *MockOIDCUserRepositoryInterface) Create(ctx context.Context, m *model.User) (model.ID, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Create", ctx, m)
+	ret0, _ := ret[0].(model.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOIDCUserRepositoryInterfaceMockRecorder) Create(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOIDCUserRepositoryInterface)(nil).Create), ctx, m)
+}
+
+// FindByEmail mocks base method.
+func (m *MockOIDCUserRepositoryInterface) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEmail", ctx, email)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByEmail indicates an expected call of FindByEmail.
+func (mr *MockOIDCUserRepositoryInterfaceMockRecorder) FindByEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockOIDCUserRepositoryInterface)(nil).FindByEmail), ctx, email)
+}
+
+// FindByIdentity mocks base method.
+func (m *MockOIDCUserRepositoryInterface) FindByIdentity(ctx context.Context, provider, subject string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIdentity", ctx, provider, subject)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIdentity indicates an expected call of FindByIdentity.
+func (mr *MockOIDCUserRepositoryInterfaceMockRecorder) FindByIdentity(ctx, provider, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdentity", reflect.TypeOf((*MockOIDCUserRepositoryInterface)(nil).FindByIdentity), ctx, provider, subject)
+}
+
+// FindByUsername mocks base method.
+func (m *MockOIDCUserRepositoryInterface) FindByUsername(ctx context.Context, username string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUsername", ctx, username)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUsername indicates an expected call of FindByUsername.
+func (mr *MockOIDCUserRepositoryInterfaceMockRecorder) FindByUsername(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsername", reflect.TypeOf((*MockOIDCUserRepositoryInterface)(nil).FindByUsername), ctx, username)
+}
@@ -0,0 +1,200 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/password_reset.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	model "walk_backend/internal/app/model"
+	mailer "walk_backend/internal/pkg/mailer"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPasswordResetTokenRepositoryInterface is a mock of PasswordResetTokenRepositoryInterface interface.
+type MockPasswordResetTokenRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordResetTokenRepositoryInterfaceMockRecorder
+}
+
+// MockPasswordResetTokenRepositoryInterfaceMockRecorder is the mock recorder for MockPasswordResetTokenRepositoryInterface.
+type MockPasswordResetTokenRepositoryInterfaceMockRecorder struct {
+	mock *MockPasswordResetTokenRepositoryInterface
+}
+
+// NewMockPasswordResetTokenRepositoryInterface creates a new mock instance.
+func NewMockPasswordResetTokenRepositoryInterface(ctrl *gomock.Controller) *MockPasswordResetTokenRepositoryInterface {
+	mock := &MockPasswordResetTokenRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetTokenRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordResetTokenRepositoryInterface) EXPECT() *MockPasswordResetTokenRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m_2 *MockPasswordResetTokenRepositoryInterface) Create(ctx context.Context, m *model.PasswordResetToken) (model.ID, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Create", ctx, m)
+	ret0, _ := ret[0].(model.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPasswordResetTokenRepositoryInterfaceMockRecorder) Create(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPasswordResetTokenRepositoryInterface)(nil).Create), ctx, m)
+}
+
+// FindByHash mocks base method.
+func (m *MockPasswordResetTokenRepositoryInterface) FindByHash(ctx context.Context, tokenHash string) (*model.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*model.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByHash indicates an expected call of FindByHash.
+func (mr *MockPasswordResetTokenRepositoryInterfaceMockRecorder) FindByHash(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHash", reflect.TypeOf((*MockPasswordResetTokenRepositoryInterface)(nil).FindByHash), ctx, tokenHash)
+}
+
+// InvalidateByUsername mocks base method.
+func (m *MockPasswordResetTokenRepositoryInterface) InvalidateByUsername(ctx context.Context, username string, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateByUsername", ctx, username, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateByUsername indicates an expected call of InvalidateByUsername.
+func (mr *MockPasswordResetTokenRepositoryInterfaceMockRecorder) InvalidateByUsername(ctx, username, usedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateByUsername", reflect.TypeOf((*MockPasswordResetTokenRepositoryInterface)(nil).InvalidateByUsername), ctx, username, usedAt)
+}
+
+// MarkUsed mocks base method.
+func (m *MockPasswordResetTokenRepositoryInterface) MarkUsed(ctx context.Context, id model.ID, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockPasswordResetTokenRepositoryInterfaceMockRecorder) MarkUsed(ctx, id, usedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockPasswordResetTokenRepositoryInterface)(nil).MarkUsed), ctx, id, usedAt)
+}
+
+// MockPasswordResetUserRepositoryInterface is a mock of PasswordResetUserRepositoryInterface interface.
+type MockPasswordResetUserRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordResetUserRepositoryInterfaceMockRecorder
+}
+
+// MockPasswordResetUserRepositoryInterfaceMockRecorder is the mock recorder for MockPasswordResetUserRepositoryInterface.
+type MockPasswordResetUserRepositoryInterfaceMockRecorder struct {
+	mock *MockPasswordResetUserRepositoryInterface
+}
+
+// NewMockPasswordResetUserRepositoryInterface creates a new mock instance.
+func NewMockPasswordResetUserRepositoryInterface(ctrl *gomock.Controller) *MockPasswordResetUserRepositoryInterface {
+	mock := &MockPasswordResetUserRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetUserRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordResetUserRepositoryInterface) EXPECT() *MockPasswordResetUserRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// FindByEmail mocks base method.
+func (m *MockPasswordResetUserRepositoryInterface) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEmail", ctx, email)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByEmail indicates an expected call of FindByEmail.
+func (mr *MockPasswordResetUserRepositoryInterfaceMockRecorder) FindByEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockPasswordResetUserRepositoryInterface)(nil).FindByEmail), ctx, email)
+}
+
+// FindByUsername mocks base method.
+func (m *MockPasswordResetUserRepositoryInterface) FindByUsername(ctx context.Context, username string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUsername", ctx, username)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUsername indicates an expected call of FindByUsername.
+func (mr *MockPasswordResetUserRepositoryInterfaceMockRecorder) FindByUsername(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsername", reflect.TypeOf((*MockPasswordResetUserRepositoryInterface)(nil).FindByUsername), ctx, username)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockPasswordResetUserRepositoryInterface) UpdatePassword(ctx context.Context, id model.ID, passwordHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, id, passwordHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockPasswordResetUserRepositoryInterfaceMockRecorder) UpdatePassword(ctx, id, passwordHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockPasswordResetUserRepositoryInterface)(nil).UpdatePassword), ctx, id, passwordHash)
+}
+
+// MockMailerInterface is a mock of MailerInterface interface.
+type MockMailerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockMailerInterfaceMockRecorder
+}
+
+// MockMailerInterfaceMockRecorder is the mock recorder for MockMailerInterface.
+type MockMailerInterfaceMockRecorder struct {
+	mock *MockMailerInterface
+}
+
+// NewMockMailerInterface creates a new mock instance.
+func NewMockMailerInterface(ctrl *gomock.Controller) *MockMailerInterface {
+	mock := &MockMailerInterface{ctrl: ctrl}
+	mock.recorder = &MockMailerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMailerInterface) EXPECT() *MockMailerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m_2 *MockMailerInterface) Send(ctx context.Context, m *mailer.Message) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Send", ctx, m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockMailerInterfaceMockRecorder) Send(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockMailerInterface)(nil).Send), ctx, m)
+}
@@ -36,6 +36,21 @@ func (m *MockPlaceRepositoryInterface) EXPECT() *MockPlaceRepositoryInterfaceMoc
 	return m.recorder
 }
 
+// CountPublishedByCategory mocks base method.
+func (m *MockPlaceRepositoryInterface) CountPublishedByCategory(ctx context.Context, categoryID model.ID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPublishedByCategory", ctx, categoryID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPublishedByCategory indicates an expected call of CountPublishedByCategory.
+func (mr *MockPlaceRepositoryInterfaceMockRecorder) CountPublishedByCategory(ctx, categoryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPublishedByCategory", reflect.TypeOf((*MockPlaceRepositoryInterface)(nil).CountPublishedByCategory), ctx, categoryID)
+}
+
 // Create mocks base method.
 func (m_2 *MockPlaceRepositoryInterface) Create(ctx context.Context, m *model.Place) (model.ID, error) {
 	m_2.ctrl.T.Helper()
@@ -95,19 +110,34 @@ func (mr *MockPlaceRepositoryInterfaceMockRecorder) FindAll(ctx interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPlaceRepositoryInterface)(nil).FindAll), ctx)
 }
 
+// FindPublishedByCategory mocks base method.
+func (m *MockPlaceRepositoryInterface) FindPublishedByCategory(ctx context.Context, categoryID model.ID, offset, limit int64) (model.PlaceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPublishedByCategory", ctx, categoryID, offset, limit)
+	ret0, _ := ret[0].(model.PlaceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPublishedByCategory indicates an expected call of FindPublishedByCategory.
+func (mr *MockPlaceRepositoryInterfaceMockRecorder) FindPublishedByCategory(ctx, categoryID, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPublishedByCategory", reflect.TypeOf((*MockPlaceRepositoryInterface)(nil).FindPublishedByCategory), ctx, categoryID, offset, limit)
+}
+
 // Search mocks base method.
-func (m *MockPlaceRepositoryInterface) Search(ctx context.Context, search string) (model.PlaceList, error) {
+func (m *MockPlaceRepositoryInterface) Search(ctx context.Context, criteria *model.PlaceSearchCriteria) (model.PlaceList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", ctx, search)
+	ret := m.ctrl.Call(m, "Search", ctx, criteria)
 	ret0, _ := ret[0].(model.PlaceList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Search indicates an expected call of Search.
-func (mr *MockPlaceRepositoryInterfaceMockRecorder) Search(ctx, search interface{}) *gomock.Call {
+func (mr *MockPlaceRepositoryInterfaceMockRecorder) Search(ctx, criteria interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockPlaceRepositoryInterface)(nil).Search), ctx, search)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockPlaceRepositoryInterface)(nil).Search), ctx, criteria)
 }
 
 // Update mocks base method.
@@ -177,6 +207,43 @@ func (mr *MockPlaceCategoryRepositoryInterfaceMockRecorder) FindAll(ctx interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPlaceCategoryRepositoryInterface)(nil).FindAll), ctx)
 }
 
+// MockPlaceTagRepositoryInterface is a mock of PlaceTagRepositoryInterface interface.
+type MockPlaceTagRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlaceTagRepositoryInterfaceMockRecorder
+}
+
+// MockPlaceTagRepositoryInterfaceMockRecorder is the mock recorder for MockPlaceTagRepositoryInterface.
+type MockPlaceTagRepositoryInterfaceMockRecorder struct {
+	mock *MockPlaceTagRepositoryInterface
+}
+
+// NewMockPlaceTagRepositoryInterface creates a new mock instance.
+func NewMockPlaceTagRepositoryInterface(ctrl *gomock.Controller) *MockPlaceTagRepositoryInterface {
+	mock := &MockPlaceTagRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockPlaceTagRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlaceTagRepositoryInterface) EXPECT() *MockPlaceTagRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Recount mocks base method.
+func (m *MockPlaceTagRepositoryInterface) Recount(ctx context.Context, names []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recount", ctx, names)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Recount indicates an expected call of Recount.
+func (mr *MockPlaceTagRepositoryInterfaceMockRecorder) Recount(ctx, names interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recount", reflect.TypeOf((*MockPlaceTagRepositoryInterface)(nil).Recount), ctx, names)
+}
+
 // MockPlaceQueueRepositoryInterface is a mock of PlaceQueueRepositoryInterface interface.
 type MockPlaceQueueRepositoryInterface struct {
 	ctrl     *gomock.Controller
@@ -256,6 +323,20 @@ func (mr *MockPlaceCacheRepositoryInterfaceMockRecorder) Del(ctx interface{}, ke
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockPlaceCacheRepositoryInterface)(nil).Del), varargs...)
 }
 
+// DelByPrefix mocks base method.
+func (m *MockPlaceCacheRepositoryInterface) DelByPrefix(ctx context.Context, prefix string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DelByPrefix", ctx, prefix)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DelByPrefix indicates an expected call of DelByPrefix.
+func (mr *MockPlaceCacheRepositoryInterfaceMockRecorder) DelByPrefix(ctx, prefix interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelByPrefix", reflect.TypeOf((*MockPlaceCacheRepositoryInterface)(nil).DelByPrefix), ctx, prefix)
+}
+
 // Get mocks base method.
 func (m *MockPlaceCacheRepositoryInterface) Get(ctx context.Context, key string) (model.PlaceList, error) {
 	m.ctrl.T.Helper()
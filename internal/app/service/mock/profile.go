@@ -0,0 +1,403 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/profile.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockProfileUserRepositoryInterface is a mock of ProfileUserRepositoryInterface interface.
+type MockProfileUserRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileUserRepositoryInterfaceMockRecorder
+}
+
+// MockProfileUserRepositoryInterfaceMockRecorder is the mock recorder for MockProfileUserRepositoryInterface.
+type MockProfileUserRepositoryInterfaceMockRecorder struct {
+	mock *MockProfileUserRepositoryInterface
+}
+
+// NewMockProfileUserRepositoryInterface creates a new mock instance.
+func NewMockProfileUserRepositoryInterface(ctrl *gomock.Controller) *MockProfileUserRepositoryInterface {
+	mock := &MockProfileUserRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockProfileUserRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileUserRepositoryInterface) EXPECT() *MockProfileUserRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CountByRole mocks base method.
+func (m *MockProfileUserRepositoryInterface) CountByRole(ctx context.Context, role model.Role) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByRole", ctx, role)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByRole indicates an expected call of CountByRole.
+func (mr *MockProfileUserRepositoryInterfaceMockRecorder) CountByRole(ctx, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByRole", reflect.TypeOf((*MockProfileUserRepositoryInterface)(nil).CountByRole), ctx, role)
+}
+
+// Delete mocks base method.
+func (m *MockProfileUserRepositoryInterface) Delete(ctx context.Context, id model.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProfileUserRepositoryInterfaceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProfileUserRepositoryInterface)(nil).Delete), ctx, id)
+}
+
+// FindByID mocks base method.
+func (m *MockProfileUserRepositoryInterface) FindByID(ctx context.Context, id model.ID) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockProfileUserRepositoryInterfaceMockRecorder) FindByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockProfileUserRepositoryInterface)(nil).FindByID), ctx, id)
+}
+
+// Update mocks base method.
+func (m_2 *MockProfileUserRepositoryInterface) Update(ctx context.Context, m *model.User) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Update", ctx, m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockProfileUserRepositoryInterfaceMockRecorder) Update(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProfileUserRepositoryInterface)(nil).Update), ctx, m)
+}
+
+// MockProfilePlaceRepositoryInterface is a mock of ProfilePlaceRepositoryInterface interface.
+type MockProfilePlaceRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfilePlaceRepositoryInterfaceMockRecorder
+}
+
+// MockProfilePlaceRepositoryInterfaceMockRecorder is the mock recorder for MockProfilePlaceRepositoryInterface.
+type MockProfilePlaceRepositoryInterfaceMockRecorder struct {
+	mock *MockProfilePlaceRepositoryInterface
+}
+
+// NewMockProfilePlaceRepositoryInterface creates a new mock instance.
+func NewMockProfilePlaceRepositoryInterface(ctrl *gomock.Controller) *MockProfilePlaceRepositoryInterface {
+	mock := &MockProfilePlaceRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockProfilePlaceRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfilePlaceRepositoryInterface) EXPECT() *MockProfilePlaceRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AnonymiseAuthor mocks base method.
+func (m *MockProfilePlaceRepositoryInterface) AnonymiseAuthor(ctx context.Context, authorID model.ID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymiseAuthor", ctx, authorID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymiseAuthor indicates an expected call of AnonymiseAuthor.
+func (mr *MockProfilePlaceRepositoryInterfaceMockRecorder) AnonymiseAuthor(ctx, authorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymiseAuthor", reflect.TypeOf((*MockProfilePlaceRepositoryInterface)(nil).AnonymiseAuthor), ctx, authorID)
+}
+
+// FindByAuthor mocks base method.
+func (m *MockProfilePlaceRepositoryInterface) FindByAuthor(ctx context.Context, authorID model.ID) (model.PlaceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByAuthor", ctx, authorID)
+	ret0, _ := ret[0].(model.PlaceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByAuthor indicates an expected call of FindByAuthor.
+func (mr *MockProfilePlaceRepositoryInterfaceMockRecorder) FindByAuthor(ctx, authorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAuthor", reflect.TypeOf((*MockProfilePlaceRepositoryInterface)(nil).FindByAuthor), ctx, authorID)
+}
+
+// MockProfileCategoryRepositoryInterface is a mock of ProfileCategoryRepositoryInterface interface.
+type MockProfileCategoryRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileCategoryRepositoryInterfaceMockRecorder
+}
+
+// MockProfileCategoryRepositoryInterfaceMockRecorder is the mock recorder for MockProfileCategoryRepositoryInterface.
+type MockProfileCategoryRepositoryInterfaceMockRecorder struct {
+	mock *MockProfileCategoryRepositoryInterface
+}
+
+// NewMockProfileCategoryRepositoryInterface creates a new mock instance.
+func NewMockProfileCategoryRepositoryInterface(ctrl *gomock.Controller) *MockProfileCategoryRepositoryInterface {
+	mock := &MockProfileCategoryRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockProfileCategoryRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileCategoryRepositoryInterface) EXPECT() *MockProfileCategoryRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method.
+func (m *MockProfileCategoryRepositoryInterface) FindAll(ctx context.Context) (model.CategoryList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].(model.CategoryList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockProfileCategoryRepositoryInterfaceMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockProfileCategoryRepositoryInterface)(nil).FindAll), ctx)
+}
+
+// MockProfileSessionRepositoryInterface is a mock of ProfileSessionRepositoryInterface interface.
+type MockProfileSessionRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileSessionRepositoryInterfaceMockRecorder
+}
+
+// MockProfileSessionRepositoryInterfaceMockRecorder is the mock recorder for MockProfileSessionRepositoryInterface.
+type MockProfileSessionRepositoryInterfaceMockRecorder struct {
+	mock *MockProfileSessionRepositoryInterface
+}
+
+// NewMockProfileSessionRepositoryInterface creates a new mock instance.
+func NewMockProfileSessionRepositoryInterface(ctrl *gomock.Controller) *MockProfileSessionRepositoryInterface {
+	mock := &MockProfileSessionRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockProfileSessionRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileSessionRepositoryInterface) EXPECT() *MockProfileSessionRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteByUsername mocks base method.
+func (m *MockProfileSessionRepositoryInterface) DeleteByUsername(ctx context.Context, username string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUsername", ctx, username)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUsername indicates an expected call of DeleteByUsername.
+func (mr *MockProfileSessionRepositoryInterfaceMockRecorder) DeleteByUsername(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUsername", reflect.TypeOf((*MockProfileSessionRepositoryInterface)(nil).DeleteByUsername), ctx, username)
+}
+
+// FindActiveByUsername mocks base method.
+func (m *MockProfileSessionRepositoryInterface) FindActiveByUsername(ctx context.Context, username string, now time.Time) ([]*model.SessionToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveByUsername", ctx, username, now)
+	ret0, _ := ret[0].([]*model.SessionToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveByUsername indicates an expected call of FindActiveByUsername.
+func (mr *MockProfileSessionRepositoryInterfaceMockRecorder) FindActiveByUsername(ctx, username, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveByUsername", reflect.TypeOf((*MockProfileSessionRepositoryInterface)(nil).FindActiveByUsername), ctx, username, now)
+}
+
+// MockProfileAPIKeyRepositoryInterface is a mock of ProfileAPIKeyRepositoryInterface interface.
+type MockProfileAPIKeyRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileAPIKeyRepositoryInterfaceMockRecorder
+}
+
+// MockProfileAPIKeyRepositoryInterfaceMockRecorder is the mock recorder for MockProfileAPIKeyRepositoryInterface.
+type MockProfileAPIKeyRepositoryInterfaceMockRecorder struct {
+	mock *MockProfileAPIKeyRepositoryInterface
+}
+
+// NewMockProfileAPIKeyRepositoryInterface creates a new mock instance.
+func NewMockProfileAPIKeyRepositoryInterface(ctrl *gomock.Controller) *MockProfileAPIKeyRepositoryInterface {
+	mock := &MockProfileAPIKeyRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockProfileAPIKeyRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileAPIKeyRepositoryInterface) EXPECT() *MockProfileAPIKeyRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteByUsername mocks base method.
+func (m *MockProfileAPIKeyRepositoryInterface) DeleteByUsername(ctx context.Context, username string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUsername", ctx, username)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUsername indicates an expected call of DeleteByUsername.
+func (mr *MockProfileAPIKeyRepositoryInterfaceMockRecorder) DeleteByUsername(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUsername", reflect.TypeOf((*MockProfileAPIKeyRepositoryInterface)(nil).DeleteByUsername), ctx, username)
+}
+
+// FindActiveByUsername mocks base method.
+func (m *MockProfileAPIKeyRepositoryInterface) FindActiveByUsername(ctx context.Context, username string, now time.Time) ([]*model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveByUsername", ctx, username, now)
+	ret0, _ := ret[0].([]*model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveByUsername indicates an expected call of FindActiveByUsername.
+func (mr *MockProfileAPIKeyRepositoryInterfaceMockRecorder) FindActiveByUsername(ctx, username, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveByUsername", reflect.TypeOf((*MockProfileAPIKeyRepositoryInterface)(nil).FindActiveByUsername), ctx, username, now)
+}
+
+// MockProfilePasswordResetRepositoryInterface is a mock of ProfilePasswordResetRepositoryInterface interface.
+type MockProfilePasswordResetRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfilePasswordResetRepositoryInterfaceMockRecorder
+}
+
+// MockProfilePasswordResetRepositoryInterfaceMockRecorder is the mock recorder for MockProfilePasswordResetRepositoryInterface.
+type MockProfilePasswordResetRepositoryInterfaceMockRecorder struct {
+	mock *MockProfilePasswordResetRepositoryInterface
+}
+
+// NewMockProfilePasswordResetRepositoryInterface creates a new mock instance.
+func NewMockProfilePasswordResetRepositoryInterface(ctrl *gomock.Controller) *MockProfilePasswordResetRepositoryInterface {
+	mock := &MockProfilePasswordResetRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockProfilePasswordResetRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfilePasswordResetRepositoryInterface) EXPECT() *MockProfilePasswordResetRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// InvalidateByUsername mocks base method.
+func (m *MockProfilePasswordResetRepositoryInterface) InvalidateByUsername(ctx context.Context, username string, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateByUsername", ctx, username, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateByUsername indicates an expected call of InvalidateByUsername.
+func (mr *MockProfilePasswordResetRepositoryInterfaceMockRecorder) InvalidateByUsername(ctx, username, usedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateByUsername", reflect.TypeOf((*MockProfilePasswordResetRepositoryInterface)(nil).InvalidateByUsername), ctx, username, usedAt)
+}
+
+// MockProfileSearchLogRepositoryInterface is a mock of ProfileSearchLogRepositoryInterface interface.
+type MockProfileSearchLogRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileSearchLogRepositoryInterfaceMockRecorder
+}
+
+// MockProfileSearchLogRepositoryInterfaceMockRecorder is the mock recorder for MockProfileSearchLogRepositoryInterface.
+type MockProfileSearchLogRepositoryInterfaceMockRecorder struct {
+	mock *MockProfileSearchLogRepositoryInterface
+}
+
+// NewMockProfileSearchLogRepositoryInterface creates a new mock instance.
+func NewMockProfileSearchLogRepositoryInterface(ctrl *gomock.Controller) *MockProfileSearchLogRepositoryInterface {
+	mock := &MockProfileSearchLogRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockProfileSearchLogRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileSearchLogRepositoryInterface) EXPECT() *MockProfileSearchLogRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AnonymiseUsername mocks base method.
+func (m *MockProfileSearchLogRepositoryInterface) AnonymiseUsername(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymiseUsername", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnonymiseUsername indicates an expected call of AnonymiseUsername.
+func (mr *MockProfileSearchLogRepositoryInterfaceMockRecorder) AnonymiseUsername(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymiseUsername", reflect.TypeOf((*MockProfileSearchLogRepositoryInterface)(nil).AnonymiseUsername), ctx, username)
+}
+
+// MockProfileTokenServiceInterface is a mock of ProfileTokenServiceInterface interface.
+type MockProfileTokenServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileTokenServiceInterfaceMockRecorder
+}
+
+// MockProfileTokenServiceInterfaceMockRecorder is the mock recorder for MockProfileTokenServiceInterface.
+type MockProfileTokenServiceInterfaceMockRecorder struct {
+	mock *MockProfileTokenServiceInterface
+}
+
+// NewMockProfileTokenServiceInterface creates a new mock instance.
+func NewMockProfileTokenServiceInterface(ctrl *gomock.Controller) *MockProfileTokenServiceInterface {
+	mock := &MockProfileTokenServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockProfileTokenServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileTokenServiceInterface) EXPECT() *MockProfileTokenServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// RevokeAll mocks base method.
+func (m *MockProfileTokenServiceInterface) RevokeAll(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockProfileTokenServiceInterfaceMockRecorder) RevokeAll(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockProfileTokenServiceInterface)(nil).RevokeAll), ctx, username)
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/rate_limit.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRateLimitRepositoryInterface is a mock of RateLimitRepositoryInterface interface.
+type MockRateLimitRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimitRepositoryInterfaceMockRecorder
+}
+
+// MockRateLimitRepositoryInterfaceMockRecorder is the mock recorder for MockRateLimitRepositoryInterface.
+type MockRateLimitRepositoryInterfaceMockRecorder struct {
+	mock *MockRateLimitRepositoryInterface
+}
+
+// NewMockRateLimitRepositoryInterface creates a new mock instance.
+func NewMockRateLimitRepositoryInterface(ctrl *gomock.Controller) *MockRateLimitRepositoryInterface {
+	mock := &MockRateLimitRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockRateLimitRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimitRepositoryInterface) EXPECT() *MockRateLimitRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Incr mocks base method.
+func (m *MockRateLimitRepositoryInterface) Incr(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Incr", ctx, key, window)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(time.Duration)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Incr indicates an expected call of Incr.
+func (mr *MockRateLimitRepositoryInterfaceMockRecorder) Incr(ctx, key, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Incr", reflect.TypeOf((*MockRateLimitRepositoryInterface)(nil).Incr), ctx, key, window)
+}
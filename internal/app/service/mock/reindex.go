@@ -0,0 +1,314 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/reindex.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReindexPlaceRepositoryInterface is a mock of ReindexPlaceRepositoryInterface interface.
+type MockReindexPlaceRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockReindexPlaceRepositoryInterfaceMockRecorder
+}
+
+// MockReindexPlaceRepositoryInterfaceMockRecorder is the mock recorder for MockReindexPlaceRepositoryInterface.
+type MockReindexPlaceRepositoryInterfaceMockRecorder struct {
+	mock *MockReindexPlaceRepositoryInterface
+}
+
+// NewMockReindexPlaceRepositoryInterface creates a new mock instance.
+func NewMockReindexPlaceRepositoryInterface(ctrl *gomock.Controller) *MockReindexPlaceRepositoryInterface {
+	mock := &MockReindexPlaceRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockReindexPlaceRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReindexPlaceRepositoryInterface) EXPECT() *MockReindexPlaceRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockReindexPlaceRepositoryInterface) Count(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockReindexPlaceRepositoryInterfaceMockRecorder) Count(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockReindexPlaceRepositoryInterface)(nil).Count), ctx)
+}
+
+// ExistingIDs mocks base method.
+func (m *MockReindexPlaceRepositoryInterface) ExistingIDs(ctx context.Context, ids []model.ID) ([]model.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistingIDs", ctx, ids)
+	ret0, _ := ret[0].([]model.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistingIDs indicates an expected call of ExistingIDs.
+func (mr *MockReindexPlaceRepositoryInterfaceMockRecorder) ExistingIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistingIDs", reflect.TypeOf((*MockReindexPlaceRepositoryInterface)(nil).ExistingIDs), ctx, ids)
+}
+
+// FindBatch mocks base method.
+func (m *MockReindexPlaceRepositoryInterface) FindBatch(ctx context.Context, after model.ID, limit int64) (model.PlaceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBatch", ctx, after, limit)
+	ret0, _ := ret[0].(model.PlaceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBatch indicates an expected call of FindBatch.
+func (mr *MockReindexPlaceRepositoryInterfaceMockRecorder) FindBatch(ctx, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBatch", reflect.TypeOf((*MockReindexPlaceRepositoryInterface)(nil).FindBatch), ctx, after, limit)
+}
+
+// FindChangedSince mocks base method.
+func (m *MockReindexPlaceRepositoryInterface) FindChangedSince(ctx context.Context, since time.Time, after model.ID, limit int64) (model.PlaceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindChangedSince", ctx, since, after, limit)
+	ret0, _ := ret[0].(model.PlaceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindChangedSince indicates an expected call of FindChangedSince.
+func (mr *MockReindexPlaceRepositoryInterfaceMockRecorder) FindChangedSince(ctx, since, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindChangedSince", reflect.TypeOf((*MockReindexPlaceRepositoryInterface)(nil).FindChangedSince), ctx, since, after, limit)
+}
+
+// MockReindexSearchRepositoryInterface is a mock of ReindexSearchRepositoryInterface interface.
+type MockReindexSearchRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockReindexSearchRepositoryInterfaceMockRecorder
+}
+
+// MockReindexSearchRepositoryInterfaceMockRecorder is the mock recorder for MockReindexSearchRepositoryInterface.
+type MockReindexSearchRepositoryInterfaceMockRecorder struct {
+	mock *MockReindexSearchRepositoryInterface
+}
+
+// NewMockReindexSearchRepositoryInterface creates a new mock instance.
+func NewMockReindexSearchRepositoryInterface(ctrl *gomock.Controller) *MockReindexSearchRepositoryInterface {
+	mock := &MockReindexSearchRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockReindexSearchRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReindexSearchRepositoryInterface) EXPECT() *MockReindexSearchRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AliasIndices mocks base method.
+func (m *MockReindexSearchRepositoryInterface) AliasIndices(ctx context.Context, alias string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AliasIndices", ctx, alias)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AliasIndices indicates an expected call of AliasIndices.
+func (mr *MockReindexSearchRepositoryInterfaceMockRecorder) AliasIndices(ctx, alias interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AliasIndices", reflect.TypeOf((*MockReindexSearchRepositoryInterface)(nil).AliasIndices), ctx, alias)
+}
+
+// BulkDelete mocks base method.
+func (m *MockReindexSearchRepositoryInterface) BulkDelete(ctx context.Context, index string, ids []model.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkDelete", ctx, index, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkDelete indicates an expected call of BulkDelete.
+func (mr *MockReindexSearchRepositoryInterfaceMockRecorder) BulkDelete(ctx, index, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDelete", reflect.TypeOf((*MockReindexSearchRepositoryInterface)(nil).BulkDelete), ctx, index, ids)
+}
+
+// BulkIndex mocks base method.
+func (m *MockReindexSearchRepositoryInterface) BulkIndex(ctx context.Context, index string, places model.PlaceList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkIndex", ctx, index, places)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkIndex indicates an expected call of BulkIndex.
+func (mr *MockReindexSearchRepositoryInterfaceMockRecorder) BulkIndex(ctx, index, places interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkIndex", reflect.TypeOf((*MockReindexSearchRepositoryInterface)(nil).BulkIndex), ctx, index, places)
+}
+
+// CreateIndex mocks base method.
+func (m *MockReindexSearchRepositoryInterface) CreateIndex(ctx context.Context, index string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIndex", ctx, index)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIndex indicates an expected call of CreateIndex.
+func (mr *MockReindexSearchRepositoryInterfaceMockRecorder) CreateIndex(ctx, index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIndex", reflect.TypeOf((*MockReindexSearchRepositoryInterface)(nil).CreateIndex), ctx, index)
+}
+
+// DeleteIndex mocks base method.
+func (m *MockReindexSearchRepositoryInterface) DeleteIndex(ctx context.Context, index string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIndex", ctx, index)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIndex indicates an expected call of DeleteIndex.
+func (mr *MockReindexSearchRepositoryInterfaceMockRecorder) DeleteIndex(ctx, index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIndex", reflect.TypeOf((*MockReindexSearchRepositoryInterface)(nil).DeleteIndex), ctx, index)
+}
+
+// IsIndex mocks base method.
+func (m *MockReindexSearchRepositoryInterface) IsIndex(ctx context.Context, name string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsIndex", ctx, name)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsIndex indicates an expected call of IsIndex.
+func (mr *MockReindexSearchRepositoryInterfaceMockRecorder) IsIndex(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsIndex", reflect.TypeOf((*MockReindexSearchRepositoryInterface)(nil).IsIndex), ctx, name)
+}
+
+// RefreshIndex mocks base method.
+func (m *MockReindexSearchRepositoryInterface) RefreshIndex(ctx context.Context, index string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshIndex", ctx, index)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshIndex indicates an expected call of RefreshIndex.
+func (mr *MockReindexSearchRepositoryInterfaceMockRecorder) RefreshIndex(ctx, index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshIndex", reflect.TypeOf((*MockReindexSearchRepositoryInterface)(nil).RefreshIndex), ctx, index)
+}
+
+// ReplaceIndexWithAlias mocks base method.
+func (m *MockReindexSearchRepositoryInterface) ReplaceIndexWithAlias(ctx context.Context, alias, index string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceIndexWithAlias", ctx, alias, index)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceIndexWithAlias indicates an expected call of ReplaceIndexWithAlias.
+func (mr *MockReindexSearchRepositoryInterfaceMockRecorder) ReplaceIndexWithAlias(ctx, alias, index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceIndexWithAlias", reflect.TypeOf((*MockReindexSearchRepositoryInterface)(nil).ReplaceIndexWithAlias), ctx, alias, index)
+}
+
+// ScanIDs mocks base method.
+func (m *MockReindexSearchRepositoryInterface) ScanIDs(ctx context.Context, index string, size int64, fn func([]model.ID) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanIDs", ctx, index, size, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScanIDs indicates an expected call of ScanIDs.
+func (mr *MockReindexSearchRepositoryInterfaceMockRecorder) ScanIDs(ctx, index, size, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanIDs", reflect.TypeOf((*MockReindexSearchRepositoryInterface)(nil).ScanIDs), ctx, index, size, fn)
+}
+
+// SwapAlias mocks base method.
+func (m *MockReindexSearchRepositoryInterface) SwapAlias(ctx context.Context, alias, index string, oldIndices []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwapAlias", ctx, alias, index, oldIndices)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SwapAlias indicates an expected call of SwapAlias.
+func (mr *MockReindexSearchRepositoryInterfaceMockRecorder) SwapAlias(ctx, alias, index, oldIndices interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwapAlias", reflect.TypeOf((*MockReindexSearchRepositoryInterface)(nil).SwapAlias), ctx, alias, index, oldIndices)
+}
+
+// MockReindexStateRepositoryInterface is a mock of ReindexStateRepositoryInterface interface.
+type MockReindexStateRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockReindexStateRepositoryInterfaceMockRecorder
+}
+
+// MockReindexStateRepositoryInterfaceMockRecorder is the mock recorder for MockReindexStateRepositoryInterface.
+type MockReindexStateRepositoryInterfaceMockRecorder struct {
+	mock *MockReindexStateRepositoryInterface
+}
+
+// NewMockReindexStateRepositoryInterface creates a new mock instance.
+func NewMockReindexStateRepositoryInterface(ctrl *gomock.Controller) *MockReindexStateRepositoryInterface {
+	mock := &MockReindexStateRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockReindexStateRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReindexStateRepositoryInterface) EXPECT() *MockReindexStateRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// FindUnfinished mocks base method.
+func (m *MockReindexStateRepositoryInterface) FindUnfinished(ctx context.Context, mode model.ReindexMode, alias string) (*model.ReindexState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUnfinished", ctx, mode, alias)
+	ret0, _ := ret[0].(*model.ReindexState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUnfinished indicates an expected call of FindUnfinished.
+func (mr *MockReindexStateRepositoryInterfaceMockRecorder) FindUnfinished(ctx, mode, alias interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUnfinished", reflect.TypeOf((*MockReindexStateRepositoryInterface)(nil).FindUnfinished), ctx, mode, alias)
+}
+
+// Save mocks base method.
+func (m_2 *MockReindexStateRepositoryInterface) Save(ctx context.Context, m *model.ReindexState) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Save", ctx, m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockReindexStateRepositoryInterfaceMockRecorder) Save(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockReindexStateRepositoryInterface)(nil).Save), ctx, m)
+}
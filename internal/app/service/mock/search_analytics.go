@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/search_analytics.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSearchLogRepositoryInterface is a mock of SearchLogRepositoryInterface interface.
+type MockSearchLogRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchLogRepositoryInterfaceMockRecorder
+}
+
+// MockSearchLogRepositoryInterfaceMockRecorder is the mock recorder for MockSearchLogRepositoryInterface.
+type MockSearchLogRepositoryInterfaceMockRecorder struct {
+	mock *MockSearchLogRepositoryInterface
+}
+
+// NewMockSearchLogRepositoryInterface creates a new mock instance.
+func NewMockSearchLogRepositoryInterface(ctrl *gomock.Controller) *MockSearchLogRepositoryInterface {
+	mock := &MockSearchLogRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockSearchLogRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchLogRepositoryInterface) EXPECT() *MockSearchLogRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddClick mocks base method.
+func (m *MockSearchLogRepositoryInterface) AddClick(ctx context.Context, searchID, placeID model.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddClick", ctx, searchID, placeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddClick indicates an expected call of AddClick.
+func (mr *MockSearchLogRepositoryInterfaceMockRecorder) AddClick(ctx, searchID, placeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddClick", reflect.TypeOf((*MockSearchLogRepositoryInterface)(nil).AddClick), ctx, searchID, placeID)
+}
+
+// CreateMany mocks base method.
+func (m *MockSearchLogRepositoryInterface) CreateMany(ctx context.Context, logs []*model.SearchLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMany", ctx, logs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMany indicates an expected call of CreateMany.
+func (mr *MockSearchLogRepositoryInterfaceMockRecorder) CreateMany(ctx, logs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMany", reflect.TypeOf((*MockSearchLogRepositoryInterface)(nil).CreateMany), ctx, logs)
+}
+
+// Exists mocks base method.
+func (m *MockSearchLogRepositoryInterface) Exists(ctx context.Context, id model.ID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockSearchLogRepositoryInterfaceMockRecorder) Exists(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockSearchLogRepositoryInterface)(nil).Exists), ctx, id)
+}
+
+// Stats mocks base method.
+func (m *MockSearchLogRepositoryInterface) Stats(ctx context.Context, from, to time.Time, limit int64) (*model.SearchStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx, from, to, limit)
+	ret0, _ := ret[0].(*model.SearchStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockSearchLogRepositoryInterfaceMockRecorder) Stats(ctx, from, to, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockSearchLogRepositoryInterface)(nil).Stats), ctx, from, to, limit)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/search_terms.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSearchTermsPlaceRepositoryInterface is a mock of SearchTermsPlaceRepositoryInterface interface.
+type MockSearchTermsPlaceRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchTermsPlaceRepositoryInterfaceMockRecorder
+}
+
+// MockSearchTermsPlaceRepositoryInterfaceMockRecorder is the mock recorder for MockSearchTermsPlaceRepositoryInterface.
+type MockSearchTermsPlaceRepositoryInterfaceMockRecorder struct {
+	mock *MockSearchTermsPlaceRepositoryInterface
+}
+
+// NewMockSearchTermsPlaceRepositoryInterface creates a new mock instance.
+func NewMockSearchTermsPlaceRepositoryInterface(ctrl *gomock.Controller) *MockSearchTermsPlaceRepositoryInterface {
+	mock := &MockSearchTermsPlaceRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockSearchTermsPlaceRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchTermsPlaceRepositoryInterface) EXPECT() *MockSearchTermsPlaceRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// FindBatch mocks base method.
+func (m *MockSearchTermsPlaceRepositoryInterface) FindBatch(ctx context.Context, after model.ID, limit int64) (model.PlaceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBatch", ctx, after, limit)
+	ret0, _ := ret[0].(model.PlaceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBatch indicates an expected call of FindBatch.
+func (mr *MockSearchTermsPlaceRepositoryInterfaceMockRecorder) FindBatch(ctx, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBatch", reflect.TypeOf((*MockSearchTermsPlaceRepositoryInterface)(nil).FindBatch), ctx, after, limit)
+}
+
+// UpdateSearchTerms mocks base method.
+func (m *MockSearchTermsPlaceRepositoryInterface) UpdateSearchTerms(ctx context.Context, places model.PlaceList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSearchTerms", ctx, places)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSearchTerms indicates an expected call of UpdateSearchTerms.
+func (mr *MockSearchTermsPlaceRepositoryInterfaceMockRecorder) UpdateSearchTerms(ctx, places interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSearchTerms", reflect.TypeOf((*MockSearchTermsPlaceRepositoryInterface)(nil).UpdateSearchTerms), ctx, places)
+}
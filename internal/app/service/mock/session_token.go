@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/session_token.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSessionTokenRepositoryInterface is a mock of SessionTokenRepositoryInterface interface.
+type MockSessionTokenRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionTokenRepositoryInterfaceMockRecorder
+}
+
+// MockSessionTokenRepositoryInterfaceMockRecorder is the mock recorder for MockSessionTokenRepositoryInterface.
+type MockSessionTokenRepositoryInterfaceMockRecorder struct {
+	mock *MockSessionTokenRepositoryInterface
+}
+
+// NewMockSessionTokenRepositoryInterface creates a new mock instance.
+func NewMockSessionTokenRepositoryInterface(ctrl *gomock.Controller) *MockSessionTokenRepositoryInterface {
+	mock := &MockSessionTokenRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockSessionTokenRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionTokenRepositoryInterface) EXPECT() *MockSessionTokenRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m_2 *MockSessionTokenRepositoryInterface) Create(ctx context.Context, m *model.SessionToken) (model.ID, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Create", ctx, m)
+	ret0, _ := ret[0].(model.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSessionTokenRepositoryInterfaceMockRecorder) Create(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSessionTokenRepositoryInterface)(nil).Create), ctx, m)
+}
+
+// FindActiveByUsername mocks base method.
+func (m *MockSessionTokenRepositoryInterface) FindActiveByUsername(ctx context.Context, username string, now time.Time) ([]*model.SessionToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveByUsername", ctx, username, now)
+	ret0, _ := ret[0].([]*model.SessionToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveByUsername indicates an expected call of FindActiveByUsername.
+func (mr *MockSessionTokenRepositoryInterfaceMockRecorder) FindActiveByUsername(ctx, username, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveByUsername", reflect.TypeOf((*MockSessionTokenRepositoryInterface)(nil).FindActiveByUsername), ctx, username, now)
+}
+
+// FindByHash mocks base method.
+func (m *MockSessionTokenRepositoryInterface) FindByHash(ctx context.Context, tokenHash string) (*model.SessionToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*model.SessionToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByHash indicates an expected call of FindByHash.
+func (mr *MockSessionTokenRepositoryInterfaceMockRecorder) FindByHash(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHash", reflect.TypeOf((*MockSessionTokenRepositoryInterface)(nil).FindByHash), ctx, tokenHash)
+}
+
+// MarkRotated mocks base method.
+func (m *MockSessionTokenRepositoryInterface) MarkRotated(ctx context.Context, id model.ID, rotatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRotated", ctx, id, rotatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRotated indicates an expected call of MarkRotated.
+func (mr *MockSessionTokenRepositoryInterfaceMockRecorder) MarkRotated(ctx, id, rotatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRotated", reflect.TypeOf((*MockSessionTokenRepositoryInterface)(nil).MarkRotated), ctx, id, rotatedAt)
+}
+
+// RevokeByUsername mocks base method.
+func (m *MockSessionTokenRepositoryInterface) RevokeByUsername(ctx context.Context, username string, exceptFamilyID model.ID, revokedAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeByUsername", ctx, username, exceptFamilyID, revokedAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeByUsername indicates an expected call of RevokeByUsername.
+func (mr *MockSessionTokenRepositoryInterfaceMockRecorder) RevokeByUsername(ctx, username, exceptFamilyID, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeByUsername", reflect.TypeOf((*MockSessionTokenRepositoryInterface)(nil).RevokeByUsername), ctx, username, exceptFamilyID, revokedAt)
+}
+
+// RevokeFamily mocks base method.
+func (m *MockSessionTokenRepositoryInterface) RevokeFamily(ctx context.Context, familyID model.ID, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFamily", ctx, familyID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeFamily indicates an expected call of RevokeFamily.
+func (mr *MockSessionTokenRepositoryInterfaceMockRecorder) RevokeFamily(ctx, familyID, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFamily", reflect.TypeOf((*MockSessionTokenRepositoryInterface)(nil).RevokeFamily), ctx, familyID, revokedAt)
+}
+
+// Touch mocks base method.
+func (m *MockSessionTokenRepositoryInterface) Touch(ctx context.Context, id model.ID, lastSeenAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", ctx, id, lastSeenAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockSessionTokenRepositoryInterfaceMockRecorder) Touch(ctx, id, lastSeenAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockSessionTokenRepositoryInterface)(nil).Touch), ctx, id, lastSeenAt)
+}
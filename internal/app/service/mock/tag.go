@@ -0,0 +1,161 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/tag.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTagRepositoryInterface is a mock of TagRepositoryInterface interface.
+type MockTagRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagRepositoryInterfaceMockRecorder
+}
+
+// MockTagRepositoryInterfaceMockRecorder is the mock recorder for MockTagRepositoryInterface.
+type MockTagRepositoryInterfaceMockRecorder struct {
+	mock *MockTagRepositoryInterface
+}
+
+// NewMockTagRepositoryInterface creates a new mock instance.
+func NewMockTagRepositoryInterface(ctrl *gomock.Controller) *MockTagRepositoryInterface {
+	mock := &MockTagRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockTagRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagRepositoryInterface) EXPECT() *MockTagRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockTagRepositoryInterface) Find(ctx context.Context, name string) (*model.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, name)
+	ret0, _ := ret[0].(*model.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockTagRepositoryInterfaceMockRecorder) Find(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockTagRepositoryInterface)(nil).Find), ctx, name)
+}
+
+// FindAll mocks base method.
+func (m *MockTagRepositoryInterface) FindAll(ctx context.Context, limit int64) (model.TagList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, limit)
+	ret0, _ := ret[0].(model.TagList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockTagRepositoryInterfaceMockRecorder) FindAll(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTagRepositoryInterface)(nil).FindAll), ctx, limit)
+}
+
+// Recount mocks base method.
+func (m *MockTagRepositoryInterface) Recount(ctx context.Context, names []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recount", ctx, names)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Recount indicates an expected call of Recount.
+func (mr *MockTagRepositoryInterfaceMockRecorder) Recount(ctx, names interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recount", reflect.TypeOf((*MockTagRepositoryInterface)(nil).Recount), ctx, names)
+}
+
+// MockTagPlaceRepositoryInterface is a mock of TagPlaceRepositoryInterface interface.
+type MockTagPlaceRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagPlaceRepositoryInterfaceMockRecorder
+}
+
+// MockTagPlaceRepositoryInterfaceMockRecorder is the mock recorder for MockTagPlaceRepositoryInterface.
+type MockTagPlaceRepositoryInterfaceMockRecorder struct {
+	mock *MockTagPlaceRepositoryInterface
+}
+
+// NewMockTagPlaceRepositoryInterface creates a new mock instance.
+func NewMockTagPlaceRepositoryInterface(ctrl *gomock.Controller) *MockTagPlaceRepositoryInterface {
+	mock := &MockTagPlaceRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockTagPlaceRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagPlaceRepositoryInterface) EXPECT() *MockTagPlaceRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// FindByIDs mocks base method.
+func (m *MockTagPlaceRepositoryInterface) FindByIDs(ctx context.Context, ids []model.ID) (model.PlaceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, ids)
+	ret0, _ := ret[0].(model.PlaceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockTagPlaceRepositoryInterfaceMockRecorder) FindByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockTagPlaceRepositoryInterface)(nil).FindByIDs), ctx, ids)
+}
+
+// FindByTags mocks base method.
+func (m *MockTagPlaceRepositoryInterface) FindByTags(ctx context.Context, tags []string) (model.PlaceList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTags", ctx, tags)
+	ret0, _ := ret[0].(model.PlaceList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTags indicates an expected call of FindByTags.
+func (mr *MockTagPlaceRepositoryInterfaceMockRecorder) FindByTags(ctx, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTags", reflect.TypeOf((*MockTagPlaceRepositoryInterface)(nil).FindByTags), ctx, tags)
+}
+
+// ReplaceTags mocks base method.
+func (m *MockTagPlaceRepositoryInterface) ReplaceTags(ctx context.Context, ids []model.ID, from []string, into string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTags", ctx, ids, from, into)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceTags indicates an expected call of ReplaceTags.
+func (mr *MockTagPlaceRepositoryInterfaceMockRecorder) ReplaceTags(ctx, ids, from, into interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTags", reflect.TypeOf((*MockTagPlaceRepositoryInterface)(nil).ReplaceTags), ctx, ids, from, into)
+}
+
+// UpdateSearchTerms mocks base method.
+func (m *MockTagPlaceRepositoryInterface) UpdateSearchTerms(ctx context.Context, places model.PlaceList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSearchTerms", ctx, places)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSearchTerms indicates an expected call of UpdateSearchTerms.
+func (mr *MockTagPlaceRepositoryInterfaceMockRecorder) UpdateSearchTerms(ctx, places interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSearchTerms", reflect.TypeOf((*MockTagPlaceRepositoryInterface)(nil).UpdateSearchTerms), ctx, places)
+}
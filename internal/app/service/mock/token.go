@@ -0,0 +1,149 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/token.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	jwt "walk_backend/internal/pkg/jwt"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTokenSignerInterface is a mock of TokenSignerInterface interface.
+type MockTokenSignerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenSignerInterfaceMockRecorder
+}
+
+// MockTokenSignerInterfaceMockRecorder is the mock recorder for MockTokenSignerInterface.
+type MockTokenSignerInterfaceMockRecorder struct {
+	mock *MockTokenSignerInterface
+}
+
+// NewMockTokenSignerInterface creates a new mock instance.
+func NewMockTokenSignerInterface(ctrl *gomock.Controller) *MockTokenSignerInterface {
+	mock := &MockTokenSignerInterface{ctrl: ctrl}
+	mock.recorder = &MockTokenSignerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenSignerInterface) EXPECT() *MockTokenSignerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Sign mocks base method.
+func (m *MockTokenSignerInterface) Sign(claims *jwt.Claims) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sign", claims)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sign indicates an expected call of Sign.
+func (mr *MockTokenSignerInterfaceMockRecorder) Sign(claims interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockTokenSignerInterface)(nil).Sign), claims)
+}
+
+// Verify mocks base method.
+func (m *MockTokenSignerInterface) Verify(token string) (*jwt.Claims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", token)
+	ret0, _ := ret[0].(*jwt.Claims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockTokenSignerInterfaceMockRecorder) Verify(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockTokenSignerInterface)(nil).Verify), token)
+}
+
+// MockTokenDenyListRepositoryInterface is a mock of TokenDenyListRepositoryInterface interface.
+type MockTokenDenyListRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenDenyListRepositoryInterfaceMockRecorder
+}
+
+// MockTokenDenyListRepositoryInterfaceMockRecorder is the mock recorder for MockTokenDenyListRepositoryInterface.
+type MockTokenDenyListRepositoryInterfaceMockRecorder struct {
+	mock *MockTokenDenyListRepositoryInterface
+}
+
+// NewMockTokenDenyListRepositoryInterface creates a new mock instance.
+func NewMockTokenDenyListRepositoryInterface(ctrl *gomock.Controller) *MockTokenDenyListRepositoryInterface {
+	mock := &MockTokenDenyListRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockTokenDenyListRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenDenyListRepositoryInterface) EXPECT() *MockTokenDenyListRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockTokenDenyListRepositoryInterface) Add(ctx context.Context, id string, expiration time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, id, expiration)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockTokenDenyListRepositoryInterfaceMockRecorder) Add(ctx, id, expiration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockTokenDenyListRepositoryInterface)(nil).Add), ctx, id, expiration)
+}
+
+// Has mocks base method.
+func (m *MockTokenDenyListRepositoryInterface) Has(ctx context.Context, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Has", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Has indicates an expected call of Has.
+func (mr *MockTokenDenyListRepositoryInterfaceMockRecorder) Has(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Has", reflect.TypeOf((*MockTokenDenyListRepositoryInterface)(nil).Has), ctx, id)
+}
+
+// SetTokensValidAfter mocks base method.
+func (m *MockTokenDenyListRepositoryInterface) SetTokensValidAfter(ctx context.Context, username string, validAfter time.Time, expiration time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTokensValidAfter", ctx, username, validAfter, expiration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTokensValidAfter indicates an expected call of SetTokensValidAfter.
+func (mr *MockTokenDenyListRepositoryInterfaceMockRecorder) SetTokensValidAfter(ctx, username, validAfter, expiration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTokensValidAfter", reflect.TypeOf((*MockTokenDenyListRepositoryInterface)(nil).SetTokensValidAfter), ctx, username, validAfter, expiration)
+}
+
+// TokensValidAfter mocks base method.
+func (m *MockTokenDenyListRepositoryInterface) TokensValidAfter(ctx context.Context, username string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokensValidAfter", ctx, username)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TokensValidAfter indicates an expected call of TokensValidAfter.
+func (mr *MockTokenDenyListRepositoryInterfaceMockRecorder) TokensValidAfter(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokensValidAfter", reflect.TypeOf((*MockTokenDenyListRepositoryInterface)(nil).TokensValidAfter), ctx, username)
+}
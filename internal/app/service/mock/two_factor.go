@@ -0,0 +1,202 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/two_factor.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTwoFactorUserRepositoryInterface is a mock of TwoFactorUserRepositoryInterface interface.
+type MockTwoFactorUserRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorUserRepositoryInterfaceMockRecorder
+}
+
+// MockTwoFactorUserRepositoryInterfaceMockRecorder is the mock recorder for MockTwoFactorUserRepositoryInterface.
+type MockTwoFactorUserRepositoryInterfaceMockRecorder struct {
+	mock *MockTwoFactorUserRepositoryInterface
+}
+
+// NewMockTwoFactorUserRepositoryInterface creates a new mock instance.
+func NewMockTwoFactorUserRepositoryInterface(ctrl *gomock.Controller) *MockTwoFactorUserRepositoryInterface {
+	mock := &MockTwoFactorUserRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorUserRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorUserRepositoryInterface) EXPECT() *MockTwoFactorUserRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteTwoFactor mocks base method.
+func (m *MockTwoFactorUserRepositoryInterface) DeleteTwoFactor(ctx context.Context, id model.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTwoFactor", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTwoFactor indicates an expected call of DeleteTwoFactor.
+func (mr *MockTwoFactorUserRepositoryInterfaceMockRecorder) DeleteTwoFactor(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTwoFactor", reflect.TypeOf((*MockTwoFactorUserRepositoryInterface)(nil).DeleteTwoFactor), ctx, id)
+}
+
+// EnableTwoFactor mocks base method.
+func (m *MockTwoFactorUserRepositoryInterface) EnableTwoFactor(ctx context.Context, id model.ID, secret string, counter int64, recoveryCodes []string, enabledAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTwoFactor", ctx, id, secret, counter, recoveryCodes, enabledAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableTwoFactor indicates an expected call of EnableTwoFactor.
+func (mr *MockTwoFactorUserRepositoryInterfaceMockRecorder) EnableTwoFactor(ctx, id, secret, counter, recoveryCodes, enabledAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTwoFactor", reflect.TypeOf((*MockTwoFactorUserRepositoryInterface)(nil).EnableTwoFactor), ctx, id, secret, counter, recoveryCodes, enabledAt)
+}
+
+// FindByUsername mocks base method.
+func (m *MockTwoFactorUserRepositoryInterface) FindByUsername(ctx context.Context, username string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUsername", ctx, username)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUsername indicates an expected call of FindByUsername.
+func (mr *MockTwoFactorUserRepositoryInterfaceMockRecorder) FindByUsername(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsername", reflect.TypeOf((*MockTwoFactorUserRepositoryInterface)(nil).FindByUsername), ctx, username)
+}
+
+// SetPendingTwoFactor mocks base method.
+func (m *MockTwoFactorUserRepositoryInterface) SetPendingTwoFactor(ctx context.Context, id model.ID, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPendingTwoFactor", ctx, id, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPendingTwoFactor indicates an expected call of SetPendingTwoFactor.
+func (mr *MockTwoFactorUserRepositoryInterfaceMockRecorder) SetPendingTwoFactor(ctx, id, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPendingTwoFactor", reflect.TypeOf((*MockTwoFactorUserRepositoryInterface)(nil).SetPendingTwoFactor), ctx, id, secret)
+}
+
+// SetRecoveryCodes mocks base method.
+func (m *MockTwoFactorUserRepositoryInterface) SetRecoveryCodes(ctx context.Context, id model.ID, recoveryCodes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRecoveryCodes", ctx, id, recoveryCodes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRecoveryCodes indicates an expected call of SetRecoveryCodes.
+func (mr *MockTwoFactorUserRepositoryInterfaceMockRecorder) SetRecoveryCodes(ctx, id, recoveryCodes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecoveryCodes", reflect.TypeOf((*MockTwoFactorUserRepositoryInterface)(nil).SetRecoveryCodes), ctx, id, recoveryCodes)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockTwoFactorUserRepositoryInterface) UseRecoveryCode(ctx context.Context, id model.ID, codeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, id, codeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockTwoFactorUserRepositoryInterfaceMockRecorder) UseRecoveryCode(ctx, id, codeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockTwoFactorUserRepositoryInterface)(nil).UseRecoveryCode), ctx, id, codeHash)
+}
+
+// UseTwoFactorCounter mocks base method.
+func (m *MockTwoFactorUserRepositoryInterface) UseTwoFactorCounter(ctx context.Context, id model.ID, counter int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTwoFactorCounter", ctx, id, counter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseTwoFactorCounter indicates an expected call of UseTwoFactorCounter.
+func (mr *MockTwoFactorUserRepositoryInterfaceMockRecorder) UseTwoFactorCounter(ctx, id, counter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTwoFactorCounter", reflect.TypeOf((*MockTwoFactorUserRepositoryInterface)(nil).UseTwoFactorCounter), ctx, id, counter)
+}
+
+// MockTwoFactorChallengeRepositoryInterface is a mock of TwoFactorChallengeRepositoryInterface interface.
+type MockTwoFactorChallengeRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorChallengeRepositoryInterfaceMockRecorder
+}
+
+// MockTwoFactorChallengeRepositoryInterfaceMockRecorder is the mock recorder for MockTwoFactorChallengeRepositoryInterface.
+type MockTwoFactorChallengeRepositoryInterfaceMockRecorder struct {
+	mock *MockTwoFactorChallengeRepositoryInterface
+}
+
+// NewMockTwoFactorChallengeRepositoryInterface creates a new mock instance.
+func NewMockTwoFactorChallengeRepositoryInterface(ctrl *gomock.Controller) *MockTwoFactorChallengeRepositoryInterface {
+	mock := &MockTwoFactorChallengeRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorChallengeRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorChallengeRepositoryInterface) EXPECT() *MockTwoFactorChallengeRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m_2 *MockTwoFactorChallengeRepositoryInterface) Create(ctx context.Context, hash string, m *model.TwoFactorChallenge) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Create", ctx, hash, m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTwoFactorChallengeRepositoryInterfaceMockRecorder) Create(ctx, hash, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTwoFactorChallengeRepositoryInterface)(nil).Create), ctx, hash, m)
+}
+
+// Delete mocks base method.
+func (m *MockTwoFactorChallengeRepositoryInterface) Delete(ctx context.Context, hash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, hash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTwoFactorChallengeRepositoryInterfaceMockRecorder) Delete(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTwoFactorChallengeRepositoryInterface)(nil).Delete), ctx, hash)
+}
+
+// Find mocks base method.
+func (m *MockTwoFactorChallengeRepositoryInterface) Find(ctx context.Context, hash string) (*model.TwoFactorChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, hash)
+	ret0, _ := ret[0].(*model.TwoFactorChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockTwoFactorChallengeRepositoryInterfaceMockRecorder) Find(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockTwoFactorChallengeRepositoryInterface)(nil).Find), ctx, hash)
+}
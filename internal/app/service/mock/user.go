@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/service/user.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	model "walk_backend/internal/app/model"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserRoleRepositoryInterface is a mock of UserRoleRepositoryInterface interface.
+type MockUserRoleRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserRoleRepositoryInterfaceMockRecorder
+}
+
+// MockUserRoleRepositoryInterfaceMockRecorder is the mock recorder for MockUserRoleRepositoryInterface.
+type MockUserRoleRepositoryInterfaceMockRecorder struct {
+	mock *MockUserRoleRepositoryInterface
+}
+
+// NewMockUserRoleRepositoryInterface creates a new mock instance.
+func NewMockUserRoleRepositoryInterface(ctrl *gomock.Controller) *MockUserRoleRepositoryInterface {
+	mock := &MockUserRoleRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockUserRoleRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserRoleRepositoryInterface) EXPECT() *MockUserRoleRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CountByRole mocks base method.
+func (m *MockUserRoleRepositoryInterface) CountByRole(ctx context.Context, role model.Role) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByRole", ctx, role)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByRole indicates an expected call of CountByRole.
+func (mr *MockUserRoleRepositoryInterfaceMockRecorder) CountByRole(ctx, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByRole", reflect.TypeOf((*MockUserRoleRepositoryInterface)(nil).CountByRole), ctx, role)
+}
+
+// Create mocks base method.
+func (m_2 *MockUserRoleRepositoryInterface) Create(ctx context.Context, m *model.User) (model.ID, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Create", ctx, m)
+	ret0, _ := ret[0].(model.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserRoleRepositoryInterfaceMockRecorder) Create(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserRoleRepositoryInterface)(nil).Create), ctx, m)
+}
+
+// FindByUsername mocks base method.
+func (m *MockUserRoleRepositoryInterface) FindByUsername(ctx context.Context, username string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUsername", ctx, username)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUsername indicates an expected call of FindByUsername.
+func (mr *MockUserRoleRepositoryInterfaceMockRecorder) FindByUsername(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsername", reflect.TypeOf((*MockUserRoleRepositoryInterface)(nil).FindByUsername), ctx, username)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockUserRoleRepositoryInterface) UpdatePassword(ctx context.Context, id model.ID, passwordHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, id, passwordHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserRoleRepositoryInterfaceMockRecorder) UpdatePassword(ctx, id, passwordHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserRoleRepositoryInterface)(nil).UpdatePassword), ctx, id, passwordHash)
+}
+
+// UpdateRoles mocks base method.
+func (m *MockUserRoleRepositoryInterface) UpdateRoles(ctx context.Context, id model.ID, roles []model.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRoles", ctx, id, roles)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRoles indicates an expected call of UpdateRoles.
+func (mr *MockUserRoleRepositoryInterfaceMockRecorder) UpdateRoles(ctx, id, roles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRoles", reflect.TypeOf((*MockUserRoleRepositoryInterface)(nil).UpdateRoles), ctx, id, roles)
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/pkg/oidc"
+)
+
+const (
+	oidcUsernameMaxLength int = 32
+	oidcUsernameAttempts  int = 5
+)
+
+var (
+	// ErrUnknownOIDCProvider ...
+	ErrUnknownOIDCProvider = errors.New("unknown oidc provider")
+	// ErrOIDCLogin code exchange or ID token verification failed
+	ErrOIDCLogin = errors.New("oidc login failed")
+	// ErrOIDCEmailTaken the verified provider email belongs to a local account with an unverified email,
+	// linking it would hand the account to whoever registered the email first
+	ErrOIDCEmailTaken = errors.New("email registered with another account, log in with the password")
+)
+
+// OIDCProviderInterface ...
+type OIDCProviderInterface interface {
+	AuthCodeURL(ctx context.Context, state string, nonce string, codeVerifier string) (string, error)
+	Exchange(ctx context.Context, code string, codeVerifier string) (string, error)
+	VerifyIDToken(ctx context.Context, rawIDToken string, nonce string) (*oidc.IDToken, error)
+}
+
+// OIDCUserRepositoryInterface ...
+type OIDCUserRepositoryInterface interface {
+	Create(ctx context.Context, m *model.User) (model.ID, error)
+	FindByUsername(ctx context.Context, username string) (*model.User, error)
+	FindByEmail(ctx context.Context, email string) (*model.User, error)
+	FindByIdentity(ctx context.Context, provider string, subject string) (*model.User, error)
+	AddIdentity(ctx context.Context, id model.ID, identity model.Identity) error
+}
+
+// DefaultOIDCService external identities by provider name, linked to users on login
+type DefaultOIDCService struct {
+	userRepo  OIDCUserRepositoryInterface
+	providers map[string]OIDCProviderInterface
+	now       func() time.Time
+}
+
+// NewDefaultOIDCService create new default oidc service
+func NewDefaultOIDCService(userRepo OIDCUserRepositoryInterface, providers map[string]OIDCProviderInterface) *DefaultOIDCService {
+	return &DefaultOIDCService{
+		userRepo:  userRepo,
+		providers: providers,
+		now:       time.Now,
+	}
+}
+
+// OIDCLogin secrets of one login, kept in the session from the provider redirect to the callback
+type OIDCLogin struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+}
+
+// AuthCodeURL provider login URL with new login secrets
+func (s *DefaultOIDCService) AuthCodeURL(ctx context.Context, provider string) (string, *OIDCLogin, error) {
+
+	p, ok := s.providers[provider]
+	if !ok {
+		return "", nil, ErrUnknownOIDCProvider
+	}
+
+	login := &OIDCLogin{}
+	for _, v := range []*string{&login.State, &login.Nonce, &login.CodeVerifier} {
+		value, err := oidc.RandomValue()
+		if err != nil {
+			return "", nil, err
+		}
+		*v = value
+	}
+
+	authURL, err := p.AuthCodeURL(ctx, login.State, login.Nonce, login.CodeVerifier)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return authURL, login, nil
+}
+
+// Login exchange the callback code and find the user of the identity. Unknown identities are linked
+// to the user of the same verified email, or a new user is created on first login
+func (s *DefaultOIDCService) Login(ctx context.Context, provider string, code string, login *OIDCLogin) (*model.User, error) {
+
+	p, ok := s.providers[provider]
+	if !ok {
+		return nil, ErrUnknownOIDCProvider
+	}
+
+	rawIDToken, err := p.Exchange(ctx, code, login.CodeVerifier)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrOIDCLogin, err)
+	}
+	idToken, err := p.VerifyIDToken(ctx, rawIDToken, login.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrOIDCLogin, err)
+	}
+
+	user, err := s.userRepo.FindByIdentity(ctx, provider, idToken.Subject)
+	if err == nil {
+		return user, nil
+	} else if !errors.Is(err, model.ErrModelNotFound) {
+		return nil, err
+	}
+
+	identity := model.Identity{
+		Provider: provider,
+		Subject:  idToken.Subject,
+		Email:    model.NormaliseEmail(idToken.Email),
+		LinkedAt: s.now(),
+	}
+	// an unverified provider email is not proof of owning it
+	email := ""
+	if bool(idToken.EmailVerified) {
+		email = identity.Email
+	}
+
+	if email != "" {
+		user, err := s.userRepo.FindByEmail(ctx, email)
+		if err == nil {
+			return s.link(ctx, user, identity)
+		} else if !errors.Is(err, model.ErrModelNotFound) {
+			return nil, err
+		}
+	}
+
+	return s.create(ctx, idToken, identity, email)
+}
+
+func (s *DefaultOIDCService) link(ctx context.Context, user *model.User, identity model.Identity) (*model.User, error) {
+
+	if !user.IsEmailVerified() {
+		return nil, ErrOIDCEmailTaken
+	}
+	if err := s.userRepo.AddIdentity(ctx, user.ID, identity); err != nil {
+		if errors.Is(err, model.ErrModelDuplicate) {
+			return nil, fmt.Errorf("%w: %s", ErrOIDCLogin, err)
+		}
+		return nil, err
+	}
+	user.Identities = append(user.Identities, identity)
+
+	return user, nil
+}
+
+func (s *DefaultOIDCService) create(ctx context.Context, idToken *oidc.IDToken, identity model.Identity, email string) (*model.User, error) {
+
+	username, err := s.freeUsername(ctx, idToken)
+	if err != nil {
+		return nil, err
+	}
+	user, err := model.NewExternalUserModel(username, identity)
+	if err != nil {
+		return nil, err
+	}
+	if email != "" {
+		verifiedAt := identity.LinkedAt
+		user.Email = email
+		user.EmailVerifiedAt = &verifiedAt
+	}
+
+	if _, err := s.userRepo.Create(ctx, user); err != nil {
+		// the same identity or email signed in concurrently
+		if errors.Is(err, model.ErrModelDuplicate) {
+			return nil, fmt.Errorf("%w: %s", ErrOIDCLogin, err)
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// freeUsername the preferred username, the email local part or the name of the token,
+// with a random suffix when taken
+func (s *DefaultOIDCService) freeUsername(ctx context.Context, idToken *oidc.IDToken) (string, error) {
+
+	base := ""
+	for _, candidate := range []string{
+		idToken.PreferredUsername,
+		strings.SplitN(idToken.Email, "@", 2)[0],
+		idToken.Name,
+	} {
+		if base = sanitizeUsername(candidate); base != "" {
+			break
+		}
+	}
+	if base == "" {
+		base = "user"
+	}
+
+	username := base
+	for i := 0; i < oidcUsernameAttempts; i++ {
+		_, err := s.userRepo.FindByUsername(ctx, username)
+		if errors.Is(err, model.ErrModelNotFound) {
+			return username, nil
+		} else if err != nil {
+			return "", err
+		}
+
+		suffix := make([]byte, 3)
+		if _, err := rand.Read(suffix); err != nil {
+			return "", err
+		}
+		username = base + "-" + hex.EncodeToString(suffix)
+	}
+
+	return "", fmt.Errorf("%w: no free username for %s", ErrOIDCLogin, base)
+}
+
+// sanitizeUsername letters, digits, dot, underscore and dash, spaces become underscores
+func sanitizeUsername(s string) string {
+
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(s) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '_' || r == '-':
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteRune('_')
+		}
+	}
+
+	username := []rune(b.String())
+	if len(username) > oidcUsernameMaxLength {
+		username = username[:oidcUsernameMaxLength]
+	}
+	return string(username)
+}
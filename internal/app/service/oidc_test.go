@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"walk_backend/internal/app/model"
+	mockService "walk_backend/internal/app/service/mock"
+	"walk_backend/internal/pkg/oidc"
+	"walk_backend/internal/pkg/oidc/oidctest"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDCService(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	server, err := oidctest.NewServer("walk", "secret")
+	assert.Nil(t, err)
+	defer server.Close()
+
+	mockUserRepository := mockService.NewMockOIDCUserRepositoryInterface(controller)
+
+	ctx := context.Background()
+	s := NewDefaultOIDCService(mockUserRepository, map[string]OIDCProviderInterface{
+		"test": oidc.NewProvider(oidc.Config{
+			Issuer:       server.Issuer(),
+			ClientID:     "walk",
+			ClientSecret: "secret",
+			RedirectURL:  "https://walk.local/api/v1/auth/oidc/test/callback",
+		}, server.Client()),
+	})
+
+	login := func(t *testing.T) (*model.User, error) {
+		authURL, login, err := s.AuthCodeURL(ctx, "test")
+		assert.Nil(t, err)
+		code, state, err := server.Login(authURL)
+		assert.Nil(t, err)
+		assert.Equal(t, login.State, state)
+		return s.Login(ctx, "test", code, login)
+	}
+
+	t.Run("Unknown_provider", func(t *testing.T) {
+		_, _, err := s.AuthCodeURL(ctx, "facebook")
+		assert.ErrorIs(t, err, ErrUnknownOIDCProvider)
+		_, err = s.Login(ctx, "facebook", "code", &OIDCLogin{})
+		assert.ErrorIs(t, err, ErrUnknownOIDCProvider)
+	})
+
+	t.Run("Invalid_code", func(t *testing.T) {
+		_, err := s.Login(ctx, "test", "forged", &OIDCLogin{State: "state", Nonce: "nonce", CodeVerifier: "verifier"})
+		assert.ErrorIs(t, err, ErrOIDCLogin)
+	})
+
+	t.Run("Linked", func(t *testing.T) {
+		user, err := model.NewExternalUserModel("Wozniak", model.Identity{Provider: "test", Subject: "108"})
+		assert.Nil(t, err)
+		server.SetUser("108", nil)
+		mockUserRepository.EXPECT().FindByIdentity(ctx, "test", "108").Return(user, nil)
+
+		got, err := login(t)
+		assert.Nil(t, err)
+		assert.Equal(t, user, got)
+	})
+
+	t.Run("First_login", func(t *testing.T) {
+		server.SetUser("109", map[string]interface{}{"email": "Woz@Apple.com", "email_verified": true, "preferred_username": "Steve Wozniak"})
+		mockUserRepository.EXPECT().FindByIdentity(ctx, "test", "109").Return(nil, model.ErrModelNotFound)
+		mockUserRepository.EXPECT().FindByEmail(ctx, "woz@apple.com").Return(nil, model.ErrModelNotFound)
+		mockUserRepository.EXPECT().FindByUsername(ctx, "Steve_Wozniak").Return(&model.User{}, nil)
+		mockUserRepository.EXPECT().FindByUsername(ctx, gomock.Any()).Return(nil, model.ErrModelNotFound)
+		mockUserRepository.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, m *model.User) (model.ID, error) {
+			return m.ID, nil
+		})
+
+		user, err := login(t)
+		assert.Nil(t, err)
+		assert.Regexp(t, `^Steve_Wozniak-[0-9a-f]{6}$`, user.Username)
+		assert.Equal(t, "woz@apple.com", user.Email)
+		assert.True(t, user.IsEmailVerified())
+		assert.Equal(t, "109", user.Identities[0].Subject)
+		assert.ErrorIs(t, user.CheckPassword("", model.DefaultPasswordHasher()), model.ErrPassMismatched)
+	})
+
+	t.Run("First_login_unverified_email", func(t *testing.T) {
+		server.SetUser("110", map[string]interface{}{"email": "jobs@apple.com", "email_verified": false})
+		mockUserRepository.EXPECT().FindByIdentity(ctx, "test", "110").Return(nil, model.ErrModelNotFound)
+		mockUserRepository.EXPECT().FindByUsername(ctx, "jobs").Return(nil, model.ErrModelNotFound)
+		mockUserRepository.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, m *model.User) (model.ID, error) {
+			return m.ID, nil
+		})
+
+		user, err := login(t)
+		assert.Nil(t, err)
+		assert.Equal(t, "jobs", user.Username)
+		assert.Empty(t, user.Email)
+		assert.False(t, user.IsEmailVerified())
+	})
+
+	t.Run("Link_verified_email", func(t *testing.T) {
+		verifiedAt := time.Now()
+		user, err := model.NewUserModel("Wozniak", "Apple-II-1977", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+		assert.Nil(t, err)
+		user.Email = "woz@apple.com"
+		user.EmailVerifiedAt = &verifiedAt
+
+		server.SetUser("111", map[string]interface{}{"email": "woz@apple.com", "email_verified": true})
+		mockUserRepository.EXPECT().FindByIdentity(ctx, "test", "111").Return(nil, model.ErrModelNotFound)
+		mockUserRepository.EXPECT().FindByEmail(ctx, "woz@apple.com").Return(user, nil)
+		mockUserRepository.EXPECT().AddIdentity(ctx, user.ID, gomock.Any()).Return(nil)
+
+		got, err := login(t)
+		assert.Nil(t, err)
+		assert.Equal(t, "Wozniak", got.Username)
+		assert.Equal(t, "111", got.Identities[0].Subject)
+	})
+
+	t.Run("Link_unverified_email", func(t *testing.T) {
+		user, err := model.NewUserModel("Squatter", "Apple-II-1977", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+		assert.Nil(t, err)
+		user.Email = "woz@apple.com"
+
+		server.SetUser("112", map[string]interface{}{"email": "woz@apple.com", "email_verified": true})
+		mockUserRepository.EXPECT().FindByIdentity(ctx, "test", "112").Return(nil, model.ErrModelNotFound)
+		mockUserRepository.EXPECT().FindByEmail(ctx, "woz@apple.com").Return(user, nil)
+
+		_, err = login(t)
+		assert.ErrorIs(t, err, ErrOIDCEmailTaken)
+	})
+}
+
+func TestSanitizeUsername(t *testing.T) {
+	assert.Equal(t, "Steve_Wozniak", sanitizeUsername(" Steve Wozniak "))
+	assert.Equal(t, "woz.1977script", sanitizeUsername("woz.1977<script>"))
+	assert.Equal(t, "Воз", sanitizeUsername("Воз!"))
+	assert.Equal(t, "", sanitizeUsername("!!!"))
+	assert.Len(t, []rune(sanitizeUsername("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")), oidcUsernameMaxLength)
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/pkg/mailer"
+)
+
+// ErrInvalidResetToken unknown, expired or used password reset token
+var ErrInvalidResetToken = errors.New("invalid password reset token")
+
+// PasswordResetTokenRepositoryInterface ...
+type PasswordResetTokenRepositoryInterface interface {
+	Create(ctx context.Context, m *model.PasswordResetToken) (model.ID, error)
+	FindByHash(ctx context.Context, tokenHash string) (*model.PasswordResetToken, error)
+	MarkUsed(ctx context.Context, id model.ID, usedAt time.Time) error
+	InvalidateByUsername(ctx context.Context, username string, usedAt time.Time) error
+}
+
+// PasswordResetUserRepositoryInterface ...
+type PasswordResetUserRepositoryInterface interface {
+	FindByUsername(ctx context.Context, username string) (*model.User, error)
+	FindByEmail(ctx context.Context, email string) (*model.User, error)
+	UpdatePassword(ctx context.Context, id model.ID, passwordHash string) error
+}
+
+// MailerInterface ...
+type MailerInterface interface {
+	Send(ctx context.Context, m *mailer.Message) error
+}
+
+// DefaultPasswordResetService ...
+type DefaultPasswordResetService struct {
+	userRepo       PasswordResetUserRepositoryInterface
+	tokenRepo      PasswordResetTokenRepositoryInterface
+	mailer         MailerInterface
+	passwordPolicy *model.PasswordPolicy
+	passwordHasher *model.PasswordHasher
+	ttl            time.Duration
+	resetURL       string
+	now            func() time.Time
+}
+
+// NewDefaultPasswordResetService create new default password reset service,
+// the emailed link is resetURL with the token query parameter, new passwords must satisfy passwordPolicy
+func NewDefaultPasswordResetService(
+	userRepo PasswordResetUserRepositoryInterface,
+	tokenRepo PasswordResetTokenRepositoryInterface,
+	mailer MailerInterface,
+	passwordPolicy *model.PasswordPolicy,
+	passwordHasher *model.PasswordHasher,
+	ttl time.Duration,
+	resetURL string,
+) *DefaultPasswordResetService {
+	return &DefaultPasswordResetService{
+		userRepo:       userRepo,
+		tokenRepo:      tokenRepo,
+		mailer:         mailer,
+		passwordPolicy: passwordPolicy,
+		passwordHasher: passwordHasher,
+		ttl:            ttl,
+		resetURL:       resetURL,
+		now:            time.Now,
+	}
+}
+
+// Forgot email a reset link to the user of the email, replaces earlier links,
+// unknown emails are ignored so the caller can not tell which accounts exist
+func (s *DefaultPasswordResetService) Forgot(ctx context.Context, email string) error {
+
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, model.ErrModelNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	token, err := newOpaqueToken()
+	if err != nil {
+		return err
+	}
+	link, err := url.Parse(s.resetURL)
+	if err != nil {
+		return err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	now := s.now()
+	if err := s.tokenRepo.InvalidateByUsername(ctx, user.Username, now); err != nil {
+		return err
+	}
+	m := &model.PasswordResetToken{
+		TokenHash: hashOpaqueToken(token),
+		Username:  user.Username,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.ttl),
+	}
+	if _, err := s.tokenRepo.Create(ctx, m); err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, &mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nopen the link to choose a new password, it expires in %s:\n\n%s\n\n"+
+				"If you did not ask for a password reset, ignore this email, your password stays the same.\n",
+			user.Username, s.ttl, link,
+		),
+	})
+}
+
+// Reset set the new password with a reset token, the token and every other reset token of the user are used up,
+// *model.ValidationError when the password violates the password policy, the token stays valid then
+func (s *DefaultPasswordResetService) Reset(ctx context.Context, token string, password string) (*model.User, error) {
+
+	if token == "" {
+		return nil, ErrInvalidResetToken
+	}
+	m, err := s.tokenRepo.FindByHash(ctx, hashOpaqueToken(token))
+	if err != nil {
+		if errors.Is(err, model.ErrModelNotFound) {
+			return nil, ErrInvalidResetToken
+		}
+		return nil, err
+	}
+
+	now := s.now()
+	if !m.IsActive(now) {
+		return nil, ErrInvalidResetToken
+	}
+
+	user, err := s.userRepo.FindByUsername(ctx, m.Username)
+	if err != nil {
+		if errors.Is(err, model.ErrModelNotFound) {
+			return nil, ErrInvalidResetToken
+		}
+		return nil, err
+	}
+	if err := user.SetPassword(password, s.passwordPolicy, s.passwordHasher); err != nil {
+		return nil, err
+	}
+
+	// lost a concurrent reset, the token was used in between
+	if err := s.tokenRepo.MarkUsed(ctx, m.ID, now); err != nil {
+		if errors.Is(err, model.ErrModelUpdate) {
+			return nil, ErrInvalidResetToken
+		}
+		return nil, err
+	}
+	if err := s.userRepo.UpdatePassword(ctx, user.ID, user.Password); err != nil {
+		return nil, err
+	}
+	if err := s.tokenRepo.InvalidateByUsername(ctx, user.Username, now); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
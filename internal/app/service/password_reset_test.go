@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"walk_backend/internal/app/model"
+	mockService "walk_backend/internal/app/service/mock"
+	"walk_backend/internal/pkg/mailer"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordResetService(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockUserRepository := mockService.NewMockPasswordResetUserRepositoryInterface(controller)
+	mockTokenRepository := mockService.NewMockPasswordResetTokenRepositoryInterface(controller)
+	mockMailer := mockService.NewMockMailerInterface(controller)
+
+	ctx := context.Background()
+	s := NewDefaultPasswordResetService(mockUserRepository, mockTokenRepository, mockMailer, &model.PasswordPolicy{}, model.DefaultPasswordHasher(), time.Hour, "https://walk.local/reset?lang=en")
+
+	user, err := model.NewUserModel("Wozniak", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+	assert.Nil(t, err)
+	user.Email = "woz@apple.com"
+
+	t.Run("Forgot_unknown_email", func(t *testing.T) {
+		mockUserRepository.EXPECT().FindByEmail(ctx, "jobs@apple.com").Return(nil, model.ErrModelNotFound)
+
+		assert.Nil(t, s.Forgot(ctx, "jobs@apple.com"))
+	})
+
+	var token string
+	t.Run("Forgot", func(t *testing.T) {
+		mockUserRepository.EXPECT().FindByEmail(ctx, "Woz@Apple.com").Return(user, nil)
+		mockTokenRepository.EXPECT().InvalidateByUsername(ctx, "Wozniak", gomock.Any()).Return(nil)
+		mockTokenRepository.
+			EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, m *model.PasswordResetToken) (model.ID, error) {
+				assert.Equal(t, "Wozniak", m.Username)
+				assert.Equal(t, time.Hour, m.ExpiresAt.Sub(m.CreatedAt))
+				return model.NewID()
+			})
+		mockMailer.
+			EXPECT().
+			Send(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, m *mailer.Message) error {
+				assert.Equal(t, "woz@apple.com", m.To)
+				link, err := url.Parse(regexp.MustCompile(`https://\S+`).FindString(m.Body))
+				assert.Nil(t, err)
+				assert.Equal(t, "en", link.Query().Get("lang"))
+				token = link.Query().Get("token")
+				return nil
+			})
+
+		assert.Nil(t, s.Forgot(ctx, "Woz@Apple.com"))
+		assert.NotEmpty(t, token)
+	})
+
+	active := &model.PasswordResetToken{Username: "Wozniak", ExpiresAt: time.Now().Add(time.Hour)}
+	active.ID, _ = model.NewID()
+
+	t.Run("Reset_invalid_token", func(t *testing.T) {
+		used := time.Now()
+		mockTokenRepository.EXPECT().FindByHash(ctx, hashOpaqueToken("forged")).Return(nil, model.ErrModelNotFound)
+		mockTokenRepository.EXPECT().FindByHash(ctx, hashOpaqueToken("used")).Return(&model.PasswordResetToken{Username: "Wozniak", ExpiresAt: time.Now().Add(time.Hour), UsedAt: &used}, nil)
+		mockTokenRepository.EXPECT().FindByHash(ctx, hashOpaqueToken("expired")).Return(&model.PasswordResetToken{Username: "Wozniak", ExpiresAt: time.Now()}, nil)
+
+		for _, token := range []string{"", "forged", "used", "expired"} {
+			_, err := s.Reset(ctx, token, "Apple-II-1977")
+			assert.ErrorIs(t, err, ErrInvalidResetToken, token)
+		}
+	})
+
+	t.Run("Reset_concurrent", func(t *testing.T) {
+		mockTokenRepository.EXPECT().FindByHash(ctx, hashOpaqueToken(token)).Return(active, nil)
+		mockUserRepository.EXPECT().FindByUsername(ctx, "Wozniak").Return(user, nil)
+		mockTokenRepository.EXPECT().MarkUsed(ctx, active.ID, gomock.Any()).Return(model.ErrModelUpdate)
+
+		_, err := s.Reset(ctx, token, "Apple-II-1977")
+		assert.ErrorIs(t, err, ErrInvalidResetToken)
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		mockTokenRepository.EXPECT().FindByHash(ctx, hashOpaqueToken(token)).Return(active, nil)
+		mockUserRepository.EXPECT().FindByUsername(ctx, "Wozniak").Return(user, nil)
+		mockTokenRepository.EXPECT().MarkUsed(ctx, active.ID, gomock.Any()).Return(nil)
+		mockUserRepository.EXPECT().UpdatePassword(ctx, user.ID, gomock.Any()).Return(nil)
+		mockTokenRepository.EXPECT().InvalidateByUsername(ctx, "Wozniak", gomock.Any()).Return(nil)
+
+		m, err := s.Reset(ctx, token, "Apple-II-1977")
+		assert.Nil(t, err)
+		assert.Nil(t, m.CheckPassword("Apple-II-1977", model.DefaultPasswordHasher()))
+	})
+}
@@ -2,11 +2,15 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 
 	"walk_backend/internal/app/dto"
 	"walk_backend/internal/app/model"
 	"walk_backend/internal/pkg/cache"
+	"walk_backend/internal/pkg/searchquery"
+	"walk_backend/internal/pkg/translit"
 
 	"github.com/gosimple/slug"
 )
@@ -16,6 +20,16 @@ const (
 	listPlacesCacheDuration       time.Duration = 5 * time.Minute
 	searchListPlacesCacheKey      string        = "search-list-places"
 	searchListPlacesCacheDuration time.Duration = 5 * time.Minute
+
+	// DefaultCategoryPlacesLimit ...
+	DefaultCategoryPlacesLimit int64 = 20
+	// MaxCategoryPlacesLimit ...
+	MaxCategoryPlacesLimit int64 = 100
+)
+
+var (
+	// ErrInvalidPaging ...
+	ErrInvalidPaging = errors.New("invalid offset or limit")
 )
 
 // PlaceRepositoryInterface ...
@@ -25,7 +39,9 @@ type PlaceRepositoryInterface interface {
 	Create(ctx context.Context, m *model.Place) (model.ID, error)
 	Update(ctx context.Context, m *model.Place) error
 	Delete(ctx context.Context, id model.ID) error
-	Search(ctx context.Context, search string) (model.PlaceList, error)
+	Search(ctx context.Context, criteria *model.PlaceSearchCriteria) (model.PlaceList, error)
+	FindPublishedByCategory(ctx context.Context, categoryID model.ID, offset int64, limit int64) (model.PlaceList, error)
+	CountPublishedByCategory(ctx context.Context, categoryID model.ID) (int64, error)
 }
 
 // PlaceCategoryRepositoryInterface ...
@@ -34,6 +50,11 @@ type PlaceCategoryRepositoryInterface interface {
 	FindAll(ctx context.Context) (model.CategoryList, error)
 }
 
+// PlaceTagRepositoryInterface ...
+type PlaceTagRepositoryInterface interface {
+	Recount(ctx context.Context, names []string) error
+}
+
 // PlaceQueueRepositoryInterface ...
 type PlaceQueueRepositoryInterface interface {
 	PublishReIndex(id model.ID) error
@@ -44,14 +65,17 @@ type PlaceCacheRepositoryInterface interface {
 	Get(ctx context.Context, key string) (model.PlaceList, error)
 	Set(ctx context.Context, key string, value model.PlaceList, expiration time.Duration) error
 	Del(ctx context.Context, keys ...string) error
+	DelByPrefix(ctx context.Context, prefix string) error
 }
 
 // DefaultPlaceService ...
 type DefaultPlaceService struct {
 	placeRepo    PlaceRepositoryInterface
 	categoryRepo PlaceCategoryRepositoryInterface
+	tagRepo      PlaceTagRepositoryInterface
 	placeQueue   PlaceQueueRepositoryInterface
 	placeCache   PlaceCacheRepositoryInterface
+	countCache   CategoryCountCacheRepositoryInterface
 	keyBuilder   cache.KeyBuilderInterface
 }
 
@@ -59,15 +83,19 @@ type DefaultPlaceService struct {
 func NewDefaultPlaceService(
 	placeRepo PlaceRepositoryInterface,
 	categoryRepo PlaceCategoryRepositoryInterface,
+	tagRepo PlaceTagRepositoryInterface,
 	placeQueue PlaceQueueRepositoryInterface,
 	placeCache PlaceCacheRepositoryInterface,
+	countCache CategoryCountCacheRepositoryInterface,
 	keyBuilder cache.KeyBuilderInterface,
 ) *DefaultPlaceService {
 	return &DefaultPlaceService{
 		placeRepo:    placeRepo,
 		categoryRepo: categoryRepo,
+		tagRepo:      tagRepo,
 		placeQueue:   placeQueue,
 		placeCache:   placeCache,
+		countCache:   countCache,
 		keyBuilder:   keyBuilder,
 	}
 }
@@ -101,6 +129,13 @@ func (s *DefaultPlaceService) Create(ctx context.Context, d *dto.Place) (model.I
 	if err != nil {
 		return model.NilID, err
 	}
+	if d.AuthorID != "" {
+		authorID, err := model.StringToID(d.AuthorID)
+		if err != nil {
+			return model.NilID, err
+		}
+		m.AuthorID = &authorID
+	}
 	m.CreatedAt = time.Now()
 
 	id, err := s.placeRepo.Create(ctx, m)
@@ -108,10 +143,18 @@ func (s *DefaultPlaceService) Create(ctx context.Context, d *dto.Place) (model.I
 		return model.NilID, err
 	}
 
+	if err := s.tagRepo.Recount(ctx, m.Tags); err != nil {
+		return model.NilID, err
+	}
+
 	if err := s.placeCache.Del(ctx, listPlacesCacheKey); err != nil {
 		return model.NilID, err
 	}
 
+	if err := s.countCache.Del(ctx); err != nil {
+		return model.NilID, err
+	}
+
 	if err := s.placeQueue.PublishReIndex(m.ID); err != nil {
 		return model.NilID, err
 	}
@@ -128,14 +171,29 @@ func (s *DefaultPlaceService) Update(ctx context.Context, d *dto.Place) error {
 	}
 	m.UpdatedAt = time.Now()
 
+	old, err := s.placeRepo.Find(ctx, m.ID)
+	if err != nil {
+		return err
+	}
+
 	if err := s.placeRepo.Update(ctx, m); err != nil {
 		return err
 	}
 
+	if err := s.tagRepo.Recount(ctx, model.NormaliseTags(append(old.Tags, m.Tags...))); err != nil {
+		return err
+	}
+
 	if err := s.placeCache.Del(ctx, listPlacesCacheKey); err != nil {
 		return err
 	}
 
+	if old.Category != m.Category {
+		if err := s.countCache.Del(ctx); err != nil {
+			return err
+		}
+	}
+
 	if err := s.placeQueue.PublishReIndex(m.ID); err != nil {
 		return err
 	}
@@ -146,14 +204,27 @@ func (s *DefaultPlaceService) Update(ctx context.Context, d *dto.Place) error {
 // Delete ...
 func (s *DefaultPlaceService) Delete(ctx context.Context, id model.ID) error {
 
+	old, err := s.placeRepo.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+
 	if err := s.placeRepo.Delete(ctx, id); err != nil {
 		return err
 	}
 
+	if err := s.tagRepo.Recount(ctx, old.Tags); err != nil {
+		return err
+	}
+
 	if err := s.placeCache.Del(ctx, listPlacesCacheKey); err != nil {
 		return err
 	}
 
+	if err := s.countCache.Del(ctx); err != nil {
+		return err
+	}
+
 	if err := s.placeQueue.PublishReIndex(id); err != nil {
 		return err
 	}
@@ -166,12 +237,47 @@ func (s *DefaultPlaceService) Find(ctx context.Context, id model.ID) (*model.Pla
 	return s.placeRepo.Find(ctx, id)
 }
 
-// Search ...
-func (s *DefaultPlaceService) Search(ctx context.Context, search string) (model.PlaceList, error) {
+// ListPlacesByCategory page of published places of the category and their total number
+func (s *DefaultPlaceService) ListPlacesByCategory(ctx context.Context, categoryID model.ID, offset int64, limit int64) (model.PlaceList, int64, error) {
+
+	if offset < 0 || limit < 1 || limit > MaxCategoryPlacesLimit {
+		return nil, 0, ErrInvalidPaging
+	}
+
+	total, err := s.placeRepo.CountPublishedByCategory(ctx, categoryID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if offset >= total {
+		return make(model.PlaceList, 0), total, nil
+	}
+
+	places, err := s.placeRepo.FindPublishedByCategory(ctx, categoryID, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return places, total, nil
+}
+
+// Search search places by query, see searchquery package for the syntax,
+// withSubcategories makes category filters match the descendants too
+func (s *DefaultPlaceService) Search(ctx context.Context, search string, withSubcategories bool) (model.PlaceList, error) {
+
+	query, err := searchquery.Parse(search)
+	if err != nil {
+		return nil, err
+	}
+	if query.IsEmpty() {
+		return make(model.PlaceList, 0), nil
+	}
 
 	key := s.keyBuilder.NewKey()
 	key.Add(searchListPlacesCacheKey)
-	if err := key.AddHashed(search); err != nil {
+	if withSubcategories {
+		key.Add("subcategories")
+	}
+	if err := key.AddHashed(query.String()); err != nil {
 		return nil, err
 	}
 	cacheKey := key.String()
@@ -183,11 +289,20 @@ func (s *DefaultPlaceService) Search(ctx context.Context, search string) (model.
 		return places, nil
 	}
 
-	places, err = s.placeRepo.Search(ctx, search)
+	criteria, err := s.makeSearchCriteria(ctx, query, withSubcategories)
 	if err != nil {
 		return nil, err
 	}
 
+	if criteria != nil {
+		places, err = s.placeRepo.Search(ctx, criteria)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		places = make(model.PlaceList, 0)
+	}
+
 	if err = s.placeCache.Set(ctx, cacheKey, places, searchListPlacesCacheDuration); err != nil {
 		return nil, err
 	}
@@ -228,17 +343,114 @@ func (s *DefaultPlaceService) makeModelFromPlaceDTO(ctx context.Context, d *dto.
 		return nil, err
 	}
 
+	var location *model.GeoPoint
+	if d.Location != nil {
+		location = model.NewGeoPoint(*d.Location.Lat, *d.Location.Lng)
+	}
+
 	m, err := model.NewPlaceModel(
 		id,
 		d.Name,
 		slug.Make(d.Name),
 		d.Description,
 		categoryID,
-		d.Tags,
+		model.NormaliseTags(d.Tags),
+		location,
 	)
 	if err != nil {
 		return nil, err
 	}
+	m.UpdateSearchTerms()
 
 	return m, nil
 }
+
+// makeSearchCriteria resolve query categories by ID or name, returns nil criteria when nothing can match
+func (s *DefaultPlaceService) makeSearchCriteria(ctx context.Context, query *searchquery.Query, withSubcategories bool) (*model.PlaceSearchCriteria, error) {
+
+	criteria := &model.PlaceSearchCriteria{
+		Terms:           expandSearchTerms(query.Terms),
+		Phrases:         query.Phrases,
+		ExcludedTerms:   expandSearchTerms(query.ExcludedTerms),
+		ExcludedPhrases: query.ExcludedPhrases,
+		// places store normalised tags and $all/$nin compare case-sensitively
+		Tags:         model.NormaliseTags(query.Tags),
+		ExcludedTags: model.NormaliseTags(query.ExcludedTags),
+	}
+
+	if query.Near != nil {
+		criteria.Near = &model.GeoCircle{
+			Center:   model.NewGeoPoint(query.Near.Lat, query.Near.Lng),
+			RadiusKm: query.Near.RadiusKm,
+		}
+	}
+
+	if len(query.Categories) == 0 && len(query.ExcludedCategories) == 0 {
+		return criteria, nil
+	}
+
+	categories, err := s.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// several category: filters are combined with OR, a place has only one category
+	criteria.Categories = resolveSearchCategories(categories, query.Categories)
+	if len(query.Categories) > 0 && len(criteria.Categories) == 0 {
+		return nil, nil
+	}
+	criteria.ExcludedCategories = resolveSearchCategories(categories, query.ExcludedCategories)
+
+	if withSubcategories {
+		criteria.Categories = withDescendantCategories(categories, criteria.Categories)
+		criteria.ExcludedCategories = withDescendantCategories(categories, criteria.ExcludedCategories)
+	}
+
+	return criteria, nil
+}
+
+// expandSearchTerms add transliterated variants, so latin terms match cyrillic places and the reverse
+func expandSearchTerms(terms []string) []string {
+
+	expanded := make([]string, 0, len(terms)*3)
+	for _, term := range terms {
+		expanded = append(expanded, term)
+		expanded = append(expanded, translit.Expand(term)...)
+	}
+
+	return expanded
+}
+
+func withDescendantCategories(categories model.CategoryList, ids []model.ID) []model.ID {
+
+	seen := make(map[model.ID]struct{}, len(ids))
+	result := make([]model.ID, 0, len(ids))
+	for _, id := range ids {
+		for _, descendant := range categories.Descendants(id) {
+			if _, ok := seen[descendant]; !ok {
+				seen[descendant] = struct{}{}
+				result = append(result, descendant)
+			}
+		}
+	}
+
+	return result
+}
+
+func resolveSearchCategories(categories model.CategoryList, values []string) []model.ID {
+
+	ids := make([]model.ID, 0, len(values))
+	for _, value := range values {
+		if id, err := model.StringToID(value); err == nil {
+			ids = append(ids, id)
+			continue
+		}
+		for _, category := range categories {
+			if strings.EqualFold(category.Name, value) {
+				ids = append(ids, category.ID)
+			}
+		}
+	}
+
+	return ids
+}
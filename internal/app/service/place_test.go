@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"walk_backend/internal/app/model"
+	mockService "walk_backend/internal/app/service/mock"
+	"walk_backend/internal/pkg/cache"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaceService_Search_tags(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockPlaceRepository := mockService.NewMockPlaceRepositoryInterface(controller)
+	mockPlaceCache := mockService.NewMockPlaceCacheRepositoryInterface(controller)
+
+	ctx := context.Background()
+	places := makeReindexPlaces(t, 1)
+
+	mockPlaceCache.EXPECT().Get(ctx, gomock.Any()).Return(nil, nil)
+	mockPlaceRepository.EXPECT().Search(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, criteria *model.PlaceSearchCriteria) (model.PlaceList, error) {
+			assert.Equal(t, []string{"park"}, criteria.Tags)
+			assert.Equal(t, []string{"old town"}, criteria.ExcludedTags)
+			return places, nil
+		},
+	)
+	mockPlaceCache.EXPECT().Set(ctx, gomock.Any(), places, searchListPlacesCacheDuration).Return(nil)
+
+	s := NewDefaultPlaceService(mockPlaceRepository, nil, nil, nil, mockPlaceCache, nil, cache.NewKeyBuilderDefault())
+	result, err := s.Search(ctx, `tag:Park tag:PARK -tag:"Old  Town"`, false)
+
+	assert.Nil(t, err)
+	assert.Equal(t, places, result)
+}
+
+func TestPlaceService_Search_excluded_terms(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockPlaceRepository := mockService.NewMockPlaceRepositoryInterface(controller)
+	mockPlaceCache := mockService.NewMockPlaceCacheRepositoryInterface(controller)
+
+	ctx := context.Background()
+
+	mockPlaceCache.EXPECT().Get(ctx, gomock.Any()).Return(nil, nil)
+	mockPlaceRepository.EXPECT().Search(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, criteria *model.PlaceSearchCriteria) (model.PlaceList, error) {
+			assert.Equal(t, []string{"парк", "park"}, criteria.ExcludedTerms)
+			return model.PlaceList{}, nil
+		},
+	)
+	mockPlaceCache.EXPECT().Set(ctx, gomock.Any(), model.PlaceList{}, searchListPlacesCacheDuration).Return(nil)
+
+	s := NewDefaultPlaceService(mockPlaceRepository, nil, nil, nil, mockPlaceCache, nil, cache.NewKeyBuilderDefault())
+	_, err := s.Search(ctx, "музей -парк", false)
+
+	assert.Nil(t, err)
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"walk_backend/internal/app/dto"
+	"walk_backend/internal/app/model"
+)
+
+// ProfileUserRepositoryInterface ...
+type ProfileUserRepositoryInterface interface {
+	FindByID(ctx context.Context, id model.ID) (*model.User, error)
+	Update(ctx context.Context, m *model.User) error
+	Delete(ctx context.Context, id model.ID) error
+	CountByRole(ctx context.Context, role model.Role) (int64, error)
+}
+
+// ProfilePlaceRepositoryInterface ...
+type ProfilePlaceRepositoryInterface interface {
+	FindByAuthor(ctx context.Context, authorID model.ID) (model.PlaceList, error)
+	AnonymiseAuthor(ctx context.Context, authorID model.ID) (int64, error)
+}
+
+// ProfileCategoryRepositoryInterface ...
+type ProfileCategoryRepositoryInterface interface {
+	FindAll(ctx context.Context) (model.CategoryList, error)
+}
+
+// ProfileSessionRepositoryInterface ...
+type ProfileSessionRepositoryInterface interface {
+	FindActiveByUsername(ctx context.Context, username string, now time.Time) ([]*model.SessionToken, error)
+	DeleteByUsername(ctx context.Context, username string) (int64, error)
+}
+
+// ProfileAPIKeyRepositoryInterface ...
+type ProfileAPIKeyRepositoryInterface interface {
+	FindActiveByUsername(ctx context.Context, username string, now time.Time) ([]*model.APIKey, error)
+	DeleteByUsername(ctx context.Context, username string) (int64, error)
+}
+
+// ProfilePasswordResetRepositoryInterface ...
+type ProfilePasswordResetRepositoryInterface interface {
+	InvalidateByUsername(ctx context.Context, username string, usedAt time.Time) error
+}
+
+// ProfileSearchLogRepositoryInterface ...
+type ProfileSearchLogRepositoryInterface interface {
+	AnonymiseUsername(ctx context.Context, username string) error
+}
+
+// ProfileTokenServiceInterface ...
+type ProfileTokenServiceInterface interface {
+	RevokeAll(ctx context.Context, username string) error
+}
+
+// DefaultProfileService profile of the current user, its deletion and personal data export
+type DefaultProfileService struct {
+	userRepo          ProfileUserRepositoryInterface
+	placeRepo         ProfilePlaceRepositoryInterface
+	categoryRepo      ProfileCategoryRepositoryInterface
+	sessionRepo       ProfileSessionRepositoryInterface
+	apiKeyRepo        ProfileAPIKeyRepositoryInterface
+	passwordResetRepo ProfilePasswordResetRepositoryInterface
+	searchLogRepo     ProfileSearchLogRepositoryInterface
+	tokens            ProfileTokenServiceInterface
+	passwordHasher    *model.PasswordHasher
+	now               func() time.Time
+}
+
+// NewDefaultProfileService create new default profile service
+func NewDefaultProfileService(
+	userRepo ProfileUserRepositoryInterface,
+	placeRepo ProfilePlaceRepositoryInterface,
+	categoryRepo ProfileCategoryRepositoryInterface,
+	sessionRepo ProfileSessionRepositoryInterface,
+	apiKeyRepo ProfileAPIKeyRepositoryInterface,
+	passwordResetRepo ProfilePasswordResetRepositoryInterface,
+	searchLogRepo ProfileSearchLogRepositoryInterface,
+	tokens ProfileTokenServiceInterface,
+	passwordHasher *model.PasswordHasher,
+) *DefaultProfileService {
+	return &DefaultProfileService{
+		userRepo:          userRepo,
+		placeRepo:         placeRepo,
+		categoryRepo:      categoryRepo,
+		sessionRepo:       sessionRepo,
+		apiKeyRepo:        apiKeyRepo,
+		passwordResetRepo: passwordResetRepo,
+		searchLogRepo:     searchLogRepo,
+		tokens:            tokens,
+		passwordHasher:    passwordHasher,
+		now:               time.Now,
+	}
+}
+
+// Update set the profile fields present in the DTO, returns the updated user
+func (s *DefaultProfileService) Update(ctx context.Context, id model.ID, d *dto.Profile) (*model.User, error) {
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName, avatarURL, locale := user.DisplayName, user.AvatarURL, user.Locale
+	if d.DisplayName != nil {
+		displayName = *d.DisplayName
+	}
+	if d.AvatarURL != nil {
+		avatarURL = *d.AvatarURL
+	}
+	if d.Locale != nil {
+		locale = *d.Locale
+	}
+	if err := user.SetProfile(displayName, avatarURL, locale); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Delete delete the account, users with a password confirm it. Sessions and API keys are deleted, bearer tokens revoked,
+// places and search log entries are kept without the author. The last admin can not be deleted
+func (s *DefaultProfileService) Delete(ctx context.Context, user *model.User, password string) error {
+
+	if user.Password != "" {
+		if err := user.CheckPassword(password, s.passwordHasher); err != nil {
+			if errors.Is(err, model.ErrPassMismatched) {
+				return ErrInvalidCurrentPassword
+			}
+			return err
+		}
+	}
+
+	if user.HasRole(model.RoleAdmin) {
+		admins, err := s.userRepo.CountByRole(ctx, model.RoleAdmin)
+		if err != nil {
+			return err
+		}
+		if admins <= 1 {
+			return ErrLastAdmin
+		}
+	}
+
+	// the user goes last, a failed deletion is retried with the account intact
+	if _, err := s.placeRepo.AnonymiseAuthor(ctx, user.ID); err != nil {
+		return err
+	}
+	if err := s.searchLogRepo.AnonymiseUsername(ctx, user.Username); err != nil {
+		return err
+	}
+	// the username may be registered again, nothing of this account must work for the new one
+	if err := s.passwordResetRepo.InvalidateByUsername(ctx, user.Username, s.now()); err != nil {
+		return err
+	}
+	if err := s.tokens.RevokeAll(ctx, user.Username); err != nil {
+		return err
+	}
+	if _, err := s.apiKeyRepo.DeleteByUsername(ctx, user.Username); err != nil {
+		return err
+	}
+	if _, err := s.sessionRepo.DeleteByUsername(ctx, user.Username); err != nil {
+		return err
+	}
+
+	return s.userRepo.Delete(ctx, user.ID)
+}
+
+// Export personal data of the user: profile, active sessions, API keys and created places
+func (s *DefaultProfileService) Export(ctx context.Context, id model.ID) (*model.UserExport, error) {
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	sessions, err := s.sessionRepo.FindActiveByUsername(ctx, user.Username, now)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.apiKeyRepo.FindActiveByUsername(ctx, user.Username, now)
+	if err != nil {
+		return nil, err
+	}
+
+	places, err := s.placeRepo.FindByAuthor(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.UserExport{
+		User:       user,
+		Sessions:   sessions,
+		APIKeys:    keys,
+		Places:     places,
+		Categories: categories,
+		ExportedAt: now,
+	}, nil
+}
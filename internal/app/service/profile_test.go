@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"walk_backend/internal/app/dto"
+	"walk_backend/internal/app/model"
+	mockService "walk_backend/internal/app/service/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileService(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockUserRepository := mockService.NewMockProfileUserRepositoryInterface(controller)
+	mockPlaceRepository := mockService.NewMockProfilePlaceRepositoryInterface(controller)
+	mockCategoryRepository := mockService.NewMockProfileCategoryRepositoryInterface(controller)
+	mockSessionRepository := mockService.NewMockProfileSessionRepositoryInterface(controller)
+	mockAPIKeyRepository := mockService.NewMockProfileAPIKeyRepositoryInterface(controller)
+	mockPasswordResetRepository := mockService.NewMockProfilePasswordResetRepositoryInterface(controller)
+	mockSearchLogRepository := mockService.NewMockProfileSearchLogRepositoryInterface(controller)
+	mockTokenService := mockService.NewMockProfileTokenServiceInterface(controller)
+
+	ctx := context.Background()
+	now := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+	s := NewDefaultProfileService(
+		mockUserRepository,
+		mockPlaceRepository,
+		mockCategoryRepository,
+		mockSessionRepository,
+		mockAPIKeyRepository,
+		mockPasswordResetRepository,
+		mockSearchLogRepository,
+		mockTokenService,
+		model.DefaultPasswordHasher(),
+	)
+	s.now = func() time.Time { return now }
+
+	user, err := model.NewUserModel("Wozniak", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+	assert.Nil(t, err)
+	assert.Nil(t, user.SetProfile("Woz", "", "en"))
+	mockUserRepository.EXPECT().FindByID(ctx, user.ID).Return(user, nil).AnyTimes()
+
+	t.Run("Update", func(t *testing.T) {
+		mockUserRepository.
+			EXPECT().
+			Update(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, m *model.User) error {
+				assert.Equal(t, "Steve Wozniak", m.DisplayName)
+				assert.Equal(t, "en", m.Locale)
+				return nil
+			})
+
+		displayName := "Steve Wozniak"
+		m, err := s.Update(ctx, user.ID, &dto.Profile{DisplayName: &displayName})
+		assert.Nil(t, err)
+		assert.Equal(t, "Steve Wozniak", m.DisplayName)
+	})
+
+	t.Run("Update_invalid", func(t *testing.T) {
+		avatarURL := "ftp://example.com/woz.png"
+		_, err := s.Update(ctx, user.ID, &dto.Profile{AvatarURL: &avatarURL})
+
+		var validationErr *model.ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		assert.Contains(t, validationErr.Fields, "avatarUrl")
+	})
+
+	t.Run("Export", func(t *testing.T) {
+		placeID, _ := model.NewID()
+		categoryID, _ := model.NewID()
+		place := &model.Place{ID: placeID, Name: "Apple Garage", Category: categoryID, AuthorID: &user.ID}
+		mockSessionRepository.EXPECT().FindActiveByUsername(ctx, "Wozniak", now).Return([]*model.SessionToken{{Username: "Wozniak"}}, nil)
+		mockAPIKeyRepository.EXPECT().FindActiveByUsername(ctx, "Wozniak", now).Return([]*model.APIKey{}, nil)
+		mockPlaceRepository.EXPECT().FindByAuthor(ctx, user.ID).Return(model.PlaceList{place}, nil)
+		mockCategoryRepository.EXPECT().FindAll(ctx).Return(model.CategoryList{{ID: categoryID, Name: "Sights"}}, nil)
+
+		m, err := s.Export(ctx, user.ID)
+		assert.Nil(t, err)
+		assert.Equal(t, user, m.User)
+		assert.Len(t, m.Sessions, 1)
+		assert.Equal(t, model.PlaceList{place}, m.Places)
+		assert.Equal(t, now, m.ExportedAt)
+	})
+
+	t.Run("Delete_invalid_password", func(t *testing.T) {
+		assert.ErrorIs(t, s.Delete(ctx, user, "guess"), ErrInvalidCurrentPassword)
+	})
+
+	t.Run("Delete_last_admin", func(t *testing.T) {
+		user.Roles = []model.Role{model.RoleAdmin}
+		defer func() { user.Roles = []model.Role{model.RoleContributor} }()
+		mockUserRepository.EXPECT().CountByRole(ctx, model.RoleAdmin).Return(int64(1), nil)
+
+		assert.ErrorIs(t, s.Delete(ctx, user, "password"), ErrLastAdmin)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		gomock.InOrder(
+			mockPlaceRepository.EXPECT().AnonymiseAuthor(ctx, user.ID).Return(int64(1), nil),
+			mockSearchLogRepository.EXPECT().AnonymiseUsername(ctx, "Wozniak").Return(nil),
+			mockPasswordResetRepository.EXPECT().InvalidateByUsername(ctx, "Wozniak", now).Return(nil),
+			mockTokenService.EXPECT().RevokeAll(ctx, "Wozniak").Return(nil),
+			mockAPIKeyRepository.EXPECT().DeleteByUsername(ctx, "Wozniak").Return(int64(0), nil),
+			mockSessionRepository.EXPECT().DeleteByUsername(ctx, "Wozniak").Return(int64(2), nil),
+			mockUserRepository.EXPECT().Delete(ctx, user.ID).Return(nil),
+		)
+
+		assert.Nil(t, s.Delete(ctx, user, "password"))
+	})
+
+	t.Run("Delete_external_user", func(t *testing.T) {
+		external, err := model.NewExternalUserModel("jobs", model.Identity{Provider: "google", Subject: "1"})
+		assert.Nil(t, err)
+		mockPlaceRepository.EXPECT().AnonymiseAuthor(ctx, external.ID).Return(int64(0), nil)
+		mockSearchLogRepository.EXPECT().AnonymiseUsername(ctx, "jobs").Return(nil)
+		mockPasswordResetRepository.EXPECT().InvalidateByUsername(ctx, "jobs", now).Return(nil)
+		mockTokenService.EXPECT().RevokeAll(ctx, "jobs").Return(nil)
+		mockAPIKeyRepository.EXPECT().DeleteByUsername(ctx, "jobs").Return(int64(0), nil)
+		mockSessionRepository.EXPECT().DeleteByUsername(ctx, "jobs").Return(int64(1), nil)
+		mockUserRepository.EXPECT().Delete(ctx, external.ID).Return(nil)
+
+		// no password to confirm with
+		assert.Nil(t, s.Delete(ctx, external, ""))
+	})
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ErrRateLimited ...
+var ErrRateLimited = errors.New("too many requests")
+
+var rateLimited = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "rate_limited_total",
+		Help: "Number of requests rejected over the rate limit",
+	},
+	[]string{"scope"},
+)
+
+func init() {
+	prometheus.MustRegister(rateLimited)
+}
+
+// RateLimitRepositoryInterface ...
+type RateLimitRepositoryInterface interface {
+	Incr(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error)
+}
+
+// RateLimitConfig ...
+type RateLimitConfig struct {
+	// Scope separates the counters and labels the metric, e.g. search_click
+	Scope string
+	// Limit requests per key within the window
+	Limit  int64
+	Window time.Duration
+	// Allowlist IPs and CIDRs never limited
+	Allowlist []string
+}
+
+// DefaultRateLimitService fixed window request counter per IP, unlike the login throttle every request counts
+type DefaultRateLimitService struct {
+	rateLimitRepo RateLimitRepositoryInterface
+	cfg           RateLimitConfig
+	allowlist     []*net.IPNet
+}
+
+// NewDefaultRateLimitService create new default rate limit service
+func NewDefaultRateLimitService(rateLimitRepo RateLimitRepositoryInterface, cfg RateLimitConfig) (*DefaultRateLimitService, error) {
+
+	allowlist, err := parseAllowlist(cfg.Allowlist)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DefaultRateLimitService{
+		rateLimitRepo: rateLimitRepo,
+		cfg:           cfg,
+		allowlist:     allowlist,
+	}, nil
+}
+
+// Allow count the request of the IP, *ThrottledError until the window ends once over the limit
+func (s *DefaultRateLimitService) Allow(ctx context.Context, ip string) error {
+
+	if allowlisted(s.allowlist, ip) {
+		return nil
+	}
+
+	requests, ttl, err := s.rateLimitRepo.Incr(ctx, s.cfg.Scope+":"+ip, s.cfg.Window)
+	if err != nil {
+		return err
+	}
+	if requests <= s.cfg.Limit {
+		return nil
+	}
+
+	rateLimited.WithLabelValues(s.cfg.Scope).Inc()
+	return &ThrottledError{RetryAfter: ttl, Err: ErrRateLimited}
+}
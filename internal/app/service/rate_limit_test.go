@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockService "walk_backend/internal/app/service/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitService(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockRateLimitRepository := mockService.NewMockRateLimitRepositoryInterface(controller)
+
+	ctx := context.Background()
+	s, err := NewDefaultRateLimitService(mockRateLimitRepository, RateLimitConfig{
+		Scope:     "search_click",
+		Limit:     60,
+		Window:    time.Minute,
+		Allowlist: []string{"127.0.0.1"},
+	})
+	assert.Nil(t, err)
+
+	t.Run("Within_limit", func(t *testing.T) {
+		mockRateLimitRepository.EXPECT().Incr(ctx, "search_click:192.0.2.1", time.Minute).Return(int64(60), 10*time.Second, nil)
+
+		assert.Nil(t, s.Allow(ctx, "192.0.2.1"))
+	})
+
+	t.Run("Over_limit", func(t *testing.T) {
+		mockRateLimitRepository.EXPECT().Incr(ctx, "search_click:192.0.2.1", time.Minute).Return(int64(61), 10*time.Second, nil)
+
+		err := s.Allow(ctx, "192.0.2.1")
+		assert.ErrorIs(t, err, ErrRateLimited)
+		assert.NotErrorIs(t, err, ErrLoginThrottled)
+		assert.Equal(t, 10*time.Second, err.(*ThrottledError).RetryAfter)
+	})
+
+	t.Run("Allowlist", func(t *testing.T) {
+		// no repository calls
+		assert.Nil(t, s.Allow(ctx, "127.0.0.1"))
+	})
+}
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"walk_backend/internal/app/model"
+)
+
+const (
+	defaultReindexBatchSize int64 = 500
+	// reindexCatchUpMargin covers the clock skew between the API servers and the reindex job
+	reindexCatchUpMargin time.Duration = time.Minute
+)
+
+// ReindexPlaceRepositoryInterface ...
+type ReindexPlaceRepositoryInterface interface {
+	FindBatch(ctx context.Context, after model.ID, limit int64) (model.PlaceList, error)
+	FindChangedSince(ctx context.Context, since time.Time, after model.ID, limit int64) (model.PlaceList, error)
+	ExistingIDs(ctx context.Context, ids []model.ID) ([]model.ID, error)
+	Count(ctx context.Context) (int64, error)
+}
+
+// ReindexSearchRepositoryInterface ...
+type ReindexSearchRepositoryInterface interface {
+	CreateIndex(ctx context.Context, index string) error
+	BulkIndex(ctx context.Context, index string, places model.PlaceList) error
+	BulkDelete(ctx context.Context, index string, ids []model.ID) error
+	ScanIDs(ctx context.Context, index string, size int64, fn func(ids []model.ID) error) error
+	RefreshIndex(ctx context.Context, index string) error
+	AliasIndices(ctx context.Context, alias string) ([]string, error)
+	SwapAlias(ctx context.Context, alias string, index string, oldIndices []string) error
+	IsIndex(ctx context.Context, name string) (bool, error)
+	ReplaceIndexWithAlias(ctx context.Context, alias string, index string) error
+	DeleteIndex(ctx context.Context, index string) error
+}
+
+// ReindexStateRepositoryInterface ...
+type ReindexStateRepositoryInterface interface {
+	FindUnfinished(ctx context.Context, mode model.ReindexMode, alias string) (*model.ReindexState, error)
+	Save(ctx context.Context, m *model.ReindexState) error
+}
+
+// ReindexProgressFunc called after every processed batch
+type ReindexProgressFunc func(state *model.ReindexState)
+
+// ReindexOptions ...
+type ReindexOptions struct {
+	Mode      model.ReindexMode
+	Alias     string
+	BatchSize int64
+	Resume    bool
+	Progress  ReindexProgressFunc
+}
+
+// DefaultReindexService ...
+type DefaultReindexService struct {
+	placeRepo  ReindexPlaceRepositoryInterface
+	searchRepo ReindexSearchRepositoryInterface
+	stateRepo  ReindexStateRepositoryInterface
+	placeQueue PlaceQueueRepositoryInterface
+}
+
+// NewDefaultReindexService create new default reindex service, searchRepo or placeQueue can be nil if the mode is not used
+func NewDefaultReindexService(
+	placeRepo ReindexPlaceRepositoryInterface,
+	searchRepo ReindexSearchRepositoryInterface,
+	stateRepo ReindexStateRepositoryInterface,
+	placeQueue PlaceQueueRepositoryInterface,
+) *DefaultReindexService {
+	return &DefaultReindexService{
+		placeRepo:  placeRepo,
+		searchRepo: searchRepo,
+		stateRepo:  stateRepo,
+		placeQueue: placeQueue,
+	}
+}
+
+// Reindex stream all places in batches into a new index or into the reindex queue
+func (s *DefaultReindexService) Reindex(ctx context.Context, opts ReindexOptions) (*model.ReindexState, error) {
+
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultReindexBatchSize
+	}
+
+	switch opts.Mode {
+	case model.ReindexModeIndex:
+		if s.searchRepo == nil {
+			return nil, fmt.Errorf("reindex mode %s: search repository is not configured", opts.Mode)
+		}
+	case model.ReindexModeQueue:
+		if s.placeQueue == nil {
+			return nil, fmt.Errorf("reindex mode %s: place queue is not configured", opts.Mode)
+		}
+	default:
+		return nil, fmt.Errorf("unknown reindex mode %q", opts.Mode)
+	}
+
+	state, err := s.startState(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		places, err := s.placeRepo.FindBatch(ctx, state.LastPlaceID, opts.BatchSize)
+		if err != nil {
+			return state, err
+		}
+		if len(places) == 0 {
+			break
+		}
+
+		if opts.Mode == model.ReindexModeIndex {
+			if err := s.searchRepo.BulkIndex(ctx, state.Index, places); err != nil {
+				return state, err
+			}
+		} else {
+			for _, place := range places {
+				if err := s.placeQueue.PublishReIndex(place.ID); err != nil {
+					return state, err
+				}
+			}
+		}
+
+		state.LastPlaceID = places[len(places)-1].ID
+		state.Processed += int64(len(places))
+		if state.Processed > state.Total {
+			state.Total = state.Processed
+		}
+		if err := s.stateRepo.Save(ctx, state); err != nil {
+			return state, err
+		}
+		if opts.Progress != nil {
+			opts.Progress(state)
+		}
+
+		if int64(len(places)) < opts.BatchSize {
+			break
+		}
+	}
+
+	if opts.Mode == model.ReindexModeIndex {
+		if err := s.catchUp(ctx, state, opts.BatchSize); err != nil {
+			return state, err
+		}
+		if err := s.prune(ctx, state, opts.BatchSize); err != nil {
+			return state, err
+		}
+		if err := s.switchAlias(ctx, state); err != nil {
+			return state, err
+		}
+	}
+
+	state.Status = model.ReindexStatusDone
+	state.FinishedAt = time.Now()
+	if err := s.stateRepo.Save(ctx, state); err != nil {
+		return state, err
+	}
+
+	return state, nil
+}
+
+func (s *DefaultReindexService) startState(ctx context.Context, opts ReindexOptions) (*model.ReindexState, error) {
+
+	if opts.Resume {
+		state, err := s.stateRepo.FindUnfinished(ctx, opts.Mode, opts.Alias)
+		if err == nil {
+			return state, nil
+		} else if !errors.Is(err, model.ErrModelNotFound) {
+			return nil, err
+		}
+	}
+
+	total, err := s.placeRepo.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var index string
+	if opts.Mode == model.ReindexModeIndex {
+		index = fmt.Sprintf("%s_v%s", opts.Alias, time.Now().UTC().Format("20060102150405"))
+		if err := s.searchRepo.CreateIndex(ctx, index); err != nil {
+			return nil, err
+		}
+	}
+
+	state, err := model.NewReindexState(opts.Mode, opts.Alias, index, total)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.stateRepo.Save(ctx, state); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// catchUp index places created or changed while the job was running, the scan has passed them
+// or indexed an older version
+func (s *DefaultReindexService) catchUp(ctx context.Context, state *model.ReindexState, batchSize int64) error {
+
+	since := state.StartedAt.Add(-reindexCatchUpMargin)
+	after := model.NilID
+	for {
+		places, err := s.placeRepo.FindChangedSince(ctx, since, after, batchSize)
+		if err != nil {
+			return err
+		}
+		if len(places) == 0 {
+			return nil
+		}
+
+		if err := s.searchRepo.BulkIndex(ctx, state.Index, places); err != nil {
+			return err
+		}
+
+		after = places[len(places)-1].ID
+		if int64(len(places)) < batchSize {
+			return nil
+		}
+	}
+}
+
+// prune delete places deleted while the job was running from the new index, the scan or the catch-up
+// has indexed them and the queue consumer deletes from the index under the alias only
+func (s *DefaultReindexService) prune(ctx context.Context, state *model.ReindexState, batchSize int64) error {
+
+	// the scan sees refreshed documents only
+	if err := s.searchRepo.RefreshIndex(ctx, state.Index); err != nil {
+		return err
+	}
+
+	return s.searchRepo.ScanIDs(ctx, state.Index, batchSize, func(ids []model.ID) error {
+		existing, err := s.placeRepo.ExistingIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		found := make(map[model.ID]struct{}, len(existing))
+		for _, id := range existing {
+			found[id] = struct{}{}
+		}
+		deleted := make([]model.ID, 0)
+		for _, id := range ids {
+			if _, ok := found[id]; !ok {
+				deleted = append(deleted, id)
+			}
+		}
+
+		return s.searchRepo.BulkDelete(ctx, state.Index, deleted)
+	})
+}
+
+func (s *DefaultReindexService) switchAlias(ctx context.Context, state *model.ReindexState) error {
+
+	if err := s.searchRepo.RefreshIndex(ctx, state.Index); err != nil {
+		return err
+	}
+
+	oldIndices, err := s.searchRepo.AliasIndices(ctx, state.Alias)
+	if err != nil {
+		return err
+	}
+
+	if len(oldIndices) == 0 {
+		// the first run, the index may have been created before the versioned indices under the alias name
+		isIndex, err := s.searchRepo.IsIndex(ctx, state.Alias)
+		if err != nil {
+			return err
+		}
+		if isIndex {
+			return s.searchRepo.ReplaceIndexWithAlias(ctx, state.Alias, state.Index)
+		}
+	}
+
+	if err := s.searchRepo.SwapAlias(ctx, state.Alias, state.Index, oldIndices); err != nil {
+		return err
+	}
+
+	for _, old := range oldIndices {
+		if old == state.Index {
+			continue
+		}
+		if err := s.searchRepo.DeleteIndex(ctx, old); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
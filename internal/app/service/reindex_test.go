@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"walk_backend/internal/app/model"
+	mockService "walk_backend/internal/app/service/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeReindexPlaces(t *testing.T, count int) model.PlaceList {
+	places := make(model.PlaceList, 0, count)
+	for i := 0; i < count; i++ {
+		id, err := model.NewID()
+		assert.Nil(t, err)
+		places = append(places, &model.Place{ID: id, Name: "place", NameSlug: "place"})
+	}
+	return places
+}
+
+func TestReindexService_Index(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockPlaceRepository := mockService.NewMockReindexPlaceRepositoryInterface(controller)
+	mockSearchRepository := mockService.NewMockReindexSearchRepositoryInterface(controller)
+	mockStateRepository := mockService.NewMockReindexStateRepositoryInterface(controller)
+
+	ctx := context.Background()
+	places := makeReindexPlaces(t, 3)
+
+	mockStateRepository.EXPECT().FindUnfinished(ctx, model.ReindexModeIndex, "places").Return(nil, model.ErrModelNotFound)
+	mockPlaceRepository.EXPECT().Count(ctx).Return(int64(3), nil)
+	mockSearchRepository.EXPECT().CreateIndex(ctx, gomock.Any()).Return(nil)
+	mockStateRepository.EXPECT().Save(ctx, gomock.Any()).Return(nil).Times(4)
+
+	gomock.InOrder(
+		mockPlaceRepository.EXPECT().FindBatch(ctx, model.NilID, int64(2)).Return(places[:2], nil),
+		mockPlaceRepository.EXPECT().FindBatch(ctx, places[1].ID, int64(2)).Return(places[2:], nil),
+	)
+	mockSearchRepository.EXPECT().BulkIndex(ctx, gomock.Any(), places[:2]).Return(nil)
+	mockSearchRepository.EXPECT().BulkIndex(ctx, gomock.Any(), places[2:]).Return(nil)
+
+	// changed after the scan had passed it, and created after the last batch
+	changed := model.PlaceList{places[0], makeReindexPlaces(t, 1)[0]}
+	mockPlaceRepository.EXPECT().FindChangedSince(ctx, gomock.Any(), model.NilID, int64(2)).
+		DoAndReturn(func(_ context.Context, since time.Time, _ model.ID, _ int64) (model.PlaceList, error) {
+			assert.True(t, since.Before(time.Now().Add(-reindexCatchUpMargin)))
+			return changed, nil
+		})
+	mockPlaceRepository.EXPECT().FindChangedSince(ctx, gomock.Any(), changed[1].ID, int64(2)).Return(model.PlaceList{}, nil)
+	mockSearchRepository.EXPECT().BulkIndex(ctx, gomock.Any(), changed).Return(nil)
+
+	// deleted after the scan had indexed it
+	indexed := []model.ID{places[0].ID, places[1].ID, places[2].ID, changed[1].ID}
+	mockSearchRepository.EXPECT().RefreshIndex(ctx, gomock.Any()).Return(nil).Times(2)
+	mockSearchRepository.EXPECT().ScanIDs(ctx, gomock.Any(), int64(2), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ int64, fn func(ids []model.ID) error) error {
+			if err := fn(indexed[:2]); err != nil {
+				return err
+			}
+			return fn(indexed[2:])
+		})
+	mockPlaceRepository.EXPECT().ExistingIDs(ctx, indexed[:2]).Return([]model.ID{places[0].ID}, nil)
+	mockPlaceRepository.EXPECT().ExistingIDs(ctx, indexed[2:]).Return(indexed[2:], nil)
+	mockSearchRepository.EXPECT().BulkDelete(ctx, gomock.Any(), []model.ID{places[1].ID}).Return(nil)
+	mockSearchRepository.EXPECT().BulkDelete(ctx, gomock.Any(), []model.ID{}).Return(nil)
+
+	mockSearchRepository.EXPECT().AliasIndices(ctx, "places").Return([]string{"places_v1"}, nil)
+	mockSearchRepository.EXPECT().SwapAlias(ctx, "places", gomock.Any(), []string{"places_v1"}).Return(nil)
+	mockSearchRepository.EXPECT().DeleteIndex(ctx, "places_v1").Return(nil)
+
+	progress := make([]int64, 0)
+	s := NewDefaultReindexService(mockPlaceRepository, mockSearchRepository, mockStateRepository, nil)
+	state, err := s.Reindex(ctx, ReindexOptions{
+		Mode:      model.ReindexModeIndex,
+		Alias:     "places",
+		BatchSize: 2,
+		Resume:    true,
+		Progress:  func(state *model.ReindexState) { progress = append(progress, state.Processed) },
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, model.ReindexStatusDone, state.Status)
+	assert.Equal(t, int64(3), state.Processed)
+	assert.Equal(t, places[2].ID, state.LastPlaceID)
+	assert.Equal(t, []int64{2, 3}, progress)
+}
+
+func TestReindexService_Index_concrete_index(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockPlaceRepository := mockService.NewMockReindexPlaceRepositoryInterface(controller)
+	mockSearchRepository := mockService.NewMockReindexSearchRepositoryInterface(controller)
+	mockStateRepository := mockService.NewMockReindexStateRepositoryInterface(controller)
+
+	ctx := context.Background()
+	places := makeReindexPlaces(t, 1)
+
+	mockStateRepository.EXPECT().FindUnfinished(ctx, model.ReindexModeIndex, "places").Return(nil, model.ErrModelNotFound)
+	mockPlaceRepository.EXPECT().Count(ctx).Return(int64(1), nil)
+	mockSearchRepository.EXPECT().CreateIndex(ctx, gomock.Any()).Return(nil)
+	mockStateRepository.EXPECT().Save(ctx, gomock.Any()).Return(nil).Times(3)
+	mockPlaceRepository.EXPECT().FindBatch(ctx, model.NilID, int64(10)).Return(places, nil)
+	mockSearchRepository.EXPECT().BulkIndex(ctx, gomock.Any(), places).Return(nil)
+	mockPlaceRepository.EXPECT().FindChangedSince(ctx, gomock.Any(), model.NilID, int64(10)).Return(model.PlaceList{}, nil)
+	mockSearchRepository.EXPECT().RefreshIndex(ctx, gomock.Any()).Return(nil).Times(2)
+	mockSearchRepository.EXPECT().ScanIDs(ctx, gomock.Any(), int64(10), gomock.Any()).Return(nil)
+
+	// the index was created as "places" before the versioned indices
+	mockSearchRepository.EXPECT().AliasIndices(ctx, "places").Return(nil, nil)
+	mockSearchRepository.EXPECT().IsIndex(ctx, "places").Return(true, nil)
+	mockSearchRepository.EXPECT().ReplaceIndexWithAlias(ctx, "places", gomock.Any()).
+		DoAndReturn(func(_ context.Context, alias string, index string) error {
+			assert.NotEqual(t, alias, index)
+			return nil
+		})
+
+	s := NewDefaultReindexService(mockPlaceRepository, mockSearchRepository, mockStateRepository, nil)
+	state, err := s.Reindex(ctx, ReindexOptions{
+		Mode:      model.ReindexModeIndex,
+		Alias:     "places",
+		BatchSize: 10,
+		Resume:    true,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, model.ReindexStatusDone, state.Status)
+}
+
+func TestReindexService_Resume(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockPlaceRepository := mockService.NewMockReindexPlaceRepositoryInterface(controller)
+	mockStateRepository := mockService.NewMockReindexStateRepositoryInterface(controller)
+	mockPlaceQueue := mockService.NewMockPlaceQueueRepositoryInterface(controller)
+
+	ctx := context.Background()
+	places := makeReindexPlaces(t, 2)
+
+	state, err := model.NewReindexState(model.ReindexModeQueue, "places", "", 5)
+	assert.Nil(t, err)
+	state.LastPlaceID = places[0].ID
+	state.Processed = 3
+
+	mockStateRepository.EXPECT().FindUnfinished(ctx, model.ReindexModeQueue, "places").Return(state, nil)
+	mockPlaceRepository.EXPECT().FindBatch(ctx, places[0].ID, int64(10)).Return(places[1:], nil)
+	mockPlaceQueue.EXPECT().PublishReIndex(places[1].ID).Return(nil)
+	mockStateRepository.EXPECT().Save(ctx, state).Return(nil).Times(2)
+
+	s := NewDefaultReindexService(mockPlaceRepository, nil, mockStateRepository, mockPlaceQueue)
+	result, err := s.Reindex(ctx, ReindexOptions{
+		Mode:      model.ReindexModeQueue,
+		Alias:     "places",
+		BatchSize: 10,
+		Resume:    true,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, state.ID, result.ID)
+	assert.Equal(t, int64(4), result.Processed)
+	assert.Equal(t, model.ReindexStatusDone, result.Status)
+}
+
+func TestReindexService_UnknownMode(t *testing.T) {
+
+	s := NewDefaultReindexService(nil, nil, nil, nil)
+	_, err := s.Reindex(context.Background(), ReindexOptions{Mode: "unknown"})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/pkg/logger"
+	"walk_backend/internal/pkg/searchquery"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	searchAnalyticsBufferSize    int           = 1024
+	searchAnalyticsBatchSize     int           = 100
+	searchAnalyticsFlushInterval time.Duration = time.Second
+	searchAnalyticsFlushTimeout  time.Duration = 5 * time.Second
+
+	// searchClickWindow clicks are accepted for searches as old
+	searchClickWindow time.Duration = 24 * time.Hour
+	// searchClickPendingGrace a newer search may not be written yet
+	searchClickPendingGrace time.Duration = searchAnalyticsFlushInterval + searchAnalyticsFlushTimeout
+
+	// MaxSearchStatsWindow raw search logs are kept as long by the TTL index
+	MaxSearchStatsWindow time.Duration = 90 * 24 * time.Hour
+	// MaxSearchStatsLimit ...
+	MaxSearchStatsLimit int64 = 100
+)
+
+var (
+	// ErrInvalidSearchStatsWindow ...
+	ErrInvalidSearchStatsWindow = errors.New("invalid search stats window")
+	// ErrInvalidSearchStatsLimit ...
+	ErrInvalidSearchStatsLimit = errors.New("invalid search stats limit")
+	// ErrUnknownSearch ...
+	ErrUnknownSearch = errors.New("unknown or expired search")
+)
+
+// SearchLogRepositoryInterface ...
+type SearchLogRepositoryInterface interface {
+	CreateMany(ctx context.Context, logs []*model.SearchLog) error
+	AddClick(ctx context.Context, searchID model.ID, placeID model.ID) error
+	Exists(ctx context.Context, id model.ID) (bool, error)
+	Stats(ctx context.Context, from time.Time, to time.Time, limit int64) (*model.SearchStats, error)
+}
+
+type searchClickEvent struct {
+	searchID model.ID
+	placeID  model.ID
+}
+
+// DefaultSearchAnalyticsService records searches in background, the request never waits for the database
+type DefaultSearchAnalyticsService struct {
+	searchLogRepo SearchLogRepositoryInterface
+	events        chan any
+	dropped       atomic.Int64
+}
+
+// NewDefaultSearchAnalyticsService create new search analytics service
+func NewDefaultSearchAnalyticsService(searchLogRepo SearchLogRepositoryInterface) *DefaultSearchAnalyticsService {
+	return &DefaultSearchAnalyticsService{
+		searchLogRepo: searchLogRepo,
+		events:        make(chan any, searchAnalyticsBufferSize),
+	}
+}
+
+// RecordSearch queue search log entry and return its ID, the entry is dropped when the buffer is full
+func (s *DefaultSearchAnalyticsService) RecordSearch(search string, hits int, latency time.Duration, username string) model.ID {
+
+	id, err := model.NewID()
+	if err != nil {
+		return model.NilID
+	}
+
+	if !s.send(model.NewSearchLog(id, search, hits, latency, username)) {
+		return model.NilID
+	}
+
+	return id
+}
+
+// RecordClick queue the place selected from search results, ErrUnknownSearch when the search was not
+// issued by RecordSearch within searchClickWindow
+func (s *DefaultSearchAnalyticsService) RecordClick(ctx context.Context, searchID model.ID, placeID model.ID) error {
+
+	// search IDs are UUIDv7, the time is checked before the database
+	issuedAt, ok := searchIDTime(searchID)
+	now := time.Now()
+	if !ok || issuedAt.Before(now.Add(-searchClickWindow)) || issuedAt.After(now.Add(searchClickPendingGrace)) {
+		return ErrUnknownSearch
+	}
+
+	if issuedAt.Before(now.Add(-searchClickPendingGrace)) {
+		exists, err := s.searchLogRepo.Exists(ctx, searchID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrUnknownSearch
+		}
+	}
+
+	s.send(&searchClickEvent{searchID: searchID, placeID: placeID})
+	return nil
+}
+
+// searchIDTime time of a UUIDv7 search ID
+func searchIDTime(id model.ID) (time.Time, bool) {
+
+	if id.Version() != uuid.V7 {
+		return time.Time{}, false
+	}
+
+	var ms int64
+	for _, b := range id[:6] {
+		ms = ms<<8 | int64(b)
+	}
+	return time.UnixMilli(ms), true
+}
+
+func (s *DefaultSearchAnalyticsService) send(event any) bool {
+	select {
+	case s.events <- event:
+		return true
+	default:
+		s.dropped.Add(1)
+		return false
+	}
+}
+
+// Run write queued events in batches until ctx is done, then flush what is left
+func (s *DefaultSearchAnalyticsService) Run(ctx context.Context) {
+
+	log := logger.LoggerFromContext(ctx)
+
+	ticker := time.NewTicker(searchAnalyticsFlushInterval)
+	defer ticker.Stop()
+
+	pending := make([]*model.SearchLog, 0, searchAnalyticsBatchSize)
+	flush := func(ctx context.Context) {
+		if dropped := s.dropped.Swap(0); dropped > 0 {
+			log.Warn().Int64("dropped", dropped).Msg("search analytics buffer is full, events dropped")
+		}
+		if len(pending) == 0 {
+			return
+		}
+		if err := s.searchLogRepo.CreateMany(ctx, pending); err != nil {
+			log.Error().Err(err).Int("count", len(pending)).Msg("search analytics write error")
+		}
+		pending = make([]*model.SearchLog, 0, searchAnalyticsBatchSize)
+	}
+	handle := func(ctx context.Context, event any) {
+		switch e := event.(type) {
+		case *model.SearchLog:
+			e.Query = normaliseSearchLogQuery(e.Query)
+			pending = append(pending, e)
+			if len(pending) >= searchAnalyticsBatchSize {
+				flush(ctx)
+			}
+		case *searchClickEvent:
+			// the search entry may still be pending
+			flush(ctx)
+			err := s.searchLogRepo.AddClick(ctx, e.searchID, e.placeID)
+			if err != nil && !errors.Is(err, model.ErrModelNotFound) {
+				log.Error().Err(err).Msg("search analytics click write error")
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), searchAnalyticsFlushTimeout)
+			defer cancel()
+			for {
+				select {
+				case event := <-s.events:
+					handle(flushCtx, event)
+				default:
+					flush(flushCtx)
+					return
+				}
+			}
+		case event := <-s.events:
+			handle(ctx, event)
+		case <-ticker.C:
+			flush(ctx)
+		}
+	}
+}
+
+// Stats search stats for the last window
+func (s *DefaultSearchAnalyticsService) Stats(ctx context.Context, window time.Duration, limit int64) (*model.SearchStats, error) {
+
+	if window <= 0 || window > MaxSearchStatsWindow {
+		return nil, ErrInvalidSearchStatsWindow
+	}
+	if limit <= 0 || limit > MaxSearchStatsLimit {
+		return nil, ErrInvalidSearchStatsLimit
+	}
+
+	to := time.Now()
+	return s.searchLogRepo.Stats(ctx, to.Add(-window), to, limit)
+}
+
+// normaliseSearchLogQuery same queries written differently are counted together
+func normaliseSearchLogQuery(search string) string {
+	query, err := searchquery.Parse(search)
+	if err != nil {
+		return strings.ToLower(strings.Join(strings.Fields(search), " "))
+	}
+	return query.String()
+}
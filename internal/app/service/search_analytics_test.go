@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"walk_backend/internal/app/model"
+	mockService "walk_backend/internal/app/service/mock"
+	"walk_backend/internal/pkg/logger"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchAnalyticsService_Run(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockSearchLogRepository := mockService.NewMockSearchLogRepositoryInterface(controller)
+
+	nop := zerolog.Nop()
+	ctx, cancel := context.WithCancel(logger.ContextWithLogger(context.Background(), &nop))
+
+	s := NewDefaultSearchAnalyticsService(mockSearchLogRepository)
+	searchID := s.RecordSearch(`Park  TAG:Old -"City Center"`, 0, 15*time.Millisecond, "user")
+	assert.False(t, searchID.IsNil())
+	placeID, err := model.NewID()
+	assert.Nil(t, err)
+	assert.Nil(t, s.RecordClick(ctx, searchID, placeID))
+
+	gomock.InOrder(
+		mockSearchLogRepository.EXPECT().CreateMany(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, logs []*model.SearchLog) error {
+				assert.Len(t, logs, 1)
+				assert.Equal(t, searchID, logs[0].ID)
+				assert.Equal(t, `park tag:old -"city center"`, logs[0].Query)
+				assert.Equal(t, 0, logs[0].Hits)
+				assert.Equal(t, float64(15), logs[0].LatencyMs)
+				assert.Equal(t, "user", logs[0].Username)
+				return nil
+			}),
+		mockSearchLogRepository.EXPECT().AddClick(gomock.Any(), searchID, placeID).Return(nil),
+	)
+
+	cancel()
+	s.Run(ctx)
+}
+
+func makeSearchID(t *testing.T, at time.Time) model.ID {
+	id, err := model.NewID()
+	assert.Nil(t, err)
+	ms := at.UnixMilli()
+	for i := 5; i >= 0; i-- {
+		id[i] = byte(ms)
+		ms >>= 8
+	}
+	return id
+}
+
+func TestSearchAnalyticsService_RecordClick(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockSearchLogRepository := mockService.NewMockSearchLogRepositoryInterface(controller)
+
+	ctx := context.Background()
+	s := NewDefaultSearchAnalyticsService(mockSearchLogRepository)
+	placeID, err := model.NewID()
+	assert.Nil(t, err)
+
+	t.Run("Not_v7", func(t *testing.T) {
+		id, err := uuid.NewV4()
+		assert.Nil(t, err)
+		assert.ErrorIs(t, s.RecordClick(ctx, id, placeID), ErrUnknownSearch)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		id := makeSearchID(t, time.Now().Add(-searchClickWindow-time.Minute))
+		assert.ErrorIs(t, s.RecordClick(ctx, id, placeID), ErrUnknownSearch)
+	})
+
+	t.Run("Future", func(t *testing.T) {
+		id := makeSearchID(t, time.Now().Add(time.Hour))
+		assert.ErrorIs(t, s.RecordClick(ctx, id, placeID), ErrUnknownSearch)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		id := makeSearchID(t, time.Now().Add(-time.Hour))
+		mockSearchLogRepository.EXPECT().Exists(ctx, id).Return(false, nil)
+		assert.ErrorIs(t, s.RecordClick(ctx, id, placeID), ErrUnknownSearch)
+	})
+
+	t.Run("Known", func(t *testing.T) {
+		id := makeSearchID(t, time.Now().Add(-time.Hour))
+		mockSearchLogRepository.EXPECT().Exists(ctx, id).Return(true, nil)
+		assert.Nil(t, s.RecordClick(ctx, id, placeID))
+	})
+
+	t.Run("Pending", func(t *testing.T) {
+		// not written yet, no database call
+		assert.Nil(t, s.RecordClick(ctx, makeSearchID(t, time.Now()), placeID))
+	})
+}
+
+func TestSearchAnalyticsService_Stats(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockSearchLogRepository := mockService.NewMockSearchLogRepositoryInterface(controller)
+
+	ctx := context.Background()
+	s := NewDefaultSearchAnalyticsService(mockSearchLogRepository)
+
+	_, err := s.Stats(ctx, 0, 10)
+	assert.ErrorIs(t, err, ErrInvalidSearchStatsWindow)
+	_, err = s.Stats(ctx, MaxSearchStatsWindow+time.Hour, 10)
+	assert.ErrorIs(t, err, ErrInvalidSearchStatsWindow)
+	_, err = s.Stats(ctx, time.Hour, MaxSearchStatsLimit+1)
+	assert.ErrorIs(t, err, ErrInvalidSearchStatsLimit)
+
+	stats := &model.SearchStats{}
+	mockSearchLogRepository.EXPECT().
+		Stats(ctx, gomock.Any(), gomock.Any(), int64(10)).
+		DoAndReturn(func(_ context.Context, from time.Time, to time.Time, _ int64) (*model.SearchStats, error) {
+			assert.Equal(t, time.Hour, to.Sub(from))
+			return stats, nil
+		})
+
+	result, err := s.Stats(ctx, time.Hour, 10)
+	assert.Nil(t, err)
+	assert.Same(t, stats, result)
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+
+	"walk_backend/internal/app/model"
+)
+
+const (
+	defaultSearchTermsBatchSize int64 = 500
+)
+
+// SearchTermsPlaceRepositoryInterface ...
+type SearchTermsPlaceRepositoryInterface interface {
+	FindBatch(ctx context.Context, after model.ID, limit int64) (model.PlaceList, error)
+	UpdateSearchTerms(ctx context.Context, places model.PlaceList) error
+}
+
+// SearchTermsProgressFunc called after every updated batch with the number of updated places
+type SearchTermsProgressFunc func(processed int64)
+
+// DefaultSearchTermsService ...
+type DefaultSearchTermsService struct {
+	placeRepo SearchTermsPlaceRepositoryInterface
+}
+
+// NewDefaultSearchTermsService create new default search terms service
+func NewDefaultSearchTermsService(placeRepo SearchTermsPlaceRepositoryInterface) *DefaultSearchTermsService {
+	return &DefaultSearchTermsService{
+		placeRepo: placeRepo,
+	}
+}
+
+// Backfill rebuild search terms of all places in batches with the same keys as new places get,
+// a database side script can not reproduce translit keys of non russian letters
+func (s *DefaultSearchTermsService) Backfill(ctx context.Context, batchSize int64, progress SearchTermsProgressFunc) (int64, error) {
+
+	if batchSize <= 0 {
+		batchSize = defaultSearchTermsBatchSize
+	}
+
+	var processed int64
+	after := model.NilID
+	for {
+		places, err := s.placeRepo.FindBatch(ctx, after, batchSize)
+		if err != nil {
+			return processed, err
+		}
+		if len(places) == 0 {
+			return processed, nil
+		}
+
+		for _, place := range places {
+			place.UpdateSearchTerms()
+		}
+		if err := s.placeRepo.UpdateSearchTerms(ctx, places); err != nil {
+			return processed, err
+		}
+
+		processed += int64(len(places))
+		after = places[len(places)-1].ID
+		if progress != nil {
+			progress(processed)
+		}
+
+		if int64(len(places)) < batchSize {
+			return processed, nil
+		}
+	}
+}
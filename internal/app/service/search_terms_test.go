@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"walk_backend/internal/app/model"
+	mockService "walk_backend/internal/app/service/mock"
+	"walk_backend/internal/pkg/translit"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchTermsService_Backfill(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockPlaceRepository := mockService.NewMockSearchTermsPlaceRepositoryInterface(controller)
+
+	ctx := context.Background()
+	places := makeReindexPlaces(t, 3)
+	places[0].Name, places[0].Tags = "Straße der Pariser Kommune", []string{"Gärten"}
+	places[1].Name = "Søndre Park/Æbleø"
+	places[2].Name = "Парк «Зарядье»"
+
+	gomock.InOrder(
+		mockPlaceRepository.EXPECT().FindBatch(ctx, model.NilID, int64(2)).Return(places[:2], nil),
+		mockPlaceRepository.EXPECT().UpdateSearchTerms(ctx, places[:2]).Return(nil),
+		mockPlaceRepository.EXPECT().FindBatch(ctx, places[1].ID, int64(2)).Return(places[2:], nil),
+		mockPlaceRepository.EXPECT().UpdateSearchTerms(ctx, places[2:]).Return(nil),
+	)
+
+	progress := make([]int64, 0)
+	s := NewDefaultSearchTermsService(mockPlaceRepository)
+	processed, err := s.Backfill(ctx, 2, func(processed int64) {
+		progress = append(progress, processed)
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), processed)
+	assert.Equal(t, []int64{2, 3}, progress)
+	assert.Equal(t, translit.Keys("Strasse der Pariser Kommune", "Garten"), places[0].SearchTerms)
+	assert.Equal(t, translit.Keys("Sondre", "Park", "Aebleo"), places[1].SearchTerms)
+	assert.Equal(t, []string{"park", "zaryade"}, places[2].SearchTerms)
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/pkg/logger"
+)
+
+const (
+	sessionTokenBytes int = 32
+	// sessionTouchInterval lastSeenAt is written at most once per interval
+	sessionTouchInterval = time.Minute
+)
+
+var (
+	// ErrInvalidSessionToken unknown, expired, rotated or revoked session token
+	ErrInvalidSessionToken = errors.New("invalid session token")
+	// ErrSessionTokenReused an already rotated token was refreshed again, the token family is revoked
+	ErrSessionTokenReused = errors.New("session token reused")
+)
+
+// SessionTokenRepositoryInterface ...
+type SessionTokenRepositoryInterface interface {
+	Create(ctx context.Context, m *model.SessionToken) (model.ID, error)
+	FindByHash(ctx context.Context, tokenHash string) (*model.SessionToken, error)
+	MarkRotated(ctx context.Context, id model.ID, rotatedAt time.Time) error
+	RevokeFamily(ctx context.Context, familyID model.ID, revokedAt time.Time) error
+	FindActiveByUsername(ctx context.Context, username string, now time.Time) ([]*model.SessionToken, error)
+	Touch(ctx context.Context, id model.ID, lastSeenAt time.Time) error
+	RevokeByUsername(ctx context.Context, username string, exceptFamilyID model.ID, revokedAt time.Time) (int64, error)
+}
+
+// DefaultSessionTokenService ...
+type DefaultSessionTokenService struct {
+	tokenRepo SessionTokenRepositoryInterface
+	ttl       time.Duration
+	now       func() time.Time
+}
+
+// NewDefaultSessionTokenService create new default session token service
+func NewDefaultSessionTokenService(tokenRepo SessionTokenRepositoryInterface, ttl time.Duration) *DefaultSessionTokenService {
+	return &DefaultSessionTokenService{
+		tokenRepo: tokenRepo,
+		ttl:       ttl,
+		now:       time.Now,
+	}
+}
+
+// Issue store a new session token of a new family, returns the raw token
+func (s *DefaultSessionTokenService) Issue(ctx context.Context, username string, device model.Device) (string, *model.SessionToken, error) {
+
+	familyID, err := model.NewID()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return s.create(ctx, username, familyID, s.now(), device)
+}
+
+// Verify the raw token is stored and active, updates last seen
+func (s *DefaultSessionTokenService) Verify(ctx context.Context, token string) (*model.SessionToken, error) {
+
+	m, err := s.find(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if !m.IsActive(now) {
+		return nil, ErrInvalidSessionToken
+	}
+
+	if now.Sub(m.LastSeenAt) >= sessionTouchInterval {
+		if err := s.tokenRepo.Touch(ctx, m.ID, now); err != nil {
+			return nil, err
+		}
+		m.LastSeenAt = now
+	}
+
+	return m, nil
+}
+
+// Rotate replace the token with a new one of the same family,
+// refreshing an already rotated token revokes the whole family
+func (s *DefaultSessionTokenService) Rotate(ctx context.Context, token string, device model.Device) (string, *model.SessionToken, error) {
+
+	m, err := s.find(ctx, token)
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := s.now()
+	if m.RevokedAt != nil || !now.Before(m.ExpiresAt) {
+		return "", nil, ErrInvalidSessionToken
+	}
+	if m.RotatedAt != nil {
+		return "", nil, s.reused(ctx, m, device)
+	}
+
+	// lost a concurrent rotation, the token was rotated in between
+	if err := s.tokenRepo.MarkRotated(ctx, m.ID, now); err != nil {
+		if errors.Is(err, model.ErrModelUpdate) {
+			return "", nil, s.reused(ctx, m, device)
+		}
+		return "", nil, err
+	}
+
+	return s.create(ctx, m.Username, m.FamilyID, m.SessionCreatedAt, device)
+}
+
+// Revoke revoke the family of the token, unknown tokens are ignored
+func (s *DefaultSessionTokenService) Revoke(ctx context.Context, token string) error {
+
+	m, err := s.find(ctx, token)
+	if err != nil {
+		if errors.Is(err, ErrInvalidSessionToken) {
+			return nil
+		}
+		return err
+	}
+
+	return s.tokenRepo.RevokeFamily(ctx, m.FamilyID, s.now())
+}
+
+// List active sessions of the user, most recently seen first
+func (s *DefaultSessionTokenService) List(ctx context.Context, username string) ([]*model.SessionToken, error) {
+	return s.tokenRepo.FindActiveByUsername(ctx, username, s.now())
+}
+
+// RevokeSession revoke the session of the user, ErrModelNotFound when the user has no such active session
+func (s *DefaultSessionTokenService) RevokeSession(ctx context.Context, username string, sessionID model.ID) error {
+
+	list, err := s.List(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range list {
+		if m.FamilyID == sessionID {
+			return s.tokenRepo.RevokeFamily(ctx, sessionID, s.now())
+		}
+	}
+
+	return model.ErrModelNotFound
+}
+
+// RevokeAll revoke every session of the user except the exceptSessionID session, NilID for none
+func (s *DefaultSessionTokenService) RevokeAll(ctx context.Context, username string, exceptSessionID model.ID) (int64, error) {
+	return s.tokenRepo.RevokeByUsername(ctx, username, exceptSessionID, s.now())
+}
+
+func (s *DefaultSessionTokenService) reused(ctx context.Context, m *model.SessionToken, device model.Device) error {
+
+	logger.LoggerFromContext(ctx).Warn().
+		Str("event", "session_token_reuse").
+		Str("username", m.Username).
+		Str("family_id", m.FamilyID.String()).
+		Str("ip", device.IP).
+		Str("user_agent", device.UserAgent).
+		Msg("rotated session token reused, token family revoked")
+
+	if err := s.tokenRepo.RevokeFamily(ctx, m.FamilyID, s.now()); err != nil {
+		return err
+	}
+
+	return ErrSessionTokenReused
+}
+
+func (s *DefaultSessionTokenService) create(
+	ctx context.Context,
+	username string,
+	familyID model.ID,
+	sessionCreatedAt time.Time,
+	device model.Device,
+) (string, *model.SessionToken, error) {
+
+	token, err := newOpaqueToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := s.now()
+	m := &model.SessionToken{
+		TokenHash:        hashOpaqueToken(token),
+		FamilyID:         familyID,
+		Username:         username,
+		Device:           device,
+		CreatedAt:        now,
+		SessionCreatedAt: sessionCreatedAt,
+		LastSeenAt:       now,
+		ExpiresAt:        now.Add(s.ttl),
+	}
+	if _, err := s.tokenRepo.Create(ctx, m); err != nil {
+		return "", nil, err
+	}
+
+	return token, m, nil
+}
+
+func (s *DefaultSessionTokenService) find(ctx context.Context, token string) (*model.SessionToken, error) {
+
+	if token == "" {
+		return nil, ErrInvalidSessionToken
+	}
+
+	m, err := s.tokenRepo.FindByHash(ctx, hashOpaqueToken(token))
+	if err != nil {
+		if errors.Is(err, model.ErrModelNotFound) {
+			return nil, ErrInvalidSessionToken
+		}
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// newOpaqueToken random base64url token of sessionTokenBytes, stored as its hashOpaqueToken only
+func newOpaqueToken() (string, error) {
+	raw := make([]byte, sessionTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func hashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
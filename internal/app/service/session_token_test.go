@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"walk_backend/internal/app/model"
+	mockService "walk_backend/internal/app/service/mock"
+	"walk_backend/internal/pkg/logger"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionTokenService_Rotate(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockTokenRepository := mockService.NewMockSessionTokenRepositoryInterface(controller)
+
+	nop := zerolog.Nop()
+	ctx := logger.ContextWithLogger(context.Background(), &nop)
+	device := model.Device{UserAgent: "test", IP: "127.0.0.1"}
+	s := NewDefaultSessionTokenService(mockTokenRepository, time.Hour)
+
+	familyID, _ := model.NewID()
+	newToken := func() *model.SessionToken {
+		id, _ := model.NewID()
+		return &model.SessionToken{ID: id, FamilyID: familyID, Username: "Wozniak", ExpiresAt: time.Now().Add(time.Hour)}
+	}
+
+	t.Run("Rotate", func(t *testing.T) {
+		current := newToken()
+
+		mockTokenRepository.EXPECT().FindByHash(ctx, hashOpaqueToken("first")).Return(current, nil)
+		mockTokenRepository.EXPECT().MarkRotated(ctx, current.ID, gomock.Any()).Return(nil)
+		mockTokenRepository.EXPECT().Create(ctx, gomock.Any()).Return(model.NilID, nil)
+
+		token, m, err := s.Rotate(ctx, "first", device)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, token)
+		assert.Equal(t, familyID, m.FamilyID)
+		assert.Equal(t, hashOpaqueToken(token), m.TokenHash)
+		assert.Equal(t, device, m.Device)
+	})
+
+	t.Run("Reused", func(t *testing.T) {
+		rotatedAt := time.Now()
+		rotated := newToken()
+		rotated.RotatedAt = &rotatedAt
+
+		mockTokenRepository.EXPECT().FindByHash(ctx, hashOpaqueToken("first")).Return(rotated, nil)
+		mockTokenRepository.EXPECT().RevokeFamily(ctx, familyID, gomock.Any()).Return(nil)
+
+		_, _, err := s.Rotate(ctx, "first", device)
+		assert.ErrorIs(t, err, ErrSessionTokenReused)
+	})
+
+	t.Run("Concurrent_rotation", func(t *testing.T) {
+		current := newToken()
+
+		mockTokenRepository.EXPECT().FindByHash(ctx, hashOpaqueToken("first")).Return(current, nil)
+		mockTokenRepository.EXPECT().MarkRotated(ctx, current.ID, gomock.Any()).Return(model.ErrModelUpdate)
+		mockTokenRepository.EXPECT().RevokeFamily(ctx, familyID, gomock.Any()).Return(nil)
+
+		_, _, err := s.Rotate(ctx, "first", device)
+		assert.ErrorIs(t, err, ErrSessionTokenReused)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		expired := newToken()
+		expired.ExpiresAt = time.Now().Add(-time.Second)
+
+		mockTokenRepository.EXPECT().FindByHash(ctx, hashOpaqueToken("first")).Return(expired, nil)
+
+		_, _, err := s.Rotate(ctx, "first", device)
+		assert.ErrorIs(t, err, ErrInvalidSessionToken)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		mockTokenRepository.EXPECT().FindByHash(ctx, hashOpaqueToken("forged")).Return(nil, model.ErrModelNotFound)
+
+		_, err := s.Verify(ctx, "forged")
+		assert.ErrorIs(t, err, ErrInvalidSessionToken)
+	})
+}
+
+func TestSessionTokenService_Sessions(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockTokenRepository := mockService.NewMockSessionTokenRepositoryInterface(controller)
+
+	ctx := context.Background()
+	s := NewDefaultSessionTokenService(mockTokenRepository, time.Hour)
+
+	tokenID, _ := model.NewID()
+	familyID, _ := model.NewID()
+	otherFamilyID, _ := model.NewID()
+	active := &model.SessionToken{
+		ID:         tokenID,
+		FamilyID:   familyID,
+		Username:   "Wozniak",
+		LastSeenAt: time.Now().Add(-time.Hour),
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+
+	t.Run("Verify_touches_last_seen", func(t *testing.T) {
+		mockTokenRepository.EXPECT().FindByHash(ctx, hashOpaqueToken("token")).Return(active, nil)
+		mockTokenRepository.EXPECT().Touch(ctx, tokenID, gomock.Any()).Return(nil)
+
+		m, err := s.Verify(ctx, "token")
+		assert.Nil(t, err)
+		assert.WithinDuration(t, time.Now(), m.LastSeenAt, time.Second)
+
+		// seen just now, no write
+		mockTokenRepository.EXPECT().FindByHash(ctx, hashOpaqueToken("token")).Return(active, nil)
+		_, err = s.Verify(ctx, "token")
+		assert.Nil(t, err)
+	})
+
+	t.Run("Revoke_session", func(t *testing.T) {
+		mockTokenRepository.EXPECT().FindActiveByUsername(ctx, "Wozniak", gomock.Any()).Return([]*model.SessionToken{active}, nil)
+		mockTokenRepository.EXPECT().RevokeFamily(ctx, familyID, gomock.Any()).Return(nil)
+
+		assert.Nil(t, s.RevokeSession(ctx, "Wozniak", familyID))
+	})
+
+	t.Run("Revoke_session_of_other_user", func(t *testing.T) {
+		mockTokenRepository.EXPECT().FindActiveByUsername(ctx, "Wozniak", gomock.Any()).Return([]*model.SessionToken{active}, nil)
+
+		err := s.RevokeSession(ctx, "Wozniak", otherFamilyID)
+		assert.ErrorIs(t, err, model.ErrModelNotFound)
+	})
+}
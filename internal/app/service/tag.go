@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"walk_backend/internal/app/model"
+)
+
+var (
+	// ErrInvalidTag ...
+	ErrInvalidTag = errors.New("invalid tag")
+)
+
+// TagRepositoryInterface ...
+type TagRepositoryInterface interface {
+	Find(ctx context.Context, name string) (*model.Tag, error)
+	FindAll(ctx context.Context, limit int64) (model.TagList, error)
+	Recount(ctx context.Context, names []string) error
+}
+
+// TagPlaceRepositoryInterface ...
+type TagPlaceRepositoryInterface interface {
+	FindByTags(ctx context.Context, tags []string) (model.PlaceList, error)
+	FindByIDs(ctx context.Context, ids []model.ID) (model.PlaceList, error)
+	ReplaceTags(ctx context.Context, ids []model.ID, from []string, into string) error
+	UpdateSearchTerms(ctx context.Context, places model.PlaceList) error
+}
+
+// DefaultTagService ...
+type DefaultTagService struct {
+	tagRepo     TagRepositoryInterface
+	placeRepo   TagPlaceRepositoryInterface
+	placeQueue  PlaceQueueRepositoryInterface
+	placeCache  PlaceCacheRepositoryInterface
+	transaction TransactionInterface
+}
+
+// NewDefaultTagService create new default tag service
+func NewDefaultTagService(
+	tagRepo TagRepositoryInterface,
+	placeRepo TagPlaceRepositoryInterface,
+	placeQueue PlaceQueueRepositoryInterface,
+	placeCache PlaceCacheRepositoryInterface,
+	transaction TransactionInterface,
+) *DefaultTagService {
+	return &DefaultTagService{
+		tagRepo:     tagRepo,
+		placeRepo:   placeRepo,
+		placeQueue:  placeQueue,
+		placeCache:  placeCache,
+		transaction: transaction,
+	}
+}
+
+// ListTags tags by popularity, limit 0 is no limit
+func (s *DefaultTagService) ListTags(ctx context.Context, limit int64) (model.TagList, error) {
+	return s.tagRepo.FindAll(ctx, limit)
+}
+
+// Rename rename tag on all places, returns the number of changed places
+func (s *DefaultTagService) Rename(ctx context.Context, tag string, name string) (int, error) {
+
+	tag = model.NormaliseTag(tag)
+	name = model.NormaliseTag(name)
+	if tag == "" || name == "" || tag == name {
+		return 0, ErrInvalidTag
+	}
+
+	return s.replaceTags(ctx, []string{tag}, name)
+}
+
+// Merge replace tags with one tag on all places, returns the number of changed places
+func (s *DefaultTagService) Merge(ctx context.Context, tags []string, into string) (int, error) {
+
+	into = model.NormaliseTag(into)
+	if into == "" {
+		return 0, ErrInvalidTag
+	}
+
+	from := make([]string, 0, len(tags))
+	for _, tag := range model.NormaliseTags(tags) {
+		if tag != into {
+			from = append(from, tag)
+		}
+	}
+	if len(from) == 0 {
+		return 0, ErrInvalidTag
+	}
+
+	return s.replaceTags(ctx, from, into)
+}
+
+func (s *DefaultTagService) replaceTags(ctx context.Context, from []string, into string) (int, error) {
+
+	var places model.PlaceList
+	err := s.transaction.WithTransaction(ctx, func(ctx context.Context) error {
+
+		found, err := s.placeRepo.FindByTags(ctx, from)
+		if err != nil {
+			return err
+		}
+		if len(found) == 0 {
+			return model.ErrModelNotFound
+		}
+
+		ids := make([]model.ID, 0, len(found))
+		for _, place := range found {
+			ids = append(ids, place.ID)
+		}
+
+		if err := s.placeRepo.ReplaceTags(ctx, ids, from, into); err != nil {
+			return err
+		}
+
+		// search terms are built from the tags, read the places back to see the replaced ones
+		places, err = s.placeRepo.FindByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for _, place := range places {
+			place.UpdateSearchTerms()
+		}
+
+		return s.placeRepo.UpdateSearchTerms(ctx, places)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.tagRepo.Recount(ctx, append(from, into)); err != nil {
+		return 0, err
+	}
+
+	if err := s.placeCache.Del(ctx, listPlacesCacheKey); err != nil {
+		return 0, err
+	}
+
+	if err := s.placeCache.DelByPrefix(ctx, searchListPlacesCacheKey); err != nil {
+		return 0, err
+	}
+
+	for _, place := range places {
+		if err := s.placeQueue.PublishReIndex(place.ID); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(places), nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"walk_backend/internal/app/model"
+	mockService "walk_backend/internal/app/service/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagService_Merge(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockTagRepository := mockService.NewMockTagRepositoryInterface(controller)
+	mockPlaceRepository := mockService.NewMockTagPlaceRepositoryInterface(controller)
+	mockPlaceQueue := mockService.NewMockPlaceQueueRepositoryInterface(controller)
+	mockPlaceCache := mockService.NewMockPlaceCacheRepositoryInterface(controller)
+	mockTransaction := mockService.NewMockTransactionInterface(controller)
+
+	ctx := context.Background()
+	found := makeReindexPlaces(t, 2)
+	ids := []model.ID{found[0].ID, found[1].ID}
+	// as stored after the replace, the garden place has got a concurrent "lake" tag meanwhile
+	places := makeReindexPlaces(t, 2)
+	places[0].ID, places[0].Tags = ids[0], []string{"park", "museum"}
+	places[1].ID, places[1].Tags = ids[1], []string{"lake", "park"}
+
+	committed := false
+	mockTransaction.EXPECT().WithTransaction(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			if err := fn(ctx); err != nil {
+				return err
+			}
+			committed = true
+			return nil
+		})
+	mockPlaceRepository.EXPECT().FindByTags(ctx, []string{"parks", "garden"}).Return(found, nil)
+	mockPlaceRepository.EXPECT().ReplaceTags(ctx, ids, []string{"parks", "garden"}, "park").Return(nil)
+	mockPlaceRepository.EXPECT().FindByIDs(ctx, ids).Return(places, nil)
+	mockPlaceRepository.EXPECT().UpdateSearchTerms(ctx, places).Return(nil)
+	mockTagRepository.EXPECT().Recount(ctx, []string{"parks", "garden", "park"}).
+		DoAndReturn(func(context.Context, []string) error {
+			assert.True(t, committed)
+			return nil
+		})
+	mockPlaceCache.EXPECT().Del(ctx, listPlacesCacheKey).Return(nil)
+	mockPlaceCache.EXPECT().DelByPrefix(ctx, searchListPlacesCacheKey).Return(nil)
+	mockPlaceQueue.EXPECT().PublishReIndex(ids[0]).Return(nil)
+	mockPlaceQueue.EXPECT().PublishReIndex(ids[1]).Return(nil)
+
+	s := NewDefaultTagService(mockTagRepository, mockPlaceRepository, mockPlaceQueue, mockPlaceCache, mockTransaction)
+	updated, err := s.Merge(ctx, []string{"Parks", "garden", "park"}, "Park")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, updated)
+	assert.Contains(t, places[1].SearchTerms, "park")
+	assert.Contains(t, places[1].SearchTerms, "lake")
+}
+
+func TestTagService_Rename(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockTagRepository := mockService.NewMockTagRepositoryInterface(controller)
+	mockPlaceRepository := mockService.NewMockTagPlaceRepositoryInterface(controller)
+	mockTransaction := mockService.NewMockTransactionInterface(controller)
+
+	ctx := context.Background()
+	mockTransaction.EXPECT().WithTransaction(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+	s := NewDefaultTagService(mockTagRepository, mockPlaceRepository, nil, nil, mockTransaction)
+
+	_, err := s.Rename(ctx, "Park", " park ")
+	assert.ErrorIs(t, err, ErrInvalidTag)
+
+	mockPlaceRepository.EXPECT().FindByTags(ctx, []string{"park"}).Return(model.PlaceList{}, nil)
+	_, err = s.Rename(ctx, "Park", "parks")
+	assert.ErrorIs(t, err, model.ErrModelNotFound)
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/pkg/jwt"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	// TokenTypeAccess short-lived token sent as Authorization: Bearer
+	TokenTypeAccess string = "access"
+	// TokenTypeRefresh long-lived token exchanged for a new pair
+	TokenTypeRefresh string = "refresh"
+)
+
+var (
+	// ErrInvalidToken malformed, expired, wrong type or unknown key
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrTokenRevoked ...
+	ErrTokenRevoked = errors.New("token revoked")
+	// ErrTokensDisabled no token signing keys configured
+	ErrTokensDisabled = errors.New("bearer tokens are disabled")
+)
+
+// TokenSignerInterface ...
+type TokenSignerInterface interface {
+	Sign(claims *jwt.Claims) (string, error)
+	Verify(token string) (*jwt.Claims, error)
+}
+
+// TokenDenyListRepositoryInterface ...
+type TokenDenyListRepositoryInterface interface {
+	// Add deny the token ID until expiration, false when already denied
+	Add(ctx context.Context, id string, expiration time.Duration) (bool, error)
+	Has(ctx context.Context, id string) (bool, error)
+	// SetTokensValidAfter deny every token of the username issued at or before validAfter, kept for expiration
+	SetTokensValidAfter(ctx context.Context, username string, validAfter time.Time, expiration time.Duration) error
+	// TokensValidAfter zero when there is none
+	TokensValidAfter(ctx context.Context, username string) (time.Time, error)
+}
+
+// DefaultTokenService ...
+type DefaultTokenService struct {
+	signer     TokenSignerInterface
+	denyList   TokenDenyListRepositoryInterface
+	issuer     string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	now        func() time.Time
+}
+
+// NewDefaultTokenService create new default token service, nil signer disables bearer tokens
+func NewDefaultTokenService(
+	signer TokenSignerInterface,
+	denyList TokenDenyListRepositoryInterface,
+	issuer string,
+	accessTTL time.Duration,
+	refreshTTL time.Duration,
+) *DefaultTokenService {
+	return &DefaultTokenService{
+		signer:     signer,
+		denyList:   denyList,
+		issuer:     issuer,
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
+		now:        time.Now,
+	}
+}
+
+// Issue sign a new access and refresh token pair for the user
+func (s *DefaultTokenService) Issue(ctx context.Context, username string) (*model.TokenPair, error) {
+
+	if s.signer == nil {
+		return nil, ErrTokensDisabled
+	}
+
+	// tokens issued in the second of a RevokeAll are denied too, the new pair is issued after it
+	now := s.now()
+	validAfter, err := s.denyList.TokensValidAfter(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	issuedAt := now.Unix()
+	if !validAfter.IsZero() && issuedAt <= validAfter.Unix() {
+		issuedAt = validAfter.Unix() + 1
+	}
+
+	accessToken, err := s.sign(username, TokenTypeAccess, issuedAt, now.Add(s.accessTTL))
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := s.sign(username, TokenTypeRefresh, issuedAt, now.Add(s.refreshTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.TokenPair{
+		Username:     username,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		AccessTTL:    s.accessTTL,
+	}, nil
+}
+
+// VerifyAccess verify the access token is valid and not revoked
+func (s *DefaultTokenService) VerifyAccess(ctx context.Context, token string) (*jwt.Claims, error) {
+
+	claims, err := s.verify(token, TokenTypeAccess)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := s.denyList.Has(ctx, claims.ID)
+	if err != nil {
+		return nil, err
+	} else if revoked {
+		return nil, ErrTokenRevoked
+	}
+	if err := s.checkValidAfter(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// Refresh exchange the refresh token for a new pair, the refresh token can be used once
+func (s *DefaultTokenService) Refresh(ctx context.Context, refreshToken string) (*model.TokenPair, error) {
+
+	claims, err := s.verify(refreshToken, TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkValidAfter(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	// deny first, of two concurrent refreshes only one gets a new pair
+	added, err := s.denyList.Add(ctx, claims.ID, claims.ExpiresTime().Sub(s.now()))
+	if err != nil {
+		return nil, err
+	} else if !added {
+		return nil, ErrTokenRevoked
+	}
+
+	return s.Issue(ctx, claims.Subject)
+}
+
+// Revoke deny the token until it expires, any token type
+func (s *DefaultTokenService) Revoke(ctx context.Context, token string) error {
+
+	claims, err := s.verify(token, "")
+	if err != nil {
+		return err
+	}
+
+	_, err = s.denyList.Add(ctx, claims.ID, claims.ExpiresTime().Sub(s.now()))
+	return err
+}
+
+// RevokeAll deny every token issued to the user so far, the tokens of a user signed in again later are valid
+func (s *DefaultTokenService) RevokeAll(ctx context.Context, username string) error {
+
+	// a denied token outlives no token, the refresh token lives longest
+	expiration := s.refreshTTL
+	if s.accessTTL > expiration {
+		expiration = s.accessTTL
+	}
+
+	return s.denyList.SetTokensValidAfter(ctx, username, s.now(), expiration)
+}
+
+func (s *DefaultTokenService) sign(username string, tokenType string, issuedAt int64, expiresAt time.Time) (string, error) {
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	return s.signer.Sign(&jwt.Claims{
+		ID:        id.String(),
+		Subject:   username,
+		Issuer:    s.issuer,
+		Type:      tokenType,
+		IssuedAt:  issuedAt,
+		ExpiresAt: expiresAt.Unix(),
+	})
+}
+
+// checkValidAfter ErrTokenRevoked when the token was issued before a RevokeAll of its user
+func (s *DefaultTokenService) checkValidAfter(ctx context.Context, claims *jwt.Claims) error {
+
+	validAfter, err := s.denyList.TokensValidAfter(ctx, claims.Subject)
+	if err != nil {
+		return err
+	}
+	if !validAfter.IsZero() && claims.IssuedAt <= validAfter.Unix() {
+		return ErrTokenRevoked
+	}
+
+	return nil
+}
+
+// verify signature, expiry, issuer and type, empty tokenType accepts any type
+func (s *DefaultTokenService) verify(token string, tokenType string) (*jwt.Claims, error) {
+
+	if s.signer == nil {
+		return nil, ErrTokensDisabled
+	}
+
+	claims, err := s.signer.Verify(token)
+	if err != nil {
+		if errors.Is(err, jwt.ErrInvalidToken) || errors.Is(err, jwt.ErrTokenExpired) || errors.Is(err, jwt.ErrUnknownKey) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	if claims.Issuer != s.issuer || claims.Subject == "" || claims.ID == "" {
+		return nil, ErrInvalidToken
+	}
+	if tokenType != "" && claims.Type != tokenType {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	mockService "walk_backend/internal/app/service/mock"
+	"walk_backend/internal/pkg/jwt"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenService(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockDenyList := mockService.NewMockTokenDenyListRepositoryInterface(controller)
+	var validAfter time.Time
+	mockDenyList.EXPECT().TokensValidAfter(gomock.Any(), "Wozniak").
+		DoAndReturn(func(context.Context, string) (time.Time, error) { return validAfter, nil }).
+		AnyTimes()
+
+	key, err := jwt.NewHS256Key("k1", bytes.Repeat([]byte("k"), jwt.MinHS256SecretLength))
+	assert.Nil(t, err)
+	keySet, err := jwt.NewKeySet("k1", key)
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	s := NewDefaultTokenService(keySet, mockDenyList, "walk", 15*time.Minute, 24*time.Hour)
+
+	pair, err := s.Issue(ctx, "Wozniak")
+	assert.Nil(t, err)
+	assert.Equal(t, 15*time.Minute, pair.AccessTTL)
+
+	t.Run("Verify_access", func(t *testing.T) {
+		mockDenyList.EXPECT().Has(ctx, gomock.Any()).Return(false, nil)
+
+		claims, err := s.VerifyAccess(ctx, pair.AccessToken)
+		assert.Nil(t, err)
+		assert.Equal(t, "Wozniak", claims.Subject)
+	})
+
+	t.Run("Refresh_token_is_not_access", func(t *testing.T) {
+		_, err := s.VerifyAccess(ctx, pair.RefreshToken)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Revoked_access", func(t *testing.T) {
+		mockDenyList.EXPECT().Has(ctx, gomock.Any()).Return(true, nil)
+
+		_, err := s.VerifyAccess(ctx, pair.AccessToken)
+		assert.ErrorIs(t, err, ErrTokenRevoked)
+	})
+
+	t.Run("Refresh", func(t *testing.T) {
+		mockDenyList.EXPECT().Add(ctx, gomock.Any(), gomock.Any()).Return(true, nil)
+
+		refreshed, err := s.Refresh(ctx, pair.RefreshToken)
+		assert.Nil(t, err)
+		assert.NotEqual(t, pair.RefreshToken, refreshed.RefreshToken)
+	})
+
+	t.Run("Refresh_reused", func(t *testing.T) {
+		mockDenyList.EXPECT().Add(ctx, gomock.Any(), gomock.Any()).Return(false, nil)
+
+		_, err := s.Refresh(ctx, pair.RefreshToken)
+		assert.ErrorIs(t, err, ErrTokenRevoked)
+	})
+
+	t.Run("Revoke_all", func(t *testing.T) {
+		now := time.Now()
+		s.now = func() time.Time { return now }
+		defer func() { s.now = time.Now }()
+
+		mockDenyList.EXPECT().SetTokensValidAfter(ctx, "Wozniak", now, 24*time.Hour).
+			DoAndReturn(func(_ context.Context, _ string, t time.Time, _ time.Duration) error {
+				validAfter = t
+				return nil
+			})
+		assert.Nil(t, s.RevokeAll(ctx, "Wozniak"))
+		defer func() { validAfter = time.Time{} }()
+
+		mockDenyList.EXPECT().Has(ctx, gomock.Any()).Return(false, nil)
+		_, err := s.VerifyAccess(ctx, pair.AccessToken)
+		assert.ErrorIs(t, err, ErrTokenRevoked)
+		other, err := s.Issue(ctx, "Wozniak")
+		assert.Nil(t, err)
+		_, err = s.Refresh(ctx, pair.RefreshToken)
+		assert.ErrorIs(t, err, ErrTokenRevoked)
+
+		// a pair issued in the same second after the revocation is valid
+		mockDenyList.EXPECT().Has(ctx, gomock.Any()).Return(false, nil)
+		_, err = s.VerifyAccess(ctx, other.AccessToken)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Other_issuer", func(t *testing.T) {
+		other := NewDefaultTokenService(keySet, mockDenyList, "other", time.Minute, time.Minute)
+		_, err := other.VerifyAccess(ctx, pair.AccessToken)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		disabled := NewDefaultTokenService(nil, mockDenyList, "walk", time.Minute, time.Minute)
+		_, err := disabled.Issue(ctx, "Wozniak")
+		assert.ErrorIs(t, err, ErrTokensDisabled)
+	})
+}
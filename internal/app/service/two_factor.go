@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/pkg/totp"
+)
+
+const (
+	// twoFactorSkew codes of the previous and the next time step are accepted for clock drift
+	twoFactorSkew int = 1
+	// recoveryCodeCount recovery codes of an enrolment
+	recoveryCodeCount int = 10
+	// recoveryCodeBytes random bytes of a recovery code, shown as xxxxx-xxxxx
+	recoveryCodeBytes int = 5
+)
+
+var (
+	// ErrTwoFactorEnabled ...
+	ErrTwoFactorEnabled = errors.New("two-factor authentication is enabled already")
+	// ErrTwoFactorNotEnabled ...
+	ErrTwoFactorNotEnabled = errors.New("two-factor authentication is not enabled")
+	// ErrTwoFactorRequired disable while a role requires it
+	ErrTwoFactorRequired = errors.New("two-factor authentication is required by your roles")
+	// ErrTwoFactorNotPending confirm without an enrolment
+	ErrTwoFactorNotPending = errors.New("two-factor enrolment not started")
+	// ErrInvalidTwoFactorCode wrong, expired or used TOTP or recovery code
+	ErrInvalidTwoFactorCode = errors.New("invalid two-factor code")
+	// ErrInvalidTwoFactorChallenge unknown or expired login challenge
+	ErrInvalidTwoFactorChallenge = errors.New("invalid or expired two-factor challenge")
+)
+
+// TwoFactorUserRepositoryInterface ...
+type TwoFactorUserRepositoryInterface interface {
+	FindByUsername(ctx context.Context, username string) (*model.User, error)
+	SetPendingTwoFactor(ctx context.Context, id model.ID, secret string) error
+	EnableTwoFactor(ctx context.Context, id model.ID, secret string, counter int64, recoveryCodes []string, enabledAt time.Time) error
+	DeleteTwoFactor(ctx context.Context, id model.ID) error
+	UseTwoFactorCounter(ctx context.Context, id model.ID, counter int64) error
+	UseRecoveryCode(ctx context.Context, id model.ID, codeHash string) error
+	SetRecoveryCodes(ctx context.Context, id model.ID, recoveryCodes []string) error
+}
+
+// TwoFactorChallengeRepositoryInterface ...
+type TwoFactorChallengeRepositoryInterface interface {
+	Create(ctx context.Context, hash string, m *model.TwoFactorChallenge) error
+	Find(ctx context.Context, hash string) (*model.TwoFactorChallenge, error)
+	Delete(ctx context.Context, hash string) error
+}
+
+// TwoFactorEnrolment secret to add to an authenticator app, directly or as a QR code of the URI
+type TwoFactorEnrolment struct {
+	Secret string
+	URI    string
+}
+
+// DefaultTwoFactorService TOTP enrolment and the second step of the password login
+type DefaultTwoFactorService struct {
+	userRepo       TwoFactorUserRepositoryInterface
+	challengeRepo  TwoFactorChallengeRepositoryInterface
+	passwordHasher *model.PasswordHasher
+	accessPolicy   *model.AccessPolicy
+	issuer         string
+	challengeTTL   time.Duration
+	now            func() time.Time
+}
+
+// NewDefaultTwoFactorService create new default two-factor service, issuer names the account in authenticator apps,
+// users of the accessPolicy two-factor roles cannot disable it
+func NewDefaultTwoFactorService(
+	userRepo TwoFactorUserRepositoryInterface,
+	challengeRepo TwoFactorChallengeRepositoryInterface,
+	passwordHasher *model.PasswordHasher,
+	accessPolicy *model.AccessPolicy,
+	issuer string,
+	challengeTTL time.Duration,
+) *DefaultTwoFactorService {
+	return &DefaultTwoFactorService{
+		userRepo:       userRepo,
+		challengeRepo:  challengeRepo,
+		passwordHasher: passwordHasher,
+		accessPolicy:   accessPolicy,
+		issuer:         issuer,
+		challengeTTL:   challengeTTL,
+		now:            time.Now,
+	}
+}
+
+// Enrol start the enrolment with a new secret, it is enabled by Confirm. A pending secret is replaced
+func (s *DefaultTwoFactorService) Enrol(ctx context.Context, user *model.User, password string) (*TwoFactorEnrolment, error) {
+
+	if err := s.checkPassword(user, password); err != nil {
+		return nil, err
+	}
+	if user.IsTwoFactorEnabled() {
+		return nil, ErrTwoFactorEnabled
+	}
+
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.SetPendingTwoFactor(ctx, user.ID, secret); err != nil {
+		if errors.Is(err, model.ErrModelNotFound) {
+			return nil, ErrTwoFactorEnabled
+		}
+		return nil, err
+	}
+
+	return &TwoFactorEnrolment{
+		Secret: secret,
+		URI:    totp.ProvisioningURI(s.issuer, user.Username, secret),
+	}, nil
+}
+
+// Confirm enable the pending secret with a code of it, returns the recovery codes, they can not be shown again
+func (s *DefaultTwoFactorService) Confirm(ctx context.Context, user *model.User, code string) ([]string, error) {
+
+	if user.IsTwoFactorEnabled() {
+		return nil, ErrTwoFactorEnabled
+	}
+	if user.TwoFactor == nil || user.TwoFactor.Secret == "" {
+		return nil, ErrTwoFactorNotPending
+	}
+
+	now := s.now()
+	counter, ok := totp.Validate(user.TwoFactor.Secret, normaliseTwoFactorCode(code), now, twoFactorSkew)
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.EnableTwoFactor(ctx, user.ID, user.TwoFactor.Secret, counter, hashes, now); err != nil {
+		if errors.Is(err, model.ErrModelNotFound) {
+			return nil, ErrTwoFactorNotPending
+		}
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// Disable remove the second factor, needs the password and a TOTP or recovery code
+func (s *DefaultTwoFactorService) Disable(ctx context.Context, user *model.User, password string, code string) error {
+
+	if err := s.checkPassword(user, password); err != nil {
+		return err
+	}
+	if !user.IsTwoFactorEnabled() {
+		return ErrTwoFactorNotEnabled
+	}
+	if s.accessPolicy.NeedsTwoFactor(user) {
+		return ErrTwoFactorRequired
+	}
+	if err := s.useCode(ctx, user, code); err != nil {
+		return err
+	}
+
+	return s.userRepo.DeleteTwoFactor(ctx, user.ID)
+}
+
+// Reset remove the second factor of the user, for users who lost the device and the recovery codes
+func (s *DefaultTwoFactorService) Reset(ctx context.Context, username string) error {
+
+	user, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	return s.userRepo.DeleteTwoFactor(ctx, user.ID)
+}
+
+// RegenerateRecoveryCodes replace the recovery codes, needs a TOTP or recovery code
+func (s *DefaultTwoFactorService) RegenerateRecoveryCodes(ctx context.Context, user *model.User, code string) ([]string, error) {
+
+	if !user.IsTwoFactorEnabled() {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	if err := s.useCode(ctx, user, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.SetRecoveryCodes(ctx, user.ID, hashes); err != nil {
+		if errors.Is(err, model.ErrModelNotFound) {
+			return nil, ErrTwoFactorNotEnabled
+		}
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// Challenge start the second step of the password login of the user, returns the raw challenge
+func (s *DefaultTwoFactorService) Challenge(ctx context.Context, user *model.User, tokens bool) (string, *model.TwoFactorChallenge, error) {
+
+	challenge, err := newOpaqueToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	m := &model.TwoFactorChallenge{
+		Username:  user.Username,
+		Tokens:    tokens,
+		ExpiresAt: s.now().Add(s.challengeTTL),
+	}
+	if err := s.challengeRepo.Create(ctx, hashOpaqueToken(challenge), m); err != nil {
+		return "", nil, err
+	}
+
+	return challenge, m, nil
+}
+
+// FindChallenge ErrInvalidTwoFactorChallenge when unknown or expired
+func (s *DefaultTwoFactorService) FindChallenge(ctx context.Context, challenge string) (*model.TwoFactorChallenge, error) {
+
+	m, err := s.challengeRepo.Find(ctx, hashOpaqueToken(challenge))
+	if err != nil {
+		if errors.Is(err, model.ErrModelNotFound) {
+			return nil, ErrInvalidTwoFactorChallenge
+		}
+		return nil, err
+	}
+	if !s.now().Before(m.ExpiresAt) {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+
+	return m, nil
+}
+
+// Verify complete the login of the challenge with a TOTP or recovery code, the challenge is single use
+func (s *DefaultTwoFactorService) Verify(ctx context.Context, challenge string, code string) (*model.User, error) {
+
+	m, err := s.FindChallenge(ctx, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByUsername(ctx, m.Username)
+	if err != nil {
+		if errors.Is(err, model.ErrModelNotFound) {
+			return nil, ErrInvalidTwoFactorChallenge
+		}
+		return nil, err
+	}
+	// disabled after the password step
+	if !user.IsTwoFactorEnabled() {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+	if err := s.useCode(ctx, user, code); err != nil {
+		return nil, err
+	}
+
+	if err := s.challengeRepo.Delete(ctx, hashOpaqueToken(challenge)); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// useCode accept a TOTP code of an unused time step or an unused recovery code
+func (s *DefaultTwoFactorService) useCode(ctx context.Context, user *model.User, code string) error {
+
+	code = normaliseTwoFactorCode(code)
+
+	var err error
+	if len(code) == totp.Digits {
+		counter, ok := totp.Validate(user.TwoFactor.Secret, code, s.now(), twoFactorSkew)
+		if !ok {
+			return ErrInvalidTwoFactorCode
+		}
+		err = s.userRepo.UseTwoFactorCounter(ctx, user.ID, counter)
+	} else {
+		err = s.userRepo.UseRecoveryCode(ctx, user.ID, hashOpaqueToken(code))
+	}
+	if errors.Is(err, model.ErrModelNotFound) {
+		return ErrInvalidTwoFactorCode
+	}
+
+	return err
+}
+
+func (s *DefaultTwoFactorService) checkPassword(user *model.User, password string) error {
+
+	if err := user.CheckPassword(password, s.passwordHasher); err != nil {
+		if errors.Is(err, model.ErrPassMismatched) {
+			return ErrInvalidCurrentPassword
+		}
+		return err
+	}
+	return nil
+}
+
+// normaliseTwoFactorCode lower case without spaces and dashes, as codes are often typed
+func normaliseTwoFactorCode(code string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(code)))
+}
+
+// newRecoveryCodes raw codes to show and their hashes to store
+func newRecoveryCodes() ([]string, []string, error) {
+
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, err
+		}
+		code := hex.EncodeToString(raw)
+		codes = append(codes, code[:len(code)/2]+"-"+code[len(code)/2:])
+		hashes = append(hashes, hashOpaqueToken(code))
+	}
+
+	return codes, hashes, nil
+}
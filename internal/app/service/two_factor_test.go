@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"walk_backend/internal/app/model"
+	mockService "walk_backend/internal/app/service/mock"
+	"walk_backend/internal/pkg/totp"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwoFactorService(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockUserRepository := mockService.NewMockTwoFactorUserRepositoryInterface(controller)
+	mockChallengeRepository := mockService.NewMockTwoFactorChallengeRepositoryInterface(controller)
+
+	ctx := context.Background()
+	now := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+	s := NewDefaultTwoFactorService(mockUserRepository, mockChallengeRepository, model.DefaultPasswordHasher(), model.NewAccessPolicy(nil, []model.Role{model.RoleEditor}), "Walk", 5*time.Minute)
+	s.now = func() time.Time { return now }
+
+	user, err := model.NewUserModel("Wozniak", "Apple-II-1977", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+	assert.Nil(t, err)
+
+	t.Run("Enrol_invalid_password", func(t *testing.T) {
+		_, err := s.Enrol(ctx, user, "wrong")
+		assert.ErrorIs(t, err, ErrInvalidCurrentPassword)
+	})
+
+	var secret string
+	t.Run("Enrol", func(t *testing.T) {
+		mockUserRepository.EXPECT().SetPendingTwoFactor(ctx, user.ID, gomock.Any()).Return(nil)
+
+		enrolment, err := s.Enrol(ctx, user, "Apple-II-1977")
+		assert.Nil(t, err)
+		assert.Contains(t, enrolment.URI, "otpauth://totp/Walk:Wozniak?")
+		assert.Contains(t, enrolment.URI, "secret="+enrolment.Secret)
+		secret = enrolment.Secret
+		user.TwoFactor = &model.TwoFactor{Secret: secret}
+	})
+
+	var recoveryCodes []string
+	t.Run("Confirm", func(t *testing.T) {
+		_, err := s.Confirm(ctx, user, "000000")
+		assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+
+		code, err := totp.Code(secret, now)
+		assert.Nil(t, err)
+		mockUserRepository.
+			EXPECT().
+			EnableTwoFactor(ctx, user.ID, secret, totp.Counter(now), gomock.Any(), now).
+			DoAndReturn(func(_ context.Context, _ model.ID, _ string, counter int64, hashes []string, enabledAt time.Time) error {
+				user.TwoFactor.EnabledAt = &enabledAt
+				user.TwoFactor.LastCounter = counter
+				user.TwoFactor.RecoveryCodes = hashes
+				return nil
+			})
+
+		recoveryCodes, err = s.Confirm(ctx, user, code[:3]+" "+code[3:])
+		assert.Nil(t, err)
+		assert.Len(t, recoveryCodes, recoveryCodeCount)
+		assert.Regexp(t, `^[0-9a-f]{5}-[0-9a-f]{5}$`, recoveryCodes[0])
+		assert.Equal(t, hashOpaqueToken(normaliseTwoFactorCode(recoveryCodes[0])), user.TwoFactor.RecoveryCodes[0])
+
+		_, err = s.Enrol(ctx, user, "Apple-II-1977")
+		assert.ErrorIs(t, err, ErrTwoFactorEnabled)
+	})
+
+	t.Run("Verify", func(t *testing.T) {
+		mockChallengeRepository.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).Return(nil)
+		challenge, m, err := s.Challenge(ctx, user, true)
+		assert.Nil(t, err)
+		assert.True(t, m.Tokens)
+		assert.Equal(t, now.Add(5*time.Minute), m.ExpiresAt)
+
+		hash := hashOpaqueToken(challenge)
+		mockChallengeRepository.EXPECT().Find(ctx, hash).Return(m, nil).AnyTimes()
+		mockUserRepository.EXPECT().FindByUsername(ctx, "Wozniak").Return(user, nil).AnyTimes()
+
+		next, err := totp.Code(secret, now.Add(totp.Period))
+		assert.Nil(t, err)
+		_, err = s.Verify(ctx, challenge, "000000")
+		assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+
+		// a code of an accepted time step is rejected
+		mockUserRepository.EXPECT().UseTwoFactorCounter(ctx, user.ID, totp.Counter(now)+1).Return(model.ErrModelNotFound)
+		_, err = s.Verify(ctx, challenge, next)
+		assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+
+		mockUserRepository.EXPECT().UseTwoFactorCounter(ctx, user.ID, totp.Counter(now)+1).Return(nil)
+		mockChallengeRepository.EXPECT().Delete(ctx, hash).Return(nil)
+		got, err := s.Verify(ctx, challenge, next)
+		assert.Nil(t, err)
+		assert.Equal(t, "Wozniak", got.Username)
+	})
+
+	t.Run("Verify_recovery_code", func(t *testing.T) {
+		mockChallengeRepository.EXPECT().Find(ctx, hashOpaqueToken("recovery")).Return(&model.TwoFactorChallenge{Username: "Wozniak", ExpiresAt: now.Add(time.Minute)}, nil)
+		mockUserRepository.EXPECT().UseRecoveryCode(ctx, user.ID, user.TwoFactor.RecoveryCodes[1]).Return(nil)
+		mockChallengeRepository.EXPECT().Delete(ctx, hashOpaqueToken("recovery")).Return(nil)
+
+		_, err := s.Verify(ctx, "recovery", " "+recoveryCodes[1]+" ")
+		assert.Nil(t, err)
+	})
+
+	t.Run("Verify_expired", func(t *testing.T) {
+		mockChallengeRepository.EXPECT().Find(ctx, hashOpaqueToken("expired")).Return(&model.TwoFactorChallenge{Username: "Wozniak", ExpiresAt: now}, nil)
+		mockChallengeRepository.EXPECT().Find(ctx, hashOpaqueToken("unknown")).Return(nil, model.ErrModelNotFound)
+
+		_, err := s.Verify(ctx, "expired", recoveryCodes[2])
+		assert.ErrorIs(t, err, ErrInvalidTwoFactorChallenge)
+		_, err = s.Verify(ctx, "unknown", recoveryCodes[2])
+		assert.ErrorIs(t, err, ErrInvalidTwoFactorChallenge)
+	})
+
+	t.Run("Disable", func(t *testing.T) {
+		assert.ErrorIs(t, s.Disable(ctx, user, "wrong", recoveryCodes[2]), ErrInvalidCurrentPassword)
+
+		user.Roles = []model.Role{model.RoleEditor}
+		assert.ErrorIs(t, s.Disable(ctx, user, "Apple-II-1977", recoveryCodes[2]), ErrTwoFactorRequired)
+		user.Roles = []model.Role{model.RoleContributor}
+
+		mockUserRepository.EXPECT().UseRecoveryCode(ctx, user.ID, user.TwoFactor.RecoveryCodes[2]).Return(nil)
+		mockUserRepository.EXPECT().DeleteTwoFactor(ctx, user.ID).Return(nil)
+		assert.Nil(t, s.Disable(ctx, user, "Apple-II-1977", recoveryCodes[2]))
+	})
+}
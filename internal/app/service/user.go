@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"walk_backend/internal/app/model"
+)
+
+var (
+	// ErrInvalidRole ...
+	ErrInvalidRole = errors.New("invalid role")
+	// ErrLastAdmin ...
+	ErrLastAdmin = errors.New("the last admin can not lose the admin role")
+	// ErrBootstrapAdminPassword ...
+	ErrBootstrapAdminPassword = errors.New("password is required to create the bootstrap admin")
+	// ErrInvalidCurrentPassword ...
+	ErrInvalidCurrentPassword = errors.New("invalid current password")
+)
+
+// UserRoleRepositoryInterface ...
+type UserRoleRepositoryInterface interface {
+	Create(ctx context.Context, m *model.User) (model.ID, error)
+	FindByUsername(ctx context.Context, username string) (*model.User, error)
+	UpdateRoles(ctx context.Context, id model.ID, roles []model.Role) error
+	CountByRole(ctx context.Context, role model.Role) (int64, error)
+	UpdatePassword(ctx context.Context, id model.ID, passwordHash string) error
+}
+
+// DefaultUserService ...
+type DefaultUserService struct {
+	userRepo       UserRoleRepositoryInterface
+	passwordPolicy *model.PasswordPolicy
+	passwordHasher *model.PasswordHasher
+}
+
+// NewDefaultUserService create new default user service, new passwords must satisfy passwordPolicy
+func NewDefaultUserService(
+	userRepo UserRoleRepositoryInterface,
+	passwordPolicy *model.PasswordPolicy,
+	passwordHasher *model.PasswordHasher,
+) *DefaultUserService {
+	return &DefaultUserService{
+		userRepo:       userRepo,
+		passwordPolicy: passwordPolicy,
+		passwordHasher: passwordHasher,
+	}
+}
+
+// Find ...
+func (s *DefaultUserService) Find(ctx context.Context, username string) (*model.User, error) {
+	return s.userRepo.FindByUsername(ctx, username)
+}
+
+// SetRoles replace roles of the user, the last admin keeps the admin role
+func (s *DefaultUserService) SetRoles(ctx context.Context, username string, roles []model.Role) (*model.User, error) {
+
+	roles, err := model.NormaliseRoles(roles)
+	if err != nil {
+		return nil, ErrInvalidRole
+	}
+
+	user, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	wasAdmin := user.HasRole(model.RoleAdmin)
+	user.Roles = roles
+	if wasAdmin && !user.HasRole(model.RoleAdmin) {
+		admins, err := s.userRepo.CountByRole(ctx, model.RoleAdmin)
+		if err != nil {
+			return nil, err
+		}
+		if admins <= 1 {
+			return nil, ErrLastAdmin
+		}
+	}
+
+	if err := s.userRepo.UpdateRoles(ctx, user.ID, roles); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// BootstrapAdmin grant the admin role to the user, the user is created when missing
+func (s *DefaultUserService) BootstrapAdmin(ctx context.Context, username string, password string) error {
+
+	user, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil && !errors.Is(err, model.ErrModelNotFound) {
+		return err
+	}
+
+	if user == nil {
+		if password == "" {
+			return ErrBootstrapAdminPassword
+		}
+		user, err = model.NewUserModel(username, password, s.passwordPolicy, s.passwordHasher)
+		if err != nil {
+			return err
+		}
+		// no email to verify, the operator vouches for the admin
+		now := time.Now()
+		user.Roles = []model.Role{model.RoleAdmin}
+		user.EmailVerifiedAt = &now
+		_, err = s.userRepo.Create(ctx, user)
+		return err
+	}
+
+	if user.HasRole(model.RoleAdmin) {
+		return nil
+	}
+
+	return s.userRepo.UpdateRoles(ctx, user.ID, append(user.Roles, model.RoleAdmin))
+}
+
+// ChangePassword replace the password of the user, the current password must match
+func (s *DefaultUserService) ChangePassword(ctx context.Context, username string, currentPassword string, newPassword string) error {
+
+	user, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if err := user.CheckPassword(currentPassword, s.passwordHasher); err != nil {
+		if errors.Is(err, model.ErrPassMismatched) {
+			return ErrInvalidCurrentPassword
+		}
+		return err
+	}
+
+	if currentPassword == newPassword {
+		validationErr := &model.ValidationError{}
+		validationErr.Add("newPassword", "must differ from the current password")
+		return validationErr
+	}
+
+	if err := user.SetPassword(newPassword, s.passwordPolicy, s.passwordHasher); err != nil {
+		var validationErr *model.ValidationError
+		if errors.As(err, &validationErr) {
+			// the request field name
+			validationErr.Fields = map[string][]string{"newPassword": validationErr.Fields["password"]}
+		}
+		return err
+	}
+
+	return s.userRepo.UpdatePassword(ctx, user.ID, user.Password)
+}
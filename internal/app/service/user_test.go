@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"walk_backend/internal/app/model"
+	mockService "walk_backend/internal/app/service/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserService_SetRoles(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockUserRepository := mockService.NewMockUserRoleRepositoryInterface(controller)
+
+	ctx := context.Background()
+	s := NewDefaultUserService(mockUserRepository, &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+
+	t.Run("Invalid_role", func(t *testing.T) {
+		_, err := s.SetRoles(ctx, "Wozniak", []model.Role{"owner"})
+		assert.ErrorIs(t, err, ErrInvalidRole)
+	})
+
+	t.Run("Last_admin", func(t *testing.T) {
+		user, err := model.NewUserModel("Wozniak", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+		assert.Nil(t, err)
+		user.Roles = []model.Role{model.RoleAdmin}
+
+		mockUserRepository.EXPECT().FindByUsername(ctx, "Wozniak").Return(user, nil)
+		mockUserRepository.EXPECT().CountByRole(ctx, model.RoleAdmin).Return(int64(1), nil)
+
+		_, err = s.SetRoles(ctx, "Wozniak", []model.Role{model.RoleEditor})
+		assert.ErrorIs(t, err, ErrLastAdmin)
+	})
+
+	t.Run("Ok", func(t *testing.T) {
+		user, err := model.NewUserModel("Wozniak", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+		assert.Nil(t, err)
+
+		mockUserRepository.EXPECT().FindByUsername(ctx, "Wozniak").Return(user, nil)
+		mockUserRepository.EXPECT().UpdateRoles(ctx, user.ID, []model.Role{model.RoleEditor, model.RoleContributor}).Return(nil)
+
+		user, err = s.SetRoles(ctx, "Wozniak", []model.Role{model.RoleEditor, model.RoleContributor, model.RoleEditor})
+		assert.Nil(t, err)
+		assert.True(t, user.Can(model.PermissionPlaceDelete))
+		assert.False(t, user.Can(model.PermissionCategoryManage))
+	})
+}
+
+func TestUserService_BootstrapAdmin(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockUserRepository := mockService.NewMockUserRoleRepositoryInterface(controller)
+
+	ctx := context.Background()
+	s := NewDefaultUserService(mockUserRepository, &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+
+	t.Run("Create", func(t *testing.T) {
+		mockUserRepository.EXPECT().FindByUsername(ctx, "admin").Return(nil, model.ErrModelNotFound)
+		mockUserRepository.EXPECT().Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, m *model.User) (model.ID, error) {
+				assert.Equal(t, []model.Role{model.RoleAdmin}, m.Roles)
+				return m.ID, nil
+			})
+
+		assert.Nil(t, s.BootstrapAdmin(ctx, "admin", "password"))
+	})
+
+	t.Run("Create_without_password", func(t *testing.T) {
+		mockUserRepository.EXPECT().FindByUsername(ctx, "admin").Return(nil, model.ErrModelNotFound)
+
+		assert.ErrorIs(t, s.BootstrapAdmin(ctx, "admin", ""), ErrBootstrapAdminPassword)
+	})
+
+	t.Run("Grant", func(t *testing.T) {
+		user, err := model.NewUserModel("Wozniak", "password", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+		assert.Nil(t, err)
+
+		mockUserRepository.EXPECT().FindByUsername(ctx, "Wozniak").Return(user, nil)
+		mockUserRepository.EXPECT().UpdateRoles(ctx, user.ID, []model.Role{model.RoleContributor, model.RoleAdmin}).Return(nil)
+
+		assert.Nil(t, s.BootstrapAdmin(ctx, "Wozniak", ""))
+	})
+}
+
+func TestUserService_ChangePassword(t *testing.T) {
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mockUserRepository := mockService.NewMockUserRoleRepositoryInterface(controller)
+
+	ctx := context.Background()
+	s := NewDefaultUserService(mockUserRepository, model.NewPasswordPolicy(8, 2, true), model.DefaultPasswordHasher())
+
+	user, err := model.NewUserModel("Wozniak", "Apple-1976", &model.PasswordPolicy{}, model.DefaultPasswordHasher())
+	assert.Nil(t, err)
+	mockUserRepository.EXPECT().FindByUsername(ctx, "Wozniak").Return(user, nil).AnyTimes()
+
+	t.Run("Invalid_current_password", func(t *testing.T) {
+		err := s.ChangePassword(ctx, "Wozniak", "Apple-1977", "Apple-II-1977")
+		assert.ErrorIs(t, err, ErrInvalidCurrentPassword)
+	})
+
+	t.Run("Same_password", func(t *testing.T) {
+		err := s.ChangePassword(ctx, "Wozniak", "Apple-1976", "Apple-1976")
+		var validationErr *model.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, validationErr.Fields, "newPassword")
+	})
+
+	t.Run("Policy", func(t *testing.T) {
+		err := s.ChangePassword(ctx, "Wozniak", "Apple-1976", "password")
+		var validationErr *model.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []string{"must use at least 2 of: lowercase letters, uppercase letters, digits, symbols", "is too common"}, validationErr.Fields["newPassword"])
+	})
+
+	t.Run("Ok", func(t *testing.T) {
+		mockUserRepository.EXPECT().UpdatePassword(ctx, user.ID, gomock.Any()).Return(nil)
+
+		assert.Nil(t, s.ChangePassword(ctx, "Wozniak", "Apple-1976", "Apple-II-1977"))
+		assert.Nil(t, user.CheckPassword("Apple-II-1977", model.DefaultPasswordHasher()))
+	})
+}
@@ -8,11 +8,24 @@ import (
 	"syscall"
 	"time"
 
+	"walk_backend/internal/app/api/handlers/account"
+	"walk_backend/internal/app/api/handlers/apikey"
+	"walk_backend/internal/app/api/handlers/audit"
 	"walk_backend/internal/app/api/handlers/auth"
 	"walk_backend/internal/app/api/handlers/category"
+	"walk_backend/internal/app/api/handlers/oidc"
+	"walk_backend/internal/app/api/handlers/password"
 	"walk_backend/internal/app/api/handlers/place"
+	"walk_backend/internal/app/api/handlers/profile"
+	"walk_backend/internal/app/api/handlers/search"
+	"walk_backend/internal/app/api/handlers/session"
+	"walk_backend/internal/app/api/handlers/tag"
+	"walk_backend/internal/app/api/handlers/twofactor"
+	"walk_backend/internal/app/api/handlers/user"
+	"walk_backend/internal/app/api/handlers/verification"
 	"walk_backend/internal/app/api/middleware"
 	"walk_backend/internal/app/api/presenter"
+	"walk_backend/internal/app/model"
 	"walk_backend/internal/app/repository"
 	"walk_backend/internal/app/service"
 	"walk_backend/internal/pkg/cache"
@@ -145,6 +158,9 @@ func (app *App) Run() {
 	// Engine
 	app.engine = gin.New()
 	app.engine.Use(gin.Recovery())
+	if err := app.engine.SetTrustedProxies(app.cfg.Api.TrustedProxies); err != nil {
+		log.Fatal().Err(err).Caller(0).Msg("trusted proxies")
+	}
 
 	// common middleware
 
@@ -166,57 +182,374 @@ func (app *App) Run() {
 	// session middleware
 	sessionMidlleware := middleware.Session(sessionName, sessionComponent.GetClient())
 
+	// bearer tokens
+	tokenKeySet, err := app.cfg.TokenKeySet()
+	if err != nil {
+		log.Fatal().Err(err).Caller(0).Msg("token keys")
+	}
+	var tokenSigner service.TokenSignerInterface
+	if tokenKeySet != nil {
+		tokenSigner = tokenKeySet
+	} else {
+		log.Print("Bearer tokens disabled, no token keys")
+	}
+	tokenDenyListRedisRepository := repository.NewTokenDenyListRedisRepository(redisClient)
+	tokenService := service.NewDefaultTokenService(
+		tokenSigner,
+		tokenDenyListRedisRepository,
+		app.cfg.Token.Issuer,
+		app.cfg.Token.AccessTTL,
+		app.cfg.Token.RefreshTTL,
+	)
+
+	// session tokens
+	collectionSessionTokens := mongoClient.Database(mongoDefaultDB).Collection("session_tokens")
+	sessionTokenMongoRepository := repository.NewSessionTokenMongoRepository(collectionSessionTokens)
+	sessionTokenService := service.NewDefaultSessionTokenService(
+		sessionTokenMongoRepository,
+		time.Duration(app.cfg.Session.MaxAge)*time.Second,
+	)
+
+	// API keys
+	collectionAPIKeys := mongoClient.Database(mongoDefaultDB).Collection("api_keys")
+	apiKeyMongoRepository := repository.NewAPIKeyMongoRepository(collectionAPIKeys)
+	apiKeyService := service.NewDefaultAPIKeyService(apiKeyMongoRepository)
+
+	// credentials, signs a user out of sessions, bearer tokens and API keys at once
+	credentialService := service.NewDefaultCredentialService(sessionTokenService, tokenService, apiKeyService)
+
 	// auth middleware
-	authMiddleware := middleware.Auth()
+	authMiddleware := middleware.Auth(tokenService, sessionTokenService, apiKeyService)
+
+	// password policy
+	passwordPolicy, err := app.cfg.NewPasswordPolicy()
+	if err != nil {
+		log.Fatal().Err(err).Caller(0).Msg("password policy")
+	}
+	passwordHasher, err := app.cfg.NewPasswordHasher()
+	if err != nil {
+		log.Fatal().Err(err).Caller(0).Msg("password hasher")
+	}
+
+	// user storage
+	collectionUsers := mongoClient.Database(mongoDefaultDB).Collection("users")
+	userMongoRepository := repository.NewUserMongoRepository(collectionUsers)
+	userService := service.NewDefaultUserService(userMongoRepository, passwordPolicy, passwordHasher)
+	if app.cfg.Bootstrap.AdminUsername != "" {
+		if err := userService.BootstrapAdmin(app.ctx, app.cfg.Bootstrap.AdminUsername, app.cfg.Bootstrap.AdminPassword); err != nil {
+			log.Fatal().Err(err).Caller(0).Msg("bootstrap admin")
+		}
+		log.Printf("Bootstrap admin: %s", app.cfg.Bootstrap.AdminUsername)
+	}
+
+	// security audit log
+	collectionAuditEvents := mongoClient.Database(mongoDefaultDB).Collection("audit_events")
+	auditEventMongoRepository := repository.NewAuditEventMongoRepository(collectionAuditEvents)
+	auditService := service.NewDefaultAuditService(auditEventMongoRepository)
+	auditDone := make(chan struct{})
+	go func() {
+		defer close(auditDone)
+		auditService.Run(app.ctx)
+	}()
 
 	// routes for version 1
 	apiV1 := app.engine.Group("/api/v1")
-	apiV1.Use(sessionMidlleware)
+	apiV1.Use(sessionMidlleware, middleware.Audit(auditService))
+
+	verifiedEmailPermissions := []model.Permission{model.PermissionPlaceCreate}
+	if app.cfg.EmailVerification.UnverifiedCanCreatePlaces {
+		verifiedEmailPermissions = nil
+	}
+	twoFactorRoles, err := app.cfg.TwoFactorRoles()
+	if err != nil {
+		log.Fatal().Err(err).Caller(0).Msg("two-factor roles")
+	}
+	accessPolicy := model.NewAccessPolicy(verifiedEmailPermissions, twoFactorRoles)
 
 	apiV1auth := apiV1.Group("")
-	apiV1auth.Use(authMiddleware)
+	apiV1auth.Use(authMiddleware, middleware.CurrentUser(userMongoRepository, accessPolicy))
+
+	apiV1optionalAuth := apiV1.Group("")
+	apiV1optionalAuth.Use(middleware.Optional(authMiddleware), middleware.Optional(middleware.CurrentUser(userMongoRepository, accessPolicy)))
 
 	// Build handlers
-	var authHandlers, categoryHandlers, placeHandlers HandlersInterface
+	var accountHandlers, apiKeyHandlers, auditHandlers, authHandlers, categoryHandlers, oidcHandlers, placeHandlers, passwordHandlers, profileHandlers, searchHandlers, sessionHandlers, tagHandlers, twoFactorHandlers, userHandlers, verificationHandlers HandlersInterface
+
+	// mail
+	mailer, mailerCloser, err := app.cfg.NewMailer()
+	if err != nil {
+		log.Fatal().Err(err).Caller(0).Msg("mailer")
+	}
+	defer func() {
+		if err := mailerCloser.Close(); err != nil {
+			log.Error().Err(err).Caller(0).Send()
+		}
+	}()
+
+	// email verification
+	loginAttemptRedisRepository := repository.NewLoginAttemptRedisRepository(redisClient)
+	rateLimitRedisRepository := repository.NewRateLimitRedisRepository(redisClient)
+	emailVerificationKeySet, err := app.cfg.EmailVerificationKeySet()
+	if err != nil {
+		log.Fatal().Err(err).Caller(0).Msg("email verification key")
+	}
+	emailVerificationService := service.NewDefaultEmailVerificationService(
+		userMongoRepository,
+		loginAttemptRedisRepository,
+		emailVerificationKeySet,
+		mailer,
+		app.cfg.Token.Issuer,
+		app.cfg.EmailVerification.TTL,
+		app.cfg.EmailVerification.URL,
+		app.cfg.EmailVerification.ResendInterval,
+	)
+
+	// two-factor authentication
+	twoFactorChallengeRedisRepository := repository.NewTwoFactorChallengeRedisRepository(redisClient)
+	twoFactorService := service.NewDefaultTwoFactorService(
+		userMongoRepository,
+		twoFactorChallengeRedisRepository,
+		passwordHasher,
+		accessPolicy,
+		app.cfg.TwoFactor.Issuer,
+		app.cfg.TwoFactor.ChallengeTTL,
+	)
 
 	// auth
-	collectionUsers := mongoClient.Database(mongoDefaultDB).Collection("users")
-	userMongoRepository := repository.NewUserMongoRepository(collectionUsers)
-	authService := service.NewDefaultAuthService(userMongoRepository)
+	authService := service.NewDefaultAuthService(
+		userMongoRepository,
+		emailVerificationService,
+		passwordPolicy,
+		passwordHasher,
+		app.cfg.EmailVerification.AllowUnverifiedLogin,
+	)
+	loginThrottleService, err := service.NewDefaultLoginThrottleService(loginAttemptRedisRepository, service.LoginThrottleConfig{
+		User: service.LoginThrottleLimits{
+			FreeAttempts: app.cfg.LoginThrottle.UserFreeAttempts,
+			MaxAttempts:  app.cfg.LoginThrottle.UserMaxAttempts,
+		},
+		IP: service.LoginThrottleLimits{
+			FreeAttempts: app.cfg.LoginThrottle.IPFreeAttempts,
+			MaxAttempts:  app.cfg.LoginThrottle.IPMaxAttempts,
+		},
+		BaseDelay: app.cfg.LoginThrottle.BaseDelay,
+		Lockout:   app.cfg.LoginThrottle.Lockout,
+		Window:    app.cfg.LoginThrottle.Window,
+		Allowlist: app.cfg.LoginThrottle.Allowlist,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Caller(0).Msg("login throttle allowlist")
+	}
 	tokenPresenter := presenter.NewTokenPresenter()
-	authHandlers = auth.NewHandler(app.ctx, apiV1, authService, tokenPresenter)
+	authHandlers = auth.NewHandler(app.ctx, apiV1, authService, loginThrottleService, sessionTokenService, tokenService, twoFactorService, tokenPresenter)
 	authHandlers.Make()
+	// resend requests are counted apart from the failed logins
+	verificationResendRateLimitService, err := service.NewDefaultRateLimitService(rateLimitRedisRepository, service.RateLimitConfig{
+		Scope:     "verification_resend",
+		Limit:     app.cfg.EmailVerification.ResendMaxRequests,
+		Window:    app.cfg.EmailVerification.ResendWindow,
+		Allowlist: app.cfg.LoginThrottle.Allowlist,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Caller(0).Msg("verification resend rate limit allowlist")
+	}
+	verificationHandlers = verification.NewHandler(app.ctx, apiV1, emailVerificationService, loginThrottleService, verificationResendRateLimitService)
+	verificationHandlers.Make()
 
-	// category
-	collectionCategories := mongoClient.Database(mongoDefaultDB).Collection("categories")
-	categoryMongoRepository := repository.NewCategoryMongoRepository(collectionCategories)
-	categoryService := service.NewDefaultCategoryService(categoryMongoRepository)
-	categoryPresenter := presenter.NewCategoryPresenter()
-	categoryHandlers = category.NewHandler(app.ctx, apiV1, apiV1auth, categoryService, categoryPresenter)
-	categoryHandlers.Make()
+	// OpenID Connect login
+	oidcProviders, err := app.cfg.NewOIDCProviders()
+	if err != nil {
+		log.Fatal().Err(err).Caller(0).Msg("oidc providers")
+	}
+	for name := range oidcProviders {
+		log.Printf("OIDC provider: %s", name)
+	}
+	oidcService := service.NewDefaultOIDCService(userMongoRepository, oidcProviders)
+	oidcHandlers = oidc.NewHandler(app.ctx, apiV1, oidcService, sessionTokenService, twoFactorService, tokenPresenter)
+	oidcHandlers.Make()
 
-	// place
+	// place storage
 	collectionPlaces := mongoClient.Database(mongoDefaultDB).Collection("places")
 	placeMongoRepository := repository.NewPlaceMongoRepository(collectionPlaces)
 	placeCacheRedisRepository := repository.NewPlaceCacheRedisRepository(redisClient)
+	categoryCountCacheRedisRepository := repository.NewCategoryCountCacheRedisRepository(redisClient)
 	placeQueueRabbitRepository := repository.NewPlaceQueueRabbitRepository(
 		app.ctx,
 		rabbitMQClient,
 		app.cfg.Queue.ReIndex.Exchange,
 		app.cfg.Queue.ReIndex.Place.RoutingKey,
 	)
+	mongoTransaction := repository.NewMongoTransaction(mongoClient)
+
+	// category
+	collectionCategories := mongoClient.Database(mongoDefaultDB).Collection("categories")
+	categoryMongoRepository := repository.NewCategoryMongoRepository(collectionCategories)
+	categoryCacheRedisRepository := repository.NewCategoryCacheRedisRepository(
+		categoryMongoRepository,
+		redisClient,
+		app.cfg.CategoryCache.Channel,
+		app.cfg.CategoryCache.LocalTTL,
+		app.cfg.CategoryCache.RedisTTL,
+	)
+	go func() {
+		// resubscribe until shutdown, the process cache is not invalidated by other instances meanwhile
+		const minBackoff, maxBackoff = time.Second, time.Minute
+		backoff := minBackoff
+		for {
+			subscribed := time.Now()
+			err := categoryCacheRedisRepository.Subscribe(app.ctx)
+			if app.ctx.Err() != nil {
+				return
+			}
+			if time.Since(subscribed) > maxBackoff {
+				backoff = minBackoff
+			}
+			log.Error().Err(err).Caller(0).Dur("backoff", backoff).Msg("category cache subscription stopped, resubscribe")
+
+			select {
+			case <-app.ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			if backoff *= 2; backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+		}
+	}()
+	categoryService := service.NewDefaultCategoryService(
+		categoryMongoRepository,
+		categoryCacheRedisRepository,
+		placeMongoRepository,
+		mongoTransaction,
+		placeQueueRabbitRepository,
+		placeCacheRedisRepository,
+		categoryCountCacheRedisRepository,
+	)
+	categoryPresenter := presenter.NewCategoryPresenter()
+	categoryHandlers = category.NewHandler(app.ctx, apiV1, apiV1auth, categoryService, categoryPresenter)
+	categoryHandlers.Make()
+
+	// search analytics
+	collectionSearchLogs := mongoClient.Database(mongoDefaultDB).Collection("search_logs")
+	searchLogMongoRepository := repository.NewSearchLogMongoRepository(collectionSearchLogs)
+	searchAnalyticsService := service.NewDefaultSearchAnalyticsService(searchLogMongoRepository)
+	searchAnalyticsDone := make(chan struct{})
+	go func() {
+		defer close(searchAnalyticsDone)
+		searchAnalyticsService.Run(app.ctx)
+	}()
+	searchClickRateLimitService, err := service.NewDefaultRateLimitService(rateLimitRedisRepository, service.RateLimitConfig{
+		Scope:     "search_click",
+		Limit:     app.cfg.SearchClickRateLimit.MaxClicks,
+		Window:    app.cfg.SearchClickRateLimit.Window,
+		Allowlist: app.cfg.LoginThrottle.Allowlist,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Caller(0).Msg("search click rate limit allowlist")
+	}
+	searchStatsPresenter := presenter.NewSearchStatsPresenter()
+	searchHandlers = search.NewHandler(app.ctx, apiV1, apiV1auth, searchAnalyticsService, searchClickRateLimitService, searchStatsPresenter)
+	searchHandlers.Make()
+
+	// place
+	collectionTags := mongoClient.Database(mongoDefaultDB).Collection("tags")
+	tagMongoRepository := repository.NewTagMongoRepository(collectionTags, collectionPlaces)
 	keyBuilder := cache.NewKeyBuilderDefault()
 	placeService := service.NewDefaultPlaceService(
 		placeMongoRepository,
-		categoryMongoRepository,
+		categoryCacheRedisRepository,
+		tagMongoRepository,
 		placeQueueRabbitRepository,
 		placeCacheRedisRepository,
+		categoryCountCacheRedisRepository,
 		keyBuilder,
 	)
 	placePresenter := presenter.NewPlacePresenter()
-	placeHandlers = place.NewHandler(app.ctx, apiV1, apiV1auth, placeService, placePresenter)
+	placeHandlers = place.NewHandler(app.ctx, apiV1, apiV1auth, apiV1optionalAuth, placeService, placePresenter, searchAnalyticsService)
 	placeHandlers.Make()
 
+	// tag
+	tagService := service.NewDefaultTagService(
+		tagMongoRepository,
+		placeMongoRepository,
+		placeQueueRabbitRepository,
+		placeCacheRedisRepository,
+		mongoTransaction,
+	)
+	tagPresenter := presenter.NewTagPresenter()
+	tagHandlers = tag.NewHandler(app.ctx, apiV1, apiV1auth, tagService, tagPresenter)
+	tagHandlers.Make()
+
+	// user
+	userPresenter := presenter.NewUserPresenter()
+	userHandlers = user.NewHandler(app.ctx, apiV1auth, userService, userPresenter)
+	userHandlers.Make()
+
+	// account
+	accountHandlers = account.NewHandler(app.ctx, apiV1auth, userService, credentialService, loginThrottleService)
+	accountHandlers.Make()
+
+	// two-factor authentication
+	twoFactorPresenter := presenter.NewTwoFactorPresenter(accessPolicy)
+	twoFactorHandlers = twofactor.NewHandler(app.ctx, apiV1auth, twoFactorService, loginThrottleService, twoFactorPresenter)
+	twoFactorHandlers.Make()
+
+	// password reset
+	collectionPasswordResetTokens := mongoClient.Database(mongoDefaultDB).Collection("password_reset_tokens")
+	passwordResetTokenMongoRepository := repository.NewPasswordResetTokenMongoRepository(collectionPasswordResetTokens)
+	passwordResetService := service.NewDefaultPasswordResetService(
+		userMongoRepository,
+		passwordResetTokenMongoRepository,
+		mailer,
+		passwordPolicy,
+		passwordHasher,
+		app.cfg.PasswordReset.TTL,
+		app.cfg.PasswordReset.URL,
+	)
+	// forgot and reset requests are counted apart from the failed logins
+	passwordResetRateLimitService, err := service.NewDefaultRateLimitService(rateLimitRedisRepository, service.RateLimitConfig{
+		Scope:     "password_reset",
+		Limit:     app.cfg.PasswordReset.MaxRequests,
+		Window:    app.cfg.PasswordReset.Window,
+		Allowlist: app.cfg.LoginThrottle.Allowlist,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Caller(0).Msg("password reset rate limit allowlist")
+	}
+	passwordHandlers = password.NewHandler(app.ctx, apiV1, passwordResetService, credentialService, loginThrottleService, passwordResetRateLimitService)
+	passwordHandlers.Make()
+
+	// session
+	sessionPresenter := presenter.NewSessionPresenter()
+	sessionHandlers = session.NewHandler(app.ctx, apiV1auth, sessionTokenService, credentialService, sessionPresenter)
+	sessionHandlers.Make()
+
+	// API keys
+	apiKeyPresenter := presenter.NewAPIKeyPresenter()
+	apiKeyHandlers = apikey.NewHandler(app.ctx, apiV1auth, apiKeyService, apiKeyPresenter)
+	apiKeyHandlers.Make()
+
+	// profile
+	profileService := service.NewDefaultProfileService(
+		userMongoRepository,
+		placeMongoRepository,
+		categoryCacheRedisRepository,
+		sessionTokenMongoRepository,
+		apiKeyMongoRepository,
+		passwordResetTokenMongoRepository,
+		searchLogMongoRepository,
+		tokenService,
+		passwordHasher,
+	)
+	profilePresenter := presenter.NewProfilePresenter()
+	profileHandlers = profile.NewHandler(app.ctx, apiV1auth, profileService, loginThrottleService, profilePresenter)
+	profileHandlers.Make()
+
+	// audit
+	auditEventPresenter := presenter.NewAuditEventPresenter()
+	auditHandlers = audit.NewHandler(app.ctx, apiV1auth, auditService, auditEventPresenter)
+	auditHandlers.Make()
+
 	app.engine.GET("/version", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"version": app.cfg.Version})
 	})
@@ -263,6 +596,11 @@ func (app *App) Run() {
 	if err := server.Shutdown(ctxShutdown); err != nil {
 		log.Panic().Err(err).Msg("server forced to shutdown")
 	}
+
+	// flush search analytics and the audit log
+	app.ctxCancel()
+	<-searchAnalyticsDone
+	<-auditDone
 }
 
 // GetEnvironment return debug release test
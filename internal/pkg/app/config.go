@@ -3,8 +3,22 @@ package app
 import (
 	"flag"
 	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"os"
+	"regexp"
+	"time"
+
+	"walk_backend/internal/app/model"
+	"walk_backend/internal/app/service"
 	"walk_backend/internal/pkg/components"
+	"walk_backend/internal/pkg/jwt"
+	"walk_backend/internal/pkg/mailer"
+	"walk_backend/internal/pkg/oidc"
 	"walk_backend/internal/pkg/util"
+
+	"golang.org/x/crypto/bcrypt"
 )
 
 type Config struct {
@@ -22,6 +36,8 @@ type Config struct {
 		Schema string `yaml:"schema" env:"API_SCHEMA" env-default:"http" env-description:"API schema"`
 		Host   string `yaml:"host"   env:"API_HOST"   env-default:""     env-description:"API host"`
 		Port   string `yaml:"port"   env:"API_PORT"   env-default:"8080" env-description:"API port"`
+		// TrustedProxies X-Forwarded-For is only read from these, the client IP throttling and allowlists rely on it
+		TrustedProxies util.StringSliceFlag `yaml:"trusted_proxies" env:"API_TRUSTED_PROXIES" env-default:"127.0.0.1,10.0.0.0/8,172.16.0.0/12,192.168.0.0/16" env-description:"Proxies trusted to set X-Forwarded-For" env-separator:","`
 	} `yaml:"api"`
 	Site struct {
 		Schema string `yaml:"schema" env:"SITE_SCHEMA" env-default:"https"     env-description:"SITE schema"`
@@ -37,6 +53,91 @@ type Config struct {
 			} `yaml:"place"`
 		} `yaml:"reindex"`
 	} `yaml:"queue"`
+	CategoryCache struct {
+		LocalTTL time.Duration `yaml:"local_ttl" env:"CATEGORY_CACHE_LOCAL_TTL" env-default:"30s"                   env-description:"Category in process cache TTL"`
+		RedisTTL time.Duration `yaml:"redis_ttl" env:"CATEGORY_CACHE_REDIS_TTL" env-default:"5m"                    env-description:"Category redis cache TTL"`
+		Channel  string        `yaml:"channel"   env:"CATEGORY_CACHE_CHANNEL"   env-default:"categories-invalidate" env-description:"Category cache invalidation pub/sub channel"`
+	} `yaml:"category_cache"`
+	Bootstrap struct {
+		AdminUsername string `yaml:"admin_username" env:"BOOTSTRAP_ADMIN_USERNAME" env-default:"" env-description:"User to grant the admin role on start, created when missing"`
+		AdminPassword string `yaml:"admin_password" env:"BOOTSTRAP_ADMIN_PASSWORD" env-default:"" env-description:"Password of the bootstrap admin when created"`
+	} `yaml:"bootstrap"`
+	Token struct {
+		Issuer     string               `yaml:"issuer"      env:"TOKEN_ISSUER"      env-default:"walk" env-description:"Bearer token issuer"`
+		AccessTTL  time.Duration        `yaml:"access_ttl"  env:"TOKEN_ACCESS_TTL"  env-default:"15m"  env-description:"Bearer access token TTL"`
+		RefreshTTL time.Duration        `yaml:"refresh_ttl" env:"TOKEN_REFRESH_TTL" env-default:"720h" env-description:"Bearer refresh token TTL"`
+		KeyID      string               `yaml:"key_id"      env:"TOKEN_KEY_ID"      env-default:""     env-description:"Bearer token signing key ID, first key when empty"`
+		Keys       util.StringSliceFlag `yaml:"keys"        env:"TOKEN_KEYS"        env-default:""     env-description:"Bearer token keys kid:HS256|EdDSA:base64, bearer tokens are disabled when empty" env-separator:","`
+	} `yaml:"token"`
+	LoginThrottle struct {
+		UserFreeAttempts int64                `yaml:"user_free_attempts" env:"LOGIN_THROTTLE_USER_FREE_ATTEMPTS" env-default:"3"   env-description:"Failed logins per username without delay"`
+		UserMaxAttempts  int64                `yaml:"user_max_attempts"  env:"LOGIN_THROTTLE_USER_MAX_ATTEMPTS"  env-default:"10"  env-description:"Failed logins per username before lockout"`
+		IPFreeAttempts   int64                `yaml:"ip_free_attempts"   env:"LOGIN_THROTTLE_IP_FREE_ATTEMPTS"   env-default:"10"  env-description:"Failed attempts per IP without delay"`
+		IPMaxAttempts    int64                `yaml:"ip_max_attempts"    env:"LOGIN_THROTTLE_IP_MAX_ATTEMPTS"    env-default:"100" env-description:"Failed attempts per IP before lockout"`
+		BaseDelay        time.Duration        `yaml:"base_delay"         env:"LOGIN_THROTTLE_BASE_DELAY"         env-default:"1s"  env-description:"First delay, doubled by every next failed attempt"`
+		Lockout          time.Duration        `yaml:"lockout"            env:"LOGIN_THROTTLE_LOCKOUT"            env-default:"15m" env-description:"Lockout after max failed attempts"`
+		Window           time.Duration        `yaml:"window"             env:"LOGIN_THROTTLE_WINDOW"             env-default:"15m" env-description:"Failed attempts are counted within"`
+		Allowlist        util.StringSliceFlag `yaml:"allowlist"          env:"LOGIN_THROTTLE_ALLOWLIST"          env-default:""    env-description:"IPs and CIDRs never throttled" env-separator:","`
+	} `yaml:"login_throttle"`
+	SearchClickRateLimit struct {
+		MaxClicks int64         `yaml:"max_clicks" env:"SEARCH_CLICK_RATE_LIMIT_MAX_CLICKS" env-default:"60" env-description:"Search clicks per IP within the window"`
+		Window    time.Duration `yaml:"window"     env:"SEARCH_CLICK_RATE_LIMIT_WINDOW"     env-default:"1m" env-description:"Search clicks are counted within"`
+	} `yaml:"search_click_rate_limit"`
+	PasswordPolicy struct {
+		MinLength    int    `yaml:"min_length"    env:"PASSWORD_MIN_LENGTH"    env-default:"8"    env-description:"Password minimum length in characters"`
+		MinClasses   int    `yaml:"min_classes"   env:"PASSWORD_MIN_CLASSES"   env-default:"2"    env-description:"Password character classes of lowercase, uppercase, digits, symbols"`
+		RejectCommon bool   `yaml:"reject_common" env:"PASSWORD_REJECT_COMMON" env-default:"true" env-description:"Reject common breached passwords"`
+		CommonList   string `yaml:"common_list"   env:"PASSWORD_COMMON_LIST"   env-default:""     env-description:"File of more common passwords, one per line"`
+	} `yaml:"password_policy"`
+	PasswordHash struct {
+		Algorithm       string `yaml:"algorithm"        env:"PASSWORD_HASH_ALGORITHM"        env-default:"argon2id" env-description:"Password hash of new passwords argon2id|bcrypt, older hashes are upgraded on login"`
+		Argon2idMemory  uint   `yaml:"argon2id_memory"  env:"PASSWORD_HASH_ARGON2ID_MEMORY"  env-default:"65536"    env-description:"Argon2id memory in KiB"`
+		Argon2idTime    uint   `yaml:"argon2id_time"    env:"PASSWORD_HASH_ARGON2ID_TIME"    env-default:"3"        env-description:"Argon2id passes"`
+		Argon2idThreads uint   `yaml:"argon2id_threads" env:"PASSWORD_HASH_ARGON2ID_THREADS" env-default:"2"        env-description:"Argon2id threads"`
+		BcryptCost      int    `yaml:"bcrypt_cost"      env:"PASSWORD_HASH_BCRYPT_COST"      env-default:"10"       env-description:"Bcrypt cost"`
+	} `yaml:"password_hash"`
+	Mail struct {
+		Driver       string `yaml:"driver"        env:"MAIL_DRIVER"        env-default:"file"               env-description:"Mail driver smtp|file, file writes the emails to the mail file"`
+		From         string `yaml:"from"          env:"MAIL_FROM"          env-default:"noreply@walk.local" env-description:"Mail sender address"`
+		File         string `yaml:"file"          env:"MAIL_FILE"          env-default:""                   env-description:"File driver output file, stdout when empty"`
+		SMTPHost     string `yaml:"smtp_host"     env:"MAIL_SMTP_HOST"     env-default:"localhost"          env-description:"SMTP host"`
+		SMTPPort     int    `yaml:"smtp_port"     env:"MAIL_SMTP_PORT"     env-default:"587"                env-description:"SMTP port, STARTTLS is used when the server supports it"`
+		SMTPUsername string `yaml:"smtp_username" env:"MAIL_SMTP_USERNAME" env-default:""                   env-description:"SMTP username, no auth when empty"`
+		SMTPPassword string `yaml:"smtp_password" env:"MAIL_SMTP_PASSWORD" env-default:""                   env-description:"SMTP password"`
+	} `yaml:"mail"`
+	PasswordReset struct {
+		TTL         time.Duration `yaml:"ttl"          env:"PASSWORD_RESET_TTL"          env-default:"1h"                                   env-description:"Password reset token TTL"`
+		URL         string        `yaml:"url"          env:"PASSWORD_RESET_URL"          env-default:"http://localhost:8080/reset-password" env-description:"Password reset page, the emailed link adds the token query parameter"`
+		MaxRequests int64         `yaml:"max_requests" env:"PASSWORD_RESET_MAX_REQUESTS" env-default:"10"                                   env-description:"Forgot and reset requests per IP within the window"`
+		Window      time.Duration `yaml:"window"       env:"PASSWORD_RESET_WINDOW"       env-default:"1h"                                   env-description:"Forgot and reset requests are counted within"`
+	} `yaml:"password_reset"`
+	EmailVerification struct {
+		Secret                    string        `yaml:"secret"                       env:"EMAIL_VERIFICATION_SECRET"                       env-default:""                                      env-description:"Verification link signing secret, at least 32 bytes"`
+		TTL                       time.Duration `yaml:"ttl"                          env:"EMAIL_VERIFICATION_TTL"                          env-default:"48h"                                   env-description:"Verification link TTL"`
+		URL                       string        `yaml:"url"                          env:"EMAIL_VERIFICATION_URL"                          env-default:"http://localhost:8080/api/v1/auth/verify" env-description:"Verification link, adds the token query parameter"`
+		ResendInterval            time.Duration `yaml:"resend_interval"              env:"EMAIL_VERIFICATION_RESEND_INTERVAL"              env-default:"1m"                                    env-description:"One verification email per user within"`
+		ResendMaxRequests         int64         `yaml:"resend_max_requests"          env:"EMAIL_VERIFICATION_RESEND_MAX_REQUESTS"          env-default:"10"                                    env-description:"Resend requests per IP within the resend window"`
+		ResendWindow              time.Duration `yaml:"resend_window"                env:"EMAIL_VERIFICATION_RESEND_WINDOW"                env-default:"1h"                                    env-description:"Resend requests are counted within"`
+		AllowUnverifiedLogin      bool          `yaml:"allow_unverified_login"       env:"EMAIL_VERIFICATION_ALLOW_UNVERIFIED_LOGIN"       env-default:"false"                                 env-description:"Unverified users may log in"`
+		UnverifiedCanCreatePlaces bool          `yaml:"unverified_can_create_places" env:"EMAIL_VERIFICATION_UNVERIFIED_CAN_CREATE_PLACES" env-default:"false"                                 env-description:"Logged in unverified users may create places"`
+	} `yaml:"email_verification"`
+	OIDC struct {
+		RedirectURL         string `yaml:"redirect_url"          env:"OIDC_REDIRECT_URL"          env-default:"http://localhost:8080/api/v1/auth/oidc" env-description:"Callback base URL, the callback of a provider is redirect_url/{provider}/callback"`
+		GoogleClientID      string `yaml:"google_client_id"      env:"OIDC_GOOGLE_CLIENT_ID"      env-default:""                                     env-description:"Google client ID, Google login is disabled when empty"`
+		GoogleClientSecret  string `yaml:"google_client_secret"  env:"OIDC_GOOGLE_CLIENT_SECRET"  env-default:""                                     env-description:"Google client secret"`
+		YandexIssuer        string `yaml:"yandex_issuer"         env:"OIDC_YANDEX_ISSUER"         env-default:""                                     env-description:"Yandex ID OpenID Connect issuer"`
+		YandexClientID      string `yaml:"yandex_client_id"      env:"OIDC_YANDEX_CLIENT_ID"      env-default:""                                     env-description:"Yandex client ID, Yandex login is disabled when empty"`
+		YandexClientSecret  string `yaml:"yandex_client_secret"  env:"OIDC_YANDEX_CLIENT_SECRET"  env-default:""                                     env-description:"Yandex client secret"`
+		GenericName         string `yaml:"generic_name"          env:"OIDC_GENERIC_NAME"          env-default:"oidc"                                 env-description:"Provider name of the generic issuer in the login URL"`
+		GenericIssuer       string `yaml:"generic_issuer"        env:"OIDC_GENERIC_ISSUER"        env-default:""                                     env-description:"Generic OpenID Connect issuer, disabled when empty"`
+		GenericClientID     string `yaml:"generic_client_id"     env:"OIDC_GENERIC_CLIENT_ID"     env-default:""                                     env-description:"Generic issuer client ID"`
+		GenericClientSecret string `yaml:"generic_client_secret" env:"OIDC_GENERIC_CLIENT_SECRET" env-default:""                                     env-description:"Generic issuer client secret"`
+	} `yaml:"oidc"`
+	TwoFactor struct {
+		Issuer        string               `yaml:"issuer"         env:"TWO_FACTOR_ISSUER"         env-default:"Walk" env-description:"Account issuer shown by authenticator apps"`
+		ChallengeTTL  time.Duration        `yaml:"challenge_ttl"  env:"TWO_FACTOR_CHALLENGE_TTL"  env-default:"5m"   env-description:"Time to enter the two-factor code after the password"`
+		RequiredRoles util.StringSliceFlag `yaml:"required_roles" env:"TWO_FACTOR_REQUIRED_ROLES" env-default:""     env-description:"Roles of which users must enable two-factor authentication, denied role permissions until enabled" env-separator:","`
+	} `yaml:"two_factor"`
 	Redis    components.RedisConfig             `yaml:"redis_component"`
 	RabbitMQ components.RabbitMQConfig          `yaml:"rabbit_mq_component"`
 	MongoDB  components.MongoDBConfig           `yaml:"mongo_db_component"`
@@ -53,12 +154,74 @@ func (cfg *Config) RegisterFlags(fs *flag.FlagSet) {
 	fs.StringVar(&cfg.Api.Schema, "api-schema", cfg.Api.Schema, "API schema")
 	fs.StringVar(&cfg.Api.Host, "api-host", cfg.Api.Host, "API host")
 	fs.StringVar(&cfg.Api.Port, "api-port", cfg.Api.Port, "API port")
+	fs.Var(&cfg.Api.TrustedProxies, "api-trusted-proxies", "Proxies trusted to set X-Forwarded-For, use , for list")
 	fs.StringVar(&cfg.Site.Schema, "site-schema", cfg.Site.Schema, "Site schema")
 	fs.StringVar(&cfg.Site.Host, "site-host", cfg.Site.Host, "Site host")
 	fs.StringVar(&cfg.Site.Port, "site-port", cfg.Site.Port, "Site port")
 	fs.StringVar(&cfg.Queue.ReIndex.Exchange, "queue-reindex-exchange", cfg.Queue.ReIndex.Exchange, "Queue exchange for reindex")
 	fs.StringVar(&cfg.Queue.ReIndex.Place.RoutingKey, "queue-routing-place-key", cfg.Queue.ReIndex.Exchange, "Queue routing key for place")
 	fs.StringVar(&cfg.Queue.ReIndex.Place.QueuePlaceReindex, "queue-name-place-reindex", cfg.Queue.ReIndex.Exchange, "Queue name for place reindex")
+	fs.DurationVar(&cfg.CategoryCache.LocalTTL, "category-cache-local-ttl", cfg.CategoryCache.LocalTTL, "Category in process cache TTL")
+	fs.DurationVar(&cfg.CategoryCache.RedisTTL, "category-cache-redis-ttl", cfg.CategoryCache.RedisTTL, "Category redis cache TTL")
+	fs.StringVar(&cfg.CategoryCache.Channel, "category-cache-channel", cfg.CategoryCache.Channel, "Category cache invalidation pub/sub channel")
+	fs.StringVar(&cfg.Bootstrap.AdminUsername, "bootstrap-admin", cfg.Bootstrap.AdminUsername, "User to grant the admin role on start, created when missing")
+	fs.StringVar(&cfg.Bootstrap.AdminPassword, "bootstrap-admin-password", cfg.Bootstrap.AdminPassword, "Password of the bootstrap admin when created")
+	fs.StringVar(&cfg.Token.Issuer, "token-issuer", cfg.Token.Issuer, "Bearer token issuer")
+	fs.DurationVar(&cfg.Token.AccessTTL, "token-access-ttl", cfg.Token.AccessTTL, "Bearer access token TTL")
+	fs.DurationVar(&cfg.Token.RefreshTTL, "token-refresh-ttl", cfg.Token.RefreshTTL, "Bearer refresh token TTL")
+	fs.StringVar(&cfg.Token.KeyID, "token-key-id", cfg.Token.KeyID, "Bearer token signing key ID, first key when empty")
+	fs.Var(&cfg.Token.Keys, "token-keys", "Bearer token keys kid:HS256|EdDSA:base64, use , for list")
+	fs.Int64Var(&cfg.LoginThrottle.UserFreeAttempts, "login-throttle-user-free-attempts", cfg.LoginThrottle.UserFreeAttempts, "Failed logins per username without delay")
+	fs.Int64Var(&cfg.LoginThrottle.UserMaxAttempts, "login-throttle-user-max-attempts", cfg.LoginThrottle.UserMaxAttempts, "Failed logins per username before lockout")
+	fs.Int64Var(&cfg.LoginThrottle.IPFreeAttempts, "login-throttle-ip-free-attempts", cfg.LoginThrottle.IPFreeAttempts, "Failed attempts per IP without delay")
+	fs.Int64Var(&cfg.LoginThrottle.IPMaxAttempts, "login-throttle-ip-max-attempts", cfg.LoginThrottle.IPMaxAttempts, "Failed attempts per IP before lockout")
+	fs.DurationVar(&cfg.LoginThrottle.BaseDelay, "login-throttle-base-delay", cfg.LoginThrottle.BaseDelay, "First delay, doubled by every next failed attempt")
+	fs.DurationVar(&cfg.LoginThrottle.Lockout, "login-throttle-lockout", cfg.LoginThrottle.Lockout, "Lockout after max failed attempts")
+	fs.DurationVar(&cfg.LoginThrottle.Window, "login-throttle-window", cfg.LoginThrottle.Window, "Failed attempts are counted within")
+	fs.Var(&cfg.LoginThrottle.Allowlist, "login-throttle-allowlist", "IPs and CIDRs never throttled, use , for list")
+	fs.Int64Var(&cfg.SearchClickRateLimit.MaxClicks, "search-click-rate-limit-max-clicks", cfg.SearchClickRateLimit.MaxClicks, "Search clicks per IP within the window")
+	fs.DurationVar(&cfg.SearchClickRateLimit.Window, "search-click-rate-limit-window", cfg.SearchClickRateLimit.Window, "Search clicks are counted within")
+	fs.IntVar(&cfg.PasswordPolicy.MinLength, "password-min-length", cfg.PasswordPolicy.MinLength, "Password minimum length in characters")
+	fs.IntVar(&cfg.PasswordPolicy.MinClasses, "password-min-classes", cfg.PasswordPolicy.MinClasses, "Password character classes of lowercase, uppercase, digits, symbols")
+	fs.BoolVar(&cfg.PasswordPolicy.RejectCommon, "password-reject-common", cfg.PasswordPolicy.RejectCommon, "Reject common breached passwords")
+	fs.StringVar(&cfg.PasswordPolicy.CommonList, "password-common-list", cfg.PasswordPolicy.CommonList, "File of more common passwords, one per line")
+	fs.StringVar(&cfg.PasswordHash.Algorithm, "password-hash-algorithm", cfg.PasswordHash.Algorithm, "Password hash of new passwords argon2id|bcrypt, older hashes are upgraded on login")
+	fs.UintVar(&cfg.PasswordHash.Argon2idMemory, "password-hash-argon2id-memory", cfg.PasswordHash.Argon2idMemory, "Argon2id memory in KiB")
+	fs.UintVar(&cfg.PasswordHash.Argon2idTime, "password-hash-argon2id-time", cfg.PasswordHash.Argon2idTime, "Argon2id passes")
+	fs.UintVar(&cfg.PasswordHash.Argon2idThreads, "password-hash-argon2id-threads", cfg.PasswordHash.Argon2idThreads, "Argon2id threads")
+	fs.IntVar(&cfg.PasswordHash.BcryptCost, "password-hash-bcrypt-cost", cfg.PasswordHash.BcryptCost, "Bcrypt cost")
+	fs.StringVar(&cfg.Mail.Driver, "mail-driver", cfg.Mail.Driver, "Mail driver smtp|file, file writes the emails to the mail file")
+	fs.StringVar(&cfg.Mail.From, "mail-from", cfg.Mail.From, "Mail sender address")
+	fs.StringVar(&cfg.Mail.File, "mail-file", cfg.Mail.File, "File driver output file, stdout when empty")
+	fs.StringVar(&cfg.Mail.SMTPHost, "mail-smtp-host", cfg.Mail.SMTPHost, "SMTP host")
+	fs.IntVar(&cfg.Mail.SMTPPort, "mail-smtp-port", cfg.Mail.SMTPPort, "SMTP port, STARTTLS is used when the server supports it")
+	fs.StringVar(&cfg.Mail.SMTPUsername, "mail-smtp-username", cfg.Mail.SMTPUsername, "SMTP username, no auth when empty")
+	fs.StringVar(&cfg.Mail.SMTPPassword, "mail-smtp-password", cfg.Mail.SMTPPassword, "SMTP password")
+	fs.DurationVar(&cfg.PasswordReset.TTL, "password-reset-ttl", cfg.PasswordReset.TTL, "Password reset token TTL")
+	fs.StringVar(&cfg.PasswordReset.URL, "password-reset-url", cfg.PasswordReset.URL, "Password reset page, the emailed link adds the token query parameter")
+	fs.Int64Var(&cfg.PasswordReset.MaxRequests, "password-reset-max-requests", cfg.PasswordReset.MaxRequests, "Forgot and reset requests per IP within the window")
+	fs.DurationVar(&cfg.PasswordReset.Window, "password-reset-window", cfg.PasswordReset.Window, "Forgot and reset requests are counted within")
+	fs.StringVar(&cfg.EmailVerification.Secret, "email-verification-secret", cfg.EmailVerification.Secret, "Verification link signing secret, at least 32 bytes")
+	fs.DurationVar(&cfg.EmailVerification.TTL, "email-verification-ttl", cfg.EmailVerification.TTL, "Verification link TTL")
+	fs.StringVar(&cfg.EmailVerification.URL, "email-verification-url", cfg.EmailVerification.URL, "Verification link, adds the token query parameter")
+	fs.DurationVar(&cfg.EmailVerification.ResendInterval, "email-verification-resend-interval", cfg.EmailVerification.ResendInterval, "One verification email per user within")
+	fs.Int64Var(&cfg.EmailVerification.ResendMaxRequests, "email-verification-resend-max-requests", cfg.EmailVerification.ResendMaxRequests, "Resend requests per IP within the resend window")
+	fs.DurationVar(&cfg.EmailVerification.ResendWindow, "email-verification-resend-window", cfg.EmailVerification.ResendWindow, "Resend requests are counted within")
+	fs.BoolVar(&cfg.EmailVerification.AllowUnverifiedLogin, "email-verification-allow-unverified-login", cfg.EmailVerification.AllowUnverifiedLogin, "Unverified users may log in")
+	fs.BoolVar(&cfg.EmailVerification.UnverifiedCanCreatePlaces, "email-verification-unverified-can-create-places", cfg.EmailVerification.UnverifiedCanCreatePlaces, "Logged in unverified users may create places")
+	fs.StringVar(&cfg.OIDC.RedirectURL, "oidc-redirect-url", cfg.OIDC.RedirectURL, "Callback base URL, the callback of a provider is redirect_url/{provider}/callback")
+	fs.StringVar(&cfg.OIDC.GoogleClientID, "oidc-google-client-id", cfg.OIDC.GoogleClientID, "Google client ID, Google login is disabled when empty")
+	fs.StringVar(&cfg.OIDC.GoogleClientSecret, "oidc-google-client-secret", cfg.OIDC.GoogleClientSecret, "Google client secret")
+	fs.StringVar(&cfg.OIDC.YandexIssuer, "oidc-yandex-issuer", cfg.OIDC.YandexIssuer, "Yandex ID OpenID Connect issuer")
+	fs.StringVar(&cfg.OIDC.YandexClientID, "oidc-yandex-client-id", cfg.OIDC.YandexClientID, "Yandex client ID, Yandex login is disabled when empty")
+	fs.StringVar(&cfg.OIDC.YandexClientSecret, "oidc-yandex-client-secret", cfg.OIDC.YandexClientSecret, "Yandex client secret")
+	fs.StringVar(&cfg.OIDC.GenericName, "oidc-generic-name", cfg.OIDC.GenericName, "Provider name of the generic issuer in the login URL")
+	fs.StringVar(&cfg.OIDC.GenericIssuer, "oidc-generic-issuer", cfg.OIDC.GenericIssuer, "Generic OpenID Connect issuer, disabled when empty")
+	fs.StringVar(&cfg.OIDC.GenericClientID, "oidc-generic-client-id", cfg.OIDC.GenericClientID, "Generic issuer client ID")
+	fs.StringVar(&cfg.OIDC.GenericClientSecret, "oidc-generic-client-secret", cfg.OIDC.GenericClientSecret, "Generic issuer client secret")
+	fs.StringVar(&cfg.TwoFactor.Issuer, "two-factor-issuer", cfg.TwoFactor.Issuer, "Account issuer shown by authenticator apps")
+	fs.DurationVar(&cfg.TwoFactor.ChallengeTTL, "two-factor-challenge-ttl", cfg.TwoFactor.ChallengeTTL, "Time to enter the two-factor code after the password")
+	fs.Var(&cfg.TwoFactor.RequiredRoles, "two-factor-required-roles", "Roles of which users must enable two-factor authentication, use , for list")
 
 	cfg.Redis.RegisterFlags(fs)
 	cfg.RabbitMQ.RegisterFlags(fs)
@@ -70,6 +233,36 @@ func (cfg *Config) Validate() error {
 	if cfg.GinMode != "debug" && cfg.GinMode != "release" && cfg.GinMode != "test" {
 		return fmt.Errorf("invalid gin mode")
 	}
+	if cfg.PasswordPolicy.MinClasses < 0 || cfg.PasswordPolicy.MinClasses > 4 {
+		return fmt.Errorf("config password_policy error: min_classes must be 0-4")
+	}
+	if _, err := cfg.NewPasswordHasher(); err != nil {
+		return fmt.Errorf("config password_hash error: %w", err)
+	}
+	if cfg.Mail.Driver != "smtp" && cfg.Mail.Driver != "file" {
+		return fmt.Errorf("config mail error: driver must be smtp or file")
+	}
+	if resetURL, err := url.Parse(cfg.PasswordReset.URL); err != nil || !resetURL.IsAbs() {
+		return fmt.Errorf("config password_reset error: url must be absolute")
+	}
+	if verifyURL, err := url.Parse(cfg.EmailVerification.URL); err != nil || !verifyURL.IsAbs() {
+		return fmt.Errorf("config email_verification error: url must be absolute")
+	}
+	if _, err := cfg.EmailVerificationKeySet(); err != nil {
+		return fmt.Errorf("config email_verification error: %w", err)
+	}
+	if _, err := cfg.TokenKeySet(); err != nil {
+		return fmt.Errorf("config token error: %w", err)
+	}
+	if _, err := cfg.NewOIDCProviders(); err != nil {
+		return fmt.Errorf("config oidc error: %w", err)
+	}
+	if cfg.TwoFactor.Issuer == "" || cfg.TwoFactor.ChallengeTTL <= 0 {
+		return fmt.Errorf("config two_factor error: issuer and challenge_ttl are required")
+	}
+	if _, err := cfg.TwoFactorRoles(); err != nil {
+		return fmt.Errorf("config two_factor error: %w", err)
+	}
 	// TODO
 	if err := cfg.Redis.Validate(); err != nil {
 		return fmt.Errorf("config redis_component error: %w", err)
@@ -85,3 +278,177 @@ func (cfg *Config) Validate() error {
 	}
 	return nil
 }
+
+// TokenKeySet bearer token key set, nil when no keys configured
+func (cfg *Config) TokenKeySet() (*jwt.KeySet, error) {
+
+	keys := make([]*jwt.Key, 0, len(cfg.Token.Keys))
+	for _, spec := range cfg.Token.Keys {
+		if spec == "" {
+			continue
+		}
+		key, err := jwt.ParseKey(spec)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	keyID := cfg.Token.KeyID
+	if keyID == "" {
+		keyID = keys[0].ID
+	}
+	return jwt.NewKeySet(keyID, keys...)
+}
+
+// TwoFactorRoles roles of which users must enable two-factor authentication
+func (cfg *Config) TwoFactorRoles() ([]model.Role, error) {
+
+	roles := make([]model.Role, 0, len(cfg.TwoFactor.RequiredRoles))
+	for _, role := range cfg.TwoFactor.RequiredRoles {
+		if role == "" {
+			continue
+		}
+		roles = append(roles, model.Role(role))
+	}
+	roles, err := model.NormaliseRoles(roles)
+	if err != nil {
+		return nil, fmt.Errorf("unknown role in required_roles")
+	}
+
+	return roles, nil
+}
+
+// NewPasswordPolicy password policy with the common passwords file added
+func (cfg *Config) NewPasswordPolicy() (*model.PasswordPolicy, error) {
+
+	policy := model.NewPasswordPolicy(
+		cfg.PasswordPolicy.MinLength,
+		cfg.PasswordPolicy.MinClasses,
+		cfg.PasswordPolicy.RejectCommon,
+	)
+	if cfg.PasswordPolicy.CommonList == "" {
+		return policy, nil
+	}
+
+	f, err := os.Open(cfg.PasswordPolicy.CommonList)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if err := policy.AddCommon(f); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// NewPasswordHasher password hasher of the configured algorithm, verifies hashes of both algorithms
+func (cfg *Config) NewPasswordHasher() (*model.PasswordHasher, error) {
+
+	h := cfg.PasswordHash
+	if h.Argon2idTime == 0 || h.Argon2idTime > math.MaxUint32 ||
+		h.Argon2idThreads == 0 || h.Argon2idThreads > math.MaxUint8 ||
+		h.Argon2idMemory < 8*h.Argon2idThreads || h.Argon2idMemory > math.MaxUint32 {
+		return nil, fmt.Errorf("argon2id time and threads must be positive, memory at least 8 KiB per thread")
+	}
+	if h.BcryptCost < bcrypt.MinCost || h.BcryptCost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("bcrypt cost must be %d-%d", bcrypt.MinCost, bcrypt.MaxCost)
+	}
+
+	return model.NewPasswordHasher(
+		h.Algorithm,
+		model.NewArgon2idHasher(uint32(h.Argon2idMemory), uint32(h.Argon2idTime), uint8(h.Argon2idThreads)),
+		model.NewBcryptHasher(h.BcryptCost),
+	)
+}
+
+// NewMailer mailer of the configured driver, close the returned closer on shutdown
+func (cfg *Config) NewMailer() (service.MailerInterface, io.Closer, error) {
+
+	if cfg.Mail.Driver == "smtp" {
+		return mailer.NewSMTPMailer(
+			cfg.Mail.SMTPHost,
+			cfg.Mail.SMTPPort,
+			cfg.Mail.SMTPUsername,
+			cfg.Mail.SMTPPassword,
+			cfg.Mail.From,
+		), io.NopCloser(nil), nil
+	}
+
+	if cfg.Mail.File == "" {
+		return mailer.NewFileMailer(os.Stdout, cfg.Mail.From), io.NopCloser(nil), nil
+	}
+	f, err := os.OpenFile(cfg.Mail.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mailer.NewFileMailer(f, cfg.Mail.From), f, nil
+}
+
+// EmailVerificationKeySet HS256 key set of the verification link tokens
+func (cfg *Config) EmailVerificationKeySet() (*jwt.KeySet, error) {
+
+	if len(cfg.EmailVerification.Secret) < jwt.MinHS256SecretLength {
+		return nil, fmt.Errorf("secret must be at least %d bytes", jwt.MinHS256SecretLength)
+	}
+	key, err := jwt.NewHS256Key("email", []byte(cfg.EmailVerification.Secret))
+	if err != nil {
+		return nil, err
+	}
+	return jwt.NewKeySet(key.ID, key)
+}
+
+// oidcProviderName provider names are path segments of the login URLs
+var oidcProviderName = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// NewOIDCProviders OpenID Connect providers by name, providers without a client ID are disabled
+func (cfg *Config) NewOIDCProviders() (map[string]service.OIDCProviderInterface, error) {
+
+	redirectURL, err := url.Parse(cfg.OIDC.RedirectURL)
+	if err != nil || !redirectURL.IsAbs() {
+		return nil, fmt.Errorf("redirect_url must be absolute")
+	}
+
+	type provider struct {
+		name         string
+		issuer       string
+		clientID     string
+		clientSecret string
+	}
+	providers := make(map[string]service.OIDCProviderInterface)
+	for _, p := range []provider{
+		{"google", "https://accounts.google.com", cfg.OIDC.GoogleClientID, cfg.OIDC.GoogleClientSecret},
+		{"yandex", cfg.OIDC.YandexIssuer, cfg.OIDC.YandexClientID, cfg.OIDC.YandexClientSecret},
+		{cfg.OIDC.GenericName, cfg.OIDC.GenericIssuer, cfg.OIDC.GenericClientID, cfg.OIDC.GenericClientSecret},
+	} {
+		if p.clientID == "" {
+			continue
+		}
+		if !oidcProviderName.MatchString(p.name) {
+			return nil, fmt.Errorf("provider name %q must be lower case letters, digits and dashes", p.name)
+		}
+		if _, ok := providers[p.name]; ok {
+			return nil, fmt.Errorf("duplicate provider name %s", p.name)
+		}
+		if issuer, err := url.Parse(p.issuer); err != nil || !issuer.IsAbs() {
+			return nil, fmt.Errorf("provider %s: issuer must be absolute", p.name)
+		}
+		if p.clientSecret == "" {
+			return nil, fmt.Errorf("provider %s: client secret is required", p.name)
+		}
+
+		providers[p.name] = oidc.NewProvider(oidc.Config{
+			Issuer:       p.issuer,
+			ClientID:     p.clientID,
+			ClientSecret: p.clientSecret,
+			RedirectURL:  redirectURL.JoinPath(p.name, "callback").String(),
+			Scopes:       []string{"openid", "email", "profile"},
+		}, nil)
+	}
+
+	return providers, nil
+}
@@ -0,0 +1,102 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ResponseError elasticsearch error response
+type ResponseError struct {
+	StatusCode int
+	Body       string
+}
+
+// Error ...
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("elasticsearch response status %d: %s", e.StatusCode, e.Body)
+}
+
+// Client minimal elasticsearch REST client
+type Client struct {
+	host       string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// NewClient create new elasticsearch client, hosts is a comma separated list, the first one is used
+func NewClient(hosts string, username string, password string) *Client {
+	host, _, _ := strings.Cut(hosts, ",")
+	return &Client{
+		host:     strings.TrimRight(strings.TrimSpace(host), "/"),
+		username: username,
+		password: password,
+		httpClient: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+	}
+}
+
+// Do send request and decode json response to out, out can be nil
+func (c *Client) Do(ctx context.Context, method string, path string, body io.Reader, contentType string, out any) error {
+
+	req, err := http.NewRequestWithContext(ctx, method, c.host+path, body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return &ResponseError{StatusCode: resp.StatusCode, Body: string(data)}
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// DoJSON marshal in to json and send request
+func (c *Client) DoJSON(ctx context.Context, method string, path string, in any, out any) error {
+
+	if in == nil {
+		return c.Do(ctx, method, path, nil, "", out)
+	}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return c.Do(ctx, method, path, bytes.NewReader(data), "application/json", out)
+}
+
+// IsNotFound check is a 404 response error
+func IsNotFound(err error) bool {
+	if respErr, ok := err.(*ResponseError); ok {
+		return respErr.StatusCode == http.StatusNotFound
+	}
+	return false
+}
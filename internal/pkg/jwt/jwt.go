@@ -0,0 +1,239 @@
+// Package jwt signs and verifies compact JWS tokens with HS256 or EdDSA (Ed25519) keys.
+//
+// Every token carries the key ID in the kid header, a key set signs with one key
+// and verifies with all of them, so keys can be rotated without logging users out:
+//
+//	new key added -> signing key switched to it -> old key removed after the longest token TTL
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Algorithm JWS alg header value
+type Algorithm string
+
+const (
+	// HS256 HMAC SHA-256
+	HS256 Algorithm = "HS256"
+	// EdDSA Ed25519
+	EdDSA Algorithm = "EdDSA"
+)
+
+const (
+	// MinHS256SecretLength ...
+	MinHS256SecretLength int = 32
+)
+
+var (
+	// ErrInvalidToken malformed token or bad signature
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrTokenExpired ...
+	ErrTokenExpired = errors.New("token expired")
+	// ErrUnknownKey token kid not in the key set
+	ErrUnknownKey = errors.New("unknown token key")
+	// ErrInvalidKey ...
+	ErrInvalidKey = errors.New("invalid token key")
+)
+
+var encoding = base64.RawURLEncoding
+
+// Key signing key
+type Key struct {
+	ID         string
+	Algorithm  Algorithm
+	secret     []byte
+	privateKey ed25519.PrivateKey
+	publicKey  ed25519.PublicKey
+}
+
+// NewHS256Key create new HMAC key, secret at least MinHS256SecretLength bytes
+func NewHS256Key(id string, secret []byte) (*Key, error) {
+	if id == "" || len(secret) < MinHS256SecretLength {
+		return nil, ErrInvalidKey
+	}
+	return &Key{ID: id, Algorithm: HS256, secret: secret}, nil
+}
+
+// NewEdDSAKey create new Ed25519 key from the 32 byte seed
+func NewEdDSAKey(id string, seed []byte) (*Key, error) {
+	if id == "" || len(seed) != ed25519.SeedSize {
+		return nil, ErrInvalidKey
+	}
+	privateKey := ed25519.NewKeyFromSeed(seed)
+	return &Key{
+		ID:         id,
+		Algorithm:  EdDSA,
+		privateKey: privateKey,
+		publicKey:  privateKey.Public().(ed25519.PublicKey),
+	}, nil
+}
+
+// ParseKey parse key from "kid:alg:base64 secret or seed", e.g. "2026-10:HS256:c2VjcmV0..."
+func ParseKey(spec string) (*Key, error) {
+
+	parts := strings.SplitN(spec, ":", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: want kid:alg:base64", ErrInvalidKey)
+	}
+
+	material, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: key %s: %s", ErrInvalidKey, parts[0], err)
+	}
+
+	var key *Key
+	switch Algorithm(parts[1]) {
+	case HS256:
+		key, err = NewHS256Key(parts[0], material)
+	case EdDSA:
+		key, err = NewEdDSAKey(parts[0], material)
+	default:
+		return nil, fmt.Errorf("%w: key %s: unsupported alg %s", ErrInvalidKey, parts[0], parts[1])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: key %s", err, parts[0])
+	}
+	return key, nil
+}
+
+func (k *Key) sign(data []byte) []byte {
+	if k.Algorithm == EdDSA {
+		return ed25519.Sign(k.privateKey, data)
+	}
+	mac := hmac.New(sha256.New, k.secret)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+func (k *Key) verify(data []byte, signature []byte) bool {
+	if k.Algorithm == EdDSA {
+		return ed25519.Verify(k.publicKey, data, signature)
+	}
+	return hmac.Equal(k.sign(data), signature)
+}
+
+type header struct {
+	Algorithm Algorithm `json:"alg"`
+	Type      string    `json:"typ"`
+	KeyID     string    `json:"kid"`
+}
+
+// Claims registered claims, Type tells access and refresh tokens apart
+type Claims struct {
+	ID        string `json:"jti"`
+	Subject   string `json:"sub"`
+	Issuer    string `json:"iss,omitempty"`
+	Type      string `json:"typ,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// ExpiresTime ...
+func (c *Claims) ExpiresTime() time.Time {
+	return time.Unix(c.ExpiresAt, 0)
+}
+
+// KeySet sign with the signing key, verify with any key of the set
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+	// Now clock, replaced in tests
+	Now func() time.Time
+}
+
+// NewKeySet create new key set, signingID must be one of the keys
+func NewKeySet(signingID string, keys ...*Key) (*KeySet, error) {
+
+	set := &KeySet{
+		keys: make(map[string]*Key, len(keys)),
+		Now:  time.Now,
+	}
+	for _, key := range keys {
+		if _, ok := set.keys[key.ID]; ok {
+			return nil, fmt.Errorf("%w: duplicate key id %s", ErrInvalidKey, key.ID)
+		}
+		set.keys[key.ID] = key
+	}
+
+	signing, ok := set.keys[signingID]
+	if !ok {
+		return nil, fmt.Errorf("%w: signing key %s not found", ErrInvalidKey, signingID)
+	}
+	set.signing = signing
+
+	return set, nil
+}
+
+// Sign encode and sign claims with the signing key
+func (s *KeySet) Sign(claims *Claims) (string, error) {
+
+	headerJSON, err := json.Marshal(header{Algorithm: s.signing.Algorithm, Type: "JWT", KeyID: s.signing.ID})
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signed := encoding.EncodeToString(headerJSON) + "." + encoding.EncodeToString(claimsJSON)
+	return signed + "." + encoding.EncodeToString(s.signing.sign([]byte(signed))), nil
+}
+
+// Verify check signature and expiry, the alg header must match the key alg
+func (s *KeySet) Verify(token string) (*Claims, error) {
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	headerJSON, err := encoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var h header
+	if err := json.Unmarshal(headerJSON, &h); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	key, ok := s.keys[h.KeyID]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	if h.Algorithm != key.Algorithm {
+		return nil, ErrInvalidToken
+	}
+
+	signature, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	if !key.verify([]byte(parts[0]+"."+parts[1]), signature) {
+		return nil, ErrInvalidToken
+	}
+
+	claimsJSON, err := encoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	claims := &Claims{}
+	if err := json.Unmarshal(claimsJSON, claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if claims.ExpiresAt == 0 || !s.Now().Before(claims.ExpiresTime()) {
+		return nil, ErrTokenExpired
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,117 @@
+package jwt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeySet(t *testing.T) {
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	claims := &Claims{
+		ID:        "id",
+		Subject:   "user",
+		Type:      "access",
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(time.Minute).Unix(),
+	}
+
+	hsOld, err := NewHS256Key("old", bytes.Repeat([]byte("a"), MinHS256SecretLength))
+	assert.Nil(t, err)
+	hsNew, err := NewHS256Key("new", bytes.Repeat([]byte("b"), MinHS256SecretLength))
+	assert.Nil(t, err)
+	ed, err := NewEdDSAKey("ed", bytes.Repeat([]byte("c"), 32))
+	assert.Nil(t, err)
+
+	newSet := func(signingID string, keys ...*Key) *KeySet {
+		set, err := NewKeySet(signingID, keys...)
+		assert.Nil(t, err)
+		set.Now = func() time.Time { return now }
+		return set
+	}
+
+	t.Run("Sign_verify", func(t *testing.T) {
+		for _, key := range []*Key{hsOld, ed} {
+			set := newSet(key.ID, key)
+			token, err := set.Sign(claims)
+			assert.Nil(t, err)
+			got, err := set.Verify(token)
+			assert.Nil(t, err)
+			assert.Equal(t, claims, got)
+		}
+	})
+
+	t.Run("Rotation", func(t *testing.T) {
+		token, err := newSet("old", hsOld).Sign(claims)
+		assert.Nil(t, err)
+
+		_, err = newSet("new", hsOld, hsNew).Verify(token)
+		assert.Nil(t, err)
+
+		_, err = newSet("new", hsNew).Verify(token)
+		assert.ErrorIs(t, err, ErrUnknownKey)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		set := newSet("old", hsOld)
+		token, err := set.Sign(claims)
+		assert.Nil(t, err)
+
+		set.Now = func() time.Time { return now.Add(time.Minute) }
+		_, err = set.Verify(token)
+		assert.ErrorIs(t, err, ErrTokenExpired)
+	})
+
+	t.Run("Tampered", func(t *testing.T) {
+		set := newSet("old", hsOld)
+		token, err := set.Sign(claims)
+		assert.Nil(t, err)
+
+		parts := strings.Split(token, ".")
+		parts[1] = encoding.EncodeToString([]byte(`{"jti":"id","sub":"admin","exp":9999999999}`))
+		_, err = set.Verify(strings.Join(parts, "."))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Alg_mismatch", func(t *testing.T) {
+		// an HS256 token signed with the kid of an EdDSA key must not verify
+		token, err := newSet("old", hsOld).Sign(claims)
+		assert.Nil(t, err)
+
+		parts := strings.Split(token, ".")
+		parts[0] = encoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT","kid":"ed"}`))
+		_, err = newSet("ed", ed).Verify(strings.Join(parts, "."))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestParseKey(t *testing.T) {
+
+	secret := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("a"), MinHS256SecretLength))
+	seed := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("a"), 32))
+
+	key, err := ParseKey("k1:HS256:" + secret)
+	assert.Nil(t, err)
+	assert.Equal(t, "k1", key.ID)
+	assert.Equal(t, HS256, key.Algorithm)
+
+	key, err = ParseKey("k2:EdDSA:" + seed)
+	assert.Nil(t, err)
+	assert.Equal(t, EdDSA, key.Algorithm)
+
+	for _, spec := range []string{
+		"k1:HS256",
+		"k1:RS256:" + secret,
+		"k1:HS256:c2hvcnQ=",
+		"k1:EdDSA:" + secret + "AAAA",
+		":HS256:" + secret,
+	} {
+		_, err = ParseKey(spec)
+		assert.ErrorIs(t, err, ErrInvalidKey, spec)
+	}
+}
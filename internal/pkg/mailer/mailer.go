@@ -0,0 +1,113 @@
+// Package mailer sends plain text emails over SMTP, or writes them to a file for dev and tests.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidAddress address with a line break or without @
+var ErrInvalidAddress = errors.New("invalid email address")
+
+// Message plain text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// MailerInterface ...
+type MailerInterface interface {
+	Send(ctx context.Context, m *Message) error
+}
+
+// SMTPMailer sends with STARTTLS when the server supports it, PLAIN auth when a username is set
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPMailer create new SMTP mailer
+func NewSMTPMailer(host string, port int, username string, password string, from string) *SMTPMailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPMailer{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		from: from,
+		auth: auth,
+	}
+}
+
+// Send ...
+func (m *SMTPMailer) Send(_ context.Context, msg *Message) error {
+
+	data, err := encode(m.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+	return smtp.SendMail(m.addr, m.auth, m.from, []string{msg.To}, data)
+}
+
+// FileMailer writes the messages to w, separated by a blank line
+type FileMailer struct {
+	w    io.Writer
+	from string
+	lock sync.Mutex
+}
+
+// NewFileMailer create new file mailer
+func NewFileMailer(w io.Writer, from string) *FileMailer {
+	return &FileMailer{
+		w:    w,
+		from: from,
+	}
+}
+
+// Send ...
+func (m *FileMailer) Send(_ context.Context, msg *Message) error {
+
+	data, err := encode(m.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	_, err = m.w.Write(append(data, "\r\n"...))
+	return err
+}
+
+func encode(from string, msg *Message, date time.Time) ([]byte, error) {
+
+	for _, address := range []string{from, msg.To} {
+		if strings.ContainsAny(address, "\r\n") || !strings.Contains(address, "@") {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidAddress, address)
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	b.WriteString("\r\n")
+
+	return b.Bytes(), nil
+}
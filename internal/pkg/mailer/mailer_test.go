@@ -0,0 +1,24 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileMailer(t *testing.T) {
+	var b bytes.Buffer
+	m := NewFileMailer(&b, "noreply@walk.local")
+
+	err := m.Send(context.Background(), &Message{To: "woz@apple.com", Subject: "Reset your password", Body: "line 1\nline 2"})
+	assert.Nil(t, err)
+	assert.Contains(t, b.String(), "From: noreply@walk.local\r\n")
+	assert.Contains(t, b.String(), "To: woz@apple.com\r\n")
+	assert.Contains(t, b.String(), "Subject: Reset your password\r\n")
+	assert.Contains(t, b.String(), "\r\n\r\nline 1\r\nline 2\r\n")
+
+	err = m.Send(context.Background(), &Message{To: "woz@apple.com\r\nBcc: eve@evil.com", Subject: "Reset"})
+	assert.ErrorIs(t, err, ErrInvalidAddress)
+}
@@ -0,0 +1,145 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// minKeysRefresh tokens with an unknown kid refetch the JWKS at most this often
+const minKeysRefresh = time.Minute
+
+// publicKey RSA or ECDSA P-256 signature key of the JWKS
+type publicKey struct {
+	rsa   *rsa.PublicKey
+	ecdsa *ecdsa.PublicKey
+}
+
+// verify the alg header must match the key type, none and HMAC algs are never accepted
+func (k publicKey) verify(alg string, data []byte, signature []byte) bool {
+
+	digest := sha256.Sum256(data)
+	switch {
+	case alg == "RS256" && k.rsa != nil:
+		return rsa.VerifyPKCS1v15(k.rsa, crypto.SHA256, digest[:], signature) == nil
+	case alg == "ES256" && k.ecdsa != nil:
+		// JWS ES256 signatures are r || s, 32 bytes each
+		if len(signature) != 64 {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		return ecdsa.Verify(k.ecdsa, digest[:], r, s)
+	}
+	return false
+}
+
+type jwk struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+// publicKey nil for keys of other uses and unsupported types
+func (k jwk) publicKey() (*publicKey, error) {
+
+	if k.Use != "" && k.Use != "sig" {
+		return nil, nil
+	}
+
+	switch k.KeyType {
+	case "RSA":
+		n, err := encoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := encoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if len(n) < 256 || !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("weak or invalid RSA key %s", k.KeyID)
+		}
+		return &publicKey{rsa: &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}}, nil
+	case "EC":
+		if k.Curve != "P-256" {
+			return nil, nil
+		}
+		x, err := encoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := encoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !key.Curve.IsOnCurve(key.X, key.Y) {
+			return nil, fmt.Errorf("invalid EC key %s", k.KeyID)
+		}
+		return &publicKey{ecdsa: key}, nil
+	}
+
+	return nil, nil
+}
+
+// key signature key of the kid, the JWKS is refetched for unknown kids so provider key rotation needs no restart
+func (p *Provider) key(ctx context.Context, m *Metadata, kid string) (publicKey, error) {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	if !p.keysFetchedAt.IsZero() && p.Now().Sub(p.keysFetchedAt) < minKeysRefresh {
+		return publicKey{}, fmt.Errorf("%w: unknown key %s", ErrInvalidIDToken, kid)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := p.getJSON(ctx, m.JWKSURI, &set); err != nil {
+		return publicKey{}, err
+	}
+	keys := make(map[string]publicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		key, err := k.publicKey()
+		if err != nil {
+			return publicKey{}, fmt.Errorf("%w: %s", ErrProvider, err)
+		}
+		if key != nil {
+			keys[k.KeyID] = *key
+		}
+	}
+	p.keys = keys
+	p.keysFetchedAt = p.Now()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	return publicKey{}, fmt.Errorf("%w: unknown key %s", ErrInvalidIDToken, kid)
+}
+
+// lookupKey tokens without kid are accepted from providers with a single key only
+func (p *Provider) lookupKey(kid string) (publicKey, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[kid]
+	return key, ok
+}
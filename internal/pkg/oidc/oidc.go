@@ -0,0 +1,358 @@
+// Package oidc signs users in with an OpenID Connect provider, authorization code flow with PKCE.
+//
+// The provider metadata is discovered from the issuer on first use, ID tokens are verified
+// against the provider JWKS (RS256 and ES256), the keys are refetched when a token carries an unknown kid:
+//
+//	AuthCodeURL(state, nonce, verifier) -> provider login -> callback code -> Exchange -> VerifyIDToken(nonce)
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Leeway clock skew allowed checking exp and iat
+	Leeway = time.Minute
+	// maxResponseBytes discovery, JWKS and token responses are small
+	maxResponseBytes int64 = 1 << 20
+)
+
+var (
+	// ErrProvider discovery or JWKS request failed or returned an invalid document
+	ErrProvider = errors.New("oidc provider error")
+	// ErrExchange the token endpoint refused the code
+	ErrExchange = errors.New("oidc code exchange failed")
+	// ErrInvalidIDToken malformed token, bad signature or claims
+	ErrInvalidIDToken = errors.New("invalid id token")
+)
+
+var encoding = base64.RawURLEncoding
+
+// Config relying party registration at the provider
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// Scopes openid is always requested
+	Scopes []string
+}
+
+// Metadata provider configuration of /.well-known/openid-configuration
+type Metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// IDToken verified ID token claims
+type IDToken struct {
+	Issuer            string   `json:"iss"`
+	Subject           string   `json:"sub"`
+	Audience          audience `json:"aud"`
+	AuthorizedParty   string   `json:"azp,omitempty"`
+	Nonce             string   `json:"nonce,omitempty"`
+	IssuedAt          int64    `json:"iat"`
+	ExpiresAt         int64    `json:"exp"`
+	Email             string   `json:"email,omitempty"`
+	EmailVerified     flexBool `json:"email_verified,omitempty"`
+	Name              string   `json:"name,omitempty"`
+	PreferredUsername string   `json:"preferred_username,omitempty"`
+}
+
+// audience one string or an array of strings
+type audience []string
+
+// UnmarshalJSON ...
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*a = audience{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+func (a audience) contains(clientID string) bool {
+	for _, aud := range a {
+		if aud == clientID {
+			return true
+		}
+	}
+	return false
+}
+
+// flexBool true or "true", some providers send email_verified as a string
+type flexBool bool
+
+// UnmarshalJSON ...
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case `true`, `"true"`:
+		*b = true
+	case `false`, `"false"`, `null`:
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	return nil
+}
+
+// Provider relying party of one provider, safe for concurrent use
+type Provider struct {
+	config Config
+	client *http.Client
+
+	mu            sync.Mutex
+	metadata      *Metadata
+	keys          map[string]publicKey
+	keysFetchedAt time.Time
+
+	// Now clock, replaced in tests
+	Now func() time.Time
+}
+
+// NewProvider create new provider, nothing is fetched until the first login
+func NewProvider(config Config, client *http.Client) *Provider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	config.Issuer = strings.TrimSuffix(config.Issuer, "/")
+	return &Provider{
+		config: config,
+		client: client,
+		Now:    time.Now,
+	}
+}
+
+// RandomValue 32 random bytes base64url encoded, for state, nonce and the PKCE code verifier
+func RandomValue() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// CodeChallenge PKCE S256 challenge of the code verifier
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return encoding.EncodeToString(sum[:])
+}
+
+// Metadata discover the provider configuration once, failures are retried on the next call
+func (p *Provider) Metadata(ctx context.Context) (*Metadata, error) {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	m := &Metadata{}
+	if err := p.getJSON(ctx, p.config.Issuer+"/.well-known/openid-configuration", m); err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(m.Issuer, "/") != p.config.Issuer {
+		return nil, fmt.Errorf("%w: issuer %s does not match %s", ErrProvider, m.Issuer, p.config.Issuer)
+	}
+	if m.AuthorizationEndpoint == "" || m.TokenEndpoint == "" || m.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: incomplete provider metadata", ErrProvider)
+	}
+	p.metadata = m
+
+	return m, nil
+}
+
+// AuthCodeURL provider login URL, the state, nonce and code verifier must be kept for the callback
+func (p *Provider) AuthCodeURL(ctx context.Context, state string, nonce string, codeVerifier string) (string, error) {
+
+	m, err := p.Metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(m.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrProvider, err)
+	}
+
+	query := u.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", p.scope())
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", CodeChallenge(codeVerifier))
+	query.Set("code_challenge_method", "S256")
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+func (p *Provider) scope() string {
+	scopes := []string{"openid"}
+	for _, s := range p.config.Scopes {
+		if s != "openid" {
+			scopes = append(scopes, s)
+		}
+	}
+	return strings.Join(scopes, " ")
+}
+
+// Exchange trade the callback code for the raw ID token, the client authenticates with HTTP basic auth
+func (p *Provider) Exchange(ctx context.Context, code string, codeVerifier string) (string, error) {
+
+	m, err := p.Metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+	form.Set("client_id", p.config.ClientID)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, m.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	request.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrExchange, err)
+	}
+	defer response.Body.Close()
+
+	var body struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(response.Body, maxResponseBytes)).Decode(&body); err != nil {
+		return "", fmt.Errorf("%w: status %d", ErrExchange, response.StatusCode)
+	}
+	if response.StatusCode != http.StatusOK || body.Error != "" {
+		return "", fmt.Errorf("%w: status %d %s %s", ErrExchange, response.StatusCode, body.Error, body.ErrorDescription)
+	}
+	if body.IDToken == "" {
+		return "", fmt.Errorf("%w: no id_token", ErrExchange)
+	}
+
+	return body.IDToken, nil
+}
+
+// VerifyIDToken check the signature against the provider JWKS, iss, aud, azp, exp, iat and the nonce
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken string, nonce string) (*IDToken, error) {
+
+	m, err := p.Metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(rawIDToken, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidIDToken
+	}
+	headerJSON, err := encoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidIDToken
+	}
+	var h struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}
+	if err := json.Unmarshal(headerJSON, &h); err != nil {
+		return nil, ErrInvalidIDToken
+	}
+	signature, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidIDToken
+	}
+
+	key, err := p.key(ctx, m, h.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	if !key.verify(h.Algorithm, []byte(parts[0]+"."+parts[1]), signature) {
+		return nil, ErrInvalidIDToken
+	}
+
+	claimsJSON, err := encoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidIDToken
+	}
+	token := &IDToken{}
+	if err := json.Unmarshal(claimsJSON, token); err != nil {
+		return nil, ErrInvalidIDToken
+	}
+
+	now := p.Now()
+	switch {
+	case strings.TrimSuffix(token.Issuer, "/") != p.config.Issuer:
+		return nil, fmt.Errorf("%w: issuer %s", ErrInvalidIDToken, token.Issuer)
+	case token.Subject == "":
+		return nil, fmt.Errorf("%w: no subject", ErrInvalidIDToken)
+	case !token.Audience.contains(p.config.ClientID):
+		return nil, fmt.Errorf("%w: audience", ErrInvalidIDToken)
+	case len(token.Audience) > 1 && token.AuthorizedParty != p.config.ClientID:
+		return nil, fmt.Errorf("%w: authorized party", ErrInvalidIDToken)
+	case token.ExpiresAt == 0 || !now.Add(-Leeway).Before(time.Unix(token.ExpiresAt, 0)):
+		return nil, fmt.Errorf("%w: expired", ErrInvalidIDToken)
+	case time.Unix(token.IssuedAt, 0).After(now.Add(Leeway)):
+		return nil, fmt.Errorf("%w: issued in the future", ErrInvalidIDToken)
+	case subtle.ConstantTimeCompare([]byte(token.Nonce), []byte(nonce)) != 1 || nonce == "":
+		return nil, fmt.Errorf("%w: nonce", ErrInvalidIDToken)
+	}
+
+	return token, nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, u string, v interface{}) error {
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrProvider, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: GET %s status %d", ErrProvider, u, response.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(response.Body, maxResponseBytes)).Decode(v); err != nil {
+		return fmt.Errorf("%w: GET %s: %s", ErrProvider, u, err)
+	}
+
+	return nil
+}
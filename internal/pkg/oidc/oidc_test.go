@@ -0,0 +1,151 @@
+package oidc
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"walk_backend/internal/pkg/oidc/oidctest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvider(t *testing.T) {
+
+	server, err := oidctest.NewServer("walk", "secret+/=")
+	assert.Nil(t, err)
+	defer server.Close()
+
+	ctx := context.Background()
+	p := NewProvider(Config{
+		Issuer:       server.Issuer() + "/",
+		ClientID:     "walk",
+		ClientSecret: "secret+/=",
+		RedirectURL:  "https://walk.local/api/v1/auth/oidc/test/callback",
+		Scopes:       []string{"openid", "email"},
+	}, server.Client())
+
+	login := func(t *testing.T, nonce string, verifier string) (string, string) {
+		authURL, err := p.AuthCodeURL(ctx, "state", nonce, verifier)
+		assert.Nil(t, err)
+		code, state, err := server.Login(authURL)
+		assert.Nil(t, err)
+		assert.Equal(t, "state", state)
+		return code, verifier
+	}
+
+	t.Run("AuthCodeURL", func(t *testing.T) {
+		authURL, err := p.AuthCodeURL(ctx, "state", "nonce", "verifier")
+		assert.Nil(t, err)
+		u, err := url.Parse(authURL)
+		assert.Nil(t, err)
+		assert.Equal(t, "openid email", u.Query().Get("scope"))
+		assert.Equal(t, CodeChallenge("verifier"), u.Query().Get("code_challenge"))
+		assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+	})
+
+	t.Run("Login", func(t *testing.T) {
+		server.SetUser("108", map[string]interface{}{"email": "Woz@Apple.com", "email_verified": "true"})
+		nonce, err := RandomValue()
+		assert.Nil(t, err)
+		verifier, err := RandomValue()
+		assert.Nil(t, err)
+
+		code, _ := login(t, nonce, verifier)
+		raw, err := p.Exchange(ctx, code, verifier)
+		assert.Nil(t, err)
+
+		token, err := p.VerifyIDToken(ctx, raw, nonce)
+		assert.Nil(t, err)
+		assert.Equal(t, "108", token.Subject)
+		assert.Equal(t, "Woz@Apple.com", token.Email)
+		assert.True(t, bool(token.EmailVerified))
+
+		_, err = p.Exchange(ctx, code, verifier)
+		assert.ErrorIs(t, err, ErrExchange, "codes are single use")
+	})
+
+	t.Run("Exchange_wrong_verifier", func(t *testing.T) {
+		code, _ := login(t, "nonce", "verifier")
+		_, err := p.Exchange(ctx, code, "another verifier")
+		assert.ErrorIs(t, err, ErrExchange)
+	})
+
+	t.Run("Verify_nonce", func(t *testing.T) {
+		code, verifier := login(t, "nonce", "verifier")
+		raw, err := p.Exchange(ctx, code, verifier)
+		assert.Nil(t, err)
+
+		_, err = p.VerifyIDToken(ctx, raw, "another nonce")
+		assert.ErrorIs(t, err, ErrInvalidIDToken)
+		_, err = p.VerifyIDToken(ctx, raw, "")
+		assert.ErrorIs(t, err, ErrInvalidIDToken)
+	})
+
+	t.Run("Verify_claims", func(t *testing.T) {
+		now := time.Now()
+		valid := func() map[string]interface{} {
+			return map[string]interface{}{
+				"iss":   server.Issuer(),
+				"sub":   "108",
+				"aud":   "walk",
+				"nonce": "nonce",
+				"iat":   now.Unix(),
+				"exp":   now.Add(time.Hour).Unix(),
+			}
+		}
+		raw, err := server.Sign(valid())
+		assert.Nil(t, err)
+		_, err = p.VerifyIDToken(ctx, raw, "nonce")
+		assert.Nil(t, err)
+
+		for name, change := range map[string]func(c map[string]interface{}){
+			"issuer":   func(c map[string]interface{}) { c["iss"] = "https://evil.com" },
+			"subject":  func(c map[string]interface{}) { delete(c, "sub") },
+			"audience": func(c map[string]interface{}) { c["aud"] = "another client" },
+			"azp":      func(c map[string]interface{}) { c["aud"] = []string{"walk", "another client"} },
+			"expired":  func(c map[string]interface{}) { c["exp"] = now.Add(-2 * Leeway).Unix() },
+			"future":   func(c map[string]interface{}) { c["iat"] = now.Add(2 * Leeway).Unix() },
+		} {
+			claims := valid()
+			change(claims)
+			raw, err := server.Sign(claims)
+			assert.Nil(t, err)
+			_, err = p.VerifyIDToken(ctx, raw, "nonce")
+			assert.ErrorIs(t, err, ErrInvalidIDToken, name)
+		}
+	})
+
+	t.Run("Verify_signature", func(t *testing.T) {
+		raw, err := server.Sign(map[string]interface{}{
+			"iss": server.Issuer(), "sub": "108", "aud": "walk", "nonce": "nonce", "exp": time.Now().Add(time.Hour).Unix(),
+		})
+		assert.Nil(t, err)
+		parts := strings.Split(raw, ".")
+
+		tampered := parts[0] + "." + encoding.EncodeToString([]byte(`{"iss":"`+server.Issuer()+`","sub":"admin","aud":"walk","nonce":"nonce","exp":9999999999}`)) + "." + parts[2]
+		_, err = p.VerifyIDToken(ctx, tampered, "nonce")
+		assert.ErrorIs(t, err, ErrInvalidIDToken)
+
+		// alg confusion, an HMAC token must not verify with the RSA key
+		hs := encoding.EncodeToString([]byte(`{"alg":"HS256","kid":"`+oidctest.KeyID+`"}`)) + "." + parts[1] + "." + parts[2]
+		_, err = p.VerifyIDToken(ctx, hs, "nonce")
+		assert.ErrorIs(t, err, ErrInvalidIDToken)
+
+		none := encoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." + parts[1] + "."
+		_, err = p.VerifyIDToken(ctx, none, "nonce")
+		assert.ErrorIs(t, err, ErrInvalidIDToken)
+
+		unknown := encoding.EncodeToString([]byte(`{"alg":"RS256","kid":"rotated"}`)) + "." + parts[1] + "." + parts[2]
+		_, err = p.VerifyIDToken(ctx, unknown, "nonce")
+		assert.ErrorIs(t, err, ErrInvalidIDToken)
+	})
+
+	t.Run("Discovery_issuer_mismatch", func(t *testing.T) {
+		other := NewProvider(Config{Issuer: server.Issuer() + "/tenant", ClientID: "walk"}, server.Client())
+		_, err := other.AuthCodeURL(ctx, "state", "nonce", "verifier")
+		assert.ErrorIs(t, err, ErrProvider)
+	})
+}
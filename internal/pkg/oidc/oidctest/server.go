@@ -0,0 +1,250 @@
+// Package oidctest local OpenID Connect provider for tests, discovery, JWKS, authorize and token endpoints.
+package oidctest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// KeyID kid of the RS256 signing key
+const KeyID string = "test-key"
+
+var encoding = base64.RawURLEncoding
+
+type authRequest struct {
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	claims        map[string]interface{}
+}
+
+// Server provider signing in every authorize request as the SetUser user
+type Server struct {
+	*httptest.Server
+	ClientID     string
+	ClientSecret string
+
+	mu      sync.Mutex
+	subject string
+	claims  map[string]interface{}
+	codes   map[string]authRequest
+	key     *rsa.PrivateKey
+}
+
+// NewServer start new provider, Close it after the test
+func NewServer(clientID string, clientSecret string) (*Server, error) {
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		subject:      "subject",
+		claims:       map[string]interface{}{},
+		codes:        map[string]authRequest{},
+		key:          key,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/jwks", s.jwks)
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+	s.Server = httptest.NewServer(mux)
+
+	return s, nil
+}
+
+// Issuer ...
+func (s *Server) Issuer() string {
+	return s.URL
+}
+
+// SetUser subject and extra ID token claims of the next logins, e.g. email and email_verified
+func (s *Server) SetUser(subject string, claims map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subject = subject
+	s.claims = claims
+}
+
+// Login open the authorize URL as a signed in user, the code and state of the callback redirect
+func (s *Server) Login(authCodeURL string) (code string, state string, err error) {
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	response, err := client.Get(authCodeURL)
+	if err != nil {
+		return "", "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusFound {
+		return "", "", errors.New("authorize: " + response.Status)
+	}
+	callback, err := url.Parse(response.Header.Get("Location"))
+	if err != nil {
+		return "", "", err
+	}
+
+	return callback.Query().Get("code"), callback.Query().Get("state"), nil
+}
+
+// Sign RS256 sign the claims with the provider key, for forged and expired tokens
+func (s *Server) Sign(claims map[string]interface{}) (string, error) {
+
+	headerJSON, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": KeyID})
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signed := encoding.EncodeToString(headerJSON) + "." + encoding.EncodeToString(claimsJSON)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return signed + "." + encoding.EncodeToString(signature), nil
+}
+
+func (s *Server) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 s.URL,
+		"authorization_endpoint": s.URL + "/authorize",
+		"token_endpoint":         s.URL + "/token",
+		"jwks_uri":               s.URL + "/jwks",
+	})
+}
+
+func (s *Server) jwks(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": KeyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   encoding.EncodeToString(s.key.N.Bytes()),
+			"e":   encoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+		}},
+	})
+}
+
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+
+	query := r.URL.Query()
+	if query.Get("response_type") != "code" || query.Get("client_id") != s.ClientID ||
+		query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		http.Error(w, "server_error", http.StatusInternalServerError)
+		return
+	}
+	code := encoding.EncodeToString(b)
+	s.mu.Lock()
+	s.codes[code] = authRequest{
+		clientID:      query.Get("client_id"),
+		redirectURI:   query.Get("redirect_uri"),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		claims:        s.userClaims(),
+	}
+	s.mu.Unlock()
+
+	callback, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+	callbackQuery := callback.Query()
+	callbackQuery.Set("code", code)
+	callbackQuery.Set("state", query.Get("state"))
+	callback.RawQuery = callbackQuery.Encode()
+
+	http.Redirect(w, r, callback.String(), http.StatusFound)
+}
+
+// userClaims under s.mu
+func (s *Server) userClaims() map[string]interface{} {
+	claims := map[string]interface{}{"sub": s.subject}
+	for k, v := range s.claims {
+		claims[k] = v
+	}
+	return claims
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok || clientID != url.QueryEscape(s.ClientID) || clientSecret != url.QueryEscape(s.ClientSecret) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	// codes are single use
+	s.mu.Lock()
+	request, ok := s.codes[r.PostFormValue("code")]
+	delete(s.codes, r.PostFormValue("code"))
+	s.mu.Unlock()
+
+	challenge := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+	if !ok || r.PostFormValue("grant_type") != "authorization_code" ||
+		r.PostFormValue("redirect_uri") != request.redirectURI ||
+		encoding.EncodeToString(challenge[:]) != request.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims := map[string]interface{}{
+		"iss":   s.URL,
+		"aud":   request.clientID,
+		"nonce": request.nonce,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}
+	for k, v := range request.claims {
+		claims[k] = v
+	}
+	idToken, err := s.Sign(claims)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
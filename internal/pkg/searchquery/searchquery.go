@@ -0,0 +1,315 @@
+// Package searchquery parses the place search box syntax:
+//
+//	tag:park category:museums "old town" -closed near:55.75,37.61,2
+package searchquery
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// DefaultNearRadiusKm radius used when near: has no radius
+	DefaultNearRadiusKm float64 = 1
+	// MaxNearRadiusKm ...
+	MaxNearRadiusKm float64 = 100
+)
+
+const (
+	filterTag      = "tag"
+	filterCategory = "category"
+	filterNear     = "near"
+)
+
+// SyntaxError invalid query syntax, Pos is a zero based character position in the query
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+// Error ...
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.Pos, e.Msg)
+}
+
+// Near geo filter
+type Near struct {
+	Lat      float64
+	Lng      float64
+	RadiusKm float64
+}
+
+// Query parsed search query
+type Query struct {
+	Terms              []string
+	Phrases            []string
+	ExcludedTerms      []string
+	ExcludedPhrases    []string
+	Tags               []string
+	ExcludedTags       []string
+	Categories         []string
+	ExcludedCategories []string
+	Near               *Near
+}
+
+// HasText query has positive terms or phrases
+func (q *Query) HasText() bool {
+	return len(q.Terms) > 0 || len(q.Phrases) > 0
+}
+
+// IsEmpty query has no clauses
+func (q *Query) IsEmpty() bool {
+	return !q.HasText() &&
+		len(q.ExcludedTerms) == 0 &&
+		len(q.ExcludedPhrases) == 0 &&
+		len(q.Tags) == 0 &&
+		len(q.ExcludedTags) == 0 &&
+		len(q.Categories) == 0 &&
+		len(q.ExcludedCategories) == 0 &&
+		q.Near == nil
+}
+
+// String normalised query, parsing it again gives the same query
+func (q *Query) String() string {
+
+	parts := make([]string, 0)
+	for _, term := range q.Terms {
+		parts = append(parts, strings.ToLower(term))
+	}
+	for _, phrase := range q.Phrases {
+		parts = append(parts, quote(phrase))
+	}
+	for _, tag := range q.Tags {
+		parts = append(parts, filterTag+":"+quoteValue(tag))
+	}
+	for _, category := range q.Categories {
+		parts = append(parts, filterCategory+":"+quoteValue(category))
+	}
+	if q.Near != nil {
+		parts = append(parts, filterNear+":"+formatFloat(q.Near.Lat)+","+formatFloat(q.Near.Lng)+","+formatFloat(q.Near.RadiusKm))
+	}
+	for _, term := range q.ExcludedTerms {
+		parts = append(parts, "-"+strings.ToLower(term))
+	}
+	for _, phrase := range q.ExcludedPhrases {
+		parts = append(parts, "-"+quote(phrase))
+	}
+	for _, tag := range q.ExcludedTags {
+		parts = append(parts, "-"+filterTag+":"+quoteValue(tag))
+	}
+	for _, category := range q.ExcludedCategories {
+		parts = append(parts, "-"+filterCategory+":"+quoteValue(category))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func quote(s string) string {
+	return `"` + strings.ToLower(s) + `"`
+}
+
+func quoteValue(s string) string {
+	if strings.IndexFunc(s, unicode.IsSpace) >= 0 {
+		return quote(s)
+	}
+	return strings.ToLower(s)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// Parse parse search query
+func Parse(s string) (*Query, error) {
+
+	p := &parser{input: []rune(s)}
+	q := &Query{}
+
+	for {
+		p.skipSpace()
+		if p.eof() {
+			break
+		}
+		if err := p.parseClause(q); err != nil {
+			return nil, err
+		}
+	}
+
+	return q, nil
+}
+
+type parser struct {
+	input []rune
+	pos   int
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) peek() rune {
+	return p.input[p.pos]
+}
+
+func (p *parser) skipSpace() {
+	for !p.eof() && unicode.IsSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+func (p *parser) errorf(pos int, format string, args ...any) *SyntaxError {
+	return &SyntaxError{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) parseClause(q *Query) error {
+
+	start := p.pos
+	negated := false
+	if p.peek() == '-' {
+		negated = true
+		p.pos++
+		if p.eof() || unicode.IsSpace(p.peek()) {
+			return p.errorf(start, "expected term after '-'")
+		}
+	}
+
+	if p.peek() == '"' {
+		phrase, err := p.parseQuoted()
+		if err != nil {
+			return err
+		}
+		if negated {
+			q.ExcludedPhrases = append(q.ExcludedPhrases, phrase)
+		} else {
+			q.Phrases = append(q.Phrases, phrase)
+		}
+		return nil
+	}
+
+	wordStart := p.pos
+	for !p.eof() && !unicode.IsSpace(p.peek()) && p.peek() != '"' {
+		p.pos++
+	}
+	word := string(p.input[wordStart:p.pos])
+
+	key, value, hasKey := strings.Cut(word, ":")
+	key = strings.ToLower(key)
+	if !hasKey || (key != filterTag && key != filterCategory && key != filterNear) {
+		if !p.eof() && p.peek() == '"' {
+			return p.errorf(p.pos, "unexpected quote")
+		}
+		if negated {
+			q.ExcludedTerms = append(q.ExcludedTerms, word)
+		} else {
+			q.Terms = append(q.Terms, word)
+		}
+		return nil
+	}
+
+	valueStart := wordStart + len([]rune(key)) + 1
+	if value == "" {
+		if p.eof() || p.peek() != '"' {
+			return p.errorf(valueStart, "missing value for %s:", key)
+		}
+		quoted, err := p.parseQuoted()
+		if err != nil {
+			return err
+		}
+		value = quoted
+	} else if !p.eof() && p.peek() == '"' {
+		return p.errorf(p.pos, "unexpected quote")
+	}
+
+	switch key {
+	case filterTag:
+		if negated {
+			q.ExcludedTags = append(q.ExcludedTags, value)
+		} else {
+			q.Tags = append(q.Tags, value)
+		}
+	case filterCategory:
+		if negated {
+			q.ExcludedCategories = append(q.ExcludedCategories, value)
+		} else {
+			q.Categories = append(q.Categories, value)
+		}
+	case filterNear:
+		if negated {
+			return p.errorf(start, "near: can not be excluded")
+		}
+		if q.Near != nil {
+			return p.errorf(wordStart, "near: specified more than once")
+		}
+		near, err := parseNear(value, valueStart)
+		if err != nil {
+			return err
+		}
+		q.Near = near
+	}
+
+	return nil
+}
+
+// parseQuoted parse "..." at the current position, the closing quote must be followed by a space or the end
+func (p *parser) parseQuoted() (string, error) {
+
+	start := p.pos
+	p.pos++
+	valueStart := p.pos
+	for !p.eof() && p.peek() != '"' {
+		p.pos++
+	}
+	if p.eof() {
+		return "", p.errorf(start, "unterminated quote")
+	}
+	value := strings.Join(strings.Fields(string(p.input[valueStart:p.pos])), " ")
+	p.pos++
+
+	if value == "" {
+		return "", p.errorf(start, "empty phrase")
+	}
+	if !p.eof() && !unicode.IsSpace(p.peek()) {
+		return "", p.errorf(p.pos, "expected space after closing quote")
+	}
+
+	return value, nil
+}
+
+func parseNear(value string, pos int) (*Near, error) {
+
+	parts := strings.Split(value, ",")
+	if len(parts) != 2 && len(parts) != 3 {
+		return nil, &SyntaxError{Pos: pos, Msg: "near: expects lat,lng or lat,lng,radius_km"}
+	}
+
+	numbers := make([]float64, len(parts))
+	offset := pos
+	for i, part := range parts {
+		number, err := strconv.ParseFloat(part, 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, &SyntaxError{Pos: offset, Msg: fmt.Sprintf("near: invalid number %q", part)}
+		}
+		numbers[i] = number
+		offset += len([]rune(part)) + 1
+	}
+
+	near := &Near{Lat: numbers[0], Lng: numbers[1], RadiusKm: DefaultNearRadiusKm}
+	if len(numbers) == 3 {
+		near.RadiusKm = numbers[2]
+	}
+
+	if near.Lat < -90 || near.Lat > 90 {
+		return nil, &SyntaxError{Pos: pos, Msg: "near: latitude must be between -90 and 90"}
+	}
+	if near.Lng < -180 || near.Lng > 180 {
+		return nil, &SyntaxError{Pos: pos, Msg: "near: longitude must be between -180 and 180"}
+	}
+	if !(near.RadiusKm > 0 && near.RadiusKm <= MaxNearRadiusKm) {
+		return nil, &SyntaxError{Pos: pos, Msg: fmt.Sprintf("near: radius must be greater than 0 and at most %s km", formatFloat(MaxNearRadiusKm))}
+	}
+
+	return near, nil
+}
@@ -0,0 +1,198 @@
+package searchquery
+
+import (
+	"errors"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+
+	type test struct {
+		name  string
+		input string
+		want  *Query
+	}
+
+	tests := []test{
+		{
+			name:  "Empty",
+			input: "   ",
+			want:  &Query{},
+		},
+		{
+			name:  "Terms",
+			input: "old  park",
+			want:  &Query{Terms: []string{"old", "park"}},
+		},
+		{
+			name:  "Phrase",
+			input: `"old   town" park`,
+			want:  &Query{Phrases: []string{"old town"}, Terms: []string{"park"}},
+		},
+		{
+			name:  "Exclusions",
+			input: `park -closed -"under construction"`,
+			want: &Query{
+				Terms:           []string{"park"},
+				ExcludedTerms:   []string{"closed"},
+				ExcludedPhrases: []string{"under construction"},
+			},
+		},
+		{
+			name:  "Filters",
+			input: `tag:park category:museums "old town" -closed`,
+			want: &Query{
+				Phrases:       []string{"old town"},
+				ExcludedTerms: []string{"closed"},
+				Tags:          []string{"park"},
+				Categories:    []string{"museums"},
+			},
+		},
+		{
+			name:  "Quoted filter values",
+			input: `tag:"street art" -category:"night clubs"`,
+			want: &Query{
+				Tags:               []string{"street art"},
+				ExcludedCategories: []string{"night clubs"},
+			},
+		},
+		{
+			name:  "Excluded tag and uppercase key",
+			input: `TAG:Park -tag:dogs`,
+			want:  &Query{Tags: []string{"Park"}, ExcludedTags: []string{"dogs"}},
+		},
+		{
+			name:  "Category id",
+			input: `category:0186a3c2-5c8a-7c6e-9b1e-1f2d3c4b5a69`,
+			want:  &Query{Categories: []string{"0186a3c2-5c8a-7c6e-9b1e-1f2d3c4b5a69"}},
+		},
+		{
+			name:  "Near default radius",
+			input: `near:55.7539,37.6208`,
+			want:  &Query{Near: &Near{Lat: 55.7539, Lng: 37.6208, RadiusKm: DefaultNearRadiusKm}},
+		},
+		{
+			name:  "Near with radius",
+			input: `cafe near:-33.8,151.2,2.5`,
+			want:  &Query{Terms: []string{"cafe"}, Near: &Near{Lat: -33.8, Lng: 151.2, RadiusKm: 2.5}},
+		},
+		{
+			name:  "Unknown key is a term",
+			input: `http://example.com 10:30`,
+			want:  &Query{Terms: []string{"http://example.com", "10:30"}},
+		},
+		{
+			name:  "Hyphen inside term",
+			input: `old-town`,
+			want:  &Query{Terms: []string{"old-town"}},
+		},
+		{
+			name:  "Cyrillic",
+			input: `тег:парк tag:парк "Красная площадь"`,
+			want:  &Query{Terms: []string{"тег:парк"}, Tags: []string{"парк"}, Phrases: []string{"Красная площадь"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := Parse(tc.input)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, q)
+		})
+	}
+}
+
+func TestParse_SyntaxError(t *testing.T) {
+
+	type test struct {
+		input string
+		pos   int
+	}
+
+	tests := []test{
+		{input: `park "old town`, pos: 5},
+		{input: `park -`, pos: 5},
+		{input: `- park`, pos: 0},
+		{input: `""`, pos: 0},
+		{input: `"  "`, pos: 0},
+		{input: `"old"town`, pos: 5},
+		{input: `old"town"`, pos: 3},
+		{input: `tag:`, pos: 4},
+		{input: `park category: museums`, pos: 14},
+		{input: `tag:park"`, pos: 8},
+		{input: `near:55.7`, pos: 5},
+		{input: `near:55.7,abc`, pos: 10},
+		{input: `near:91,37`, pos: 5},
+		{input: `near:55,181`, pos: 5},
+		{input: `near:55,37,0`, pos: 5},
+		{input: `near:55,37,1000`, pos: 5},
+		{input: `near:NaN,37`, pos: 5},
+		{input: `near:1,2,3,4`, pos: 5},
+		{input: `-near:55,37`, pos: 0},
+		{input: `near:55,37 near:55,37`, pos: 11},
+		{input: `тег "`, pos: 4},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			q, err := Parse(tc.input)
+			assert.Nil(t, q)
+
+			var syntaxErr *SyntaxError
+			assert.True(t, errors.As(err, &syntaxErr))
+			assert.Equal(t, tc.pos, syntaxErr.Pos)
+		})
+	}
+}
+
+func TestQuery_String(t *testing.T) {
+
+	q, err := Parse(`-closed Tag:"Street  Art" PARK near:55.75,37.6 "Old Town" category:museums -tag:dogs`)
+	assert.Nil(t, err)
+	assert.Equal(t, `park "old town" tag:"street art" category:museums near:55.75,37.6,1 -closed -tag:dogs`, q.String())
+}
+
+func FuzzParse(f *testing.F) {
+
+	seeds := []string{
+		`tag:park category:museums "old town" -closed`,
+		`near:55.75,37.61,2 cafe`,
+		`-"under construction" -tag:"street art"`,
+		`"unterminated`,
+		`category:"night clubs" тег:парк`,
+		`near:1e2,3`,
+		`--x ::: tag:"a b" -`,
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		q, err := Parse(input)
+		if err != nil {
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("unexpected error type %T: %v", err, err)
+			}
+			if syntaxErr.Pos < 0 || syntaxErr.Pos > utf8.RuneCountInString(input) {
+				t.Fatalf("error position %d out of range for %q", syntaxErr.Pos, input)
+			}
+			return
+		}
+
+		normalised := q.String()
+		q2, err := Parse(normalised)
+		if err != nil {
+			t.Fatalf("normalised query %q of %q does not parse: %v", normalised, input, err)
+		}
+		if q2.String() != normalised {
+			t.Fatalf("normalised query is not stable: %q != %q", q2.String(), normalised)
+		}
+		if q.IsEmpty() != q2.IsEmpty() {
+			t.Fatalf("IsEmpty mismatch for %q", input)
+		}
+	})
+}
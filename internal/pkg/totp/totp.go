@@ -0,0 +1,125 @@
+// Package totp time-based one-time passwords of RFC 6238, HMAC-SHA1 with 6 digits and 30 second steps,
+// the defaults every authenticator app supports
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Digits code length
+	Digits int = 6
+	// Period time step of a code
+	Period = 30 * time.Second
+	// secretSize 160 bits, the HMAC-SHA1 key size recommended by RFC 4226
+	secretSize int = 20
+)
+
+var (
+	// ErrInvalidSecret the secret is not base32
+	ErrInvalidSecret = errors.New("invalid totp secret")
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewSecret random base32 secret
+func NewSecret() (string, error) {
+	raw := make([]byte, secretSize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(raw), nil
+}
+
+// Counter time step of t
+func Counter(t time.Time) int64 {
+	return t.Unix() / int64(Period/time.Second)
+}
+
+// Code code of the secret at t
+func Code(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return generate(key, uint64(Counter(t)), Digits), nil
+}
+
+// Validate the code matches the secret within skew time steps around t,
+// returns the matched time step, codes of a step must not be accepted twice
+func Validate(secret string, code string, t time.Time, skew int) (int64, bool) {
+
+	key, err := decodeSecret(secret)
+	if err != nil || len(code) != Digits {
+		return 0, false
+	}
+
+	current := Counter(t)
+	for i := -skew; i <= skew; i++ {
+		counter := current + int64(i)
+		if counter < 0 {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(generate(key, uint64(counter), Digits)), []byte(code)) == 1 {
+			return counter, true
+		}
+	}
+
+	return 0, false
+}
+
+// ProvisioningURI otpauth URI of the Key URI Format, shown as a QR code to authenticator apps
+func ProvisioningURI(issuer string, account string, secret string) string {
+
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(Digits))
+	query.Set("period", fmt.Sprint(int(Period/time.Second)))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+	return key, nil
+}
+
+// generate HOTP of RFC 4226
+func generate(key []byte, counter uint64, digits int) string {
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%mod)
+}
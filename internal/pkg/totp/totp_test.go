@@ -0,0 +1,77 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+
+	// SHA1 test vectors of RFC 6238 appendix B
+	key := []byte("12345678901234567890")
+	for unix, code := range map[int64]string{
+		59:          "94287082",
+		1111111109:  "07081804",
+		1111111111:  "14050471",
+		1234567890:  "89005924",
+		2000000000:  "69279037",
+		20000000000: "65353130",
+	} {
+		assert.Equal(t, code, generate(key, uint64(Counter(time.Unix(unix, 0))), 8))
+	}
+}
+
+func TestValidate(t *testing.T) {
+
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	now := time.Unix(1111111109, 0)
+
+	code, err := Code(secret, now)
+	assert.Nil(t, err)
+	assert.Equal(t, "081804", code)
+
+	counter, ok := Validate(secret, code, now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, Counter(now), counter)
+
+	_, ok = Validate(secret, code, now.Add(Period), 1)
+	assert.True(t, ok)
+	_, ok = Validate(secret, code, now.Add(2*Period), 1)
+	assert.False(t, ok)
+	_, ok = Validate(secret, "000000", now, 1)
+	assert.False(t, ok)
+	_, ok = Validate(secret, "81804", now, 1)
+	assert.False(t, ok)
+	_, ok = Validate("not base32!", code, now, 1)
+	assert.False(t, ok)
+
+	_, err = Code("", now)
+	assert.ErrorIs(t, err, ErrInvalidSecret)
+}
+
+func TestNewSecret(t *testing.T) {
+
+	secret, err := NewSecret()
+	assert.Nil(t, err)
+	assert.Len(t, secret, 32)
+
+	other, err := NewSecret()
+	assert.Nil(t, err)
+	assert.NotEqual(t, secret, other)
+}
+
+func TestProvisioningURI(t *testing.T) {
+
+	u, err := url.Parse(ProvisioningURI("Walk", "Wozniak", "GEZDGNBVGY3TQOJQ"))
+	assert.Nil(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/Walk:Wozniak", u.Path)
+	assert.Equal(t, "GEZDGNBVGY3TQOJQ", u.Query().Get("secret"))
+	assert.Equal(t, "Walk", u.Query().Get("issuer"))
+	assert.Equal(t, "6", u.Query().Get("digits"))
+}
@@ -0,0 +1,182 @@
+// Package translit makes script independent search keys so that
+// "Tretyakovskaya" and "Третьяковская" produce the same key.
+package translit
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/gosimple/unidecode"
+)
+
+// cyrillicToLatin run cmd/search_terms after a change to rebuild the stored keys
+var cyrillicToLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g", 'ў': "u",
+}
+
+// keyReplacements applied one after another to fold spelling variants of the same sound
+var keyReplacements = [][2]string{
+	{"shch", "sch"},
+	{"kh", "h"},
+	{"tz", "ts"},
+	{"x", "ks"},
+	{"w", "v"},
+	{"j", "y"},
+	{"ia", "ya"},
+	{"iu", "yu"},
+	{"io", "yo"},
+	{"ye", "e"},
+	{"iy", "y"},
+}
+
+// latinToCyrillic longest sequences first
+var latinToCyrillic = []struct {
+	latin    string
+	cyrillic string
+}{
+	{"shch", "щ"}, {"sch", "щ"},
+	{"zh", "ж"}, {"kh", "х"}, {"ts", "ц"}, {"tz", "ц"}, {"ch", "ч"}, {"sh", "ш"},
+	{"yu", "ю"}, {"iu", "ю"}, {"ya", "я"}, {"ia", "я"}, {"yo", "ё"}, {"ye", "е"},
+	{"a", "а"}, {"b", "б"}, {"c", "ц"}, {"d", "д"}, {"e", "е"}, {"f", "ф"}, {"g", "г"},
+	{"h", "х"}, {"i", "и"}, {"j", "й"}, {"k", "к"}, {"l", "л"}, {"m", "м"}, {"n", "н"},
+	{"o", "о"}, {"p", "п"}, {"q", "к"}, {"r", "р"}, {"s", "с"}, {"t", "т"}, {"u", "у"},
+	{"v", "в"}, {"w", "в"}, {"x", "кс"}, {"y", "ы"}, {"z", "з"},
+}
+
+// Key make script independent key of a word
+func Key(word string) string {
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(word) {
+		if latin, ok := cyrillicToLatin[r]; ok {
+			b.WriteString(latin)
+			continue
+		}
+		if r < unicode.MaxASCII {
+			if isKeyRune(r) {
+				b.WriteRune(r)
+			}
+			continue
+		}
+		for _, d := range strings.ToLower(unidecode.Unidecode(string(r))) {
+			if isKeyRune(d) {
+				b.WriteRune(d)
+			}
+		}
+	}
+
+	key := b.String()
+	for _, replacement := range keyReplacements {
+		key = strings.ReplaceAll(key, replacement[0], replacement[1])
+	}
+
+	return collapseRepeats(key)
+}
+
+// Keys make keys of all words, without duplicates
+func Keys(texts ...string) []string {
+
+	seen := make(map[string]struct{})
+	keys := make([]string, 0)
+	for _, text := range texts {
+		for _, word := range splitWords(text) {
+			key := Key(word)
+			if key == "" {
+				continue
+			}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// ToCyrillic transliterate latin word to russian cyrillic, other letters are kept
+func ToCyrillic(word string) string {
+
+	lower := strings.ToLower(word)
+	var b strings.Builder
+	for i := 0; i < len(lower); {
+		matched := false
+		for _, m := range latinToCyrillic {
+			if strings.HasPrefix(lower[i:], m.latin) {
+				b.WriteString(m.cyrillic)
+				i += len(m.latin)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			b.WriteByte(lower[i])
+			i++
+		}
+	}
+
+	return b.String()
+}
+
+// IsLatin word has latin letters and no cyrillic letters
+func IsLatin(word string) bool {
+
+	hasLatin := false
+	for _, r := range word {
+		if unicode.Is(unicode.Cyrillic, r) {
+			return false
+		}
+		if r < unicode.MaxASCII && unicode.IsLetter(r) {
+			hasLatin = true
+		}
+	}
+	return hasLatin
+}
+
+// Expand return search variants of a word in both scripts, without the word itself
+func Expand(word string) []string {
+
+	lower := strings.ToLower(word)
+	variants := make([]string, 0, 2)
+	if key := Key(word); key != "" && key != lower {
+		variants = append(variants, key)
+	}
+	if IsLatin(word) {
+		if cyrillic := ToCyrillic(word); cyrillic != lower {
+			variants = append(variants, cyrillic)
+		}
+	}
+
+	return variants
+}
+
+func splitWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func isKeyRune(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9')
+}
+
+func collapseRepeats(s string) string {
+
+	if len(s) < 2 {
+		return s
+	}
+
+	b := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if i > 0 && s[i] == s[i-1] {
+			continue
+		}
+		b = append(b, s[i])
+	}
+	return string(b)
+}
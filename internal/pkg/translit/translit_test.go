@@ -0,0 +1,59 @@
+package translit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKey(t *testing.T) {
+
+	type test struct {
+		a string
+		b string
+	}
+
+	tests := []test{
+		{a: "Третьяковская", b: "Tretyakovskaya"},
+		{a: "Третьяковская", b: "Tretiakovskaia"},
+		{a: "Хамовники", b: "Hamovniki"},
+		{a: "Хамовники", b: "Khamovniki"},
+		{a: "Екатеринбург", b: "Yekaterinburg"},
+		{a: "Красный", b: "Krasniy"},
+		{a: "Красный", b: "Krasny"},
+		{a: "Щукинская", b: "Shchukinskaya"},
+		{a: "Щукинская", b: "Schukinskaya"},
+		{a: "Цветной", b: "Tsvetnoy"},
+		{a: "Café", b: "cafe"},
+		{a: "Straße", b: "Strasse"},
+		{a: "Søndre", b: "Sondre"},
+		{a: "Ærø", b: "Aero"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.a+"_"+tc.b, func(t *testing.T) {
+			assert.Equal(t, Key(tc.a), Key(tc.b))
+		})
+	}
+
+	assert.NotEqual(t, Key("Арбат"), Key("Тверская"))
+	assert.Equal(t, "", Key("!!!"))
+}
+
+func TestKeys(t *testing.T) {
+	assert.Equal(t, []string{"tretyakovskaya", "galereya", "muzey"}, Keys("Третьяковская галерея", "музей", "Музей"))
+	assert.Equal(t, []string{"park", "zaryade", "sad"}, Keys("Парк «Зарядье»—сад"))
+}
+
+func TestToCyrillic(t *testing.T) {
+	assert.Equal(t, "третяковская", ToCyrillic("Tretyakovskaya"))
+	assert.Equal(t, "щукинская", ToCyrillic("Shchukinskaya"))
+	assert.Equal(t, "кремл", ToCyrillic("Kreml"))
+}
+
+func TestExpand(t *testing.T) {
+	assert.Equal(t, []string{"tretyakovskaya"}, Expand("Третьяковская"))
+	assert.Equal(t, []string{"третяковская"}, Expand("Tretyakovskaya"))
+	assert.Equal(t, []string{"hamovniki", "хамовники"}, Expand("Khamovniki"))
+	assert.Empty(t, Expand("123"))
+}